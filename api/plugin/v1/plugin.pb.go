@@ -143,7 +143,7 @@ func (x *InfoResponse) GetUrl() string {
 // ValidateRequest contains the context passed to plugin validators.
 type ValidateRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// event_type is the hook event type ("PreToolUse", "PostToolUse", "Notification").
+	// event_type is the hook event type ("PreToolUse", "PostToolUse", "UserPromptSubmit", "Stop", etc.).
 	EventType string `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
 	// tool_name is the tool being invoked ("Bash", "Write", "Edit", etc.).
 	ToolName string `protobuf:"bytes,2,opt,name=tool_name,json=toolName,proto3" json:"tool_name,omitempty"`
@@ -357,6 +357,14 @@ func outputAuditTable(entries []*exceptions.AuditEntry) {
 			fmt.Printf("    Source: %s\n", entry.Source)
 		}
 
+		if entry.Signed {
+			fmt.Println("    Signed: yes")
+		}
+
+		if entry.ApprovalID != "" {
+			fmt.Printf("    Approval: %s (%s)\n", entry.ApprovalID, entry.ApprovalStatus)
+		}
+
 		if entry.Command != "" {
 			cmd := entry.Command
 			if len(cmd) > maxCommandDisplayLen {
@@ -3,6 +3,7 @@ package main
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"slices"
@@ -167,7 +168,10 @@ func setupDebugContext(cmdName, extraKey, extraVal string) (*config.Config, erro
 func loadConfigForDebug(log logger.Logger) (*config.Config, error) {
 	flags := buildFlagsMap()
 
-	loader, err := internalconfig.NewKoanfLoader()
+	loader, err := internalconfig.NewKoanfLoader(
+		internalconfig.WithVersion(version),
+		internalconfig.WithLoaderLogger(log),
+	)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create config loader")
 	}
@@ -184,7 +188,17 @@ func loadConfigForDebug(log logger.Logger) (*config.Config, error) {
 
 func displayRulesConfig(cfg *config.Config, filter string) {
 	rules := cfg.GetRules()
-	if rules == nil || len(rules.Rules) == 0 {
+
+	var (
+		effective []config.RuleConfig
+		overrides map[string][]string
+	)
+
+	if rules != nil {
+		effective, overrides = effectiveRules(rules)
+	}
+
+	if len(effective) == 0 {
 		fmt.Println("No rules configured.")
 		fmt.Println("")
 		fmt.Println("To configure rules, add them to:")
@@ -204,11 +218,15 @@ func displayRulesConfig(cfg *config.Config, filter string) {
 	// Rules engine status
 	fmt.Printf("Engine Enabled: %v\n", rules.IsEnabled())
 	fmt.Printf("Stop on First Match: %v\n", rules.ShouldStopOnFirstMatch())
-	fmt.Printf("Total Rules: %d\n", len(rules.Rules))
+	fmt.Printf("Standalone Rules: %v\n", rules.IsStandaloneEnabled())
+	fmt.Printf("Rule Statistics: %v\n", rules.IsStatsEnabled())
+	fmt.Printf("Total Rules: %d\n", len(effective))
 	fmt.Println("")
 
+	displayRulePacks(rules.ResolvedPacks)
+
 	// Filter rules if needed
-	filteredRules := filterRules(rules.Rules, filter)
+	filteredRules := filterRules(effective, filter)
 
 	if len(filteredRules) == 0 {
 		fmt.Printf("No rules match filter: %s\n", filter)
@@ -218,8 +236,81 @@ func displayRulesConfig(cfg *config.Config, filter string) {
 
 	// Display rules
 	for i, rule := range filteredRules {
-		displayRule(i+1, &rule)
+		displayRule(i+1, &rule, overrides[rule.Name])
+	}
+}
+
+// effectiveRules merges pack and config rules the way the rule engine does:
+// later packs override earlier ones and config rules override pack rules
+// with the same name. It also returns the origins of the overridden rules
+// by rule name.
+func effectiveRules(rules *config.RulesConfig) ([]config.RuleConfig, map[string][]string) {
+	var merged []config.RuleConfig
+
+	overrides := make(map[string][]string)
+
+	add := func(rule config.RuleConfig) {
+		for i := range merged {
+			if rule.Name != "" && merged[i].Name == rule.Name {
+				overrides[rule.Name] = append(overrides[rule.Name], ruleOrigin(&merged[i]))
+				merged[i] = rule
+
+				return
+			}
+		}
+
+		merged = append(merged, rule)
+	}
+
+	for _, pack := range rules.ResolvedPacks {
+		for _, rule := range pack.Rules {
+			add(rule)
+		}
+	}
+
+	for _, rule := range rules.Rules {
+		add(rule)
+	}
+
+	return merged, overrides
+}
+
+// ruleOrigin describes where a rule was defined.
+func ruleOrigin(rule *config.RuleConfig) string {
+	if rule.Pack != "" {
+		return fmt.Sprintf("pack %s (%s)", rule.Pack, rule.Source)
+	}
+
+	if rule.Source != "" {
+		return rule.Source
+	}
+
+	return "unknown"
+}
+
+func displayRulePacks(packs []config.RulePack) {
+	if len(packs) == 0 {
+		return
 	}
+
+	fmt.Println("Rule Packs:")
+
+	for _, pack := range packs {
+		fmt.Printf("  %s\n", pack.ID())
+
+		if pack.Description != "" {
+			fmt.Printf("    Description: %s\n", pack.Description)
+		}
+
+		fmt.Printf("    Path: %s\n", pack.Path)
+		fmt.Printf("    Rules: %d\n", len(pack.Rules))
+
+		if pack.KlaudiushVersion != "" {
+			fmt.Printf("    Requires: klaudiush %s\n", pack.KlaudiushVersion)
+		}
+	}
+
+	fmt.Println("")
 }
 
 func filterRules(rules []config.RuleConfig, filter string) []config.RuleConfig {
@@ -272,7 +363,7 @@ func filterRules(rules []config.RuleConfig, filter string) []config.RuleConfig {
 	return filtered
 }
 
-func displayRule(index int, rule *config.RuleConfig) {
+func displayRule(index int, rule *config.RuleConfig, overrides []string) {
 	enabledStr := statusEnabledLower
 	if !rule.IsRuleEnabled() {
 		enabledStr = statusDisabled
@@ -280,6 +371,11 @@ func displayRule(index int, rule *config.RuleConfig) {
 
 	fmt.Printf("Rule #%d: %s [%s]\n", index, rule.Name, enabledStr)
 	fmt.Printf("  Priority: %d\n", rule.Priority)
+	fmt.Printf("  Origin: %s\n", ruleOrigin(rule))
+
+	for _, overridden := range overrides {
+		fmt.Printf("  Overrides: %s\n", overridden)
+	}
 
 	if rule.Description != "" {
 		fmt.Printf("  Description: %s\n", rule.Description)
@@ -303,6 +399,10 @@ func displayRule(index int, rule *config.RuleConfig) {
 		if rule.Action.Reference != "" {
 			fmt.Printf("    Reference: %s\n", rule.Action.Reference)
 		}
+
+		if rule.Action.Suggestion != "" {
+			fmt.Printf("    Suggestion: %s\n", rule.Action.Suggestion)
+		}
 	}
 
 	fmt.Println("")
@@ -344,6 +444,125 @@ func displayMatchCondition(indent string, match *config.RuleMatchConfig) {
 	if match.EventType != "" {
 		fmt.Printf("%sEvent Type: %s\n", indent, match.EventType)
 	}
+
+	displayParsedCommandConditions(indent, match)
+	displayContextConditions(indent, match)
+	displayGitStateConditions(indent, match)
+
+	displayNestedMatchConditions(indent, "All", match.All)
+	displayNestedMatchConditions(indent, "Any", match.Any)
+
+	if match.Not != nil {
+		fmt.Printf("%sNot:\n", indent)
+		displayMatchCondition(indent+"  ", match.Not)
+	}
+}
+
+func displayParsedCommandConditions(indent string, match *config.RuleMatchConfig) {
+	if match.Program != "" {
+		fmt.Printf("%sProgram: %s\n", indent, match.Program)
+	}
+
+	if match.Subcommand != "" {
+		fmt.Printf("%sSubcommand: %s\n", indent, match.Subcommand)
+	}
+
+	if len(match.Flags) > 0 {
+		fmt.Printf("%sFlags: %s\n", indent, strings.Join(match.Flags, ", "))
+	}
+
+	if len(match.WithoutFlags) > 0 {
+		fmt.Printf("%sWithout Flags: %s\n", indent, strings.Join(match.WithoutFlags, ", "))
+	}
+
+	if len(match.Args) > 0 {
+		fmt.Printf("%sArgs: %s\n", indent, strings.Join(match.Args, ", "))
+	}
+
+	if match.InPipeline != nil {
+		fmt.Printf("%sIn Pipeline: %v\n", indent, *match.InPipeline)
+	}
+
+	if match.InSubshell != nil {
+		fmt.Printf("%sIn Subshell: %v\n", indent, *match.InSubshell)
+	}
+
+	if match.InCommandSubstitution != nil {
+		fmt.Printf("%sIn Command Substitution: %v\n", indent, *match.InCommandSubstitution)
+	}
+
+	if match.PipelinePosition != "" {
+		fmt.Printf("%sPipeline Position: %s\n", indent, match.PipelinePosition)
+	}
+}
+
+func displayContextConditions(indent string, match *config.RuleMatchConfig) {
+	for _, name := range slices.Sorted(maps.Keys(match.Env)) {
+		fmt.Printf("%sEnv: %s=%s\n", indent, name, match.Env[name])
+	}
+
+	if match.CWDPattern != "" {
+		fmt.Printf("%sCWD Pattern: %s\n", indent, match.CWDPattern)
+	}
+
+	if window := match.TimeWindow; window != nil {
+		fmt.Printf("%sTime Window: %s-%s", indent, window.Start, window.End)
+
+		if len(window.Days) > 0 {
+			fmt.Printf(" %s", strings.Join(window.Days, ","))
+		}
+
+		if window.Timezone != "" {
+			fmt.Printf(" (%s)", window.Timezone)
+		}
+
+		fmt.Println()
+	}
+
+	if match.MinSessionCommands != nil {
+		fmt.Printf("%sMin Session Commands: %d\n", indent, *match.MinSessionCommands)
+	}
+
+	if match.MaxSessionCommands != nil {
+		fmt.Printf("%sMax Session Commands: %d\n", indent, *match.MaxSessionCommands)
+	}
+}
+
+func displayGitStateConditions(indent string, match *config.RuleMatchConfig) {
+	if match.RemoteHost != "" {
+		fmt.Printf("%sRemote Host: %s\n", indent, match.RemoteHost)
+	}
+
+	if match.RemoteOwner != "" {
+		fmt.Printf("%sRemote Owner: %s\n", indent, match.RemoteOwner)
+	}
+
+	if match.RemoteRepo != "" {
+		fmt.Printf("%sRemote Repo: %s\n", indent, match.RemoteRepo)
+	}
+
+	if len(match.StagedFiles) > 0 {
+		fmt.Printf("%sStaged Files: %s\n", indent, strings.Join(match.StagedFiles, ", "))
+	}
+
+	if match.Dirty != nil {
+		fmt.Printf("%sDirty: %v\n", indent, *match.Dirty)
+	}
+
+	if match.MinCommitsAhead != nil {
+		fmt.Printf("%sMin Commits Ahead: %d\n", indent, *match.MinCommitsAhead)
+	}
+
+	if match.MaxCommitsAhead != nil {
+		fmt.Printf("%sMax Commits Ahead: %d\n", indent, *match.MaxCommitsAhead)
+	}
+}
+
+func displayNestedMatchConditions(indent, label string, blocks []config.RuleMatchConfig) {
+	for i := range blocks {
+		fmt.Printf("%s%s[%d]:\n", indent, label, i)
+		displayMatchCondition(indent+"  ", &blocks[i])
+	}
 }
 
 func runDebugExceptions(_ *cobra.Command, _ []string) error {
@@ -461,6 +680,40 @@ func displayPolicy(code string, policy *config.ExceptionPolicyConfig) {
 	if maxHour > 0 || maxDay > 0 {
 		fmt.Printf("    Rate Limits: %s\n", formatLimits(maxHour, maxDay))
 	}
+
+	if scopes := requiredTokenScopes(policy); len(scopes) > 0 {
+		fmt.Printf("    Required Token Scopes: %s\n", strings.Join(scopes, ", "))
+	}
+
+	if policy.IsApprovalRequired() {
+		fmt.Println("    Require Approval: true")
+	}
+}
+
+// requiredTokenScopes returns the token scopes required by the policy.
+func requiredTokenScopes(policy *config.ExceptionPolicyConfig) []string {
+	var scopes []string
+
+	if policy.IsSessionBound() {
+		scopes = append(scopes, "session")
+	}
+
+	if policy.IsCommandBound() {
+		scopes = append(scopes, "command")
+	}
+
+	switch maxTTL := policy.GetMaxTokenTTL(); {
+	case maxTTL > 0:
+		scopes = append(scopes, "expiry (max "+maxTTL.String()+")")
+	case policy.IsExpiryRequired():
+		scopes = append(scopes, "expiry")
+	}
+
+	if policy.IsSignatureRequired() {
+		scopes = append(scopes, "signature")
+	}
+
+	return scopes
 }
 
 func displayRateLimitConfig(exc *config.ExceptionsConfig) {
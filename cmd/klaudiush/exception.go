@@ -0,0 +1,422 @@
+// Package main provides the CLI entry point for klaudiush.
+package main
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/smykla-labs/klaudiush/internal/exceptions"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// defaultGrantTTL is how long granted tokens are valid by default.
+const defaultGrantTTL = time.Hour
+
+// confirmCodeBytes is the number of random bytes of confirmation codes.
+const confirmCodeBytes = 3
+
+// openTerminal opens the controlling terminal of the process. Commands that
+// only the user may run ask for a confirmation on it, which the agent cannot
+// give, as it runs commands without a controlling terminal.
+var openTerminal = func() (io.ReadWriteCloser, error) {
+	return os.OpenFile("/dev/tty", os.O_RDWR, 0)
+}
+
+// confirmByUser asks the user to confirm the command by typing a random code
+// shown on the controlling terminal. Redirected input cannot confirm it, as
+// the code is not known in advance.
+func confirmByUser(cmd *cobra.Command) error {
+	tty, err := openTerminal()
+	if err != nil {
+		return errors.Newf(
+			"%s must be run by the user in an interactive terminal",
+			cmd.CommandPath(),
+		)
+	}
+	defer tty.Close()
+
+	random := make([]byte, confirmCodeBytes)
+	if _, err := rand.Read(random); err != nil {
+		return errors.Wrap(err, "failed to generate confirmation code")
+	}
+
+	code := hex.EncodeToString(random)
+
+	fmt.Fprintf(tty, "To confirm %s, type %s: ", cmd.CommandPath(), code)
+
+	answer, err := bufio.NewReader(tty).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return errors.Wrap(err, "failed to read confirmation code")
+	}
+
+	if strings.TrimSpace(answer) != code {
+		return errors.Newf("%s was not confirmed: wrong confirmation code", cmd.CommandPath())
+	}
+
+	return nil
+}
+
+var exceptionCmd = &cobra.Command{
+	Use:   "exception",
+	Short: "Manage exception tokens",
+	Long: `Manage exception tokens that bypass validation blocks.
+
+Subcommands:
+  grant    Mint a signed exception token
+  list     List exception requests awaiting approval
+  approve  Approve an exception request
+  deny     Deny an exception request`,
+}
+
+var exceptionGrantCmd = &cobra.Command{
+	Use:   "grant <error-code>",
+	Short: "Mint a signed exception token",
+	Long: `Mint an exception token signed with the local signing key, so it is accepted
+by policies with require_signature. The key is created on the first grant at
+exceptions.signing_key_file (default: ~/.klaudiush/exception_signing.key).
+
+The token can be bound to a session, to the exact command it may be used with
+and to an expiry. Bound tokens are rejected in other sessions, with other
+commands or after they expire. The token is printed to stdout.
+
+Tokens can only be granted from an interactive terminal, after typing the
+confirmation code shown on it. klaudiush also blocks the agent from running
+this command or reading the signing key.
+
+Examples:
+  klaudiush exception grant SEC001 --reason "Test fixture"
+  klaudiush exception grant SEC001 --session abc-123 --ttl 15m
+  klaudiush exception grant GIT022 --command "git push origin main" --ttl 0`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runExceptionGrant,
+}
+
+var exceptionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List exception requests awaiting approval",
+	Long: `List exception requests of policies with require_approval.
+
+A request is queued when a command is blocked by such a policy, and stays in
+the queue until it expires (exceptions.approval.max_age, default: 24h) or an
+approved request is used by a retry of the command.
+
+Examples:
+  klaudiush exception list
+  klaudiush exception list --json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runExceptionList,
+}
+
+var exceptionApproveCmd = &cobra.Command{
+	Use:   "approve <id>",
+	Short: "Approve an exception request",
+	Long: `Approve a queued exception request. The next retry of the same command in
+the same session is allowed, once. Requests can only be approved from an
+interactive terminal, after typing the confirmation code shown on it.
+
+Examples:
+  klaudiush exception approve 3f2a9c0d1e8b`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runExceptionResolve(cmd, args[0], exceptions.ApprovalStatusApproved)
+	},
+}
+
+var exceptionDenyCmd = &cobra.Command{
+	Use:   "deny <id>",
+	Short: "Deny an exception request",
+	Long: `Deny a queued exception request. Retries of the same command in the same
+session are blocked until the request expires. Requests can only be denied
+from an interactive terminal, after typing the confirmation code shown on it.
+
+Examples:
+  klaudiush exception deny 3f2a9c0d1e8b`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runExceptionResolve(cmd, args[0], exceptions.ApprovalStatusDenied)
+	},
+}
+
+// Exception command flags.
+var (
+	exceptionReason    string
+	exceptionSessionID string
+	exceptionCommand   string
+	exceptionTTL       time.Duration
+	exceptionJSON      bool
+)
+
+func init() {
+	rootCmd.AddCommand(exceptionCmd)
+	exceptionCmd.AddCommand(exceptionGrantCmd)
+	exceptionCmd.AddCommand(exceptionListCmd)
+	exceptionCmd.AddCommand(exceptionApproveCmd)
+	exceptionCmd.AddCommand(exceptionDenyCmd)
+
+	exceptionGrantCmd.Flags().StringVar(
+		&exceptionReason,
+		"reason",
+		"",
+		"Justification reason of the exception",
+	)
+
+	exceptionGrantCmd.Flags().StringVar(
+		&exceptionSessionID,
+		"session",
+		"",
+		"Bind the token to this session ID",
+	)
+
+	exceptionGrantCmd.Flags().StringVar(
+		&exceptionCommand,
+		"command",
+		"",
+		"Bind the token to this command",
+	)
+
+	exceptionGrantCmd.Flags().DurationVar(
+		&exceptionTTL,
+		"ttl",
+		defaultGrantTTL,
+		"How long the token is valid (0 = no expiry)",
+	)
+
+	exceptionListCmd.Flags().BoolVar(
+		&exceptionJSON,
+		"json",
+		false,
+		"Output requests as JSON",
+	)
+}
+
+func runExceptionGrant(cmd *cobra.Command, args []string) error {
+	if err := confirmByUser(cmd); err != nil {
+		return err
+	}
+
+	log, err := newCommandLogger("exception grant command invoked")
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(log)
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	excCfg := cfg.GetExceptions()
+	parser := exceptions.NewParser(exceptions.WithTokenPrefix(excCfg.GetTokenPrefix()))
+
+	token, err := parser.ParseToken(excCfg.GetTokenPrefix() + ":" + strings.ToUpper(args[0]))
+	if err != nil {
+		return err
+	}
+
+	token.Reason = strings.TrimSpace(exceptionReason)
+	token.SessionID = strings.TrimSpace(exceptionSessionID)
+
+	if exceptionCommand != "" {
+		token.CommandHash, err = parser.CommandHash(exceptionCommand)
+		if err != nil {
+			return errors.Wrap(err, "failed to hash command")
+		}
+	}
+
+	if exceptionTTL < 0 {
+		return errors.New("--ttl must not be negative")
+	}
+
+	if exceptionTTL > 0 {
+		expiresAt := time.Now().Add(exceptionTTL).Truncate(time.Second).UTC()
+		token.ExpiresAt = &expiresAt
+	}
+
+	if err := checkGrantPolicy(excCfg.GetPolicy(token.ErrorCode), token, exceptionTTL); err != nil {
+		return err
+	}
+
+	signer, err := exceptions.LoadOrCreateSigner(excCfg.GetSigningKeyFile())
+	if err != nil {
+		return err
+	}
+
+	token.Signature = signer.Sign(token)
+
+	log.Info("exception token granted",
+		"error_code", token.ErrorCode,
+		"session_id", token.SessionID,
+		"command_bound", token.CommandHash != "",
+		"ttl", exceptionTTL.String(),
+	)
+
+	fmt.Fprintln(cmd.OutOrStdout(), token.String())
+
+	return nil
+}
+
+// checkGrantPolicy returns an error if the policy of the token's error code
+// would reject the token for a missing reason or missing scopes.
+func checkGrantPolicy(
+	policy *config.ExceptionPolicyConfig,
+	token *exceptions.Token,
+	ttl time.Duration,
+) error {
+	code := token.ErrorCode
+
+	switch {
+	case !policy.IsPolicyEnabled() || !policy.IsExceptionAllowed():
+		return errors.Newf("exceptions are not allowed for %s", code)
+	case policy.IsReasonRequired() && token.Reason == "":
+		return errors.Newf("policy for %s requires --reason", code)
+	case policy.IsSessionBound() && token.SessionID == "":
+		return errors.Newf("policy for %s requires --session", code)
+	case policy.IsCommandBound() && token.CommandHash == "":
+		return errors.Newf("policy for %s requires --command", code)
+	case (policy.IsExpiryRequired() || policy.GetMaxTokenTTL() > 0) && token.ExpiresAt == nil:
+		return errors.Newf("policy for %s requires a --ttl", code)
+	case policy.GetMaxTokenTTL() > 0 && ttl > policy.GetMaxTokenTTL():
+		return errors.Newf(
+			"--ttl %s exceeds the maximum lifetime of %s for %s",
+			ttl,
+			policy.GetMaxTokenTTL(),
+			code,
+		)
+	}
+
+	return nil
+}
+
+// newApprovalQueue loads the configuration and creates the approval queue.
+func newApprovalQueue(
+	message string,
+) (*exceptions.ApprovalQueue, *config.ExceptionsConfig, logger.Logger, error) {
+	log, err := newCommandLogger(message)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	cfg, err := loadConfig(log)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to load configuration")
+	}
+
+	excCfg := cfg.GetExceptions()
+	queue := exceptions.NewApprovalQueue(
+		excCfg.Approval,
+		exceptions.WithApprovalQueueLogger(log),
+		exceptions.WithApprovalSigningKeyFile(excCfg.GetSigningKeyFile()),
+	)
+
+	return queue, excCfg, log, nil
+}
+
+func runExceptionList(cmd *cobra.Command, _ []string) error {
+	queue, _, _, err := newApprovalQueue("exception list command invoked")
+	if err != nil {
+		return err
+	}
+
+	requests, err := queue.List()
+	if err != nil {
+		return err
+	}
+
+	if exceptionJSON {
+		if requests == nil {
+			requests = []*exceptions.ApprovalRequest{}
+		}
+
+		return writeSessionJSON(cmd.OutOrStdout(), requests)
+	}
+
+	printApprovalRequests(cmd.OutOrStdout(), requests, time.Now())
+
+	return nil
+}
+
+func runExceptionResolve(
+	cmd *cobra.Command,
+	id string,
+	status exceptions.ApprovalStatus,
+) error {
+	if err := confirmByUser(cmd); err != nil {
+		return err
+	}
+
+	queue, excCfg, log, err := newApprovalQueue("exception " + string(status) + " command invoked")
+	if err != nil {
+		return err
+	}
+
+	request, err := queue.Resolve(id, status)
+	if err != nil {
+		return err
+	}
+
+	auditLogger := exceptions.NewAuditLogger(excCfg.Audit, exceptions.WithAuditLoggerLogger(log))
+	if err := auditLogger.Log(request.AuditEntry()); err != nil {
+		log.Error("failed to log exception approval", "error", err.Error())
+	}
+
+	log.Info("exception request resolved",
+		"id", request.ID,
+		"status", string(request.Status),
+		"error_code", request.ErrorCode,
+	)
+
+	out := cmd.OutOrStdout()
+
+	fmt.Fprintf(out, "Exception request %s %s: %s\n", request.ID, request.Status, request.ErrorCode)
+	fmt.Fprintf(out, "  Command: %s\n", request.Command)
+
+	if status == exceptions.ApprovalStatusApproved {
+		fmt.Fprintln(out, "The next retry of the command in its session is allowed.")
+	}
+
+	return nil
+}
+
+// printApprovalRequests prints a table of approval requests.
+func printApprovalRequests(out io.Writer, requests []*exceptions.ApprovalRequest, now time.Time) {
+	if len(requests) == 0 {
+		fmt.Fprintln(out, "No exception requests found.")
+
+		return
+	}
+
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "ID\tSTATUS\tCODE\tSESSION\tREQUESTED\tCOMMAND")
+
+	for _, request := range requests {
+		sessionID := request.SessionID
+		if sessionID == "" {
+			sessionID = "-"
+		}
+
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			request.ID,
+			request.Status,
+			request.ErrorCode,
+			sessionID,
+			describeLastActivity(request.RequestedAt, now),
+			strings.ReplaceAll(request.Command, "\n", " "),
+		)
+	}
+
+	_ = table.Flush()
+}
@@ -0,0 +1,362 @@
+// Package main provides the CLI entry point for klaudiush.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/smykla-labs/klaudiush/internal/dispatcher"
+	"github.com/smykla-labs/klaudiush/internal/exceptions"
+	"github.com/smykla-labs/klaudiush/internal/explain"
+	"github.com/smykla-labs/klaudiush/internal/session"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// ErrExplainInputConflict is returned when both --command and --file are given.
+var ErrExplainInputConflict = errors.New("--command and --file are mutually exclusive")
+
+// Explain command flags.
+var (
+	explainCommand string
+	explainFile    string
+	explainJSON    bool
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain",
+	Short: "Trace how a hook payload is validated",
+	Long: `Run a hook payload through the configured validators and print a full
+decision trace:
+
+  - every validator, whether it was selected and the predicate that selects it
+  - every rule that was evaluated, whether it matched and which condition failed
+  - exception token checks for blocking errors
+  - session poisoning (read-only: session state is never modified)
+  - the findings and the final decision (allow, warn, ask or deny)
+
+The payload is read from stdin in the format Claude Code sends to hooks.
+Use --command or --file to explain a Bash command or a file write instead.
+
+Examples:
+  klaudiush explain --command "git push --force origin main"
+  klaudiush explain --file README.md
+  klaudiush explain < payload.json
+  klaudiush explain --json --command "rm -rf /" > trace.json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runExplain,
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+
+	explainCmd.Flags().StringVarP(
+		&configPath,
+		"config",
+		"c",
+		"",
+		"Path to project configuration file (default: .klaudiush/config.toml or klaudiush.toml)",
+	)
+
+	explainCmd.Flags().StringVar(
+		&globalConfig,
+		"global-config",
+		"",
+		"Path to global configuration file (default: ~/.klaudiush/config.toml)",
+	)
+
+	explainCmd.Flags().StringVar(
+		&explainCommand,
+		"command",
+		"",
+		"Explain a PreToolUse Bash payload for this command",
+	)
+
+	explainCmd.Flags().StringVar(
+		&explainFile,
+		"file",
+		"",
+		"Explain a PreToolUse Write payload for this file (uses its current content)",
+	)
+
+	explainCmd.Flags().BoolVar(&explainJSON, "json", false, "Output the trace as JSON")
+}
+
+func runExplain(cmd *cobra.Command, _ []string) error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return errors.Wrap(err, "failed to get home directory")
+	}
+
+	logFile := filepath.Join(homeDir, ".claude", "hooks", "dispatcher.log")
+
+	log, err := logger.NewFileLogger(logFile, false, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to create logger")
+	}
+
+	hookCtx, err := explainInput(cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+
+	log.Info("explain command invoked",
+		"event", hookCtx.EventType,
+		"tool", hookCtx.ToolName,
+	)
+
+	cfg, err := loadConfig(log)
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	var opts []explain.Option
+
+	if checker := initExplainExceptionChecker(cfg, log); checker != nil {
+		opts = append(opts, explain.WithExceptionChecker(checker))
+	}
+
+	if tracker := initSessionTracker(cfg, log); tracker != nil {
+		opts = append(opts, explain.WithSessionTracker(tracker))
+	}
+
+	explainer, err := explain.New(cfg, log, opts...)
+	if err != nil {
+		return err
+	}
+
+	trace := explainer.Explain(context.Background(), hookCtx)
+
+	if explainJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(trace); err != nil {
+			return errors.Wrap(err, "encoding JSON output")
+		}
+
+		return nil
+	}
+
+	printTrace(cmd.OutOrStdout(), trace)
+
+	return nil
+}
+
+// explainInput builds the hook context from the flags or stdin.
+func explainInput(stdin io.Reader) (*hook.Context, error) {
+	if explainCommand != "" && explainFile != "" {
+		return nil, ErrExplainInputConflict
+	}
+
+	cwd, _ := os.Getwd()
+
+	switch {
+	case explainCommand != "":
+		return explain.CommandContext(explainCommand, cwd), nil
+	case explainFile != "":
+		return explain.FileContext(explainFile, cwd)
+	default:
+		return explain.ParsePayload(stdin)
+	}
+}
+
+// initExplainExceptionChecker creates an exception checker that does not write
+// audit entries or queue approval requests. Returns nil if exceptions are disabled.
+//
+//nolint:ireturn // nil when exceptions are disabled
+func initExplainExceptionChecker(
+	cfg *config.Config,
+	log logger.Logger,
+) dispatcher.ExceptionChecker {
+	exceptionsCfg := cfg.GetExceptions()
+	if !exceptionsCfg.IsEnabled() {
+		return nil
+	}
+
+	auditDisabled := false
+
+	handler := exceptions.NewHandler(
+		exceptionsCfg,
+		exceptions.WithHandlerLogger(log),
+		exceptions.WithAuditLogger(exceptions.NewAuditLogger(
+			&config.ExceptionAuditConfig{Enabled: &auditDisabled},
+		)),
+		exceptions.WithApprovalQueue(exceptions.NewApprovalQueue(
+			exceptionsCfg.Approval,
+			exceptions.WithApprovalSigningKeyFile(exceptionsCfg.GetSigningKeyFile()),
+			exceptions.WithApprovalPreview(),
+		)),
+	)
+
+	if err := handler.LoadState(); err != nil {
+		log.Info("failed to load exception state, starting fresh", "error", err)
+	}
+
+	return dispatcher.NewExceptionChecker(handler, dispatcher.WithExceptionCheckerLogger(log))
+}
+
+// printTrace prints a human-readable decision trace.
+func printTrace(out io.Writer, trace *explain.Trace) {
+	fmt.Fprintln(out, "Decision Trace")
+	fmt.Fprintln(out, "==============")
+	fmt.Fprintln(out, "")
+
+	fmt.Fprintf(out, "Event:    %s %s\n", trace.Event, trace.Tool)
+
+	if trace.Command != "" {
+		fmt.Fprintf(out, "Command:  %s\n", trace.Command)
+	}
+
+	if trace.FilePath != "" {
+		fmt.Fprintf(out, "File:     %s\n", trace.FilePath)
+	}
+
+	if trace.SessionID != "" {
+		fmt.Fprintf(out, "Session:  %s\n", trace.SessionID)
+	}
+
+	printTraceValidators(out, trace.Validators)
+	printTraceRules(out, trace.Rules)
+	printTraceExceptions(out, trace.Exceptions)
+	printTraceSession(out, trace.Session)
+	printTraceFindings(out, trace.Findings)
+
+	fmt.Fprintf(out, "\nDecision: %s\n", strings.ToUpper(string(trace.Decision)))
+}
+
+func printTraceValidators(out io.Writer, validators []explain.ValidatorTrace) {
+	fmt.Fprintln(out, "\nValidators:")
+
+	if len(validators) == 0 {
+		fmt.Fprintln(out, "  (none registered)")
+
+		return
+	}
+
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	for _, v := range validators {
+		status := "skip"
+		if v.Selected {
+			status = "RUN"
+		}
+
+		fmt.Fprintf(table, "  %s\t%s\t%s\n", status, v.Name, v.Predicate)
+	}
+
+	_ = table.Flush()
+}
+
+func printTraceRules(out io.Writer, ruleTraces []explain.RuleTrace) {
+	fmt.Fprintln(out, "\nRules:")
+
+	if len(ruleTraces) == 0 {
+		fmt.Fprintln(out, "  (no rules evaluated)")
+
+		return
+	}
+
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	for _, r := range ruleTraces {
+		if r.Matched {
+			fmt.Fprintf(table, "  MATCH\t%s\t%s\taction: %s\n", r.Rule, r.Validator, r.Action)
+
+			continue
+		}
+
+		fmt.Fprintf(table, "  miss\t%s\t%s\tfailed: %s\n", r.Rule, r.Validator, r.FailedMatcher)
+	}
+
+	_ = table.Flush()
+}
+
+func printTraceExceptions(out io.Writer, exceptionTraces []explain.ExceptionTrace) {
+	if len(exceptionTraces) == 0 {
+		return
+	}
+
+	fmt.Fprintln(out, "\nExceptions:")
+
+	for _, e := range exceptionTraces {
+		outcome := "not bypassed"
+		if e.Bypassed {
+			outcome = "BYPASSED by exception token"
+		}
+
+		fmt.Fprintf(out, "  %s %s: %s\n", e.Validator, e.Reference, outcome)
+	}
+}
+
+func printTraceSession(out io.Writer, sessionTrace *explain.SessionTrace) {
+	if sessionTrace == nil {
+		return
+	}
+
+	fmt.Fprintln(out, "\nSession:")
+
+	switch {
+	case sessionTrace.Poisoned && sessionTrace.Unpoisoned:
+		fmt.Fprintf(out, "  poisoned by %s, acknowledged by this payload\n",
+			strings.Join(sessionTrace.PoisonCodes, ", "))
+	case sessionTrace.Poisoned:
+		fmt.Fprintf(out, "  poisoned by %s, validators were not run\n",
+			strings.Join(sessionTrace.PoisonCodes, ", "))
+	default:
+		fmt.Fprintln(out, "  not poisoned")
+	}
+
+	switch {
+	case sessionTrace.WouldStatus == session.StatusHardPoisoned.String():
+		fmt.Fprintf(out, "  would hard-poison the session with %s\n",
+			strings.Join(sessionTrace.WouldPoison, ", "))
+	case len(sessionTrace.WouldPoison) > 0:
+		fmt.Fprintf(out, "  would poison the session with %s\n",
+			strings.Join(sessionTrace.WouldPoison, ", "))
+	case sessionTrace.WouldStatus == session.StatusWarned.String():
+		fmt.Fprintln(out, "  would warn the session about repeated blocked attempts")
+	}
+}
+
+func printTraceFindings(out io.Writer, findings []explain.Finding) {
+	fmt.Fprintln(out, "\nFindings:")
+
+	if len(findings) == 0 {
+		fmt.Fprintln(out, "  (none)")
+
+		return
+	}
+
+	for _, f := range findings {
+		icon := "⚠️ "
+
+		switch {
+		case f.Block:
+			icon = "❌"
+		case f.Ask:
+			icon = "❓"
+		}
+
+		message, _, _ := strings.Cut(strings.TrimSpace(f.Message), "\n")
+
+		if f.Reference != "" {
+			fmt.Fprintf(out, "  %s %s [%s] %s\n", icon, f.Validator, f.Reference, message)
+
+			continue
+		}
+
+		fmt.Fprintf(out, "  %s %s %s\n", icon, f.Validator, message)
+	}
+}
@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -15,8 +16,11 @@ import (
 	"github.com/smykla-labs/klaudiush/internal/config/factory"
 	"github.com/smykla-labs/klaudiush/internal/crashdump"
 	"github.com/smykla-labs/klaudiush/internal/dispatcher"
+	"github.com/smykla-labs/klaudiush/internal/exceptions"
 	"github.com/smykla-labs/klaudiush/internal/parser"
+	"github.com/smykla-labs/klaudiush/internal/rules"
 	"github.com/smykla-labs/klaudiush/internal/session"
+	"github.com/smykla-labs/klaudiush/internal/templates"
 	"github.com/smykla-labs/klaudiush/pkg/config"
 	"github.com/smykla-labs/klaudiush/pkg/hook"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
@@ -43,6 +47,7 @@ var (
 	configPath   string
 	globalConfig string
 	disableList  []string
+	outputFormat string
 
 	// crashContext stores the current hook context for crash recovery.
 	// Set during validation dispatch and accessed by panic handler.
@@ -93,7 +98,9 @@ func init() {
 		"hook-type",
 		"T",
 		"",
-		"Hook event type (PreToolUse, PostToolUse, Notification)",
+		"Hook event type (PreToolUse, PostToolUse, Notification, UserPromptSubmit, Stop, "+
+			"SubagentStop, SessionStart, SessionEnd, PreCompact); defaults to hook_event_name "+
+			"from the input, then PreToolUse",
 	)
 	rootCmd.Flags().BoolVar(&debugMode, "debug", true, "Enable debug logging")
 	rootCmd.Flags().BoolVar(&traceMode, "trace", false, "Enable trace logging")
@@ -116,6 +123,12 @@ func init() {
 		[]string{},
 		"Comma-separated list of validators to disable (e.g., commit,markdown)",
 	)
+	rootCmd.Flags().StringVar(
+		&outputFormat,
+		"output-format",
+		"",
+		"Hook output format: text (stderr + exit code) or json (structured response on stdout)",
+	)
 }
 
 func run(_ *cobra.Command, _ []string) error {
@@ -137,10 +150,11 @@ func run(_ *cobra.Command, _ []string) error {
 		log.Error("first-run migration failed", "error", migErr)
 	}
 
-	// Determine event type using enumer-generated function
+	// Determine event type using enumer-generated function. Unknown lets the
+	// parser fall back to the hook_event_name field of the input.
 	eventType, err := hook.EventTypeString(hookType)
 	if err != nil {
-		eventType = hook.EventTypePreToolUse // Default to PreToolUse
+		eventType = hook.EventTypeUnknown
 	}
 
 	log.Info("hook invoked",
@@ -169,7 +183,12 @@ func run(_ *cobra.Command, _ []string) error {
 		return errors.Wrap(err, "failed to parse input")
 	}
 
+	if ctx.EventType == hook.EventTypeUnknown {
+		ctx.EventType = hook.EventTypePreToolUse // Default to PreToolUse
+	}
+
 	log.Info("context parsed",
+		"eventType", ctx.EventType,
 		"tool", ctx.ToolName,
 		"command", ctx.GetCommand(),
 		"file", filepath.Base(ctx.GetFilePath()),
@@ -179,19 +198,37 @@ func run(_ *cobra.Command, _ []string) error {
 	crashContext = ctx
 	crashConfig = cfg
 
-	// Build validator registry from configuration
+	// Build validator registry and rule engine from configuration
 	registryBuilder := factory.NewRegistryBuilder(log)
-	registry := registryBuilder.Build(cfg)
+
+	registry, ruleEngine, err := registryBuilder.BuildWithRuleEngine(cfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to build validator registry")
+	}
 
 	// Create and initialize session tracker if enabled
 	sessionTracker := initSessionTracker(cfg, log)
 
-	// Create dispatcher with session tracker
+	// Expose session data to rules with session conditions
+	if ruleEngine != nil && sessionTracker != nil {
+		ruleEngine.SetSessionProvider(ruleSessionProvider(sessionTracker))
+	}
+
+	// Record log rule matches in the rules audit log
+	initRuleAuditLog(cfg, ruleEngine, log)
+
+	// Record rule hit statistics if enabled
+	ruleStats := initRuleStats(cfg, ruleEngine, log)
+
+	// Create exception handler if enabled
+	exceptionHandler := initExceptionHandler(cfg, log)
+
+	// Create dispatcher with executor, exception checker and session tracking
 	disp := dispatcher.NewDispatcherWithOptions(
 		registry,
 		log,
-		dispatcher.NewSequentialExecutor(log),
-		dispatcher.WithSessionTracker(sessionTracker),
+		newExecutor(cfg, log),
+		dispatcherOptions(cfg, log, sessionTracker, exceptionHandler)...,
 	)
 
 	// Dispatch validation
@@ -204,8 +241,41 @@ func run(_ *cobra.Command, _ []string) error {
 		}
 	}
 
-	// Check if we should block
-	if dispatcher.ShouldBlock(errs) {
+	// Save rule hit statistics after dispatch
+	if ruleStats != nil {
+		if err := ruleStats.Save(); err != nil {
+			log.Info("failed to save rule statistics", "error", err)
+		}
+	}
+
+	// Save exception rate limit state after dispatch
+	if exceptionHandler != nil {
+		if err := exceptionHandler.SaveState(); err != nil {
+			log.Info("failed to save exception state", "error", err)
+		}
+	}
+
+	if exitCode := reportResults(cfg.GetOutput(), ctx.EventType, errs, log); exitCode != ExitCodeAllow {
+		os.Exit(exitCode)
+	}
+
+	return nil
+}
+
+// reportResults reports validation results in the configured output format
+// and returns the exit code the hook should exit with.
+func reportResults(
+	outputCfg *config.OutputConfig,
+	eventType hook.EventType,
+	errs []*dispatcher.ValidationError,
+	log logger.Logger,
+) int {
+	if outputCfg.IsJSON() {
+		return reportJSON(outputCfg, eventType, errs, log)
+	}
+
+	// Text output cannot prompt the user, so confirmation requests block
+	if dispatcher.ShouldBlock(errs) || dispatcher.ShouldAsk(errs) {
 		errorMsg := dispatcher.FormatErrors(errs)
 		fmt.Fprint(os.Stderr, errorMsg)
 
@@ -213,7 +283,7 @@ func run(_ *cobra.Command, _ []string) error {
 			"errorCount", len(errs),
 		)
 
-		os.Exit(ExitCodeBlock)
+		return ExitCodeBlock
 	}
 
 	// If there are warnings, log them
@@ -228,7 +298,44 @@ func run(_ *cobra.Command, _ []string) error {
 		log.Info("validation passed")
 	}
 
-	return nil
+	return ExitCodeAllow
+}
+
+// reportJSON writes a structured hook response to stdout.
+// The decision is carried in the JSON, so the exit code is always ExitCodeAllow.
+func reportJSON(
+	outputCfg *config.OutputConfig,
+	eventType hook.EventType,
+	errs []*dispatcher.ValidationError,
+	log logger.Logger,
+) int {
+	resp := dispatcher.BuildResponse(eventType, errs, dispatcher.ResponseOptions{
+		SuppressOutput: outputCfg.IsSuppressOutput(),
+		StopOnBlock:    outputCfg.IsStopOnBlock(),
+	})
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		// Fall back to text output so a blocking result is never lost
+		log.Error("failed to marshal hook response", "error", err)
+
+		return reportResults(&config.OutputConfig{Format: config.OutputFormatText}, eventType, errs, log)
+	}
+
+	fmt.Fprintln(os.Stdout, string(data))
+
+	switch {
+	case dispatcher.ShouldBlock(errs):
+		log.Error("validation blocked", "errorCount", len(errs), "output", config.OutputFormatJSON)
+	case dispatcher.ShouldAsk(errs):
+		log.Info("validation requires confirmation", "errorCount", len(errs))
+	case len(errs) > 0:
+		log.Info("validation passed with warnings", "warningCount", len(errs))
+	default:
+		log.Info("validation passed")
+	}
+
+	return ExitCodeAllow
 }
 
 // loadConfig loads configuration from all sources with precedence.
@@ -237,7 +344,10 @@ func loadConfig(log logger.Logger) (*config.Config, error) {
 	flags := buildFlagsMap()
 
 	// Create koanf loader
-	loader, err := internalconfig.NewKoanfLoader()
+	loader, err := internalconfig.NewKoanfLoader(
+		internalconfig.WithVersion(version),
+		internalconfig.WithLoaderLogger(log),
+	)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create config loader")
 	}
@@ -278,6 +388,168 @@ func initSessionTracker(cfg *config.Config, log logger.Logger) *session.Tracker
 	return tracker
 }
 
+// initRuleAuditLog attaches the rules audit logger to the rule engine, so log
+// rules are only recorded for real hook invocations.
+func initRuleAuditLog(cfg *config.Config, engine *rules.RuleEngine, log logger.Logger) {
+	if engine == nil {
+		return
+	}
+
+	engine.SetAuditLogger(rules.NewAuditLogger(
+		cfg.Rules.GetAuditLogFile(),
+		rules.WithAuditLoggerLogger(log),
+	))
+}
+
+// initRuleStats creates a rule statistics recorder for the rule engine if
+// enabled in the config.
+func initRuleStats(cfg *config.Config, engine *rules.RuleEngine, log logger.Logger) *rules.StatsRecorder {
+	if engine == nil || !cfg.Rules.IsStatsEnabled() {
+		return nil
+	}
+
+	recorder := rules.NewStatsRecorder(
+		cfg.GetRuleStatsFile(),
+		rules.WithStatsRecorderLogger(log),
+	)
+
+	engine.SetStatsRecorder(recorder)
+
+	return recorder
+}
+
+// ruleSessionProvider returns a rules.SessionProvider backed by the session
+// tracker. Sessions the tracker has not seen yet have no recorded commands.
+func ruleSessionProvider(tracker *session.Tracker) rules.SessionProvider {
+	return func(sessionID string) *rules.SessionContext {
+		sessionCtx := &rules.SessionContext{ID: sessionID}
+
+		if info := tracker.GetInfo(sessionID); info != nil {
+			sessionCtx.CommandCount = info.CommandCount
+		}
+
+		return sessionCtx
+	}
+}
+
+// newExecutor creates the validator executor selected by the global configuration.
+//
+//nolint:ireturn // executor implementation depends on configuration
+func newExecutor(cfg *config.Config, log logger.Logger) dispatcher.Executor {
+	globalCfg := cfg.GetGlobal()
+	if !globalCfg.IsParallelExecutionEnabled() {
+		log.Debug("using sequential executor")
+
+		return dispatcher.NewSequentialExecutor(log)
+	}
+
+	parallelCfg := dispatcher.DefaultParallelConfig()
+
+	if globalCfg.MaxCPUWorkers != nil && *globalCfg.MaxCPUWorkers > 0 {
+		parallelCfg.MaxCPUWorkers = *globalCfg.MaxCPUWorkers
+	}
+
+	if globalCfg.MaxIOWorkers != nil && *globalCfg.MaxIOWorkers > 0 {
+		parallelCfg.MaxIOWorkers = *globalCfg.MaxIOWorkers
+	}
+
+	if globalCfg.MaxGitWorkers != nil && *globalCfg.MaxGitWorkers > 0 {
+		parallelCfg.MaxGitWorkers = *globalCfg.MaxGitWorkers
+	}
+
+	log.Info("using parallel executor",
+		"max_cpu_workers", parallelCfg.MaxCPUWorkers,
+		"max_io_workers", parallelCfg.MaxIOWorkers,
+		"max_git_workers", parallelCfg.MaxGitWorkers,
+	)
+
+	return dispatcher.NewParallelExecutor(log, parallelCfg)
+}
+
+// initExceptionHandler creates the exception handler if exceptions are enabled.
+func initExceptionHandler(cfg *config.Config, log logger.Logger) *exceptions.Handler {
+	exceptionsCfg := cfg.GetExceptions()
+	if !exceptionsCfg.IsEnabled() {
+		return nil
+	}
+
+	handler := exceptions.NewHandler(exceptionsCfg, exceptions.WithHandlerLogger(log))
+
+	// Load existing rate limit state
+	if err := handler.LoadState(); err != nil {
+		log.Info("failed to load exception state, starting fresh", "error", err)
+	}
+
+	return handler
+}
+
+// initMessageOverrides creates the message overrides of validation errors
+// if any are configured.
+func initMessageOverrides(cfg *config.Config, log logger.Logger) *dispatcher.MessageOverrides {
+	if len(cfg.Messages) == 0 {
+		return nil
+	}
+
+	gitProvider := factory.NewGitProvider()
+
+	overrides, err := dispatcher.NewMessageOverrides(
+		cfg.Messages,
+		dispatcher.WithMessageOverridesLogger(log),
+		dispatcher.WithMessageDataFunc(func(hookCtx *hook.Context) *templates.ContextData {
+			return rules.TemplateData(hookCtx, gitProvider)
+		}),
+	)
+	if err != nil {
+		log.Error("failed to compile message overrides", "error", err)
+
+		return nil
+	}
+
+	return overrides
+}
+
+// dispatcherOptions builds the dispatcher options for the optional components.
+func dispatcherOptions(
+	cfg *config.Config,
+	log logger.Logger,
+	sessionTracker *session.Tracker,
+	exceptionHandler *exceptions.Handler,
+) []dispatcher.DispatcherOption {
+	opts := []dispatcher.DispatcherOption{
+		dispatcher.WithExceptionGuard(
+			dispatcher.NewExceptionGuard(cfg.GetExceptions(), log),
+		),
+	}
+
+	if exceptionHandler != nil {
+		opts = append(opts, dispatcher.WithExceptionChecker(
+			dispatcher.NewExceptionChecker(
+				exceptionHandler,
+				dispatcher.WithExceptionCheckerLogger(log),
+			),
+		))
+	}
+
+	if overrides := initMessageOverrides(cfg, log); overrides != nil {
+		opts = append(opts, dispatcher.WithMessageOverrides(overrides))
+	}
+
+	if sessionTracker == nil {
+		return opts
+	}
+
+	opts = append(opts, dispatcher.WithSessionTracker(sessionTracker))
+
+	auditCfg := cfg.GetSession().GetAudit()
+	if auditCfg.IsAuditEnabled() {
+		opts = append(opts, dispatcher.WithSessionAuditLogger(
+			session.NewAuditLogger(auditCfg, session.WithAuditLoggerLogger(log)),
+		))
+	}
+
+	return opts
+}
+
 // buildFlagsMap converts CLI flags to a map for the config provider.
 func buildFlagsMap() map[string]any {
 	flags := make(map[string]any)
@@ -294,6 +566,10 @@ func buildFlagsMap() map[string]any {
 		flags["disable"] = disableList
 	}
 
+	if outputFormat != "" {
+		flags["output-format"] = outputFormat
+	}
+
 	return flags
 }
 
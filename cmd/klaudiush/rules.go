@@ -0,0 +1,368 @@
+// Package main provides the CLI entry point for klaudiush.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/smykla-labs/klaudiush/internal/config/factory"
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// ErrRuleTestsFailed is returned when at least one rule test does not meet its expectations.
+var ErrRuleTestsFailed = errors.New("rule tests failed")
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Work with validation rules",
+	Long: `Work with validation rules.
+
+Subcommands:
+  test        Run the tests embedded in rule configuration
+  stats       Show rule hit statistics and flag dead and shadowed rules`,
+}
+
+var rulesTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Run rule tests",
+	Long: `Run the test cases embedded in rule configuration.
+
+Each rule may define [[rules.rules.tests]] entries with an input and the
+expected outcome. The input is evaluated against all rules, like a hook
+invocation, and the test checks whether the rule matched and, optionally,
+the resulting action:
+
+  [[rules.rules]]
+  name = "block-main-push"
+  [rules.rules.match]
+  validator_type = "git.push"
+  branch_pattern = "main"
+  [rules.rules.action]
+  type = "block"
+
+  [[rules.rules.tests]]
+  name = "push to main"
+  validator_type = "git.push"
+  command = "git push origin main"
+  branch = "main"
+  matched = true
+  action = "block"
+
+  [[rules.rules.tests]]
+  name = "push to feature branch"
+  validator_type = "git.push"
+  branch = "feat/x"
+  matched = false
+
+Test inputs: validator_type, tool_type, event_type, command, file_path,
+content, branch and remote. Git data is limited to the branch and remote of
+the input, so tests do not depend on the repository they run in.
+
+Exits with a non-zero status if any test fails.
+
+Examples:
+  klaudiush rules test             # Run all rule tests
+  klaudiush rules test --verbose   # Show the deciding rule for every test`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runRulesTest,
+}
+
+var rulesStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show rule hit statistics",
+	Long: `Show how often each enabled rule matched, when it last matched and the
+action taken, as recorded by hook invocations.
+
+Rules are flagged as:
+  dead       No match within --days (or, never matching, tracked for longer)
+  shadowed   Only matched when a higher-priority rule decided first
+
+Statistics are stored in rule_stats.json next to the session state file.
+Set rules.stats = false to stop recording them.
+
+Examples:
+  klaudiush rules stats             # Flag rules without a match in 30 days
+  klaudiush rules stats --days 7    # Flag rules without a match in 7 days`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runRulesStats,
+}
+
+// Rules command flags.
+var (
+	rulesTestVerbose bool
+	rulesStatsDays   int
+)
+
+// defaultDeadRuleDays is the default number of days without a match after
+// which a rule is flagged as dead.
+const defaultDeadRuleDays = 30
+
+func init() {
+	rootCmd.AddCommand(rulesCmd)
+	rulesCmd.AddCommand(rulesTestCmd)
+	rulesCmd.AddCommand(rulesStatsCmd)
+
+	rulesCmd.PersistentFlags().StringVarP(
+		&configPath,
+		"config",
+		"c",
+		"",
+		"Path to project configuration file (default: .klaudiush/config.toml or klaudiush.toml)",
+	)
+
+	rulesCmd.PersistentFlags().StringVar(
+		&globalConfig,
+		"global-config",
+		"",
+		"Path to global configuration file (default: ~/.klaudiush/config.toml)",
+	)
+
+	rulesTestCmd.Flags().BoolVarP(
+		&rulesTestVerbose,
+		"verbose",
+		"v",
+		false,
+		"Show the deciding rule for passing tests",
+	)
+
+	rulesStatsCmd.Flags().IntVar(
+		&rulesStatsDays,
+		"days",
+		defaultDeadRuleDays,
+		"Flag rules that have not matched in this many days as dead",
+	)
+}
+
+func runRulesTest(cmd *cobra.Command, _ []string) error {
+	log, err := newCommandLogger("rules test command invoked")
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(log)
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	engine, err := factory.NewRulesFactory(log).CreateRuleEngine(cfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to create rule engine")
+	}
+
+	out := cmd.OutOrStdout()
+
+	var results []*rules.RuleTestResult
+	if engine != nil {
+		results = engine.RunTests()
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintln(out, "No rule tests defined.")
+
+		return nil
+	}
+
+	failed := printRuleTestResults(out, results)
+	if failed > 0 {
+		return errors.Mark(
+			errors.Newf("%d of %d rule tests failed", failed, len(results)),
+			ErrRuleTestsFailed,
+		)
+	}
+
+	return nil
+}
+
+// newCommandLogger creates the dispatcher file logger and logs the invocation.
+func newCommandLogger(msg string) (logger.Logger, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get home directory")
+	}
+
+	logFile := filepath.Join(homeDir, ".claude", "hooks", "dispatcher.log")
+
+	log, err := logger.NewFileLogger(logFile, false, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create logger")
+	}
+
+	log.Info(msg)
+
+	return log, nil
+}
+
+// printRuleTestResults prints a pass/fail table and returns the number of failed tests.
+func printRuleTestResults(out io.Writer, results []*rules.RuleTestResult) int {
+	fmt.Fprintln(out, "Rule Tests")
+	fmt.Fprintln(out, "==========")
+	fmt.Fprintln(out, "")
+
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "STATUS\tRULE\tTEST\tEXPECTED\tACTUAL")
+
+	failed := 0
+
+	for _, result := range results {
+		status := "✅ PASS"
+		if !result.Passed() {
+			status = "❌ FAIL"
+			failed++
+		}
+
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n",
+			status,
+			result.Rule.Name,
+			result.Test.DisplayName(),
+			describeRuleTestOutcome(result.Test.Matched, result.Test.Action),
+			describeRuleTestOutcome(result.Matched, result.Action),
+		)
+	}
+
+	_ = table.Flush()
+
+	for _, result := range results {
+		if result.Passed() && !rulesTestVerbose {
+			continue
+		}
+
+		decidedBy := result.DecidedBy
+		if decidedBy == "" {
+			decidedBy = "no rule"
+		}
+
+		fmt.Fprintf(out, "\n%s (%s)\n", result.Rule.Name, result.Test.DisplayName())
+		fmt.Fprintf(out, "  decided by: %s\n", decidedBy)
+	}
+
+	fmt.Fprintf(out, "\n%d passed, %d failed\n", len(results)-failed, failed)
+
+	return failed
+}
+
+// describeRuleTestOutcome formats a test outcome as "matched, block" or
+// "not matched". The action is omitted when empty.
+func describeRuleTestOutcome(matched bool, action rules.ActionType) string {
+	outcome := "not matched"
+	if matched {
+		outcome = "matched"
+	}
+
+	if action != "" {
+		outcome += ", " + string(action)
+	}
+
+	return outcome
+}
+
+func runRulesStats(cmd *cobra.Command, _ []string) error {
+	if rulesStatsDays <= 0 {
+		return errors.Newf("--days must be positive, got %d", rulesStatsDays)
+	}
+
+	log, err := newCommandLogger("rules stats command invoked")
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(log)
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	engine, err := factory.NewRulesFactory(log).CreateRuleEngine(cfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to create rule engine")
+	}
+
+	out := cmd.OutOrStdout()
+
+	if engine == nil {
+		fmt.Fprintln(out, "No rules configured.")
+
+		return nil
+	}
+
+	recorder := rules.NewStatsRecorder(cfg.GetRuleStatsFile(), rules.WithStatsRecorderLogger(log))
+
+	state, err := recorder.Load()
+	if err != nil {
+		return errors.Wrap(err, "failed to load rule statistics")
+	}
+
+	printRuleStats(out, engine.GetEnabledRules(), state, time.Now())
+
+	return nil
+}
+
+// printRuleStats prints the statistics of the enabled rules with their status.
+func printRuleStats(out io.Writer, enabled []*rules.Rule, state *rules.StatsState, now time.Time) {
+	deadAfter := time.Duration(rulesStatsDays) * 24 * time.Hour
+
+	fmt.Fprintln(out, "Rule Statistics")
+	fmt.Fprintln(out, "===============")
+	fmt.Fprintln(out, "")
+
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "RULE\tPRIORITY\tMATCHES\tLAST MATCH\tLAST ACTION\tSTATUS")
+
+	counts := make(map[rules.RuleStatus]int)
+
+	for _, rule := range enabled {
+		stats := state.Rules[rule.Name]
+		status := stats.Status(now, deadAfter)
+		counts[status]++
+
+		matches, lastMatch, lastAction := "-", "never", "-"
+
+		if stats != nil {
+			matches = strconv.Itoa(stats.Matches)
+
+			if !stats.LastMatch.IsZero() {
+				lastMatch = stats.LastMatch.Local().Format(time.DateTime)
+				lastAction = string(stats.LastAction)
+			}
+		}
+
+		fmt.Fprintf(table, "%s\t%d\t%s\t%s\t%s\t%s\n",
+			rule.Name,
+			rule.Priority,
+			matches,
+			lastMatch,
+			lastAction,
+			describeRuleStatus(status, stats),
+		)
+	}
+
+	_ = table.Flush()
+
+	fmt.Fprintf(out, "\n%d rules: %d active, %d dead (no match in %d days), %d shadowed, %d untracked\n",
+		len(enabled),
+		counts[rules.RuleStatusActive],
+		counts[rules.RuleStatusDead],
+		rulesStatsDays,
+		counts[rules.RuleStatusShadowed],
+		counts[rules.RuleStatusUntracked],
+	)
+}
+
+// describeRuleStatus formats a rule status, naming the shadowing rule of
+// shadowed rules.
+func describeRuleStatus(status rules.RuleStatus, stats *rules.RuleStats) string {
+	if status == rules.RuleStatusShadowed {
+		return "shadowed by " + stats.TopShadower()
+	}
+
+	return string(status)
+}
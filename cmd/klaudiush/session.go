@@ -0,0 +1,846 @@
+// Package main provides the CLI entry point for klaudiush.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/smykla-labs/klaudiush/internal/exceptions"
+	"github.com/smykla-labs/klaudiush/internal/session"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// sessionCLISource is the audit source of session changes made with the CLI.
+const sessionCLISource = "cli"
+
+// sessionEventException is the type of exception events in the audit
+// timeline. Session events are typed by their lowercase audit action.
+const sessionEventException = "exception"
+
+// ErrSessionNotFound is returned when a session is neither tracked nor audited.
+var ErrSessionNotFound = errors.New("session not found")
+
+var sessionCmd = &cobra.Command{
+	Use:   "session",
+	Short: "Inspect and manage tracked sessions",
+	Long: `Inspect and manage the Claude Code sessions tracked by klaudiush.
+
+Sessions are poisoned when a command is blocked and fast-fail until the
+blocking codes are acknowledged. These commands work on the session state
+file and audit logs, whether or not session tracking is enabled.
+
+Subcommands:
+  list      List tracked sessions
+  show      Show a session with its audit timeline
+  poison    Poison a session with error codes
+  unpoison  Clear the poisoned state of a session
+  prune     Remove inactive sessions from the state file
+  audit     Show session and exception audit entries`,
+}
+
+var sessionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tracked sessions",
+	Long: `List tracked sessions, most recently active first.
+
+Examples:
+  klaudiush session list                      # List all sessions
+  klaudiush session list --status poisoned    # Only poisoned sessions
+  klaudiush session list --newer-than 1h      # Active within the last hour
+  klaudiush session list --older-than 12h     # Inactive for over 12 hours
+  klaudiush session list --json               # Output as JSON`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runSessionList,
+}
+
+var sessionShowCmd = &cobra.Command{
+	Use:   "show <session-id>",
+	Short: "Show a session with its audit timeline",
+	Long: `Show the state of a session together with its audit timeline: poison and
+unpoison entries of the session audit log joined with the exception audit
+entries of the session.
+
+Examples:
+  klaudiush session show abc-123
+  klaudiush session show abc-123 --json`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runSessionShow,
+}
+
+var sessionPoisonCmd = &cobra.Command{
+	Use:   "poison <session-id>",
+	Short: "Poison a session with error codes",
+	Long: `Poison a session, so its commands are blocked until the given codes are
+acknowledged with an unpoison token.
+
+Examples:
+  klaudiush session poison abc-123 --code GIT001
+  klaudiush session poison abc-123 --code GIT001 --code SEC001 --message "Manual review"`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runSessionPoison,
+}
+
+var sessionUnpoisonCmd = &cobra.Command{
+	Use:   "unpoison <session-id>",
+	Short: "Clear the poisoned state of a session",
+	Long: `Clear the poisoned state of a session without acknowledging its codes
+from within the session.
+
+Examples:
+  klaudiush session unpoison abc-123`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runSessionUnpoison,
+}
+
+var sessionPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove inactive sessions",
+	Long: `Remove sessions from the state file that have been inactive for longer than
+--older-than (default: session.max_session_age).
+
+Examples:
+  klaudiush session prune                   # Remove expired sessions
+  klaudiush session prune --older-than 1h   # Remove sessions inactive for an hour`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runSessionPrune,
+}
+
+var sessionAuditCmd = &cobra.Command{
+	Use:   "audit [session-id]",
+	Short: "Show session and exception audit entries",
+	Long: `Show poison and unpoison entries of the session audit log joined with the
+exception audit entries recorded for sessions, newest first.
+
+Examples:
+  klaudiush session audit                 # All sessions
+  klaudiush session audit abc-123         # A single session
+  klaudiush session audit --limit 20      # Last 20 entries
+  klaudiush session audit --json          # Output as JSON`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runSessionAudit,
+}
+
+// Session command flags.
+var (
+	sessionJSON       bool
+	sessionStatus     string
+	sessionOlderThan  time.Duration
+	sessionNewerThan  time.Duration
+	sessionCodes      []string
+	sessionMessage    string
+	sessionAuditLimit int
+)
+
+func init() {
+	rootCmd.AddCommand(sessionCmd)
+	sessionCmd.AddCommand(sessionListCmd)
+	sessionCmd.AddCommand(sessionShowCmd)
+	sessionCmd.AddCommand(sessionPoisonCmd)
+	sessionCmd.AddCommand(sessionUnpoisonCmd)
+	sessionCmd.AddCommand(sessionPruneCmd)
+	sessionCmd.AddCommand(sessionAuditCmd)
+
+	for _, cmd := range []*cobra.Command{sessionListCmd, sessionShowCmd, sessionAuditCmd} {
+		cmd.Flags().BoolVar(&sessionJSON, "json", false, "Output as JSON")
+	}
+
+	sessionListCmd.Flags().StringVar(
+		&sessionStatus,
+		"status",
+		"",
+		"Filter sessions by status (clean, poisoned)",
+	)
+
+	sessionListCmd.Flags().DurationVar(
+		&sessionOlderThan,
+		"older-than",
+		0,
+		"Only sessions inactive for longer than this duration (e.g., 12h)",
+	)
+
+	sessionListCmd.Flags().DurationVar(
+		&sessionNewerThan,
+		"newer-than",
+		0,
+		"Only sessions active within this duration (e.g., 30m)",
+	)
+
+	sessionPoisonCmd.Flags().StringSliceVar(
+		&sessionCodes,
+		"code",
+		nil,
+		"Error code that must be acknowledged (repeatable)",
+	)
+
+	sessionPoisonCmd.Flags().StringVar(
+		&sessionMessage,
+		"message",
+		"",
+		"Message shown for blocked commands (default: poisoned via CLI)",
+	)
+
+	_ = sessionPoisonCmd.MarkFlagRequired("code")
+
+	sessionPruneCmd.Flags().DurationVar(
+		&sessionOlderThan,
+		"older-than",
+		0,
+		"Remove sessions inactive for longer than this duration (default: max_session_age)",
+	)
+
+	sessionAuditCmd.Flags().IntVar(
+		&sessionAuditLimit,
+		"limit",
+		0,
+		"Limit number of entries to show (0 = all)",
+	)
+}
+
+// sessionEnv holds the configuration and logger of session commands.
+type sessionEnv struct {
+	cfg *config.Config
+	log logger.Logger
+}
+
+// newSessionEnv creates the logger and loads the configuration.
+func newSessionEnv(msg string) (*sessionEnv, error) {
+	log, err := newCommandLogger(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := loadConfig(log)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load configuration")
+	}
+
+	return &sessionEnv{cfg: cfg, log: log}, nil
+}
+
+// tracker creates a session tracker on the configured state file and loads
+// the state. Additional options override the configured ones.
+func (e *sessionEnv) tracker(opts ...session.TrackerOption) (*session.Tracker, error) {
+	opts = append([]session.TrackerOption{session.WithLogger(e.log)}, opts...)
+
+	tracker := session.NewTracker(e.cfg.GetSession(), opts...)
+	if err := tracker.Load(); err != nil {
+		return nil, errors.Wrap(err, "failed to load session state")
+	}
+
+	return tracker, nil
+}
+
+// auditLogger creates the session audit logger.
+func (e *sessionEnv) auditLogger() *session.AuditLogger {
+	return session.NewAuditLogger(
+		e.cfg.GetSession().GetAudit(),
+		session.WithAuditLoggerLogger(e.log),
+	)
+}
+
+// timeline reads the session and exception audit entries of sessionID (all
+// sessions if empty), newest first.
+func (e *sessionEnv) timeline(sessionID string) ([]*sessionEvent, error) {
+	sessionEntries, err := e.auditLogger().Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading session audit log")
+	}
+
+	var exceptionAuditCfg *config.ExceptionAuditConfig
+	if exc := e.cfg.GetExceptions(); exc != nil {
+		exceptionAuditCfg = exc.Audit
+	}
+
+	exceptionEntries, err := exceptions.NewAuditLogger(
+		exceptionAuditCfg,
+		exceptions.WithAuditLoggerLogger(e.log),
+	).Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading exception audit log")
+	}
+
+	return joinSessionEvents(sessionID, sessionEntries, exceptionEntries), nil
+}
+
+// sessionEvent is an entry of the session audit timeline. Exactly one of
+// Session and Exception is set.
+type sessionEvent struct {
+	Timestamp time.Time              `json:"timestamp"`
+	Type      string                 `json:"type"`
+	SessionID string                 `json:"session_id"`
+	Session   *session.AuditEntry    `json:"session,omitempty"`
+	Exception *exceptions.AuditEntry `json:"exception,omitempty"`
+}
+
+// joinSessionEvents merges session audit entries with the exception audit
+// entries recorded for sessions, newest first. Only entries of sessionID are
+// kept unless it is empty. Exception entries without a session are skipped.
+func joinSessionEvents(
+	sessionID string,
+	sessionEntries []*session.AuditEntry,
+	exceptionEntries []*exceptions.AuditEntry,
+) []*sessionEvent {
+	events := make([]*sessionEvent, 0, len(sessionEntries)+len(exceptionEntries))
+
+	for _, entry := range sessionEntries {
+		if sessionID != "" && entry.SessionID != sessionID {
+			continue
+		}
+
+		events = append(events, &sessionEvent{
+			Timestamp: entry.Timestamp,
+			Type:      strings.ToLower(entry.Action.String()),
+			SessionID: entry.SessionID,
+			Session:   entry,
+		})
+	}
+
+	for _, entry := range exceptionEntries {
+		if entry.SessionID == "" || (sessionID != "" && entry.SessionID != sessionID) {
+			continue
+		}
+
+		events = append(events, &sessionEvent{
+			Timestamp: entry.Timestamp,
+			Type:      sessionEventException,
+			SessionID: entry.SessionID,
+			Exception: entry,
+		})
+	}
+
+	slices.SortStableFunc(events, func(a, b *sessionEvent) int {
+		return b.Timestamp.Compare(a.Timestamp)
+	})
+
+	return events
+}
+
+func runSessionList(cmd *cobra.Command, _ []string) error {
+	var statusFilter *session.Status
+
+	if sessionStatus != "" {
+		status, err := session.StatusString(sessionStatus)
+		if err != nil {
+			return errors.Newf(
+				"invalid --status %q, expected one of: %s",
+				sessionStatus,
+				strings.ToLower(strings.Join(session.StatusStrings(), ", ")),
+			)
+		}
+
+		statusFilter = &status
+	}
+
+	env, err := newSessionEnv("session list command invoked")
+	if err != nil {
+		return err
+	}
+
+	tracker, err := env.tracker()
+	if err != nil {
+		return err
+	}
+
+	sessions := filterSessions(tracker.GetState().Sessions, statusFilter, time.Now())
+
+	if sessionJSON {
+		return writeSessionJSON(cmd.OutOrStdout(), sessions)
+	}
+
+	printSessions(cmd.OutOrStdout(), sessions, time.Now())
+
+	return nil
+}
+
+// filterSessions returns the sessions matching the status and activity
+// filters, most recently active first.
+func filterSessions(
+	sessions map[string]*session.SessionInfo,
+	status *session.Status,
+	now time.Time,
+) []*session.SessionInfo {
+	filtered := make([]*session.SessionInfo, 0, len(sessions))
+
+	for _, info := range sessions {
+		if status != nil && info.Status != *status {
+			continue
+		}
+
+		inactive := now.Sub(info.LastActivity)
+
+		if sessionOlderThan > 0 && inactive <= sessionOlderThan {
+			continue
+		}
+
+		if sessionNewerThan > 0 && inactive > sessionNewerThan {
+			continue
+		}
+
+		filtered = append(filtered, info)
+	}
+
+	slices.SortFunc(filtered, func(a, b *session.SessionInfo) int {
+		if c := b.LastActivity.Compare(a.LastActivity); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.SessionID, b.SessionID)
+	})
+
+	return filtered
+}
+
+// printSessions prints a table of sessions.
+func printSessions(out io.Writer, sessions []*session.SessionInfo, now time.Time) {
+	if len(sessions) == 0 {
+		fmt.Fprintln(out, "No sessions found.")
+
+		return
+	}
+
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "SESSION\tSTATUS\tCOMMANDS\tLAST ACTIVITY\tCODES")
+
+	poisoned, warned := 0, 0
+
+	for _, info := range sessions {
+		codes := "-"
+
+		switch {
+		case info.IsPoisoned():
+			poisoned++
+			codes = strings.Join(info.PoisonCodes, ",")
+		case info.Status == session.StatusWarned:
+			warned++
+		}
+
+		fmt.Fprintf(table, "%s\t%s\t%d\t%s\t%s\n",
+			info.SessionID,
+			describeSessionStatus(info.Status),
+			info.CommandCount,
+			describeLastActivity(info.LastActivity, now),
+			codes,
+		)
+	}
+
+	_ = table.Flush()
+
+	summary := fmt.Sprintf("%d poisoned", poisoned)
+	if warned > 0 {
+		summary += fmt.Sprintf(", %d warned", warned)
+	}
+
+	fmt.Fprintf(out, "\n%d sessions: %s, %d clean\n",
+		len(sessions),
+		summary,
+		len(sessions)-poisoned-warned,
+	)
+}
+
+func runSessionShow(cmd *cobra.Command, args []string) error {
+	sessionID := args[0]
+
+	env, err := newSessionEnv("session show command invoked")
+	if err != nil {
+		return err
+	}
+
+	tracker, err := env.tracker()
+	if err != nil {
+		return err
+	}
+
+	info := tracker.GetInfo(sessionID)
+
+	events, err := env.timeline(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if info == nil && len(events) == 0 {
+		return errors.Wrap(ErrSessionNotFound, sessionID)
+	}
+
+	out := cmd.OutOrStdout()
+
+	if sessionJSON {
+		return writeSessionJSON(out, struct {
+			Session *session.SessionInfo `json:"session"`
+			Events  []*sessionEvent      `json:"events"`
+		}{Session: info, Events: events})
+	}
+
+	fmt.Fprintf(out, "Session: %s\n", sessionID)
+
+	if info == nil {
+		fmt.Fprintln(out, "Status: not tracked (expired or pruned)")
+	} else {
+		now := time.Now()
+
+		fmt.Fprintf(out, "Status: %s\n", describeSessionStatus(info.Status))
+		fmt.Fprintf(out, "Commands: %d\n", info.CommandCount)
+		fmt.Fprintf(out, "Last Activity: %s\n", describeLastActivity(info.LastActivity, now))
+
+		if info.IsPoisoned() {
+			fmt.Fprintf(out, "Poison Codes: %s\n", strings.Join(info.PoisonCodes, ", "))
+
+			if info.PoisonedAt != nil {
+				fmt.Fprintf(out, "Poisoned At: %s\n", info.PoisonedAt.Local().Format(time.DateTime))
+			}
+
+			if info.PoisonMessage != "" {
+				fmt.Fprintf(out, "Message: %s\n", info.PoisonMessage)
+			}
+
+			if info.PoisonExpiresAt != nil {
+				fmt.Fprintf(out, "Poison Expires: %s\n", info.PoisonExpiresAt.Local().Format(time.DateTime))
+			}
+		}
+
+		if len(info.BlockedAttempts) > 0 {
+			fmt.Fprintf(out, "Blocked Attempts: %s\n", describeBlockedAttempts(info.BlockedAttempts))
+		}
+	}
+
+	fmt.Fprintln(out, "")
+	printSessionEvents(out, events, false)
+
+	return nil
+}
+
+func runSessionPoison(cmd *cobra.Command, args []string) error {
+	sessionID := args[0]
+
+	codes := make([]string, 0, len(sessionCodes))
+
+	for _, code := range sessionCodes {
+		if code = strings.ToUpper(strings.TrimSpace(code)); code != "" {
+			codes = append(codes, code)
+		}
+	}
+
+	if len(codes) == 0 {
+		return errors.New("at least one --code is required")
+	}
+
+	message := sessionMessage
+	if message == "" {
+		message = "poisoned via CLI"
+	}
+
+	env, err := newSessionEnv("session poison command invoked")
+	if err != nil {
+		return err
+	}
+
+	tracker, err := env.tracker()
+	if err != nil {
+		return err
+	}
+
+	previous := session.StatusClean
+	if info := tracker.GetInfo(sessionID); info != nil {
+		previous = info.Status
+	}
+
+	tracker.Poison(sessionID, codes, message)
+
+	if err := tracker.Save(); err != nil {
+		return errors.Wrap(err, "failed to save session state")
+	}
+
+	env.logAudit(&session.AuditEntry{
+		Action:         session.AuditActionPoison,
+		SessionID:      sessionID,
+		PoisonCodes:    codes,
+		Source:         sessionCLISource,
+		PoisonMessage:  message,
+		PreviousStatus: previous,
+		Status:         session.StatusPoisoned,
+	})
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✅ Session %s poisoned with %s\n", sessionID, strings.Join(codes, ", "))
+
+	return nil
+}
+
+func runSessionUnpoison(cmd *cobra.Command, args []string) error {
+	sessionID := args[0]
+
+	env, err := newSessionEnv("session unpoison command invoked")
+	if err != nil {
+		return err
+	}
+
+	tracker, err := env.tracker()
+	if err != nil {
+		return err
+	}
+
+	info := tracker.GetInfo(sessionID)
+	if info == nil {
+		return errors.Wrap(ErrSessionNotFound, sessionID)
+	}
+
+	out := cmd.OutOrStdout()
+
+	if !info.IsPoisoned() {
+		fmt.Fprintf(out, "Session %s is not poisoned.\n", sessionID)
+
+		return nil
+	}
+
+	tracker.Unpoison(sessionID)
+
+	if err := tracker.Save(); err != nil {
+		return errors.Wrap(err, "failed to save session state")
+	}
+
+	env.logAudit(&session.AuditEntry{
+		Action:         session.AuditActionUnpoison,
+		SessionID:      sessionID,
+		PoisonCodes:    info.PoisonCodes,
+		Source:         sessionCLISource,
+		PreviousStatus: info.Status,
+		Status:         session.StatusClean,
+	})
+
+	fmt.Fprintf(out, "✅ Session %s unpoisoned\n", sessionID)
+
+	return nil
+}
+
+// logAudit logs a session change made with the CLI. Failures are logged but
+// do not fail the command, as the change is already saved.
+func (e *sessionEnv) logAudit(entry *session.AuditEntry) {
+	entry.Timestamp = time.Now()
+
+	if wd, err := os.Getwd(); err == nil {
+		entry.WorkingDir = wd
+	}
+
+	if err := e.auditLogger().Log(entry); err != nil {
+		e.log.Error("failed to log session audit entry", "error", err)
+	}
+}
+
+func runSessionPrune(cmd *cobra.Command, _ []string) error {
+	if sessionOlderThan < 0 {
+		return errors.Newf("--older-than must be positive, got %s", sessionOlderThan)
+	}
+
+	env, err := newSessionEnv("session prune command invoked")
+	if err != nil {
+		return err
+	}
+
+	maxAge := sessionOlderThan
+	if maxAge == 0 {
+		maxAge = env.cfg.GetSession().GetMaxSessionAge()
+	}
+
+	// Count all stored sessions, as loading drops the expired ones
+	stored, err := env.tracker(session.WithMaxSessionAge(math.MaxInt64))
+	if err != nil {
+		return err
+	}
+
+	before := len(stored.GetState().Sessions)
+
+	tracker, err := env.tracker(session.WithMaxSessionAge(maxAge))
+	if err != nil {
+		return err
+	}
+
+	// Saving rewrites the state file without the sessions dropped on load
+	if err := tracker.Save(); err != nil {
+		return errors.Wrap(err, "failed to save session state")
+	}
+
+	after := len(tracker.GetState().Sessions)
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✅ Removed %d sessions inactive for over %s (%d remaining)\n",
+		max(0, before-after),
+		maxAge,
+		after,
+	)
+
+	return nil
+}
+
+func runSessionAudit(cmd *cobra.Command, args []string) error {
+	var sessionID string
+	if len(args) > 0 {
+		sessionID = args[0]
+	}
+
+	env, err := newSessionEnv("session audit command invoked")
+	if err != nil {
+		return err
+	}
+
+	events, err := env.timeline(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if sessionAuditLimit > 0 && len(events) > sessionAuditLimit {
+		events = events[:sessionAuditLimit]
+	}
+
+	if sessionJSON {
+		return writeSessionJSON(cmd.OutOrStdout(), events)
+	}
+
+	printSessionEvents(cmd.OutOrStdout(), events, sessionID == "")
+
+	return nil
+}
+
+// printSessionEvents prints the audit timeline, with the session of each
+// entry if withSession is set.
+func printSessionEvents(out io.Writer, events []*sessionEvent, withSession bool) {
+	if len(events) == 0 {
+		fmt.Fprintln(out, "No audit entries found.")
+
+		return
+	}
+
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	if withSession {
+		fmt.Fprintln(table, "TIME\tSESSION\tEVENT\tCODES\tDETAILS")
+	} else {
+		fmt.Fprintln(table, "TIME\tEVENT\tCODES\tDETAILS")
+	}
+
+	for _, event := range events {
+		codes, details := describeSessionEvent(event)
+
+		row := []string{event.Timestamp.Local().Format(time.DateTime)}
+		if withSession {
+			row = append(row, event.SessionID)
+		}
+
+		row = append(row, event.Type, codes, details)
+
+		fmt.Fprintln(table, strings.Join(row, "\t"))
+	}
+
+	_ = table.Flush()
+}
+
+// describeSessionEvent returns the codes and details of an audit event.
+func describeSessionEvent(event *sessionEvent) (string, string) {
+	var codes, details []string
+
+	switch {
+	case event.Session != nil:
+		codes = event.Session.PoisonCodes
+
+		if event.Session.PoisonMessage != "" {
+			details = append(details, event.Session.PoisonMessage)
+		}
+
+		if event.Session.Source != "" {
+			details = append(details, "source: "+event.Session.Source)
+		}
+
+		if event.Session.PreviousStatus != event.Session.Status {
+			details = append(details, fmt.Sprintf("%s → %s",
+				strings.ToLower(event.Session.PreviousStatus.String()),
+				strings.ToLower(event.Session.Status.String())))
+		}
+
+		if event.Session.Attempts > 0 {
+			details = append(details, fmt.Sprintf("attempts: %d", event.Session.Attempts))
+		}
+	case event.Exception != nil:
+		codes = []string{event.Exception.ErrorCode}
+
+		if event.Exception.Allowed {
+			details = append(details, "allowed")
+		} else {
+			details = append(details, "denied: "+event.Exception.DenialReason)
+		}
+
+		if event.Exception.Reason != "" {
+			details = append(details, strconv.Quote(event.Exception.Reason))
+		}
+	}
+
+	codeList := strings.Join(codes, ",")
+	if codeList == "" {
+		codeList = "-"
+	}
+
+	return codeList, strings.Join(details, ", ")
+}
+
+// describeSessionStatus formats a session status.
+func describeSessionStatus(status session.Status) string {
+	switch status {
+	case session.StatusHardPoisoned:
+		return "⛔ hard-poisoned"
+	case session.StatusPoisoned:
+		return "❌ poisoned"
+	case session.StatusWarned:
+		return "⚠️ warned"
+	default:
+		return "✅ clean"
+	}
+}
+
+// describeBlockedAttempts formats the blocked attempts per code.
+func describeBlockedAttempts(attempts map[string][]time.Time) string {
+	codes := slices.Sorted(maps.Keys(attempts))
+	parts := make([]string, 0, len(codes))
+
+	for _, code := range codes {
+		parts = append(parts, fmt.Sprintf("%s (%d)", code, len(attempts[code])))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// describeLastActivity formats a last activity time with its age.
+func describeLastActivity(lastActivity, now time.Time) string {
+	if lastActivity.IsZero() {
+		return "never"
+	}
+
+	age := now.Sub(lastActivity).Round(time.Second)
+
+	return fmt.Sprintf("%s (%s ago)", lastActivity.Local().Format(time.DateTime), age)
+}
+
+// writeSessionJSON writes v as indented JSON.
+func writeSessionJSON(out io.Writer, v any) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(v); err != nil {
+		return errors.Wrap(err, "encoding JSON output")
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+// Package main provides the CLI entry point for klaudiush.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/smykla-labs/klaudiush/internal/fixtures"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// ErrFixturesFailed is returned when at least one fixture does not meet its expectations.
+var ErrFixturesFailed = errors.New("fixture tests failed")
+
+// Test command flags.
+var testVerbose bool
+
+var testCmd = &cobra.Command{
+	Use:   "test [path]",
+	Short: "Replay hook fixtures and assert decisions",
+	Long: `Replay recorded hook payloads through the configured validators and
+compare the outcome with the expected decision, validators and reference codes.
+
+Fixtures are JSON files in .klaudiush/tests/ (or the given directory or file):
+
+  {
+    "name": "blocks force push to main",
+    "input": {
+      "tool_name": "Bash",
+      "tool_input": {"command": "git push --force origin main"}
+    },
+    "expect": {
+      "decision": "deny",
+      "validators": ["validate-git-push"],
+      "references": ["GIT026"]
+    }
+  }
+
+Valid decisions: allow, warn, ask, deny. Listed validators and references must
+all be reported; other validators and references may be reported too.
+
+Exits with a non-zero status if any fixture fails.
+
+Examples:
+  klaudiush test                           # Run .klaudiush/tests/
+  klaudiush test tests/hooks               # Run fixtures in a directory
+  klaudiush test tests/hooks/push.json     # Run a single fixture
+  klaudiush test --verbose                 # Show reported validators for every fixture`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runTest,
+}
+
+func init() {
+	rootCmd.AddCommand(testCmd)
+
+	testCmd.Flags().StringVarP(
+		&configPath,
+		"config",
+		"c",
+		"",
+		"Path to project configuration file (default: .klaudiush/config.toml or klaudiush.toml)",
+	)
+
+	testCmd.Flags().StringVar(
+		&globalConfig,
+		"global-config",
+		"",
+		"Path to global configuration file (default: ~/.klaudiush/config.toml)",
+	)
+
+	testCmd.Flags().BoolVarP(
+		&testVerbose,
+		"verbose",
+		"v",
+		false,
+		"Show reported validators and references for passing fixtures",
+	)
+}
+
+func runTest(cmd *cobra.Command, args []string) error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return errors.Wrap(err, "failed to get home directory")
+	}
+
+	logFile := filepath.Join(homeDir, ".claude", "hooks", "dispatcher.log")
+
+	log, err := logger.NewFileLogger(logFile, false, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to create logger")
+	}
+
+	dir := fixtures.DefaultDir
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	log.Info("test command invoked", "path", dir)
+
+	loaded, err := fixtures.Load(dir)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(log)
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+
+	runner, err := fixtures.NewRunner(cfg, log)
+	if err != nil {
+		return err
+	}
+
+	results := runner.Run(context.Background(), loaded)
+
+	failed := printTestResults(cmd.OutOrStdout(), results)
+	if failed > 0 {
+		return errors.Mark(
+			errors.Newf("%d of %d fixtures failed", failed, len(results)),
+			ErrFixturesFailed,
+		)
+	}
+
+	return nil
+}
+
+// printTestResults prints a pass/fail table and returns the number of failed fixtures.
+func printTestResults(out io.Writer, results []*fixtures.Result) int {
+	fmt.Fprintln(out, "Hook Fixture Tests")
+	fmt.Fprintln(out, "==================")
+	fmt.Fprintln(out, "")
+
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "STATUS\tFIXTURE\tEXPECTED\tACTUAL")
+
+	failed := 0
+
+	for _, result := range results {
+		status := "✅ PASS"
+		if !result.Passed() {
+			status = "❌ FAIL"
+			failed++
+		}
+
+		actual := string(result.Decision)
+		if result.Err != nil {
+			actual = "error"
+		}
+
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\n",
+			status,
+			result.Fixture.Name,
+			result.Fixture.Expect.Decision,
+			actual,
+		)
+	}
+
+	_ = table.Flush()
+
+	printTestDetails(out, results)
+
+	fmt.Fprintf(out, "\n%d passed, %d failed\n", len(results)-failed, failed)
+
+	return failed
+}
+
+// printTestDetails prints failure reasons, and reported validators in verbose mode.
+func printTestDetails(out io.Writer, results []*fixtures.Result) {
+	for _, result := range results {
+		if result.Passed() && !testVerbose {
+			continue
+		}
+
+		fmt.Fprintf(out, "\n%s (%s)\n", result.Fixture.Name, result.Fixture.Path)
+
+		if result.Err != nil {
+			fmt.Fprintf(out, "  error: %v\n", result.Err)
+
+			continue
+		}
+
+		for _, failure := range result.Failures {
+			fmt.Fprintf(out, "  %s\n", failure)
+		}
+
+		if len(result.Validators) > 0 {
+			fmt.Fprintf(out, "  validators: %s\n", strings.Join(result.Validators, ", "))
+		}
+
+		if len(result.References) > 0 {
+			fmt.Fprintf(out, "  references: %s\n", strings.Join(result.References, ", "))
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"bytes"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/rogpeppe/go-internal/testscript"
@@ -25,6 +28,7 @@ func mainFunc() {
 	configPath = ""
 	globalConfig = ""
 	disableList = []string{}
+	outputFormat = ""
 	globalFlag = false
 	forceFlag = false
 	noTUIFlag = false
@@ -32,6 +36,20 @@ func mainFunc() {
 	fixFlag = false
 	categoryFlag = []string{}
 	validatorFilter = ""
+	testVerbose = false
+	explainCommand = ""
+	explainFile = ""
+	explainJSON = false
+
+	// Scripts run without a terminal, so user-only commands are confirmed by a
+	// fake one unless a script opts out
+	openTerminal = func() (io.ReadWriteCloser, error) {
+		if os.Getenv("KLAUDIUSH_TEST_NO_TERMINAL") != "" {
+			return nil, os.ErrNotExist
+		}
+
+		return &fakeTerminal{wrongCode: os.Getenv("KLAUDIUSH_TEST_WRONG_CODE") != ""}, nil
+	}
 
 	// Reset git repository cache so each test discovers its own repo
 	gitpkg.ResetRepositoryCache()
@@ -41,6 +59,33 @@ func mainFunc() {
 	}
 }
 
+// fakeTerminal answers the confirmation prompt written to it with the code it
+// shows, or with a wrong code.
+type fakeTerminal struct {
+	wrongCode bool
+	answer    bytes.Buffer
+}
+
+func (t *fakeTerminal) Write(p []byte) (int, error) {
+	code := "000000"
+
+	if fields := strings.Fields(string(p)); !t.wrongCode && len(fields) > 0 {
+		code = strings.TrimSuffix(fields[len(fields)-1], ":")
+	}
+
+	t.answer.WriteString(code + "\n")
+
+	return len(p), nil
+}
+
+func (t *fakeTerminal) Read(p []byte) (int, error) {
+	return t.answer.Read(p)
+}
+
+func (*fakeTerminal) Close() error {
+	return nil
+}
+
 // setupTestEnv creates the necessary directories and files for testscript.
 func setupTestEnv(env *testscript.Env) error {
 	// Create .claude/hooks directory in the work directory
@@ -93,3 +138,45 @@ func TestScriptDebug(t *testing.T) {
 		Setup: setupTestEnv,
 	})
 }
+
+func TestScriptEvents(t *testing.T) {
+	testscript.Run(t, testscript.Params{
+		Dir:   "testdata/scripts/events",
+		Setup: setupTestEnv,
+	})
+}
+
+func TestScriptTest(t *testing.T) {
+	testscript.Run(t, testscript.Params{
+		Dir:   "testdata/scripts/test",
+		Setup: setupTestEnv,
+	})
+}
+
+func TestScriptExplain(t *testing.T) {
+	testscript.Run(t, testscript.Params{
+		Dir:   "testdata/scripts/explain",
+		Setup: setupTestEnv,
+	})
+}
+
+func TestScriptRules(t *testing.T) {
+	testscript.Run(t, testscript.Params{
+		Dir:   "testdata/scripts/rules",
+		Setup: setupTestEnv,
+	})
+}
+
+func TestScriptSession(t *testing.T) {
+	testscript.Run(t, testscript.Params{
+		Dir:   "testdata/scripts/session",
+		Setup: setupTestEnv,
+	})
+}
+
+func TestScriptException(t *testing.T) {
+	testscript.Run(t, testscript.Params{
+		Dir:   "testdata/scripts/exception",
+		Setup: setupTestEnv,
+	})
+}
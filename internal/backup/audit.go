@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/internal/statestore"
 )
 
 const (
@@ -116,32 +118,25 @@ func NewJSONLAuditLogger(baseDir string) (*JSONLAuditLogger, error) {
 
 // Log writes an audit entry to the log file.
 func (l *JSONLAuditLogger) Log(entry AuditEntry) error {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	// Ensure directory exists
-	dir := filepath.Dir(l.logPath)
-	if err := os.MkdirAll(dir, AuditLogDirPerms); err != nil {
-		return errors.Wrap(err, "failed to create audit log directory")
-	}
-
-	// Open file in append mode
-	file, err := os.OpenFile(l.logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, AuditLogPerms)
+	// Encode entry as JSON
+	data, err := json.Marshal(entry)
 	if err != nil {
-		return errors.Wrap(err, "failed to open audit log")
+		return errors.Wrap(err, "failed to encode audit entry")
 	}
 
-	defer func() {
-		if closeErr := file.Close(); closeErr != nil {
-			// Best-effort close, already returning or continuing
-			_ = closeErr
-		}
-	}()
+	l.mu.Lock()
+	defer l.mu.Unlock()
 
-	// Encode entry as JSON
-	encoder := json.NewEncoder(file)
-	if err := encoder.Encode(entry); err != nil {
-		return errors.Wrap(err, "failed to encode audit entry")
+	store := statestore.New(
+		l.logPath,
+		statestore.WithFilePermissions(AuditLogPerms),
+		statestore.WithDirPermissions(AuditLogDirPerms),
+	)
+
+	// Append under the log file lock, so entries of concurrent processes
+	// do not interleave
+	if err := store.Append(append(data, '\n')); err != nil {
+		return errors.Wrap(err, "failed to write audit entry")
 	}
 
 	return nil
@@ -105,6 +105,28 @@ func (m *Manager) CreateBackup(opts CreateBackupOptions) (*Snapshot, error) {
 		}
 	}
 
+	var snapshot *Snapshot
+
+	// Lock the index, so concurrent backups do not overwrite each other's
+	// index updates
+	err = m.storage.WithIndexLock(func() error {
+		var createErr error
+
+		snapshot, createErr = m.createSnapshot(opts, data)
+
+		return createErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// createSnapshot stores data as a new snapshot and adds it to the index,
+// unless identical content is already backed up.
+// Must be called with the index lock held.
+func (m *Manager) createSnapshot(opts CreateBackupOptions, data []byte) (*Snapshot, error) {
 	// Load index
 	index, err := m.storage.LoadIndex()
 	if err != nil {
@@ -315,6 +337,25 @@ func (m *Manager) ApplyRetention(policy RetentionPolicy) (*RetentionResult, erro
 		return &RetentionResult{}, nil
 	}
 
+	var result *RetentionResult
+
+	err := m.storage.WithIndexLock(func() error {
+		var retentionErr error
+
+		result, retentionErr = m.applyRetention(policy)
+
+		return retentionErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// applyRetention removes the snapshots policy does not retain.
+// Must be called with the index lock held.
+func (m *Manager) applyRetention(policy RetentionPolicy) (*RetentionResult, error) {
 	// Load index
 	index, err := m.storage.LoadIndex()
 	if err != nil {
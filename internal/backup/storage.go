@@ -10,6 +10,8 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/internal/statestore"
 )
 
 var (
@@ -69,6 +71,11 @@ type Storage interface {
 	// LoadIndex loads the snapshot index.
 	LoadIndex() (*SnapshotIndex, error)
 
+	// WithIndexLock runs fn while holding the exclusive lock of the snapshot
+	// index, serializing index updates of concurrent processes. fn must not
+	// call WithIndexLock.
+	WithIndexLock(fn func() error) error
+
 	// Exists checks if storage is initialized.
 	Exists() bool
 
@@ -149,15 +156,17 @@ func (f *FilesystemStorage) Initialize() error {
 		return errors.Wrap(err, "failed to create snapshots directory")
 	}
 
-	metadataPath := f.getMetadataPath()
-	if _, err := os.Stat(metadataPath); os.IsNotExist(err) {
-		index := NewSnapshotIndex()
-		if err := f.SaveIndex(index); err != nil {
-			return errors.Wrap(err, "failed to initialize metadata")
+	return f.WithIndexLock(func() error {
+		metadataPath := f.getMetadataPath()
+		if _, err := os.Stat(metadataPath); os.IsNotExist(err) {
+			index := NewSnapshotIndex()
+			if err := f.SaveIndex(index); err != nil {
+				return errors.Wrap(err, "failed to initialize metadata")
+			}
 		}
-	}
 
-	return nil
+		return nil
+	})
 }
 
 // Save stores snapshot data and returns the storage path.
@@ -230,14 +239,12 @@ func (f *FilesystemStorage) List() ([]string, error) {
 
 // SaveIndex saves the snapshot index.
 func (f *FilesystemStorage) SaveIndex(index *SnapshotIndex) error {
-	metadataPath := f.getMetadataPath()
-
 	data, err := json.MarshalIndent(index, "", "  ")
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal index")
 	}
 
-	if err := os.WriteFile(metadataPath, data, FilePerm); err != nil {
+	if err := f.indexStore().WriteLocked(data); err != nil {
 		return errors.Wrap(err, "failed to write index")
 	}
 
@@ -266,6 +273,20 @@ func (f *FilesystemStorage) LoadIndex() (*SnapshotIndex, error) {
 	return &index, nil
 }
 
+// WithIndexLock runs fn while holding the exclusive lock of the snapshot index.
+func (f *FilesystemStorage) WithIndexLock(fn func() error) error {
+	return f.indexStore().WithLock(fn)
+}
+
+// indexStore returns the state store of the snapshot index.
+func (f *FilesystemStorage) indexStore() *statestore.Store {
+	return statestore.New(
+		f.getMetadataPath(),
+		statestore.WithFilePermissions(FilePerm),
+		statestore.WithDirPermissions(DirPerm),
+	)
+}
+
 // SanitizePath sanitizes a file path for use as a directory name.
 // Converts /Users/bart/project to Users_bart_project.
 func SanitizePath(path string) string {
@@ -154,3 +154,17 @@ func (mr *MockStorageMockRecorder) SaveIndex(index any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIndex", reflect.TypeOf((*MockStorage)(nil).SaveIndex), index)
 }
+
+// WithIndexLock mocks base method.
+func (m *MockStorage) WithIndexLock(fn func() error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithIndexLock", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithIndexLock indicates an expected call of WithIndexLock.
+func (mr *MockStorageMockRecorder) WithIndexLock(fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithIndexLock", reflect.TypeOf((*MockStorage)(nil).WithIndexLock), fn)
+}
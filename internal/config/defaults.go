@@ -30,10 +30,14 @@ func DefaultConfig() *config.Config {
 func DefaultRulesConfig() *config.RulesConfig {
 	enabled := true
 	stopOnFirstMatch := true
+	standalone := true
+	stats := true
 
 	return &config.RulesConfig{
 		Enabled:          &enabled,
 		StopOnFirstMatch: &stopOnFirstMatch,
+		Standalone:       &standalone,
+		Stats:            &stats,
 		Rules:            []config.RuleConfig{},
 	}
 }
@@ -177,14 +181,20 @@ func DefaultCommitMessageConfig() *config.CommitMessageConfig {
 func DefaultPushValidatorConfig() *config.PushValidatorConfig {
 	enabled := true
 	requireTracking := true
+	blockForcePushProtected := true
+	requireForceWithLease := true
+	blockRemoteDelete := true
 
 	return &config.PushValidatorConfig{
 		ValidatorConfig: config.ValidatorConfig{
 			Enabled:  &enabled,
 			Severity: config.SeverityError,
 		},
-		BlockedRemotes:  []string{},
-		RequireTracking: &requireTracking,
+		BlockedRemotes:          []string{},
+		RequireTracking:         &requireTracking,
+		BlockForcePushProtected: &blockForcePushProtected,
+		RequireForceWithLease:   &requireForceWithLease,
+		BlockRemoteDelete:       &blockRemoteDelete,
 	}
 }
 
@@ -40,6 +40,11 @@ type ValidatorFactory interface {
 	// CreatePluginValidators creates all plugin validators from config.
 	CreatePluginValidators(cfg *config.Config) []ValidatorWithPredicate
 
+	// CreateRulesValidators creates the standalone rules validator from config.
+	// coveredBy matches the hook contexts for which built-in validators
+	// evaluate rules.
+	CreateRulesValidators(cfg *config.Config, coveredBy validator.Predicate) []ValidatorWithPredicate
+
 	// CreateAll creates all validators from config.
 	CreateAll(cfg *config.Config) []ValidatorWithPredicate
 }
@@ -53,6 +58,7 @@ type DefaultValidatorFactory struct {
 	secretsFactory      *SecretsValidatorFactory
 	shellFactory        *ShellValidatorFactory
 	pluginFactory       *PluginValidatorFactory
+	rulesFactory        *RulesValidatorFactory
 }
 
 // NewValidatorFactory creates a new DefaultValidatorFactory.
@@ -65,6 +71,7 @@ func NewValidatorFactory(log logger.Logger) *DefaultValidatorFactory {
 		secretsFactory:      NewSecretsValidatorFactory(log),
 		shellFactory:        NewShellValidatorFactory(log),
 		pluginFactory:       NewPluginValidatorFactory(log),
+		rulesFactory:        NewRulesValidatorFactory(log),
 	}
 }
 
@@ -76,6 +83,7 @@ func (f *DefaultValidatorFactory) SetRuleEngine(engine *rules.RuleEngine) {
 	f.notificationFactory.SetRuleEngine(engine)
 	f.secretsFactory.SetRuleEngine(engine)
 	f.shellFactory.SetRuleEngine(engine)
+	f.rulesFactory.SetRuleEngine(engine)
 }
 
 // CreateGitValidators creates all git validators from config.
@@ -125,6 +133,14 @@ func (f *DefaultValidatorFactory) CreatePluginValidators(
 	return f.pluginFactory.CreateValidators(cfg)
 }
 
+// CreateRulesValidators creates the standalone rules validator from config.
+func (f *DefaultValidatorFactory) CreateRulesValidators(
+	cfg *config.Config,
+	coveredBy validator.Predicate,
+) []ValidatorWithPredicate {
+	return f.rulesFactory.CreateValidators(cfg, coveredBy)
+}
+
 // CreateAll creates all validators from config.
 func (f *DefaultValidatorFactory) CreateAll(cfg *config.Config) []ValidatorWithPredicate {
 	var all []ValidatorWithPredicate
@@ -135,7 +151,25 @@ func (f *DefaultValidatorFactory) CreateAll(cfg *config.Config) []ValidatorWithP
 	all = append(all, f.CreateNotificationValidators(cfg)...)
 	all = append(all, f.CreateSecretsValidators(cfg)...)
 	all = append(all, f.CreateShellValidators(cfg)...)
+
+	// Every built-in validator evaluates rules, so the standalone rules
+	// validator only evaluates unscoped rules where none of them runs
+	coveredBy := anySelected(all)
+
 	all = append(all, f.CreatePluginValidators(cfg)...)
+	all = append(all, f.CreateRulesValidators(cfg, coveredBy)...)
 
 	return all
 }
+
+// anySelected returns a predicate that matches if any of the validators is
+// selected.
+func anySelected(validators []ValidatorWithPredicate) validator.Predicate {
+	predicates := make([]validator.Predicate, 0, len(validators))
+
+	for _, vp := range validators {
+		predicates = append(predicates, vp.Predicate)
+	}
+
+	return validator.Or(predicates...)
+}
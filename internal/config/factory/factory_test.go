@@ -1,12 +1,18 @@
 package factory_test
 
 import (
+	"context"
+	"path/filepath"
+
+	gogit "github.com/go-git/go-git/v6"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/smykla-labs/klaudiush/internal/config/factory"
 	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/internal/validator"
 	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
 )
 
@@ -547,6 +553,41 @@ var _ = Describe("DefaultValidatorFactory", func() {
 			Expect(validators).To(BeEmpty())
 		})
 
+		It("should resolve the destructive validator's repository from the hook cwd", func() {
+			repoDir, err := filepath.EvalSymlinks(GinkgoT().TempDir())
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = gogit.PlainInit(repoDir, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					Shell: &config.ShellConfig{
+						Destructive: &config.DestructiveValidatorConfig{
+							ValidatorConfig: config.ValidatorConfig{Enabled: ptrBool(true)},
+						},
+					},
+				},
+			}
+
+			validators := validatorFactory.CreateShellValidators(cfg)
+			Expect(validators).To(HaveLen(1))
+
+			validate := func(command string) *validator.Result {
+				return validators[0].Validator.Validate(context.Background(), &hook.Context{
+					EventType: hook.EventTypePreToolUse,
+					ToolName:  hook.ToolTypeBash,
+					CWD:       repoDir,
+					ToolInput: hook.ToolInput{Command: command},
+				})
+			}
+
+			Expect(validate("rm -rf build").Passed).To(BeTrue())
+			Expect(validate("rm -rf ../other").Reference).To(
+				Equal(validator.RefShellRecursiveDelete),
+			)
+		})
+
 		It("should return empty when shell config is nil", func() {
 			cfg := &config.Config{
 				Validators: &config.ValidatorsConfig{
@@ -577,6 +618,39 @@ var _ = Describe("DefaultValidatorFactory", func() {
 		})
 	})
 
+	Describe("CreateRulesValidators", func() {
+		var engine *rules.RuleEngine
+
+		BeforeEach(func() {
+			var err error
+
+			engine, err = rules.NewRuleEngine(nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should return empty without a rule engine", func() {
+			validators := validatorFactory.CreateRulesValidators(&config.Config{}, validator.Never())
+			Expect(validators).To(BeEmpty())
+		})
+
+		It("should create the standalone rules validator with a rule engine", func() {
+			validatorFactory.SetRuleEngine(engine)
+
+			validators := validatorFactory.CreateRulesValidators(&config.Config{}, validator.Never())
+			Expect(validators).To(HaveLen(1))
+			Expect(validators[0].Validator.Name()).To(Equal("validate-rules"))
+		})
+
+		It("should not create validators when standalone is disabled", func() {
+			validatorFactory.SetRuleEngine(engine)
+
+			validators := validatorFactory.CreateRulesValidators(&config.Config{
+				Rules: &config.RulesConfig{Standalone: ptrBool(false)},
+			}, validator.Never())
+			Expect(validators).To(BeEmpty())
+		})
+	})
+
 	Describe("CreateAll", func() {
 		It("should create validators from all categories", func() {
 			cfg := &config.Config{
@@ -57,6 +57,9 @@ func (f *FileValidatorFactory) CreateValidators(cfg *config.Config) []ValidatorW
 	rustfmtChecker := linters.NewRustfmtChecker(runner)
 	githubClient := githubpkg.NewClient()
 
+	// Formatter validators also run on the written file after the tool ran
+	postWrite := cfg.Validators.File.PostWrite != nil && cfg.Validators.File.PostWrite.IsEnabled()
+
 	if cfg.Validators.File.Markdown != nil && cfg.Validators.File.Markdown.IsEnabled() {
 		// Create markdown linter with config for rule support
 		markdownLinter := linters.NewMarkdownLinterWithConfig(runner, cfg.Validators.File.Markdown)
@@ -68,8 +71,13 @@ func (f *FileValidatorFactory) CreateValidators(cfg *config.Config) []ValidatorW
 	}
 
 	if cfg.Validators.File.Terraform != nil && cfg.Validators.File.Terraform.IsEnabled() {
-		validators = append(validators, f.createTerraformValidator(
-			cfg.Validators.File.Terraform, terraformFormatter, tfLinter))
+		terraform := f.createTerraformValidator(
+			cfg.Validators.File.Terraform, terraformFormatter, tfLinter)
+		if postWrite {
+			terraform = withPostWrite(terraform, ".tf")
+		}
+
+		validators = append(validators, terraform)
 	}
 
 	if cfg.Validators.File.ShellScript != nil && cfg.Validators.File.ShellScript.IsEnabled() {
@@ -85,10 +93,12 @@ func (f *FileValidatorFactory) CreateValidators(cfg *config.Config) []ValidatorW
 	}
 
 	if cfg.Validators.File.Gofumpt != nil && cfg.Validators.File.Gofumpt.IsEnabled() {
-		validators = append(
-			validators,
-			f.createGofumptValidator(cfg.Validators.File.Gofumpt, gofumptChecker),
-		)
+		gofumpt := f.createGofumptValidator(cfg.Validators.File.Gofumpt, gofumptChecker)
+		if postWrite {
+			gofumpt = withPostWrite(gofumpt, ".go")
+		}
+
+		validators = append(validators, gofumpt)
 	}
 
 	if cfg.Validators.File.Python != nil && cfg.Validators.File.Python.IsEnabled() {
@@ -106,15 +116,39 @@ func (f *FileValidatorFactory) CreateValidators(cfg *config.Config) []ValidatorW
 	}
 
 	if cfg.Validators.File.Rust != nil && cfg.Validators.File.Rust.IsEnabled() {
+		rust := f.createRustValidator(cfg.Validators.File.Rust, rustfmtChecker)
+		if postWrite {
+			rust = withPostWrite(rust, ".rs")
+		}
+
+		validators = append(validators, rust)
+	}
+
+	if cfg.Validators.File.SensitiveRead != nil && cfg.Validators.File.SensitiveRead.IsEnabled() {
 		validators = append(
 			validators,
-			f.createRustValidator(cfg.Validators.File.Rust, rustfmtChecker),
+			f.createSensitiveReadValidator(cfg.Validators.File.SensitiveRead),
 		)
 	}
 
 	return validators
 }
 
+// withPostWrite extends a formatter validator to also run after Write, Edit and
+// MultiEdit (PostToolUse), where it validates the file as written.
+func withPostWrite(vp ValidatorWithPredicate, extension string) ValidatorWithPredicate {
+	vp.Predicate = validator.Or(
+		vp.Predicate,
+		validator.And(
+			validator.EventTypeIs(hook.EventTypePostToolUse),
+			validator.ToolTypeIn(hook.ToolTypeWrite, hook.ToolTypeEdit, hook.ToolTypeMultiEdit),
+			validator.FileExtensionIs(extension),
+		),
+	)
+
+	return vp
+}
+
 func (f *FileValidatorFactory) createMarkdownValidator(
 	cfg *config.MarkdownValidatorConfig,
 	linter linters.MarkdownLinter,
@@ -238,7 +272,7 @@ func (f *FileValidatorFactory) createGofumptValidator(
 		Validator: filevalidators.NewGofumptValidator(f.log, checker, cfg, ruleAdapter),
 		Predicate: validator.And(
 			validator.EventTypeIs(hook.EventTypePreToolUse),
-			validator.ToolTypeIn(hook.ToolTypeWrite),
+			validator.ToolTypeIn(hook.ToolTypeWrite, hook.ToolTypeEdit, hook.ToolTypeMultiEdit),
 			validator.FileExtensionIs(".go"),
 		),
 	}
@@ -319,3 +353,29 @@ func (f *FileValidatorFactory) createRustValidator(
 		),
 	}
 }
+
+func (f *FileValidatorFactory) createSensitiveReadValidator(
+	cfg *config.SensitiveReadValidatorConfig,
+) ValidatorWithPredicate {
+	var ruleAdapter *rules.RuleValidatorAdapter
+	if f.ruleEngine != nil {
+		ruleAdapter = rules.NewRuleValidatorAdapter(
+			f.ruleEngine,
+			rules.ValidatorFileSensitiveRead,
+			rules.WithAdapterLogger(f.log),
+		)
+	}
+
+	return ValidatorWithPredicate{
+		Validator: filevalidators.NewSensitiveReadValidator(f.log, cfg, ruleAdapter),
+		Predicate: validator.And(
+			validator.EventTypeIs(hook.EventTypePreToolUse),
+			validator.ToolTypeIn(
+				hook.ToolTypeRead,
+				hook.ToolTypeGrep,
+				hook.ToolTypeGlob,
+				hook.ToolTypeBash,
+			),
+		),
+	}
+}
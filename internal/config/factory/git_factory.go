@@ -159,7 +159,13 @@ func (f *GitValidatorFactory) createPushValidator(
 	}
 
 	return ValidatorWithPredicate{
-		Validator: gitvalidators.NewPushValidator(f.log, f.getGitRunner(), cfg, ruleAdapter),
+		Validator: gitvalidators.NewPushValidator(
+			f.log,
+			f.getGitRunner(),
+			cfg,
+			ruleAdapter,
+			gitvalidators.WithPushProtectedBranches(f.protectedBranches()),
+		),
 		Predicate: validator.And(
 			validator.EventTypeIs(hook.EventTypePreToolUse),
 			validator.GitSubcommandIs("push"),
@@ -167,6 +173,16 @@ func (f *GitValidatorFactory) createPushValidator(
 	}
 }
 
+// protectedBranches returns the protected branches from the branch validator config.
+func (f *GitValidatorFactory) protectedBranches() []string {
+	if f.cfg == nil || f.cfg.Validators == nil || f.cfg.Validators.Git == nil ||
+		f.cfg.Validators.Git.Branch == nil {
+		return nil
+	}
+
+	return f.cfg.Validators.Git.Branch.ProtectedBranches
+}
+
 func (f *GitValidatorFactory) createFetchValidator(
 	cfg *config.FetchValidatorConfig,
 ) ValidatorWithPredicate {
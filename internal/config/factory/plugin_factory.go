@@ -50,7 +50,8 @@ func (f *PluginValidatorFactory) CreateValidators(cfg *config.Config) []Validato
 	return []ValidatorWithPredicate{
 		{
 			Validator: pluginValidator,
-			Predicate: validator.EventTypeIs(hook.EventTypePreToolUse),
+			// Event types are filtered per plugin by the plugin predicates.
+			Predicate: validator.Always(),
 		},
 	}
 }
@@ -2,7 +2,9 @@
 package factory
 
 import (
+	"github.com/smykla-labs/klaudiush/internal/git"
 	"github.com/smykla-labs/klaudiush/internal/rules"
+	gitvalidators "github.com/smykla-labs/klaudiush/internal/validators/git"
 	"github.com/smykla-labs/klaudiush/pkg/config"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
 )
@@ -35,22 +37,29 @@ func (f *RulesFactory) CreateRuleEngine(cfg *config.Config) (*rules.RuleEngine,
 		return nil, nil
 	}
 
-	if len(rulesConfig.Rules) == 0 {
+	if len(rulesConfig.Rules) == 0 && len(rulesConfig.ResolvedPacks) == 0 {
 		f.log.Debug("no rules defined")
 
 		return nil, nil
 	}
 
-	// Convert config rules to internal rules
-	internalRules := make([]*rules.Rule, 0, len(rulesConfig.Rules))
+	// Pack rules are merged in load order, so later packs override earlier
+	// ones, and config rules override pack rules with the same name. Disabled
+	// rules are merged too, so that they can disable pack rules.
+	var packRules []*rules.Rule
 
-	for _, ruleConfig := range rulesConfig.Rules {
-		if !ruleConfig.IsRuleEnabled() {
-			continue
-		}
+	for _, pack := range rulesConfig.ResolvedPacks {
+		packRules = rules.MergeRules(packRules, convertRuleConfigs(pack.Rules))
+	}
+
+	mergedRules := rules.MergeRules(packRules, convertRuleConfigs(rulesConfig.Rules))
 
-		internalRule := convertRuleConfig(ruleConfig)
-		internalRules = append(internalRules, internalRule)
+	internalRules := make([]*rules.Rule, 0, len(mergedRules))
+
+	for _, rule := range mergedRules {
+		if rule.Enabled {
+			internalRules = append(internalRules, rule)
+		}
 	}
 
 	if len(internalRules) == 0 {
@@ -63,6 +72,7 @@ func (f *RulesFactory) CreateRuleEngine(cfg *config.Config) (*rules.RuleEngine,
 	opts := []rules.EngineOption{
 		rules.WithLogger(f.log),
 		rules.WithEngineStopOnFirstMatch(rulesConfig.ShouldStopOnFirstMatch()),
+		rules.WithGitProvider(NewGitProvider()),
 	}
 
 	engine, err := rules.NewRuleEngine(internalRules, opts...)
@@ -77,6 +87,17 @@ func (f *RulesFactory) CreateRuleEngine(cfg *config.Config) (*rules.RuleEngine,
 	return engine, nil
 }
 
+// convertRuleConfigs converts config.RuleConfigs to rules.Rules.
+func convertRuleConfigs(cfgs []config.RuleConfig) []*rules.Rule {
+	converted := make([]*rules.Rule, 0, len(cfgs))
+
+	for _, cfg := range cfgs {
+		converted = append(converted, convertRuleConfig(cfg))
+	}
+
+	return converted
+}
+
 // convertRuleConfig converts a config.RuleConfig to a rules.Rule.
 func convertRuleConfig(cfg config.RuleConfig) *rules.Rule {
 	rule := &rules.Rule{
@@ -88,38 +109,119 @@ func convertRuleConfig(cfg config.RuleConfig) *rules.Rule {
 
 	// Convert match conditions
 	if cfg.Match != nil {
-		rule.Match = &rules.RuleMatch{
-			ValidatorType:   rules.ValidatorType(cfg.Match.ValidatorType),
-			RepoPattern:     cfg.Match.RepoPattern,
-			RepoPatterns:    cfg.Match.RepoPatterns,
-			Remote:          cfg.Match.Remote,
-			BranchPattern:   cfg.Match.BranchPattern,
-			BranchPatterns:  cfg.Match.BranchPatterns,
-			FilePattern:     cfg.Match.FilePattern,
-			FilePatterns:    cfg.Match.FilePatterns,
-			ContentPattern:  cfg.Match.ContentPattern,
-			ContentPatterns: cfg.Match.ContentPatterns,
-			CommandPattern:  cfg.Match.CommandPattern,
-			CommandPatterns: cfg.Match.CommandPatterns,
-			ToolType:        cfg.Match.ToolType,
-			EventType:       cfg.Match.EventType,
-			CaseInsensitive: cfg.Match.IsCaseInsensitive(),
-			PatternMode:     cfg.Match.GetPatternMode(),
-		}
+		rule.Match = convertMatchConfig(cfg.Match)
 	}
 
 	// Convert action
 	if cfg.Action != nil {
 		rule.Action = &rules.RuleAction{
-			Type:      convertActionType(cfg.Action.GetActionType()),
-			Message:   cfg.Action.Message,
-			Reference: cfg.Action.Reference,
+			Type:       convertActionType(cfg.Action.GetActionType()),
+			Message:    cfg.Action.Message,
+			Reference:  cfg.Action.Reference,
+			Suggestion: cfg.Action.Suggestion,
 		}
 	}
 
+	for _, test := range cfg.Tests {
+		rule.Tests = append(rule.Tests, convertRuleTestConfig(test))
+	}
+
 	return rule
 }
 
+// convertRuleTestConfig converts a config.RuleTestConfig to a rules.RuleTest.
+func convertRuleTestConfig(cfg config.RuleTestConfig) *rules.RuleTest {
+	test := &rules.RuleTest{
+		Name: cfg.Name,
+		Input: rules.RuleTestInput{
+			ValidatorType: rules.ValidatorType(cfg.ValidatorType),
+			ToolType:      cfg.ToolType,
+			EventType:     cfg.EventType,
+			Command:       cfg.Command,
+			FilePath:      cfg.FilePath,
+			Content:       cfg.Content,
+			Branch:        cfg.Branch,
+			Remote:        cfg.Remote,
+		},
+		Matched: cfg.Matched,
+	}
+
+	if cfg.Action != "" {
+		test.Action = convertActionType(cfg.Action)
+	}
+
+	return test
+}
+
+// convertMatchConfig converts a config.RuleMatchConfig, including its nested
+// all/any/not blocks, to a rules.RuleMatch.
+func convertMatchConfig(cfg *config.RuleMatchConfig) *rules.RuleMatch {
+	match := &rules.RuleMatch{
+		ValidatorType:   rules.ValidatorType(cfg.ValidatorType),
+		RepoPattern:     cfg.RepoPattern,
+		RepoPatterns:    cfg.RepoPatterns,
+		Remote:          cfg.Remote,
+		BranchPattern:   cfg.BranchPattern,
+		BranchPatterns:  cfg.BranchPatterns,
+		FilePattern:     cfg.FilePattern,
+		FilePatterns:    cfg.FilePatterns,
+		ContentPattern:  cfg.ContentPattern,
+		ContentPatterns: cfg.ContentPatterns,
+		CommandPattern:  cfg.CommandPattern,
+		CommandPatterns: cfg.CommandPatterns,
+		ToolType:        cfg.ToolType,
+		EventType:       cfg.EventType,
+		CaseInsensitive: cfg.IsCaseInsensitive(),
+		PatternMode:     cfg.GetPatternMode(),
+
+		Program:               cfg.Program,
+		Subcommand:            cfg.Subcommand,
+		Flags:                 cfg.Flags,
+		WithoutFlags:          cfg.WithoutFlags,
+		Args:                  cfg.Args,
+		InPipeline:            cfg.InPipeline,
+		InSubshell:            cfg.InSubshell,
+		InCommandSubstitution: cfg.InCommandSubstitution,
+		PipelinePosition:      cfg.PipelinePosition,
+
+		Env:                cfg.Env,
+		CWDPattern:         cfg.CWDPattern,
+		MinSessionCommands: cfg.MinSessionCommands,
+		MaxSessionCommands: cfg.MaxSessionCommands,
+
+		RemoteHost:      cfg.RemoteHost,
+		RemoteOwner:     cfg.RemoteOwner,
+		RemoteRepo:      cfg.RemoteRepo,
+		StagedFiles:     cfg.StagedFiles,
+		Dirty:           cfg.Dirty,
+		MinCommitsAhead: cfg.MinCommitsAhead,
+		MaxCommitsAhead: cfg.MaxCommitsAhead,
+	}
+
+	if cfg.TimeWindow != nil {
+		match.TimeWindow = &rules.TimeWindow{
+			Start:    cfg.TimeWindow.Start,
+			End:      cfg.TimeWindow.End,
+			Days:     cfg.TimeWindow.Days,
+			Timezone: cfg.TimeWindow.Timezone,
+		}
+	}
+
+	for i := range cfg.All {
+		match.All = append(match.All, convertMatchConfig(&cfg.All[i]))
+	}
+
+	for i := range cfg.Any {
+		match.Any = append(match.Any, convertMatchConfig(&cfg.Any[i]))
+	}
+
+	if cfg.Not != nil {
+		match.Not = convertMatchConfig(cfg.Not)
+	}
+
+	return match
+}
+
 // convertActionType converts a string action type to rules.ActionType.
 func convertActionType(actionType string) rules.ActionType {
 	switch actionType {
@@ -129,7 +231,31 @@ func convertActionType(actionType string) rules.ActionType {
 		return rules.ActionWarn
 	case "allow":
 		return rules.ActionAllow
+	case "ask":
+		return rules.ActionAsk
+	case "suggest":
+		return rules.ActionSuggest
+	case "poison":
+		return rules.ActionPoison
+	case "log":
+		return rules.ActionLog
 	default:
 		return rules.ActionBlock
 	}
 }
+
+// NewGitProvider creates the git data provider of rules, loading data from
+// the working directory of each hook.
+func NewGitProvider() *rules.RunnerGitProvider {
+	return rules.NewGitProvider(
+		git.NewCachedRunner(gitvalidators.NewGitRunner()),
+		rules.WithRunnerForDir(newCachedRunnerForDir),
+	)
+}
+
+// newCachedRunnerForDir creates a cached git runner for the repository of dir.
+//
+//nolint:ireturn // git.Runner is the interface consumers depend on
+func newCachedRunnerForDir(dir string) git.Runner {
+	return git.NewCachedRunner(gitvalidators.NewGitRunnerForPath(dir))
+}
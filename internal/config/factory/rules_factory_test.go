@@ -218,5 +218,196 @@ var _ = Describe("RulesFactory", func() {
 			rule := engine.GetRule("unknown-action-rule")
 			Expect(rule.Action.Type).To(Equal(rules.ActionBlock))
 		})
+
+		It("should convert nested match blocks", func() {
+			cfg := &config.Config{
+				Rules: &config.RulesConfig{
+					Rules: []config.RuleConfig{
+						{
+							Name: "nested-rule",
+							Match: &config.RuleMatchConfig{
+								ValidatorType: "git.push",
+								Not: &config.RuleMatchConfig{
+									Any: []config.RuleMatchConfig{
+										{RepoPattern: "**/sandbox/**"},
+										{BranchPattern: "release/*"},
+									},
+								},
+							},
+							Action: &config.RuleActionConfig{Type: "block"},
+						},
+					},
+				},
+			}
+
+			engine, err := rulesFactory.CreateRuleEngine(cfg)
+			Expect(err).NotTo(HaveOccurred())
+
+			rule := engine.GetRule("nested-rule")
+			Expect(rule.Match.Not).NotTo(BeNil())
+			Expect(rule.Match.Not.Any).To(HaveLen(2))
+			Expect(rule.Match.Not.Any[0].RepoPattern).To(Equal("**/sandbox/**"))
+			Expect(rule.Match.Not.Any[1].BranchPattern).To(Equal("release/*"))
+		})
+
+		It("should convert context conditions", func() {
+			maxCommands := 3
+			cfg := &config.Config{
+				Rules: &config.RulesConfig{
+					Rules: []config.RuleConfig{
+						{
+							Name: "context-rule",
+							Match: &config.RuleMatchConfig{
+								Env:                map[string]string{"AWS_PROFILE": "prod-*"},
+								CWDPattern:         "**/infra/**",
+								MaxSessionCommands: &maxCommands,
+								TimeWindow: &config.TimeWindowConfig{
+									Start:    "09:00",
+									End:      "18:00",
+									Days:     []string{"mon"},
+									Timezone: "UTC",
+								},
+							},
+							Action: &config.RuleActionConfig{Type: "warn"},
+						},
+					},
+				},
+			}
+
+			engine, err := rulesFactory.CreateRuleEngine(cfg)
+			Expect(err).NotTo(HaveOccurred())
+
+			rule := engine.GetRule("context-rule")
+			Expect(rule.Match.Env).To(HaveKeyWithValue("AWS_PROFILE", "prod-*"))
+			Expect(rule.Match.CWDPattern).To(Equal("**/infra/**"))
+			Expect(rule.Match.MinSessionCommands).To(BeNil())
+			Expect(rule.Match.MaxSessionCommands).To(HaveValue(Equal(3)))
+			Expect(rule.Match.TimeWindow).To(Equal(&rules.TimeWindow{
+				Start:    "09:00",
+				End:      "18:00",
+				Days:     []string{"mon"},
+				Timezone: "UTC",
+			}))
+		})
+
+		It("should merge pack rules with config rules overriding them", func() {
+			disabled := false
+			packRule := func(name, message string) config.RuleConfig {
+				return config.RuleConfig{
+					Name:   name,
+					Match:  &config.RuleMatchConfig{ValidatorType: "git.push"},
+					Action: &config.RuleActionConfig{Type: "block", Message: message},
+				}
+			}
+
+			cfg := &config.Config{
+				Rules: &config.RulesConfig{
+					ResolvedPacks: []config.RulePack{
+						{
+							Name:    "base",
+							Version: "1.0.0",
+							Rules: []config.RuleConfig{
+								packRule("shared", "from base"),
+								packRule("base-only", "from base"),
+								packRule("disabled-by-config", "from base"),
+							},
+						},
+						{
+							Name:    "team",
+							Version: "2.0.0",
+							Rules:   []config.RuleConfig{packRule("shared", "from team")},
+						},
+					},
+					Rules: []config.RuleConfig{
+						packRule("base-only", "from config"),
+						{Name: "disabled-by-config", Enabled: &disabled},
+					},
+				},
+			}
+
+			engine, err := rulesFactory.CreateRuleEngine(cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(engine.Size()).To(Equal(2))
+			Expect(engine.GetRule("shared").Action.Message).To(Equal("from team"))
+			Expect(engine.GetRule("base-only").Action.Message).To(Equal("from config"))
+			Expect(engine.GetRule("disabled-by-config")).To(BeNil())
+		})
+
+		It("should convert git state conditions", func() {
+			dirty := true
+			minAhead := 1
+			cfg := &config.Config{
+				Rules: &config.RulesConfig{
+					Rules: []config.RuleConfig{
+						{
+							Name: "git-rule",
+							Match: &config.RuleMatchConfig{
+								RemoteHost:      "github.com",
+								RemoteOwner:     "Kong",
+								RemoteRepo:      "kong",
+								StagedFiles:     []string{"**/*.tf"},
+								Dirty:           &dirty,
+								MinCommitsAhead: &minAhead,
+							},
+							Action: &config.RuleActionConfig{Type: "block"},
+						},
+					},
+				},
+			}
+
+			engine, err := rulesFactory.CreateRuleEngine(cfg)
+			Expect(err).NotTo(HaveOccurred())
+
+			rule := engine.GetRule("git-rule")
+			Expect(rule.Match.RemoteHost).To(Equal("github.com"))
+			Expect(rule.Match.RemoteOwner).To(Equal("Kong"))
+			Expect(rule.Match.RemoteRepo).To(Equal("kong"))
+			Expect(rule.Match.StagedFiles).To(Equal([]string{"**/*.tf"}))
+			Expect(rule.Match.Dirty).To(HaveValue(BeTrue()))
+			Expect(rule.Match.MinCommitsAhead).To(HaveValue(Equal(1)))
+			Expect(rule.Match.MaxCommitsAhead).To(BeNil())
+		})
+
+		It("should convert rule tests", func() {
+			cfg := &config.Config{
+				Rules: &config.RulesConfig{
+					Rules: []config.RuleConfig{
+						{
+							Name:   "tested-rule",
+							Match:  &config.RuleMatchConfig{BranchPattern: "main"},
+							Action: &config.RuleActionConfig{Type: "block"},
+							Tests: []config.RuleTestConfig{
+								{
+									Name:          "push to main",
+									ValidatorType: "git.push",
+									Command:       "git push origin main",
+									Branch:        "main",
+									Matched:       true,
+									Action:        "block",
+								},
+								{Branch: "feat/x"},
+							},
+						},
+					},
+				},
+			}
+
+			engine, err := rulesFactory.CreateRuleEngine(cfg)
+			Expect(err).NotTo(HaveOccurred())
+
+			tests := engine.GetRule("tested-rule").Tests
+			Expect(tests).To(HaveLen(2))
+			Expect(tests[0].Name).To(Equal("push to main"))
+			Expect(tests[0].Input.ValidatorType).To(Equal(rules.ValidatorGitPush))
+			Expect(tests[0].Input.Command).To(Equal("git push origin main"))
+			Expect(tests[0].Matched).To(BeTrue())
+			Expect(tests[0].Action).To(Equal(rules.ActionBlock))
+			Expect(tests[1].Action).To(BeEmpty())
+
+			results := engine.RunTests()
+			Expect(results).To(HaveLen(2))
+			Expect(results[0].Passed()).To(BeTrue())
+			Expect(results[1].Passed()).To(BeTrue())
+		})
 	})
 })
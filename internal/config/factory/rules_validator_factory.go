@@ -0,0 +1,43 @@
+package factory
+
+import (
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// RulesValidatorFactory creates the standalone rules validator.
+type RulesValidatorFactory struct {
+	log        logger.Logger
+	ruleEngine *rules.RuleEngine
+}
+
+// NewRulesValidatorFactory creates a new RulesValidatorFactory.
+func NewRulesValidatorFactory(log logger.Logger) *RulesValidatorFactory {
+	return &RulesValidatorFactory{log: log}
+}
+
+// SetRuleEngine sets the rule engine for the factory.
+func (f *RulesValidatorFactory) SetRuleEngine(engine *rules.RuleEngine) {
+	f.ruleEngine = engine
+}
+
+// CreateValidators creates the standalone rules validator if a rule engine is
+// set and the standalone validator is not disabled. coveredBy matches the
+// hook contexts for which built-in validators evaluate rules.
+func (f *RulesValidatorFactory) CreateValidators(
+	cfg *config.Config,
+	coveredBy validator.Predicate,
+) []ValidatorWithPredicate {
+	if f.ruleEngine == nil || !cfg.Rules.IsStandaloneEnabled() {
+		return nil
+	}
+
+	return []ValidatorWithPredicate{
+		{
+			Validator: rules.NewValidator(f.ruleEngine, f.log, coveredBy),
+			Predicate: validator.Always(),
+		},
+	}
+}
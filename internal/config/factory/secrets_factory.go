@@ -62,12 +62,24 @@ func (f *SecretsValidatorFactory) CreateValidators(cfg *config.Config) []Validat
 		)
 	}
 
-	validators = append(validators, ValidatorWithPredicate{
-		Validator: secrets.NewSecretsValidator(f.log, detector, gitleaks, secretsCfg, ruleAdapter),
-		Predicate: validator.And(
+	predicates := []validator.Predicate{
+		validator.And(
 			validator.EventTypeIs(hook.EventTypePreToolUse),
 			validator.ToolTypeIn(hook.ToolTypeWrite, hook.ToolTypeEdit),
 		),
+		validator.EventTypeIs(hook.EventTypeUserPromptSubmit),
+	}
+
+	if secretsCfg.IsCheckToolOutputEnabled() {
+		predicates = append(predicates, validator.And(
+			validator.EventTypeIs(hook.EventTypePostToolUse),
+			validator.ToolTypeIs(hook.ToolTypeBash),
+		))
+	}
+
+	validators = append(validators, ValidatorWithPredicate{
+		Validator: secrets.NewSecretsValidator(f.log, detector, gitleaks, secretsCfg, ruleAdapter),
+		Predicate: validator.Or(predicates...),
 	})
 
 	return validators
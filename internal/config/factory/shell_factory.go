@@ -1,8 +1,10 @@
 package factory
 
 import (
+	"github.com/smykla-labs/klaudiush/internal/git"
 	"github.com/smykla-labs/klaudiush/internal/rules"
 	"github.com/smykla-labs/klaudiush/internal/validator"
+	gitvalidators "github.com/smykla-labs/klaudiush/internal/validators/git"
 	shellvalidators "github.com/smykla-labs/klaudiush/internal/validators/shell"
 	"github.com/smykla-labs/klaudiush/pkg/config"
 	"github.com/smykla-labs/klaudiush/pkg/hook"
@@ -41,6 +43,20 @@ func (f *ShellValidatorFactory) CreateValidators(cfg *config.Config) []Validator
 		validators = append(validators, f.createBacktickValidator(cfg.Validators.Shell.Backtick))
 	}
 
+	if cfg.Validators.Shell.Destructive != nil && cfg.Validators.Shell.Destructive.IsEnabled() {
+		validators = append(
+			validators,
+			f.createDestructiveValidator(cfg.Validators.Shell.Destructive),
+		)
+	}
+
+	if cfg.Validators.Shell.TestFailure != nil && cfg.Validators.Shell.TestFailure.IsEnabled() {
+		validators = append(
+			validators,
+			f.createTestFailureValidator(cfg.Validators.Shell.TestFailure),
+		)
+	}
+
 	return validators
 }
 
@@ -72,3 +88,53 @@ func (f *ShellValidatorFactory) createBacktickValidator(
 		),
 	}
 }
+
+func (f *ShellValidatorFactory) createDestructiveValidator(
+	cfg *config.DestructiveValidatorConfig,
+) ValidatorWithPredicate {
+	var ruleAdapter *rules.RuleValidatorAdapter
+	if f.ruleEngine != nil {
+		ruleAdapter = rules.NewRuleValidatorAdapter(
+			f.ruleEngine,
+			rules.ValidatorShellDestructive,
+			rules.WithAdapterLogger(f.log),
+		)
+	}
+
+	gitRunner := git.NewCachedRunner(gitvalidators.NewGitRunner())
+
+	return ValidatorWithPredicate{
+		Validator: shellvalidators.NewDestructiveValidator(
+			f.log,
+			gitRunner,
+			cfg,
+			ruleAdapter,
+			shellvalidators.WithDestructiveRunnerForDir(newCachedRunnerForDir),
+		),
+		Predicate: validator.And(
+			validator.EventTypeIs(hook.EventTypePreToolUse),
+			validator.ToolTypeIs(hook.ToolTypeBash),
+		),
+	}
+}
+
+func (f *ShellValidatorFactory) createTestFailureValidator(
+	cfg *config.TestFailureValidatorConfig,
+) ValidatorWithPredicate {
+	var ruleAdapter *rules.RuleValidatorAdapter
+	if f.ruleEngine != nil {
+		ruleAdapter = rules.NewRuleValidatorAdapter(
+			f.ruleEngine,
+			rules.ValidatorShellTestFailure,
+			rules.WithAdapterLogger(f.log),
+		)
+	}
+
+	return ValidatorWithPredicate{
+		Validator: shellvalidators.NewTestFailureValidator(f.log, cfg, ruleAdapter),
+		Predicate: validator.And(
+			validator.EventTypeIs(hook.EventTypePostToolUse),
+			validator.ToolTypeIs(hook.ToolTypeBash),
+		),
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/knadh/koanf/v2"
 
 	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
 )
 
 var (
@@ -90,11 +91,32 @@ type KoanfLoader struct {
 	k        *koanf.Koanf
 	homeDir  string
 	workDir  string
+	version  string
 	tomlOpts koanf.UnmarshalConf
+	log      logger.Logger
+}
+
+// LoaderOption configures a KoanfLoader.
+type LoaderOption func(*KoanfLoader)
+
+// WithVersion sets the running klaudiush version, used to check the
+// klaudiush_version requirement of rule packs. Without it, or with a version
+// that is not a semantic version (e.g., "dev"), the check is skipped.
+func WithVersion(version string) LoaderOption {
+	return func(l *KoanfLoader) {
+		l.version = version
+	}
+}
+
+// WithLoaderLogger sets the logger for warnings about skipped rule packs.
+func WithLoaderLogger(log logger.Logger) LoaderOption {
+	return func(l *KoanfLoader) {
+		l.log = log
+	}
 }
 
 // NewKoanfLoader creates a new KoanfLoader with default directories.
-func NewKoanfLoader() (*KoanfLoader, error) {
+func NewKoanfLoader(opts ...LoaderOption) (*KoanfLoader, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get home directory")
@@ -105,14 +127,14 @@ func NewKoanfLoader() (*KoanfLoader, error) {
 		return nil, errors.Wrap(err, "failed to get working directory")
 	}
 
-	return NewKoanfLoaderWithDirs(homeDir, workDir)
+	return NewKoanfLoaderWithDirs(homeDir, workDir, opts...)
 }
 
 // NewKoanfLoaderWithDirs creates a new KoanfLoader with custom directories (for testing).
-func NewKoanfLoaderWithDirs(homeDir, workDir string) (*KoanfLoader, error) {
+func NewKoanfLoaderWithDirs(homeDir, workDir string, opts ...LoaderOption) (*KoanfLoader, error) {
 	k := koanf.New(".")
 
-	return &KoanfLoader{
+	l := &KoanfLoader{
 		k:       k,
 		homeDir: homeDir,
 		workDir: workDir,
@@ -120,7 +142,14 @@ func NewKoanfLoaderWithDirs(homeDir, workDir string) (*KoanfLoader, error) {
 			Tag:       "koanf",
 			FlatPaths: false,
 		},
-	}, nil
+		log: logger.NewNoOpLogger(),
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l, nil
 }
 
 // Load loads configuration from all sources with precedence.
@@ -129,6 +158,7 @@ func NewKoanfLoaderWithDirs(homeDir, workDir string) (*KoanfLoader, error) {
 // Rules have special merge semantics:
 // - Rules with the same name: project overrides global
 // - Rules with different names: combined (both included)
+// - Rule packs go to Rules.ResolvedPacks; config rules override pack rules
 func (l *KoanfLoader) Load(flags map[string]any) (*config.Config, error) {
 	cfg, err := l.LoadWithoutValidation(flags)
 	if err != nil {
@@ -150,33 +180,61 @@ func (l *KoanfLoader) LoadWithoutValidation(flags map[string]any) (*config.Confi
 	// Reset koanf instance for fresh load
 	l.k = koanf.New(".")
 
-	// Track rules from each source for proper merging
+	// Track rules and packs from each source for proper merging
 	var globalRules []config.RuleConfig
 
 	var projectRules []config.RuleConfig
 
+	var packs []config.RulePack
+
 	// 1. Load defaults first (lowest priority)
 	defaults := defaultsToMap()
 	if err := l.k.Load(confmap.Provider(defaults, "."), nil); err != nil {
 		return nil, errors.Wrap(err, "failed to load defaults")
 	}
 
-	// 2. Global config: ~/.klaudiush/config.toml
+	// 2. Global config: ~/.klaudiush/config.toml. In the home directory, the
+	// global config is also the project config and is loaded once, as such.
 	globalPath := l.GlobalConfigPath()
-	if err := l.loadTOMLFile(globalPath); err != nil && !os.IsNotExist(err) {
-		return nil, errors.Wrap(err, "failed to load global config")
-	} else if err == nil {
-		globalRules = l.extractRules()
+	projectPath := l.findProjectConfig()
+
+	if globalPath != projectPath {
+		fileK, err := l.loadTOMLFile(globalPath)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, errors.Wrap(err, "failed to load global config")
+		}
+
+		if err == nil {
+			globalRules, err = l.extractRules(fileK, "rules.rules", globalPath)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to load global rules")
+			}
+
+			packs, err = l.resolvePacks(fileK.Strings("rules.packs"), filepath.Dir(globalPath))
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to load global rule packs")
+			}
+		}
 	}
 
 	// 3. Project config: .klaudiush/config.toml or klaudiush.toml
-	projectPath := l.findProjectConfig()
 	if projectPath != "" {
-		if err := l.loadTOMLFile(projectPath); err != nil {
+		fileK, err := l.loadTOMLFile(projectPath)
+		if err != nil {
 			return nil, errors.Wrap(err, "failed to load project config")
 		}
 
-		projectRules = l.extractRules()
+		projectRules, err = l.extractRules(fileK, "rules.rules", projectPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load project rules")
+		}
+
+		projectPacks, err := l.resolvePacks(fileK.Strings("rules.packs"), l.workDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load project rule packs")
+		}
+
+		packs = appendNewPacks(packs, projectPacks)
 	}
 
 	// 4. Environment variables: KLAUDIUSH_*
@@ -211,17 +269,23 @@ func (l *KoanfLoader) LoadWithoutValidation(flags map[string]any) (*config.Confi
 	}
 
 	cfg.Rules.Rules = mergedRules
+	cfg.Rules.ResolvedPacks = packs
 
 	return &cfg, nil
 }
 
-// extractRules extracts rules from the current koanf state.
-func (l *KoanfLoader) extractRules() []config.RuleConfig {
-	rulesSlice := l.k.Slices("rules.rules")
+// extractRules extracts the rules at key from k, recording source as the
+// file they were loaded from.
+func (l *KoanfLoader) extractRules(
+	k *koanf.Koanf,
+	key string,
+	source string,
+) ([]config.RuleConfig, error) {
+	rulesSlice := k.Slices(key)
 	rules := make([]config.RuleConfig, 0, len(rulesSlice))
 
 	for _, ruleK := range rulesSlice {
-		var rule config.RuleConfig
+		rule := config.RuleConfig{Source: source}
 
 		// Extract rule fields from the slice element
 		rule.Name = ruleK.String("name")
@@ -233,34 +297,37 @@ func (l *KoanfLoader) extractRules() []config.RuleConfig {
 			rule.Enabled = &enabled
 		}
 
-		// Extract match conditions
+		// Extract match conditions, including pattern lists and nested
+		// all/any/not blocks
 		if ruleK.Exists("match") {
-			rule.Match = &config.RuleMatchConfig{
-				ValidatorType:  ruleK.String("match.validator_type"),
-				RepoPattern:    ruleK.String("match.repo_pattern"),
-				Remote:         ruleK.String("match.remote"),
-				BranchPattern:  ruleK.String("match.branch_pattern"),
-				FilePattern:    ruleK.String("match.file_pattern"),
-				ContentPattern: ruleK.String("match.content_pattern"),
-				CommandPattern: ruleK.String("match.command_pattern"),
-				ToolType:       ruleK.String("match.tool_type"),
-				EventType:      ruleK.String("match.event_type"),
+			var match config.RuleMatchConfig
+			if err := ruleK.UnmarshalWithConf("match", &match, l.tomlOpts); err != nil {
+				return nil, errors.Wrapf(err, "failed to unmarshal match of rule %q", rule.Name)
 			}
+
+			rule.Match = &match
 		}
 
 		// Extract action
 		if ruleK.Exists("action") {
 			rule.Action = &config.RuleActionConfig{
-				Type:      ruleK.String("action.type"),
-				Message:   ruleK.String("action.message"),
-				Reference: ruleK.String("action.reference"),
+				Type:       ruleK.String("action.type"),
+				Message:    ruleK.String("action.message"),
+				Reference:  ruleK.String("action.reference"),
+				Suggestion: ruleK.String("action.suggestion"),
+			}
+		}
+
+		if ruleK.Exists("tests") {
+			if err := ruleK.UnmarshalWithConf("tests", &rule.Tests, l.tomlOpts); err != nil {
+				return nil, errors.Wrapf(err, "failed to unmarshal tests of rule %q", rule.Name)
 			}
 		}
 
 		rules = append(rules, rule)
 	}
 
-	return rules
+	return rules, nil
 }
 
 // mergeRules merges global and project rules.
@@ -318,17 +385,33 @@ func mergeRules(globalRules, projectRules []config.RuleConfig) []config.RuleConf
 	return merged
 }
 
-// loadTOMLFile loads a TOML configuration file with security checks.
-func (l *KoanfLoader) loadTOMLFile(path string) error {
+// loadTOMLFile loads a TOML configuration file with security checks and
+// merges it into the loader state. Returns the file's own configuration.
+func (l *KoanfLoader) loadTOMLFile(path string) (*koanf.Koanf, error) {
+	fileK, err := readTOMLFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.k.Merge(fileK); err != nil {
+		return nil, err
+	}
+
+	return fileK, nil
+}
+
+// readTOMLFile reads a TOML file into a new koanf instance, rejecting
+// world-writable files.
+func readTOMLFile(path string) (*koanf.Koanf, error) {
 	// Check if file exists
 	info, err := os.Stat(path)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Security check: reject world-writable files
 	if info.Mode().Perm()&0o002 != 0 {
-		return errors.Wrapf(
+		return nil, errors.Wrapf(
 			ErrInvalidPermissions,
 			"%s is world-writable (mode: %s)",
 			path,
@@ -336,7 +419,12 @@ func (l *KoanfLoader) loadTOMLFile(path string) error {
 		)
 	}
 
-	return l.k.Load(file.Provider(path), tomlparser.Parser())
+	k := koanf.New(".")
+	if err := k.Load(file.Provider(path), tomlparser.Parser()); err != nil {
+		return nil, err
+	}
+
+	return k, nil
 }
 
 // envTransform transforms environment variable names to config paths.
@@ -446,6 +534,12 @@ func (*KoanfLoader) flagsToConfig(flags map[string]any) map[string]any {
 				globalMap := ensureMapKey(result, "global")
 				globalMap["default_timeout"] = strVal
 			}
+
+		case "output-format":
+			if strVal, ok := value.(string); ok && strVal != "" {
+				outputMap := ensureMapKey(result, "output")
+				outputMap["format"] = strVal
+			}
 		}
 	}
 
@@ -516,6 +610,8 @@ func defaultRulesMap() map[string]any {
 	return map[string]any{
 		"enabled":             true,
 		"stop_on_first_match": true,
+		"standalone":          true,
+		"stats":               true,
 		"rules":               []any{},
 	}
 }
@@ -600,10 +696,13 @@ func defaultCommitMap() map[string]any {
 
 func defaultPushMap() map[string]any {
 	return map[string]any{
-		"enabled":          true,
-		"severity":         "error",
-		"blocked_remotes":  []string{},
-		"require_tracking": true,
+		"enabled":                    true,
+		"severity":                   "error",
+		"blocked_remotes":            []string{},
+		"require_tracking":           true,
+		"block_force_push_protected": true,
+		"require_force_with_lease":   true,
+		"block_remote_delete":        true,
 	}
 }
 
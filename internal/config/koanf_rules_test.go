@@ -195,6 +195,201 @@ message = "Don't push to origin"
 			Expect(cfg.Rules.Rules[0].Action.Type).To(Equal("block"))
 		})
 
+		It("should load pattern lists and nested match blocks", func() {
+			projectDir := filepath.Join(workDir, ProjectConfigDir)
+			Expect(os.MkdirAll(projectDir, 0o755)).To(Succeed())
+
+			projectConfig := `
+[[rules.rules]]
+name = "protect-main"
+[rules.rules.match]
+validator_type = "git.push"
+branch_patterns = ["main", "master"]
+
+[rules.rules.match.not]
+any = [
+  { repo_pattern = "**/sandbox/**" },
+  { branch_pattern = "release/*" },
+]
+
+[rules.rules.action]
+type = "block"
+`
+			err := os.WriteFile(
+				filepath.Join(projectDir, ProjectConfigFile),
+				[]byte(projectConfig),
+				0o600,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			cfg, err := loader.Load(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Rules.Rules).To(HaveLen(1))
+
+			match := cfg.Rules.Rules[0].Match
+			Expect(match.BranchPatterns).To(Equal([]string{"main", "master"}))
+			Expect(match.Not).NotTo(BeNil())
+			Expect(match.Not.Any).To(HaveLen(2))
+			Expect(match.Not.Any[0].RepoPattern).To(Equal("**/sandbox/**"))
+			Expect(match.Not.Any[1].BranchPattern).To(Equal("release/*"))
+		})
+
+		It("should load suggest action templates", func() {
+			projectDir := filepath.Join(workDir, ProjectConfigDir)
+			Expect(os.MkdirAll(projectDir, 0o755)).To(Succeed())
+
+			projectConfig := `
+[[rules.rules]]
+name = "force-with-lease"
+[rules.rules.match]
+program = "git"
+flags = ["--force"]
+[rules.rules.action]
+type = "suggest"
+suggestion = '{{replace "--force" "--force-with-lease" .Command}}'
+`
+			err := os.WriteFile(
+				filepath.Join(projectDir, ProjectConfigFile),
+				[]byte(projectConfig),
+				0o600,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			cfg, err := loader.Load(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Rules.Rules).To(HaveLen(1))
+			Expect(cfg.Rules.Rules[0].Action.Type).To(Equal("suggest"))
+			Expect(cfg.Rules.Rules[0].Action.Suggestion).To(
+				Equal(`{{replace "--force" "--force-with-lease" .Command}}`),
+			)
+		})
+
+		It("should load context conditions", func() {
+			projectDir := filepath.Join(workDir, ProjectConfigDir)
+			Expect(os.MkdirAll(projectDir, 0o755)).To(Succeed())
+
+			projectConfig := `
+[[rules.rules]]
+name = "prod-terraform"
+[rules.rules.match]
+program = "terraform"
+cwd_pattern = "**/infra/**"
+env = { AWS_PROFILE = "prod-*" }
+max_session_commands = 3
+[rules.rules.match.time_window]
+start = "09:00"
+end = "18:00"
+days = ["mon", "fri"]
+timezone = "UTC"
+`
+			err := os.WriteFile(
+				filepath.Join(projectDir, ProjectConfigFile),
+				[]byte(projectConfig),
+				0o600,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			cfg, err := loader.Load(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Rules.Rules).To(HaveLen(1))
+
+			match := cfg.Rules.Rules[0].Match
+			Expect(match.CWDPattern).To(Equal("**/infra/**"))
+			Expect(match.Env).To(Equal(map[string]string{"AWS_PROFILE": "prod-*"}))
+			Expect(match.MinSessionCommands).To(BeNil())
+			Expect(match.MaxSessionCommands).To(HaveValue(Equal(3)))
+			Expect(match.TimeWindow).To(Equal(&config.TimeWindowConfig{
+				Start:    "09:00",
+				End:      "18:00",
+				Days:     []string{"mon", "fri"},
+				Timezone: "UTC",
+			}))
+		})
+
+		It("should load git state conditions", func() {
+			projectDir := filepath.Join(workDir, ProjectConfigDir)
+			Expect(os.MkdirAll(projectDir, 0o755)).To(Succeed())
+
+			projectConfig := `
+[[rules.rules]]
+name = "org-push"
+[rules.rules.match]
+program = "git"
+subcommand = "push"
+remote_host = "github.com"
+remote_owner = "Kong"
+remote_repo = "kong"
+staged_files = ["**/*.tf"]
+dirty = false
+min_commits_ahead = 1
+`
+			err := os.WriteFile(
+				filepath.Join(projectDir, ProjectConfigFile),
+				[]byte(projectConfig),
+				0o600,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			cfg, err := loader.Load(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Rules.Rules).To(HaveLen(1))
+
+			match := cfg.Rules.Rules[0].Match
+			Expect(match.RemoteHost).To(Equal("github.com"))
+			Expect(match.RemoteOwner).To(Equal("Kong"))
+			Expect(match.RemoteRepo).To(Equal("kong"))
+			Expect(match.StagedFiles).To(Equal([]string{"**/*.tf"}))
+			Expect(match.Dirty).To(HaveValue(BeFalse()))
+			Expect(match.MinCommitsAhead).To(HaveValue(Equal(1)))
+			Expect(match.MaxCommitsAhead).To(BeNil())
+		})
+
+		It("should load rule tests", func() {
+			projectDir := filepath.Join(workDir, ProjectConfigDir)
+			Expect(os.MkdirAll(projectDir, 0o755)).To(Succeed())
+
+			projectConfig := `
+[[rules.rules]]
+name = "block-main"
+[rules.rules.match]
+branch_pattern = "main"
+[rules.rules.action]
+type = "block"
+
+[[rules.rules.tests]]
+name = "push to main"
+validator_type = "git.push"
+command = "git push origin main"
+branch = "main"
+matched = true
+action = "block"
+
+[[rules.rules.tests]]
+branch = "feat/x"
+`
+			err := os.WriteFile(
+				filepath.Join(projectDir, ProjectConfigFile),
+				[]byte(projectConfig),
+				0o600,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			cfg, err := loader.Load(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Rules.Rules).To(HaveLen(1))
+			Expect(cfg.Rules.Rules[0].Tests).To(Equal([]config.RuleTestConfig{
+				{
+					Name:          "push to main",
+					ValidatorType: "git.push",
+					Command:       "git push origin main",
+					Branch:        "main",
+					Matched:       true,
+					Action:        "block",
+				},
+				{Branch: "feat/x"},
+			}))
+		})
+
 		It("should load rules from project config", func() {
 			projectDir := filepath.Join(workDir, ProjectConfigDir)
 			Expect(os.MkdirAll(projectDir, 0o755)).To(Succeed())
@@ -0,0 +1,333 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/pkg/config"
+)
+
+var (
+	// ErrPackNotFound is returned when a rule pack reference cannot be resolved.
+	ErrPackNotFound = errors.New("rule pack not found")
+
+	// ErrInvalidPack is returned when a rule pack has invalid metadata or rules.
+	ErrInvalidPack = errors.New("invalid rule pack")
+
+	// ErrIncompatiblePack is returned when a rule pack requires a different
+	// klaudiush version than the running one.
+	ErrIncompatiblePack = errors.New("rule pack requires a different klaudiush version")
+)
+
+const (
+	// PacksDir is the directory name for rule packs, relative to the global
+	// and project configuration directories.
+	PacksDir = "packs"
+
+	// PackFile is the metadata file of a directory rule pack.
+	PackFile = "pack.toml"
+
+	// packFileExt is the extension of single-file rule packs.
+	packFileExt = ".toml"
+
+	// packVersionSeparator separates the pack name and version constraint in
+	// pack references (e.g., "kong-oss@1").
+	packVersionSeparator = "@"
+)
+
+// PackSearchDirs returns the directories rule packs are resolved from by
+// name, in order of precedence.
+func (l *KoanfLoader) PackSearchDirs() []string {
+	return []string{
+		filepath.Join(l.workDir, ProjectConfigDir, PacksDir),
+		filepath.Join(l.homeDir, GlobalConfigDir, PacksDir),
+	}
+}
+
+// resolvePacks resolves and loads the referenced rule packs. Relative pack
+// paths are resolved against baseDir.
+func (l *KoanfLoader) resolvePacks(refs []string, baseDir string) ([]config.RulePack, error) {
+	packs := make([]config.RulePack, 0, len(refs))
+
+	for _, ref := range refs {
+		pack, err := l.resolvePack(ref, baseDir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "rule pack %q", ref)
+		}
+
+		packs = appendNewPacks(packs, []config.RulePack{*pack})
+	}
+
+	return packs, nil
+}
+
+// appendNewPacks appends the packs whose path is not already in packs.
+func appendNewPacks(packs, newPacks []config.RulePack) []config.RulePack {
+	for _, pack := range newPacks {
+		loaded := slices.ContainsFunc(packs, func(p config.RulePack) bool {
+			return p.Path == pack.Path
+		})
+
+		if !loaded {
+			packs = append(packs, pack)
+		}
+	}
+
+	return packs
+}
+
+// resolvePack resolves a pack reference, which is either a path or a pack
+// name with an optional version constraint, and loads the pack.
+func (l *KoanfLoader) resolvePack(ref, baseDir string) (*config.RulePack, error) {
+	var (
+		pack *config.RulePack
+		err  error
+	)
+
+	if isPackPath(ref) {
+		pack, err = l.loadPack(l.expandPackPath(ref, baseDir))
+	} else {
+		pack, err = l.findPack(ref)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.checkPackCompatibility(pack); err != nil {
+		return nil, err
+	}
+
+	return pack, nil
+}
+
+// isPackPath reports whether a pack reference is a path rather than a name.
+func isPackPath(ref string) bool {
+	return strings.HasPrefix(ref, ".") ||
+		strings.HasPrefix(ref, "~") ||
+		strings.ContainsRune(ref, filepath.Separator) ||
+		filepath.IsAbs(ref)
+}
+
+// expandPackPath expands "~" and resolves relative paths against baseDir.
+func (l *KoanfLoader) expandPackPath(ref, baseDir string) string {
+	if rest, ok := strings.CutPrefix(ref, "~"); ok {
+		return filepath.Join(l.homeDir, rest)
+	}
+
+	if filepath.IsAbs(ref) {
+		return ref
+	}
+
+	return filepath.Join(baseDir, ref)
+}
+
+// findPack finds the pack with the given name in the pack search
+// directories. The first directory with a matching pack wins; within it, the
+// highest version satisfying the constraint is used. Candidates that cannot
+// be loaded are skipped with a warning; the error of the last one is returned
+// if no candidate matches.
+func (l *KoanfLoader) findPack(ref string) (*config.RulePack, error) {
+	name, constraintStr, _ := strings.Cut(ref, packVersionSeparator)
+
+	var constraint *semver.Constraints
+
+	if constraintStr != "" {
+		var err error
+
+		constraint, err = semver.NewConstraint(constraintStr)
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidPack, "invalid version constraint %q", constraintStr)
+		}
+	}
+
+	searchDirs := l.PackSearchDirs()
+
+	var loadErr error
+
+	for _, dir := range searchDirs {
+		var (
+			best        *config.RulePack
+			bestVersion *semver.Version
+		)
+
+		for _, path := range packCandidates(dir, name) {
+			pack, err := l.loadPack(path)
+			if err != nil {
+				l.log.Info("skipping rule pack that cannot be loaded", "path", path, "error", err)
+
+				loadErr = err
+
+				continue
+			}
+
+			if pack.Name != name {
+				continue
+			}
+
+			version := semver.MustParse(pack.Version)
+			if constraint != nil && !constraint.Check(version) {
+				continue
+			}
+
+			if best == nil || version.GreaterThan(bestVersion) {
+				best, bestVersion = pack, version
+			}
+		}
+
+		if best != nil {
+			return best, nil
+		}
+	}
+
+	if loadErr != nil {
+		return nil, loadErr
+	}
+
+	return nil, errors.Wrapf(ErrPackNotFound, "searched %s", strings.Join(searchDirs, ", "))
+}
+
+// packCandidates returns the entries of dir that may hold the named pack:
+// "<name>", "<name>.toml", "<name>@<version>" and "<name>@<version>.toml".
+func packCandidates(dir, name string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var candidates []string
+
+	for _, entry := range entries {
+		base := strings.TrimSuffix(entry.Name(), packFileExt)
+
+		if base == name || strings.HasPrefix(base, name+packVersionSeparator) {
+			candidates = append(candidates, filepath.Join(dir, entry.Name()))
+		}
+	}
+
+	return candidates
+}
+
+// loadPack loads a rule pack from a single TOML file, or from a directory
+// with a pack.toml file and optional additional *.toml rule files.
+func (l *KoanfLoader) loadPack(path string) (*config.RulePack, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrapf(ErrPackNotFound, "%s", path)
+	}
+
+	files := []string{path}
+
+	if info.IsDir() {
+		ruleFiles, err := filepath.Glob(filepath.Join(path, "*"+packFileExt))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list %s", path)
+		}
+
+		metadataFile := filepath.Join(path, PackFile)
+		if !slices.Contains(ruleFiles, metadataFile) {
+			return nil, errors.Wrapf(ErrInvalidPack, "%s has no %s", path, PackFile)
+		}
+
+		files = append([]string{metadataFile}, slices.DeleteFunc(ruleFiles, func(file string) bool {
+			return file == metadataFile
+		})...)
+	}
+
+	pack := &config.RulePack{Path: path}
+
+	for i, file := range files {
+		fileK, err := readTOMLFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load %s", file)
+		}
+
+		if i == 0 {
+			if err := fileK.UnmarshalWithConf("pack", pack, l.tomlOpts); err != nil {
+				return nil, errors.Wrapf(err, "failed to unmarshal metadata of %s", file)
+			}
+		}
+
+		rules, err := l.extractRules(fileK, "rules", file)
+		if err != nil {
+			return nil, err
+		}
+
+		pack.Rules = append(pack.Rules, rules...)
+	}
+
+	if err := validatePack(pack); err != nil {
+		return nil, err
+	}
+
+	for i := range pack.Rules {
+		pack.Rules[i].Pack = pack.ID()
+	}
+
+	return pack, nil
+}
+
+// validatePack validates the pack metadata and requires every pack rule to
+// have a name, so that configuration rules can override it.
+func validatePack(pack *config.RulePack) error {
+	if pack.Name == "" {
+		return errors.Wrapf(ErrInvalidPack, "%s has no pack.name", pack.Path)
+	}
+
+	if _, err := semver.NewVersion(pack.Version); err != nil {
+		return errors.Wrapf(
+			ErrInvalidPack,
+			"%s has invalid pack.version %q",
+			pack.Path,
+			pack.Version,
+		)
+	}
+
+	for i, rule := range pack.Rules {
+		if rule.Name == "" {
+			return errors.Wrapf(ErrInvalidPack, "%s has unnamed rule[%d]", pack.Path, i)
+		}
+	}
+
+	return nil
+}
+
+// checkPackCompatibility checks the running version against the pack's
+// klaudiush_version constraint. Unknown and development versions pass.
+func (l *KoanfLoader) checkPackCompatibility(pack *config.RulePack) error {
+	if pack.KlaudiushVersion == "" {
+		return nil
+	}
+
+	constraint, err := semver.NewConstraint(pack.KlaudiushVersion)
+	if err != nil {
+		return errors.Wrapf(
+			ErrInvalidPack,
+			"%s has invalid pack.klaudiush_version %q",
+			pack.ID(),
+			pack.KlaudiushVersion,
+		)
+	}
+
+	running, err := semver.NewVersion(l.version)
+	if err != nil {
+		return nil //nolint:nilerr // development builds have no semantic version
+	}
+
+	if !constraint.Check(running) {
+		return errors.Wrapf(
+			ErrIncompatiblePack,
+			"%s requires klaudiush %s, running %s",
+			pack.ID(),
+			pack.KlaudiushVersion,
+			l.version,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,243 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KoanfLoader rule packs", func() {
+	var (
+		homeDir string
+		workDir string
+	)
+
+	writeFile := func(path, content string) {
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+	}
+
+	pack := func(name, version, ruleName string) string {
+		return `
+[pack]
+name = "` + name + `"
+version = "` + version + `"
+
+[[rules]]
+name = "` + ruleName + `"
+[rules.match]
+command_pattern = "git push*"
+[rules.action]
+type = "block"
+`
+	}
+
+	globalPacks := func() string {
+		return filepath.Join(homeDir, GlobalConfigDir, PacksDir)
+	}
+
+	projectConfig := func(content string) {
+		writeFile(filepath.Join(workDir, ProjectConfigDir, ProjectConfigFile), content)
+	}
+
+	load := func(opts ...LoaderOption) *KoanfLoader {
+		loader, err := NewKoanfLoaderWithDirs(homeDir, workDir, opts...)
+		Expect(err).NotTo(HaveOccurred())
+
+		return loader
+	}
+
+	BeforeEach(func() {
+		homeDir = GinkgoT().TempDir()
+		workDir = GinkgoT().TempDir()
+	})
+
+	It("should load a directory pack by name", func() {
+		writeFile(filepath.Join(globalPacks(), "kong-oss", PackFile), `
+[pack]
+name = "kong-oss"
+version = "1.2.0"
+description = "Kong OSS policies"
+
+[[rules]]
+name = "no-force-push"
+[rules.match]
+command_pattern = "git push --force*"
+[rules.action]
+type = "block"
+`)
+		writeFile(filepath.Join(globalPacks(), "kong-oss", "secrets.toml"), `
+[[rules]]
+name = "no-env-files"
+[rules.match]
+file_pattern = "**/.env"
+[rules.action]
+type = "block"
+`)
+		projectConfig(`
+[rules]
+packs = ["kong-oss"]
+`)
+
+		cfg, err := load().Load(nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(cfg.Rules.ResolvedPacks).To(HaveLen(1))
+
+		loaded := cfg.Rules.ResolvedPacks[0]
+		Expect(loaded.ID()).To(Equal("kong-oss@1.2.0"))
+		Expect(loaded.Description).To(Equal("Kong OSS policies"))
+		Expect(loaded.Path).To(Equal(filepath.Join(globalPacks(), "kong-oss")))
+		Expect(loaded.Rules).To(HaveLen(2))
+		Expect(loaded.Rules[0].Name).To(Equal("no-force-push"))
+		Expect(loaded.Rules[0].Pack).To(Equal("kong-oss@1.2.0"))
+		Expect(loaded.Rules[1].Source).To(Equal(filepath.Join(globalPacks(), "kong-oss", "secrets.toml")))
+	})
+
+	It("should pick the highest version satisfying the constraint", func() {
+		writeFile(filepath.Join(globalPacks(), "kong-oss@1.0.0.toml"), pack("kong-oss", "1.0.0", "v100"))
+		writeFile(filepath.Join(globalPacks(), "kong-oss@1.2.0.toml"), pack("kong-oss", "1.2.0", "v120"))
+		writeFile(filepath.Join(globalPacks(), "kong-oss@2.0.0.toml"), pack("kong-oss", "2.0.0", "v200"))
+		projectConfig(`
+[rules]
+packs = ["kong-oss@1"]
+`)
+
+		cfg, err := load().Load(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Rules.ResolvedPacks).To(HaveLen(1))
+		Expect(cfg.Rules.ResolvedPacks[0].ID()).To(Equal("kong-oss@1.2.0"))
+	})
+
+	It("should skip versions that cannot be loaded", func() {
+		writeFile(filepath.Join(globalPacks(), "kong-oss@0.9.toml"), pack("kong-oss", "latest", "v09"))
+		writeFile(filepath.Join(globalPacks(), "kong-oss@1.2.toml"), pack("kong-oss", "1.2.0", "v120"))
+		projectConfig(`
+[rules]
+packs = ["kong-oss"]
+`)
+
+		cfg, err := load().Load(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Rules.ResolvedPacks).To(HaveLen(1))
+		Expect(cfg.Rules.ResolvedPacks[0].ID()).To(Equal("kong-oss@1.2.0"))
+	})
+
+	It("should prefer project packs over global packs", func() {
+		writeFile(filepath.Join(globalPacks(), "team.toml"), pack("team", "2.0.0", "global"))
+		writeFile(
+			filepath.Join(workDir, ProjectConfigDir, PacksDir, "team.toml"),
+			pack("team", "1.0.0", "project"),
+		)
+		projectConfig(`
+[rules]
+packs = ["team"]
+`)
+
+		cfg, err := load().Load(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Rules.ResolvedPacks[0].Rules[0].Name).To(Equal("project"))
+	})
+
+	It("should resolve pack paths against the config location", func() {
+		writeFile(filepath.Join(workDir, "ci", "klaudiush-rules", PackFile), pack("ci", "0.1.0", "ci-rule"))
+		writeFile(filepath.Join(homeDir, GlobalConfigDir, "mine.toml"), pack("mine", "1.0.0", "my-rule"))
+		writeFile(filepath.Join(homeDir, GlobalConfigDir, GlobalConfigFile), `
+[rules]
+packs = ["./mine.toml"]
+`)
+		projectConfig(`
+[rules]
+packs = ["./ci/klaudiush-rules"]
+`)
+
+		cfg, err := load().Load(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Rules.ResolvedPacks).To(HaveLen(2))
+		Expect(cfg.Rules.ResolvedPacks[0].ID()).To(Equal("mine@1.0.0"))
+		Expect(cfg.Rules.ResolvedPacks[1].ID()).To(Equal("ci@0.1.0"))
+	})
+
+	It("should load a pack referenced by global and project config once", func() {
+		writeFile(filepath.Join(globalPacks(), "shared.toml"), pack("shared", "1.0.0", "shared-rule"))
+		writeFile(filepath.Join(homeDir, GlobalConfigDir, GlobalConfigFile), `
+[rules]
+packs = ["shared"]
+`)
+		projectConfig(`
+[rules]
+packs = ["shared@1"]
+`)
+
+		cfg, err := load().Load(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Rules.ResolvedPacks).To(HaveLen(1))
+	})
+
+	DescribeTable("should reject unusable packs",
+		func(packContent, ref string, expected error) {
+			writeFile(filepath.Join(globalPacks(), "broken.toml"), packContent)
+			projectConfig(`
+[rules]
+packs = ["` + ref + `"]
+`)
+
+			_, err := load(WithVersion("1.0.0")).Load(nil)
+			Expect(err).To(MatchError(expected))
+		},
+		Entry("unknown pack", pack("broken", "1.0.0", "rule"), "missing", ErrPackNotFound),
+		Entry("no matching version", pack("broken", "1.0.0", "rule"), "broken@2", ErrPackNotFound),
+		Entry("invalid constraint", pack("broken", "1.0.0", "rule"), "broken@one", ErrInvalidPack),
+		Entry("invalid version", pack("broken", "latest", "rule"), "broken", ErrInvalidPack),
+		Entry("unnamed rule", pack("broken", "1.0.0", ""), "broken", ErrInvalidPack),
+		Entry("newer klaudiush required",
+			`
+[pack]
+name = "broken"
+version = "1.0.0"
+klaudiush_version = ">= 1.5.0"
+`, "broken", ErrIncompatiblePack),
+	)
+
+	It("should skip the klaudiush version check for development builds", func() {
+		writeFile(filepath.Join(globalPacks(), "future.toml"), `
+[pack]
+name = "future"
+version = "1.0.0"
+klaudiush_version = ">= 99.0.0"
+`)
+		projectConfig(`
+[rules]
+packs = ["future"]
+`)
+
+		cfg, err := load(WithVersion("dev")).Load(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Rules.ResolvedPacks).To(HaveLen(1))
+	})
+
+	It("should validate pack rules", func() {
+		writeFile(filepath.Join(globalPacks(), "invalid.toml"), `
+[pack]
+name = "invalid"
+version = "1.0.0"
+
+[[rules]]
+name = "no-conditions"
+[rules.action]
+type = "block"
+`)
+		projectConfig(`
+[rules]
+packs = ["invalid"]
+`)
+
+		cfg, err := load().LoadWithoutValidation(nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = NewValidator().validateRulesConfig(cfg.Rules)
+		Expect(err).To(MatchError(ContainSubstring(`rule["no-conditions"] of pack invalid@1.0.0`)))
+	})
+})
@@ -3,10 +3,14 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 
+	"github.com/smykla-labs/klaudiush/internal/templates"
 	"github.com/smykla-labs/klaudiush/pkg/config"
 	"github.com/smykla-labs/klaudiush/pkg/stringutil"
 )
@@ -34,6 +38,9 @@ var (
 	ErrEmptyMatchConditions = errors.New("rule has no match conditions")
 )
 
+// timeOfDayLayout is the layout of rule time window start and end times.
+const timeOfDayLayout = "15:04"
+
 // Validator validates configuration semantics.
 type Validator struct{}
 
@@ -72,6 +79,25 @@ func (v *Validator) Validate(cfg *config.Config) error {
 		}
 	}
 
+	// Validate output config
+	if cfg.Output != nil {
+		if err := v.validateOutputConfig(cfg.Output); err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+	}
+
+	// Validate session config
+	if cfg.Session != nil {
+		if err := v.validateSessionConfig(cfg.Session); err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+	}
+
+	// Validate message overrides
+	if err := v.validateMessageOverrides(cfg.Messages); err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+
 	if len(validationErrors) > 0 {
 		return errors.WithSecondaryError(
 			errors.Wrapf(
@@ -86,12 +112,115 @@ func (v *Validator) Validate(cfg *config.Config) error {
 	return nil
 }
 
+// validateMessageOverrides validates the message overrides of validation
+// errors.
+func (*Validator) validateMessageOverrides(overrides map[string]config.MessageOverrideConfig) error {
+	codes := slices.Sorted(maps.Keys(overrides))
+
+	for _, code := range codes {
+		override := overrides[code]
+
+		if strings.TrimSpace(code) == "" {
+			return errors.Wrap(ErrEmptyValue, "messages has an empty reference code")
+		}
+
+		if override.Message == "" && override.FixHint == "" {
+			return errors.Wrapf(
+				ErrEmptyValue,
+				"messages.%s must set message or fix_hint",
+				code,
+			)
+		}
+
+		if _, err := templates.Compile(code, override.Message); err != nil {
+			return errors.Wrapf(ErrInvalidOption, "messages.%s.message is invalid: %v", code, err)
+		}
+
+		if _, err := templates.Compile(code, override.FixHint); err != nil {
+			return errors.Wrapf(ErrInvalidOption, "messages.%s.fix_hint is invalid: %v", code, err)
+		}
+	}
+
+	return nil
+}
+
+// validateSessionConfig validates session configuration.
+func (*Validator) validateSessionConfig(cfg *config.SessionConfig) error {
+	escalation := cfg.Escalation
+	if escalation == nil {
+		return nil
+	}
+
+	if escalation.Window < 0 {
+		return errors.Wrapf(
+			ErrInvalidOption,
+			"session.escalation.window must not be negative, got %s",
+			escalation.Window,
+		)
+	}
+
+	if escalation.PoisonCooldown < 0 {
+		return errors.Wrapf(
+			ErrInvalidOption,
+			"session.escalation.poison_cooldown must not be negative, got %s",
+			escalation.PoisonCooldown,
+		)
+	}
+
+	thresholds := []struct {
+		field string
+		value int
+	}{
+		{"warn_threshold", escalation.GetWarnThreshold()},
+		{"ack_threshold", escalation.GetAckThreshold()},
+		{"poison_threshold", escalation.GetPoisonThreshold()},
+	}
+
+	for i, threshold := range thresholds {
+		if threshold.value < 0 {
+			return errors.Wrapf(
+				ErrInvalidOption,
+				"session.escalation.%s must not be negative, got %d",
+				threshold.field,
+				threshold.value,
+			)
+		}
+
+		if i > 0 && threshold.value < thresholds[i-1].value {
+			return errors.Wrapf(
+				ErrInvalidOption,
+				"session.escalation.%s %d must not be lower than %s %d",
+				threshold.field,
+				threshold.value,
+				thresholds[i-1].field,
+				thresholds[i-1].value,
+			)
+		}
+	}
+
+	return nil
+}
+
 // validateGlobalConfig validates global configuration.
 func (*Validator) validateGlobalConfig(*config.GlobalConfig) error {
 	// No specific validation needed for global config currently
 	return nil
 }
 
+// validateOutputConfig validates output configuration.
+func (*Validator) validateOutputConfig(cfg *config.OutputConfig) error {
+	if cfg.Format != "" && !slices.Contains(config.ValidOutputFormats, cfg.Format) {
+		return errors.Wrapf(
+			ErrInvalidOption,
+			"output.format %q is invalid (valid: %v)",
+			cfg.Format,
+			config.ValidOutputFormats,
+		)
+	}
+
+	return nil
+}
+
 // validateValidatorsConfig validates the validators configuration.
 func (v *Validator) validateValidatorsConfig(cfg *config.ValidatorsConfig) error {
 	var validationErrors []error
@@ -114,6 +243,12 @@ func (v *Validator) validateValidatorsConfig(cfg *config.ValidatorsConfig) error
 		}
 	}
 
+	if cfg.Shell != nil {
+		if err := v.validateShellConfig(cfg.Shell); err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+	}
+
 	if len(validationErrors) > 0 {
 		return combineErrors(validationErrors)
 	}
@@ -217,6 +352,24 @@ func (v *Validator) validateFileConfig(cfg *config.FileConfig) error {
 		}
 	}
 
+	if cfg.PostWrite != nil {
+		if err := v.validateBaseConfig(&cfg.PostWrite.ValidatorConfig); err != nil {
+			validationErrors = append(
+				validationErrors,
+				errors.Wrap(err, "validators.file.post_write"),
+			)
+		}
+	}
+
+	if cfg.SensitiveRead != nil {
+		if err := v.validateBaseConfig(&cfg.SensitiveRead.ValidatorConfig); err != nil {
+			validationErrors = append(
+				validationErrors,
+				errors.Wrap(err, "validators.file.sensitive_read"),
+			)
+		}
+	}
+
 	if len(validationErrors) > 0 {
 		return combineErrors(validationErrors)
 	}
@@ -235,6 +388,79 @@ func (v *Validator) validateNotificationConfig(cfg *config.NotificationConfig) e
 	return nil
 }
 
+// validateShellConfig validates shell validators configuration.
+func (v *Validator) validateShellConfig(cfg *config.ShellConfig) error {
+	if cfg.Backtick != nil {
+		if err := v.validateBaseConfig(&cfg.Backtick.ValidatorConfig); err != nil {
+			return errors.Wrap(err, "validators.shell.backtick")
+		}
+	}
+
+	if cfg.Destructive != nil {
+		if err := v.validateDestructiveConfig(cfg.Destructive); err != nil {
+			return errors.Wrap(err, "validators.shell.destructive")
+		}
+	}
+
+	if cfg.TestFailure != nil {
+		if err := v.validateTestFailureConfig(cfg.TestFailure); err != nil {
+			return errors.Wrap(err, "validators.shell.test_failure")
+		}
+	}
+
+	return nil
+}
+
+// validateTestFailureConfig validates test failure validator configuration.
+func (v *Validator) validateTestFailureConfig(cfg *config.TestFailureValidatorConfig) error {
+	if err := v.validateBaseConfig(&cfg.ValidatorConfig); err != nil {
+		return err
+	}
+
+	if cfg.MaxOutputLines != nil && *cfg.MaxOutputLines < 0 {
+		return errors.Wrapf(
+			ErrInvalidLength,
+			"max_output_lines must be >= 0, got %d",
+			*cfg.MaxOutputLines,
+		)
+	}
+
+	return nil
+}
+
+// validateDestructiveConfig validates destructive command validator configuration.
+func (v *Validator) validateDestructiveConfig(cfg *config.DestructiveValidatorConfig) error {
+	if err := v.validateBaseConfig(&cfg.ValidatorConfig); err != nil {
+		return err
+	}
+
+	actions := []struct {
+		name  string
+		value string
+	}{
+		{"recursive_delete", cfg.RecursiveDelete},
+		{"protected_path_delete", cfg.ProtectedPathDelete},
+		{"git_discard", cfg.GitDiscard},
+		{"find_delete", cfg.FindDelete},
+		{"device_write", cfg.DeviceWrite},
+		{"chmod_recursive", cfg.ChmodRecursive},
+	}
+
+	for _, action := range actions {
+		if action.value != "" && !slices.Contains(config.ValidDestructiveActions, action.value) {
+			return errors.Wrapf(
+				ErrInvalidOption,
+				"%s must be one of %v, got %q",
+				action.name,
+				config.ValidDestructiveActions,
+				action.value,
+			)
+		}
+	}
+
+	return nil
+}
+
 // validateCommitConfig validates commit validator configuration.
 func (v *Validator) validateCommitConfig(cfg *config.CommitValidatorConfig) error {
 	if err := v.validateBaseConfig(&cfg.ValidatorConfig); err != nil {
@@ -468,39 +694,55 @@ func (*Validator) validateBaseConfig(cfg *config.ValidatorConfig) error {
 
 // validateRulesConfig validates the rules configuration.
 func (v *Validator) validateRulesConfig(cfg *config.RulesConfig) error {
-	if cfg == nil || len(cfg.Rules) == 0 {
+	if cfg == nil {
 		return nil
 	}
 
+	validationErrors := v.validateRules(cfg.Rules)
+
+	for i := range cfg.ResolvedPacks {
+		validationErrors = append(validationErrors, v.validateRules(cfg.ResolvedPacks[i].Rules)...)
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.Wrap(combineErrors(validationErrors), "rules")
+	}
+
+	return nil
+}
+
+// validateRules validates each enabled rule of a rule list.
+func (v *Validator) validateRules(rules []config.RuleConfig) []error {
 	var validationErrors []error
 
-	for i := range cfg.Rules {
+	for i := range rules {
 		// Skip validation for disabled rules
-		if !cfg.Rules[i].IsRuleEnabled() {
+		if !rules[i].IsRuleEnabled() {
 			continue
 		}
 
-		ruleID := v.getRuleIdentifier(cfg.Rules[i], i)
+		ruleID := v.getRuleIdentifier(rules[i], i)
 
-		if err := v.validateRule(&cfg.Rules[i], ruleID); err != nil {
+		if err := v.validateRule(&rules[i], ruleID); err != nil {
 			validationErrors = append(validationErrors, err)
 		}
 	}
 
-	if len(validationErrors) > 0 {
-		return errors.Wrap(combineErrors(validationErrors), "rules")
-	}
-
-	return nil
+	return validationErrors
 }
 
 // getRuleIdentifier returns a human-readable identifier for a rule.
 func (*Validator) getRuleIdentifier(rule config.RuleConfig, index int) string {
+	id := fmt.Sprintf("rule[%d]", index)
 	if rule.Name != "" {
-		return fmt.Sprintf("rule[%q]", rule.Name)
+		id = fmt.Sprintf("rule[%q]", rule.Name)
+	}
+
+	if rule.Pack != "" {
+		id += " of pack " + rule.Pack
 	}
 
-	return fmt.Sprintf("rule[%d]", index)
+	return id
 }
 
 // validateRule validates a single rule configuration.
@@ -524,6 +766,11 @@ func (v *Validator) validateRule(rule *config.RuleConfig, ruleID string) error {
 		validationErrors = append(validationErrors, err)
 	}
 
+	// Validate tests
+	for i := range rule.Tests {
+		validationErrors = append(validationErrors, validateRuleTest(&rule.Tests[i], ruleID, i)...)
+	}
+
 	if len(validationErrors) > 0 {
 		return combineErrors(validationErrors)
 	}
@@ -531,6 +778,50 @@ func (v *Validator) validateRule(rule *config.RuleConfig, ruleID string) error {
 	return nil
 }
 
+// validateRuleTest validates the input and expected action of a rule test.
+func validateRuleTest(test *config.RuleTestConfig, ruleID string, index int) []error {
+	var validationErrors []error
+
+	testID := fmt.Sprintf("%s test[%d]", ruleID, index)
+	if test.Name != "" {
+		testID = fmt.Sprintf("%s test[%q]", ruleID, test.Name)
+	}
+
+	checks := []struct {
+		field string
+		value string
+		valid []string
+	}{
+		{"tool_type", test.ToolType, config.ValidToolTypes},
+		{"event_type", test.EventType, config.ValidEventTypes},
+	}
+
+	for _, check := range checks {
+		if check.value != "" && !stringutil.ContainsCaseInsensitive(check.valid, check.value) {
+			validationErrors = append(validationErrors, errors.Wrapf(
+				ErrInvalidRule,
+				"%s has invalid %s %q (valid: %v)",
+				testID,
+				check.field,
+				check.value,
+				check.valid,
+			))
+		}
+	}
+
+	if test.Action != "" && !slices.Contains(config.ValidActionTypes, test.Action) {
+		validationErrors = append(validationErrors, errors.Wrapf(
+			ErrInvalidRule,
+			"%s has invalid action %q (valid: %v)",
+			testID,
+			test.Action,
+			config.ValidActionTypes,
+		))
+	}
+
+	return validationErrors
+}
+
 // validateRuleMatchConditions validates that a rule has at least one match condition.
 func (*Validator) validateRuleMatchConditions(match *config.RuleMatchConfig, ruleID string) error {
 	if match == nil {
@@ -550,7 +841,7 @@ func (*Validator) validateRuleMatchConditions(match *config.RuleMatchConfig, rul
 }
 
 // validateRuleMatchFields validates the field values in a rule's match section.
-func (*Validator) validateRuleMatchFields(match *config.RuleMatchConfig, ruleID string) error {
+func (v *Validator) validateRuleMatchFields(match *config.RuleMatchConfig, ruleID string) error {
 	var validationErrors []error
 
 	// Validate event_type if specified
@@ -585,6 +876,27 @@ func (*Validator) validateRuleMatchFields(match *config.RuleMatchConfig, ruleID
 		}
 	}
 
+	// Validate pipeline_position if specified
+	if match.PipelinePosition != "" &&
+		!slices.Contains(config.ValidPipelinePositions, match.PipelinePosition) {
+		validationErrors = append(
+			validationErrors,
+			errors.Wrapf(
+				ErrInvalidRule,
+				"%s has invalid pipeline_position %q (valid: %v)",
+				ruleID,
+				match.PipelinePosition,
+				config.ValidPipelinePositions,
+			),
+		)
+	}
+
+	validationErrors = append(validationErrors, validateContextConditions(match, ruleID)...)
+	validationErrors = append(validationErrors, validateCountBounds(
+		"commits_ahead", match.MinCommitsAhead, match.MaxCommitsAhead, ruleID,
+	)...)
+	validationErrors = append(validationErrors, v.validateNestedMatches(match, ruleID)...)
+
 	if len(validationErrors) > 0 {
 		return combineErrors(validationErrors)
 	}
@@ -592,6 +904,169 @@ func (*Validator) validateRuleMatchFields(match *config.RuleMatchConfig, ruleID
 	return nil
 }
 
+// validateContextConditions validates the env, time_window and session
+// command conditions of a match section.
+func validateContextConditions(match *config.RuleMatchConfig, ruleID string) []error {
+	var validationErrors []error
+
+	if _, ok := match.Env[""]; ok {
+		validationErrors = append(validationErrors, errors.Wrapf(
+			ErrInvalidRule,
+			"%s has env condition with empty variable name",
+			ruleID,
+		))
+	}
+
+	if match.TimeWindow != nil {
+		validationErrors = append(validationErrors, validateTimeWindow(match.TimeWindow, ruleID)...)
+	}
+
+	validationErrors = append(validationErrors, validateCountBounds(
+		"session_commands", match.MinSessionCommands, match.MaxSessionCommands, ruleID,
+	)...)
+
+	return validationErrors
+}
+
+// validateCountBounds validates the min_<name> and max_<name> bounds of a
+// count condition: both must be non-negative and min must not exceed max.
+func validateCountBounds(name string, minValue, maxValue *int, ruleID string) []error {
+	var validationErrors []error
+
+	bounds := []struct {
+		field string
+		value *int
+	}{
+		{"min_" + name, minValue},
+		{"max_" + name, maxValue},
+	}
+
+	for _, bound := range bounds {
+		if bound.value != nil && *bound.value < 0 {
+			validationErrors = append(validationErrors, errors.Wrapf(
+				ErrInvalidRule,
+				"%s has negative %s %d",
+				ruleID,
+				bound.field,
+				*bound.value,
+			))
+		}
+	}
+
+	if minValue != nil && maxValue != nil && *minValue > *maxValue {
+		validationErrors = append(validationErrors, errors.Wrapf(
+			ErrInvalidRule,
+			"%s has min_%s %d greater than max_%s %d",
+			ruleID,
+			name,
+			*minValue,
+			name,
+			*maxValue,
+		))
+	}
+
+	return validationErrors
+}
+
+// validateTimeWindow validates the times, days and timezone of a time window.
+func validateTimeWindow(window *config.TimeWindowConfig, ruleID string) []error {
+	var validationErrors []error
+
+	times := []struct {
+		field string
+		value string
+	}{
+		{"start", window.Start},
+		{"end", window.End},
+	}
+
+	for _, t := range times {
+		if t.value == "" {
+			continue
+		}
+
+		if _, err := time.Parse(timeOfDayLayout, t.value); err != nil {
+			validationErrors = append(validationErrors, errors.Wrapf(
+				ErrInvalidRule,
+				"%s has invalid time_window.%s %q (expected HH:MM)",
+				ruleID,
+				t.field,
+				t.value,
+			))
+		}
+	}
+
+	if window.Start != "" && window.Start == window.End {
+		validationErrors = append(validationErrors, errors.Wrapf(
+			ErrInvalidRule,
+			"%s has an empty time_window (start and end are both %q)",
+			ruleID,
+			window.Start,
+		))
+	}
+
+	for _, day := range window.Days {
+		if !slices.Contains(config.ValidTimeWindowDays, strings.ToLower(day)) {
+			validationErrors = append(validationErrors, errors.Wrapf(
+				ErrInvalidRule,
+				"%s has invalid time_window day %q (valid: %v)",
+				ruleID,
+				day,
+				config.ValidTimeWindowDays,
+			))
+		}
+	}
+
+	if window.Timezone != "" {
+		if _, err := time.LoadLocation(window.Timezone); err != nil {
+			validationErrors = append(validationErrors, errors.Wrapf(
+				ErrInvalidRule,
+				"%s has invalid time_window.timezone %q",
+				ruleID,
+				window.Timezone,
+			))
+		}
+	}
+
+	return validationErrors
+}
+
+// validateNestedMatches validates the nested all/any/not blocks of a match
+// section. Every nested block must have conditions and valid field values.
+func (v *Validator) validateNestedMatches(match *config.RuleMatchConfig, ruleID string) []error {
+	var validationErrors []error
+
+	validateNested := func(nested *config.RuleMatchConfig, nestedID string) {
+		if !nested.HasMatchConditions() {
+			validationErrors = append(validationErrors, errors.Wrapf(
+				ErrEmptyMatchConditions,
+				"%s has empty match block (block will never match)",
+				nestedID,
+			))
+
+			return
+		}
+
+		if err := v.validateRuleMatchFields(nested, nestedID); err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+	}
+
+	for i := range match.All {
+		validateNested(&match.All[i], fmt.Sprintf("%s match.all[%d]", ruleID, i))
+	}
+
+	for i := range match.Any {
+		validateNested(&match.Any[i], fmt.Sprintf("%s match.any[%d]", ruleID, i))
+	}
+
+	if match.Not != nil {
+		validateNested(match.Not, ruleID+" match.not")
+	}
+
+	return validationErrors
+}
+
 // validateRuleAction validates a rule's action configuration.
 func (*Validator) validateRuleAction(action *config.RuleActionConfig, ruleID string) error {
 	if action == nil {
@@ -610,6 +1085,25 @@ func (*Validator) validateRuleAction(action *config.RuleActionConfig, ruleID str
 		)
 	}
 
+	if _, err := templates.Compile(ruleID, action.Message); err != nil {
+		return errors.Wrapf(ErrInvalidRule, "%s has invalid message template: %v", ruleID, err)
+	}
+
+	switch action.Type {
+	case "suggest":
+		if action.Suggestion == "" {
+			return errors.Wrapf(ErrInvalidRule, "%s suggest action requires a suggestion", ruleID)
+		}
+
+		if _, err := templates.Compile(ruleID, action.Suggestion); err != nil {
+			return errors.Wrapf(ErrInvalidRule, "%s has invalid suggestion template: %v", ruleID, err)
+		}
+	case "poison":
+		if action.Reference == "" {
+			return errors.Wrapf(ErrInvalidRule, "%s poison action requires a reference", ruleID)
+		}
+	}
+
 	return nil
 }
 
@@ -74,6 +74,20 @@ var _ = Describe("Validator", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 
+			It("should pass for rule with lifecycle event_type", func() {
+				err := validator.validateRulesConfig(&config.RulesConfig{
+					Rules: []config.RuleConfig{
+						{
+							Name: "prompt-event-rule",
+							Match: &config.RuleMatchConfig{
+								EventType: "UserPromptSubmit",
+							},
+						},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
 			It("should pass for rule with file_pattern", func() {
 				err := validator.validateRulesConfig(&config.RulesConfig{
 					Rules: []config.RuleConfig{
@@ -106,7 +120,7 @@ var _ = Describe("Validator", func() {
 			})
 
 			It("should accept all valid action types", func() {
-				for _, actionType := range []string{"allow", "block", "warn"} {
+				for _, actionType := range []string{"allow", "block", "warn", "ask"} {
 					err := validator.validateRulesConfig(&config.RulesConfig{
 						Rules: []config.RuleConfig{
 							{
@@ -200,6 +214,44 @@ var _ = Describe("Validator", func() {
 				Expect(err.Error()).To(ContainSubstring("empty match section"))
 			})
 
+			It("should fail when a nested match block is empty", func() {
+				err := validator.validateRulesConfig(&config.RulesConfig{
+					Rules: []config.RuleConfig{
+						{
+							Name: "empty-nested-rule",
+							Match: &config.RuleMatchConfig{
+								ValidatorType: "git.push",
+								Any: []config.RuleMatchConfig{
+									{BranchPattern: "main"},
+									{},
+								},
+							},
+						},
+					},
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("match.any[1] has empty match block"))
+			})
+
+			It("should fail when a nested match block has invalid fields", func() {
+				err := validator.validateRulesConfig(&config.RulesConfig{
+					Rules: []config.RuleConfig{
+						{
+							Name: "invalid-nested-rule",
+							Match: &config.RuleMatchConfig{
+								Not: &config.RuleMatchConfig{
+									All: []config.RuleMatchConfig{
+										{ToolType: "InvalidTool"},
+									},
+								},
+							},
+						},
+					},
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("match.not match.all[0] has invalid tool_type"))
+			})
+
 			It("should fail when tool_type is invalid", func() {
 				err := validator.validateRulesConfig(&config.RulesConfig{
 					Rules: []config.RuleConfig{
@@ -232,6 +284,118 @@ var _ = Describe("Validator", func() {
 				Expect(err.Error()).To(ContainSubstring("InvalidEvent"))
 			})
 
+			It("should fail when pipeline_position is invalid", func() {
+				err := validator.validateRulesConfig(&config.RulesConfig{
+					Rules: []config.RuleConfig{
+						{
+							Name: "invalid-position-rule",
+							Match: &config.RuleMatchConfig{
+								Program:          "sh",
+								PipelinePosition: "second",
+							},
+						},
+					},
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid pipeline_position"))
+			})
+
+			DescribeTable("should validate context conditions",
+				func(match *config.RuleMatchConfig, expected string) {
+					err := validator.validateRulesConfig(&config.RulesConfig{
+						Rules: []config.RuleConfig{{Name: "context-rule", Match: match}},
+					})
+
+					if expected == "" {
+						Expect(err).NotTo(HaveOccurred())
+						return
+					}
+
+					Expect(err).To(MatchError(ContainSubstring(expected)))
+				},
+				Entry("valid time window",
+					&config.RuleMatchConfig{TimeWindow: &config.TimeWindowConfig{
+						Start:    "22:00",
+						End:      "06:00",
+						Days:     []string{"Fri", "sat"},
+						Timezone: "Europe/Warsaw",
+					}},
+					"",
+				),
+				Entry("invalid start",
+					&config.RuleMatchConfig{TimeWindow: &config.TimeWindowConfig{Start: "9am"}},
+					`invalid time_window.start "9am"`,
+				),
+				Entry("empty window",
+					&config.RuleMatchConfig{TimeWindow: &config.TimeWindowConfig{
+						Start: "09:00",
+						End:   "09:00",
+					}},
+					`empty time_window (start and end are both "09:00")`,
+				),
+				Entry("invalid day",
+					&config.RuleMatchConfig{TimeWindow: &config.TimeWindowConfig{Days: []string{"monday"}}},
+					`invalid time_window day "monday"`,
+				),
+				Entry("invalid timezone",
+					&config.RuleMatchConfig{TimeWindow: &config.TimeWindowConfig{Timezone: "Mars/Olympus"}},
+					"invalid time_window.timezone",
+				),
+				Entry("empty env variable name",
+					&config.RuleMatchConfig{Env: map[string]string{"": "prod"}},
+					"env condition with empty variable name",
+				),
+				Entry("negative session commands",
+					&config.RuleMatchConfig{MinSessionCommands: intPtr(-1)},
+					"negative min_session_commands",
+				),
+				Entry("min greater than max",
+					&config.RuleMatchConfig{MinSessionCommands: intPtr(10), MaxSessionCommands: intPtr(5)},
+					"min_session_commands 10 greater than max_session_commands 5",
+				),
+				Entry("negative commits ahead",
+					&config.RuleMatchConfig{MaxCommitsAhead: intPtr(-2)},
+					"negative max_commits_ahead",
+				),
+				Entry("min commits ahead greater than max",
+					&config.RuleMatchConfig{MinCommitsAhead: intPtr(3), MaxCommitsAhead: intPtr(1)},
+					"min_commits_ahead 3 greater than max_commits_ahead 1",
+				),
+				Entry("valid git state conditions",
+					&config.RuleMatchConfig{
+						RemoteOwner:     "Kong",
+						StagedFiles:     []string{"**/*.tf"},
+						MinCommitsAhead: intPtr(1),
+					},
+					"",
+				),
+				Entry("cwd pattern only",
+					&config.RuleMatchConfig{CWDPattern: "**/prod/**"},
+					"",
+				),
+			)
+
+			It("should fail when a rule test is invalid", func() {
+				err := validator.validateRulesConfig(&config.RulesConfig{
+					Rules: []config.RuleConfig{
+						{
+							Name:  "tested-rule",
+							Match: &config.RuleMatchConfig{BranchPattern: "main"},
+							Tests: []config.RuleTestConfig{
+								{Name: "bad action", Branch: "main", Matched: true, Action: "deny"},
+								{Branch: "main", ToolType: "Terminal"},
+							},
+						},
+					},
+				})
+				Expect(err).To(MatchError(ContainSubstring(
+					`rule["tested-rule"] test["bad action"] has invalid action "deny"`,
+				)))
+				Expect(err).To(MatchError(ContainSubstring(
+					`rule["tested-rule"] test[1] has invalid tool_type "Terminal"`,
+				)))
+			})
+
 			It("should fail when action type is invalid", func() {
 				err := validator.validateRulesConfig(&config.RulesConfig{
 					Rules: []config.RuleConfig{
@@ -251,6 +415,59 @@ var _ = Describe("Validator", func() {
 				Expect(err.Error()).To(ContainSubstring("invalid-action"))
 			})
 
+			DescribeTable("should validate action-specific fields",
+				func(action *config.RuleActionConfig, expected string) {
+					err := validator.validateRulesConfig(&config.RulesConfig{
+						Rules: []config.RuleConfig{
+							{
+								Name:   "action-rule",
+								Match:  &config.RuleMatchConfig{Program: "git"},
+								Action: action,
+							},
+						},
+					})
+
+					if expected == "" {
+						Expect(err).NotTo(HaveOccurred())
+						return
+					}
+
+					Expect(err).To(MatchError(ContainSubstring(expected)))
+				},
+				Entry("suggest without suggestion",
+					&config.RuleActionConfig{Type: "suggest"},
+					"suggest action requires a suggestion",
+				),
+				Entry("suggest with invalid template",
+					&config.RuleActionConfig{Type: "suggest", Suggestion: "{{.Command"},
+					"invalid suggestion template",
+				),
+				Entry("suggest with template",
+					&config.RuleActionConfig{Type: "suggest", Suggestion: "{{.Command}} --dry-run"},
+					"",
+				),
+				Entry("message with invalid template",
+					&config.RuleActionConfig{Type: "block", Message: "Push to {{.Branch"},
+					"invalid message template",
+				),
+				Entry("message with template",
+					&config.RuleActionConfig{Type: "block", Message: "Push to {{.Branch}} blocked"},
+					"",
+				),
+				Entry("poison without reference",
+					&config.RuleActionConfig{Type: "poison"},
+					"poison action requires a reference",
+				),
+				Entry("poison with reference",
+					&config.RuleActionConfig{Type: "poison", Reference: "SEC001"},
+					"",
+				),
+				Entry("log without message",
+					&config.RuleActionConfig{Type: "log"},
+					"",
+				),
+			)
+
 			It("should report multiple errors", func() {
 				err := validator.validateRulesConfig(&config.RulesConfig{
 					Rules: []config.RuleConfig{
@@ -321,3 +538,8 @@ var _ = Describe("Validator", func() {
 		})
 	})
 })
+
+// intPtr returns a pointer to an int value.
+func intPtr(i int) *int {
+	return &i
+}
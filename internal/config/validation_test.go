@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/cockroachdb/errors"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -352,6 +354,58 @@ var _ = Describe("Validator", func() {
 		})
 	})
 
+	Describe("validateSessionConfig", func() {
+		It("should pass with default escalation config", func() {
+			cfg := &config.Config{
+				Session: &config.SessionConfig{
+					Escalation: &config.SessionEscalationConfig{},
+				},
+			}
+			err := validator.Validate(cfg)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a negative window", func() {
+			cfg := &config.SessionConfig{
+				Escalation: &config.SessionEscalationConfig{
+					Window: config.Duration(-time.Minute),
+				},
+			}
+			err := validator.validateSessionConfig(cfg)
+			Expect(errors.Is(err, ErrInvalidOption)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("session.escalation.window"))
+		})
+
+		It("should reject thresholds out of order", func() {
+			cfg := &config.SessionConfig{
+				Escalation: &config.SessionEscalationConfig{
+					WarnThreshold:   3,
+					AckThreshold:    2,
+					PoisonThreshold: 4,
+				},
+			}
+			err := validator.validateSessionConfig(cfg)
+			Expect(errors.Is(err, ErrInvalidOption)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(
+				"session.escalation.ack_threshold 2 must not be lower than warn_threshold 3",
+			))
+		})
+
+		It("should allow equal thresholds", func() {
+			cfg := &config.Config{
+				Session: &config.SessionConfig{
+					Escalation: &config.SessionEscalationConfig{
+						WarnThreshold:   2,
+						AckThreshold:    2,
+						PoisonThreshold: 2,
+					},
+				},
+			}
+			err := validator.Validate(cfg)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
 	Describe("validateMarkdownConfig", func() {
 		It("should reject negative context_lines", func() {
 			negativeContext := -1
@@ -537,6 +591,63 @@ var _ = Describe("Validator", func() {
 		})
 	})
 
+	Describe("validateShellConfig", func() {
+		It("should reject invalid destructive action", func() {
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					Shell: &config.ShellConfig{
+						Destructive: &config.DestructiveValidatorConfig{
+							FindDelete: "ignore",
+						},
+					},
+				},
+			}
+
+			err := validator.Validate(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+		})
+
+		It("should reject negative test failure output lines", func() {
+			maxLines := -1
+			cfg := &config.Config{
+				Validators: &config.ValidatorsConfig{
+					Shell: &config.ShellConfig{
+						TestFailure: &config.TestFailureValidatorConfig{
+							MaxOutputLines: &maxLines,
+						},
+					},
+				},
+			}
+
+			err := validator.Validate(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+		})
+
+		It("should accept valid destructive actions", func() {
+			for _, action := range config.ValidDestructiveActions {
+				cfg := &config.Config{
+					Validators: &config.ValidatorsConfig{
+						Shell: &config.ShellConfig{
+							Destructive: &config.DestructiveValidatorConfig{
+								RecursiveDelete:     action,
+								ProtectedPathDelete: action,
+								GitDiscard:          action,
+								FindDelete:          action,
+								DeviceWrite:         action,
+								ChmodRecursive:      action,
+							},
+						},
+					},
+				}
+
+				err := validator.Validate(cfg)
+				Expect(err).NotTo(HaveOccurred(), "action %q should be valid", action)
+			}
+		})
+	})
+
 	Describe("validateBaseConfig", func() {
 		It("should reject invalid severity", func() {
 			cfg := &config.Config{
@@ -608,6 +719,39 @@ var _ = Describe("Validator", func() {
 		})
 	})
 
+	Describe("validateMessageOverrides", func() {
+		DescribeTable("should validate message overrides",
+			func(overrides map[string]config.MessageOverrideConfig, expected string) {
+				err := validator.validateMessageOverrides(overrides)
+
+				if expected == "" {
+					Expect(err).NotTo(HaveOccurred())
+					return
+				}
+
+				Expect(err).To(MatchError(ContainSubstring(expected)))
+			},
+			Entry("valid templates",
+				map[string]config.MessageOverrideConfig{
+					"GIT019": {FixHint: "{{.FixHint}}. See https://runbooks.example.com/{{.Code}}"},
+				},
+				"",
+			),
+			Entry("empty override",
+				map[string]config.MessageOverrideConfig{"GIT019": {}},
+				"messages.GIT019 must set message or fix_hint",
+			),
+			Entry("invalid message template",
+				map[string]config.MessageOverrideConfig{"GIT019": {Message: "{{.Message"}},
+				"messages.GIT019.message is invalid",
+			),
+			Entry("invalid fix hint template",
+				map[string]config.MessageOverrideConfig{"GIT019": {FixHint: "{{.FixHint"}},
+				"messages.GIT019.fix_hint is invalid",
+			),
+		)
+	})
+
 	Describe("combineErrors", func() {
 		It("should return nil for empty slice", func() {
 			err := combineErrors(nil)
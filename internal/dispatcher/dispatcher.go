@@ -10,6 +10,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 
+	"github.com/smykla-labs/klaudiush/internal/rules"
 	"github.com/smykla-labs/klaudiush/internal/session"
 	"github.com/smykla-labs/klaudiush/internal/validator"
 	"github.com/smykla-labs/klaudiush/pkg/hook"
@@ -39,11 +40,25 @@ type SessionTracker interface {
 	// RecordCommand increments the command count for a session.
 	RecordCommand(sessionID string)
 
+	// RecordBlocked records a blocked attempt of the given error codes and
+	// escalates the session. Returns the resulting status transition.
+	RecordBlocked(sessionID string, codes []string, message string) session.Transition
+
+	// PreviewBlocked returns the transition RecordBlocked would make, without
+	// changing the session state.
+	PreviewBlocked(sessionID string, codes []string) session.Transition
+
+	// ExpireCooldown cleans a session whose poisoning expired after its
+	// cooldown, or whose warning expired. Returns the resulting status
+	// transition.
+	ExpireCooldown(sessionID string) session.Transition
+
 	// IsEnabled returns true if session tracking is enabled.
 	IsEnabled() bool
 }
 
-// SessionAuditLogger logs session events (poison/unpoison) for audit purposes.
+// SessionAuditLogger logs session events (poison/unpoison/warn/expire) for
+// audit purposes.
 type SessionAuditLogger interface {
 	// Log writes an audit entry to the log file.
 	Log(entry *session.AuditEntry) error
@@ -66,6 +81,13 @@ type ValidationError struct {
 	// ShouldBlock indicates whether this error should block the operation.
 	ShouldBlock bool
 
+	// ShouldAsk indicates whether the user should be asked to confirm the operation.
+	ShouldAsk bool
+
+	// ShouldPoison indicates that the session should be poisoned with this
+	// error's reference, even for events that do not poison on blocking errors.
+	ShouldPoison bool
+
 	// Reference is the URL that uniquely identifies this error type.
 	// Format: https://klaudiu.sh/{CODE} (e.g., https://klaudiu.sh/GIT001).
 	Reference validator.Reference
@@ -94,8 +116,10 @@ type Dispatcher struct {
 	logger             logger.Logger
 	executor           Executor
 	exceptionChecker   ExceptionChecker
+	exceptionGuard     *ExceptionGuard
 	sessionTracker     SessionTracker
 	sessionAuditLogger SessionAuditLogger
+	messageOverrides   *MessageOverrides
 }
 
 // NewDispatcher creates a new Dispatcher with sequential execution.
@@ -132,6 +156,15 @@ func WithExceptionChecker(checker ExceptionChecker) DispatcherOption {
 	}
 }
 
+// WithExceptionGuard sets the exception guard for the dispatcher.
+func WithExceptionGuard(guard *ExceptionGuard) DispatcherOption {
+	return func(d *Dispatcher) {
+		if guard != nil {
+			d.exceptionGuard = guard
+		}
+	}
+}
+
 // WithSessionTracker sets the session tracker for the dispatcher.
 func WithSessionTracker(tracker SessionTracker) DispatcherOption {
 	return func(d *Dispatcher) {
@@ -150,6 +183,15 @@ func WithSessionAuditLogger(auditLogger SessionAuditLogger) DispatcherOption {
 	}
 }
 
+// WithMessageOverrides sets the message overrides for the dispatcher.
+func WithMessageOverrides(overrides *MessageOverrides) DispatcherOption {
+	return func(d *Dispatcher) {
+		if overrides != nil {
+			d.messageOverrides = overrides
+		}
+	}
+}
+
 // NewDispatcherWithOptions creates a new Dispatcher with options.
 func NewDispatcherWithOptions(
 	registry *validator.Registry,
@@ -178,8 +220,14 @@ func (d *Dispatcher) Dispatch(ctx context.Context, hookCtx *hook.Context) []*Val
 		"tool", hookCtx.ToolName,
 	)
 
+	// Validators evaluate rules independently, so rule matches are recorded
+	// once for the whole invocation
+	ctx = rules.WithInvocation(ctx)
+
 	// Check if session tracking is enabled and session is poisoned
 	if d.sessionTracker != nil && d.sessionTracker.IsEnabled() && hookCtx.HasSessionID() {
+		d.expireSessionCooldown(hookCtx)
+
 		if poisoned, info := d.sessionTracker.IsPoisoned(hookCtx.SessionID); poisoned {
 			d.logger.Info("session is poisoned",
 				"session_id", hookCtx.SessionID,
@@ -206,36 +254,142 @@ func (d *Dispatcher) Dispatch(ctx context.Context, hookCtx *hook.Context) []*Val
 		validationErrors = append(validationErrors, syntheticErrors...)
 	}
 
-	// Poison session if there are blocking errors, otherwise record command
+	// Exception guard errors are added after exception checking, so exception
+	// tokens cannot bypass them
+	if d.exceptionGuard != nil {
+		validationErrors = append(validationErrors, d.exceptionGuard.Check(ctx, hookCtx)...)
+	}
+
 	if d.sessionTracker != nil && d.sessionTracker.IsEnabled() && hookCtx.HasSessionID() {
-		if ShouldBlock(validationErrors) {
-			codes := extractSessionPoisonCodes(validationErrors)
-			message := extractSessionPoisonMessage(validationErrors)
+		validationErrors = d.updateSession(hookCtx, validationErrors)
+	}
 
-			d.logger.Info("poisoning session due to blocking error",
-				"session_id", hookCtx.SessionID,
-				"error_codes", codes,
-			)
+	return validationErrors
+}
+
+// updateSession escalates the session if there are blocking errors, otherwise
+// records the command. PostToolUse results report on a tool that already ran,
+// so they are fed back to the model without escalating the session, unless an
+// error explicitly requests poisoning (e.g., a rule with the poison action).
+// Returns the validation errors with an escalation warning added, if any.
+func (d *Dispatcher) updateSession(
+	hookCtx *hook.Context,
+	validationErrors []*ValidationError,
+) []*ValidationError {
+	postToolUse := hookCtx.EventType == hook.EventTypePostToolUse
 
-			d.sessionTracker.Poison(hookCtx.SessionID, codes, message)
+	switch {
+	case ShouldPoison(validationErrors):
+		d.poisonSession(hookCtx, validationErrors)
+	case !postToolUse && ShouldBlock(validationErrors):
+		return d.escalateSession(hookCtx, validationErrors)
+	case !postToolUse:
+		// Record command when validation passes or only has warnings (no blocking errors)
+		d.sessionTracker.RecordCommand(hookCtx.SessionID)
+	}
 
-			// Log audit entry for poison
-			d.logSessionAuditEntry(
-				hookCtx,
-				session.AuditActionPoison,
-				codes,
-				"", // no source for poison (it's from validation failure)
-				message,
-			)
-		} else {
-			// Record command when validation passes or only has warnings (no blocking errors)
-			d.sessionTracker.RecordCommand(hookCtx.SessionID)
+	return validationErrors
+}
+
+// poisonSession poisons the session with the codes of errors that explicitly
+// request it, regardless of the escalation thresholds.
+func (d *Dispatcher) poisonSession(hookCtx *hook.Context, validationErrors []*ValidationError) {
+	codes := extractSessionPoisonCodes(validationErrors)
+	message := extractSessionPoisonMessage(validationErrors)
+
+	d.logger.Info("poisoning session due to blocking error",
+		"session_id", hookCtx.SessionID,
+		"error_codes", codes,
+	)
+
+	previous := session.StatusClean
+	if _, info := d.sessionTracker.IsPoisoned(hookCtx.SessionID); info != nil {
+		previous = info.Status
+	}
+
+	d.sessionTracker.Poison(hookCtx.SessionID, codes, message)
+
+	// Log audit entry for poison
+	d.logSessionAuditEntry(
+		hookCtx,
+		session.AuditActionPoison,
+		session.Transition{From: previous, To: session.StatusPoisoned, Codes: codes},
+		"", // no source for poison (it's from validation failure)
+		message,
+	)
+}
+
+// escalateSession records a blocked attempt and escalates the session
+// according to the escalation policy. Returns the validation errors with a
+// warning added while the session is warned.
+func (d *Dispatcher) escalateSession(
+	hookCtx *hook.Context,
+	validationErrors []*ValidationError,
+) []*ValidationError {
+	codes := extractSessionPoisonCodes(validationErrors)
+	message := extractSessionPoisonMessage(validationErrors)
+
+	transition := d.sessionTracker.RecordBlocked(hookCtx.SessionID, codes, message)
+
+	if transition.Changed() {
+		action := session.AuditActionPoison
+		if transition.To == session.StatusWarned {
+			action = session.AuditActionWarn
 		}
+
+		d.logger.Info("escalating session due to blocking error",
+			"session_id", hookCtx.SessionID,
+			"error_codes", codes,
+			"attempts", transition.Attempts,
+			"status", transition.To.String(),
+		)
+
+		// Log audit entry for the escalation
+		d.logSessionAuditEntry(
+			hookCtx,
+			action,
+			transition,
+			"", // no source for escalation (it's from validation failure)
+			message,
+		)
+	}
+
+	if transition.To == session.StatusWarned {
+		validationErrors = append(validationErrors, createSessionWarningError(transition))
 	}
 
 	return validationErrors
 }
 
+// expireSessionCooldown cleans the session if its poisoning expired after the
+// cooldown, or its warning expired as blocked attempts left the window.
+func (d *Dispatcher) expireSessionCooldown(hookCtx *hook.Context) {
+	transition := d.sessionTracker.ExpireCooldown(hookCtx.SessionID)
+	if !transition.Changed() {
+		return
+	}
+
+	source := "cooldown"
+	if transition.From == session.StatusWarned {
+		source = "window"
+	}
+
+	d.logger.Info("session escalation expired",
+		"session_id", hookCtx.SessionID,
+		"status", transition.From.String(),
+		"poison_codes", transition.Codes,
+		"source", source,
+	)
+
+	d.logSessionAuditEntry(
+		hookCtx,
+		session.AuditActionExpire,
+		transition,
+		source,
+		"",
+	)
+}
+
 // runValidators runs validators on a context and returns validation errors.
 func (d *Dispatcher) runValidators(ctx context.Context, hookCtx *hook.Context) []*ValidationError {
 	validators := d.registry.FindValidators(hookCtx)
@@ -256,6 +410,11 @@ func (d *Dispatcher) runValidators(ctx context.Context, hookCtx *hook.Context) [
 	// Use executor to run validators (sequential or parallel)
 	validationErrors := d.executor.Execute(ctx, hookCtx, validators)
 
+	// Replace messages and fix hints overridden in the configuration
+	if d.messageOverrides != nil {
+		validationErrors = d.messageOverrides.Apply(hookCtx, validationErrors)
+	}
+
 	// Apply exception checking to blocking errors
 	validationErrors = d.applyExceptionChecking(hookCtx, validationErrors)
 
@@ -263,12 +422,18 @@ func (d *Dispatcher) runValidators(ctx context.Context, hookCtx *hook.Context) [
 	for _, verr := range validationErrors {
 		name := shortName(verr.Validator)
 
-		if verr.ShouldBlock {
+		switch {
+		case verr.ShouldBlock:
 			d.logger.Error("validator failed",
 				"validator", name,
 				"message", verr.Message,
 			)
-		} else {
+		case verr.ShouldAsk:
+			d.logger.Info("validator requested confirmation",
+				"validator", name,
+				"message", verr.Message,
+			)
+		default:
 			d.logger.Info("validator warned",
 				"validator", name,
 				"message", verr.Message,
@@ -374,6 +539,11 @@ func (d *Dispatcher) checkUnpoisonAcknowledgment(
 	hookCtx *hook.Context,
 	info *session.SessionInfo,
 ) bool {
+	// Hard-poisoned sessions do not accept acknowledgment tokens
+	if info.IsHardPoisoned() {
+		return false
+	}
+
 	// Get command from context (only Bash commands have commands to check)
 	command := hookCtx.GetCommand()
 	if command == "" {
@@ -409,7 +579,7 @@ func (d *Dispatcher) checkUnpoisonAcknowledgment(
 			d.logSessionAuditEntry(
 				hookCtx,
 				session.AuditActionUnpoison,
-				info.PoisonCodes,
+				unpoisonTransition(info),
 				"lenient_fallback",
 				"",
 			)
@@ -440,7 +610,7 @@ func (d *Dispatcher) checkUnpoisonAcknowledgment(
 	d.logSessionAuditEntry(
 		hookCtx,
 		session.AuditActionUnpoison,
-		info.PoisonCodes,
+		unpoisonTransition(info),
 		result.Source.String(),
 		"",
 	)
@@ -448,11 +618,20 @@ func (d *Dispatcher) checkUnpoisonAcknowledgment(
 	return true
 }
 
+// unpoisonTransition returns the transition of unpoisoning the session.
+func unpoisonTransition(info *session.SessionInfo) session.Transition {
+	return session.Transition{
+		From:  info.Status,
+		To:    session.StatusClean,
+		Codes: info.PoisonCodes,
+	}
+}
+
 // logSessionAuditEntry logs a session audit entry if audit logging is enabled.
 func (d *Dispatcher) logSessionAuditEntry(
 	hookCtx *hook.Context,
 	action session.AuditAction,
-	codes []string,
+	transition session.Transition,
 	source string,
 	poisonMessage string,
 ) {
@@ -472,14 +651,17 @@ func (d *Dispatcher) logSessionAuditEntry(
 	workingDir, _ := os.Getwd()
 
 	entry := &session.AuditEntry{
-		Timestamp:     time.Now(),
-		Action:        action,
-		SessionID:     hookCtx.SessionID,
-		PoisonCodes:   codes,
-		Source:        source,
-		Command:       command,
-		PoisonMessage: poisonMessage,
-		WorkingDir:    workingDir,
+		Timestamp:      time.Now(),
+		Action:         action,
+		SessionID:      hookCtx.SessionID,
+		PoisonCodes:    transition.Codes,
+		Source:         source,
+		Command:        command,
+		PoisonMessage:  poisonMessage,
+		WorkingDir:     workingDir,
+		PreviousStatus: transition.From,
+		Status:         transition.To,
+		Attempts:       transition.Attempts,
 	}
 
 	if err := d.sessionAuditLogger.Log(entry); err != nil {
@@ -501,20 +683,52 @@ func ShouldBlock(errors []*ValidationError) bool {
 	return false
 }
 
-// categorizeErrors separates validation errors into blocking errors and warnings.
-func categorizeErrors(errors []*ValidationError) (blocking, warnings []*ValidationError) {
+// ShouldPoison returns true if any error explicitly requests poisoning the session.
+func ShouldPoison(errors []*ValidationError) bool {
+	for _, err := range errors {
+		if err.ShouldPoison {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ShouldAsk returns true if no error blocks the operation and at least one
+// error requests user confirmation.
+func ShouldAsk(errors []*ValidationError) bool {
+	if ShouldBlock(errors) {
+		return false
+	}
+
+	for _, err := range errors {
+		if err.ShouldAsk {
+			return true
+		}
+	}
+
+	return false
+}
+
+// categorizeErrors separates validation errors into blocking errors,
+// confirmation requests and warnings.
+func categorizeErrors(errors []*ValidationError) (blocking, asks, warnings []*ValidationError) {
 	blockingErrors := make([]*ValidationError, 0)
+	askErrors := make([]*ValidationError, 0)
 	warningErrors := make([]*ValidationError, 0)
 
 	for _, err := range errors {
-		if err.ShouldBlock {
+		switch {
+		case err.ShouldBlock:
 			blockingErrors = append(blockingErrors, err)
-		} else {
+		case err.ShouldAsk:
+			askErrors = append(askErrors, err)
+		default:
 			warningErrors = append(warningErrors, err)
 		}
 	}
 
-	return blockingErrors, warningErrors
+	return blockingErrors, askErrors, warningErrors
 }
 
 // formatErrorList formats a list of errors with a header.
@@ -598,9 +812,10 @@ func FormatErrors(errors []*ValidationError) string {
 		return ""
 	}
 
-	blockingErrors, warnings := categorizeErrors(errors)
+	blockingErrors, asks, warnings := categorizeErrors(errors)
 
 	result := formatErrorList("❌ Validation Failed:", blockingErrors)
+	result += formatErrorList("❓ Confirmation Required:", asks)
 	result += formatErrorList("⚠️  Warnings:", warnings)
 
 	return result
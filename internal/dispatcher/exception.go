@@ -2,6 +2,7 @@
 package dispatcher
 
 import (
+	"maps"
 	"strings"
 
 	"github.com/smykla-labs/klaudiush/internal/exceptions"
@@ -97,6 +98,10 @@ func (c *DefaultExceptionChecker) CheckException(
 			"reason", resp.Reason,
 		)
 
+		if resp.Approval != nil {
+			return withApprovalDetails(verr, resp.Approval), false
+		}
+
 		return verr, false
 	}
 
@@ -146,6 +151,26 @@ func extractErrorCode(ref validator.Reference) string {
 	return strings.TrimSuffix(refStr[len(prefix):], "/")
 }
 
+// withApprovalDetails returns a copy of the error that tells how to get the
+// pending exception approved, or that it was denied.
+func withApprovalDetails(verr *ValidationError, approval *exceptions.ApprovalRequest) *ValidationError {
+	details := make(map[string]string, len(verr.Details)+1)
+	maps.Copy(details, verr.Details)
+
+	if approval.Status == exceptions.ApprovalStatusDenied {
+		details["approval"] = "Exception approval " + approval.ID + " was denied by the user"
+	} else {
+		details["approval"] = "Exception approval " + approval.ID +
+			" is pending. Ask the user to run in their terminal: klaudiush exception approve " +
+			approval.ID + "\nThen retry the same command."
+	}
+
+	modified := *verr
+	modified.Details = details
+
+	return &modified
+}
+
 // formatBypassedMessage formats the message to indicate it was bypassed.
 func formatBypassedMessage(originalMsg string, resp *exceptions.CheckResponse) string {
 	if resp.TokenReason != "" {
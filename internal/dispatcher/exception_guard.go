@@ -0,0 +1,355 @@
+package dispatcher
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	filevalidators "github.com/smykla-labs/klaudiush/internal/validators/file"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
+)
+
+// exceptionGuardValidator is the validator name for exception guard errors.
+const exceptionGuardValidator = "exception-guard"
+
+// approvalFileExample is the name of a request file of the approval queue,
+// matched against wildcards to tell whether they select the queue's files.
+const approvalFileExample = "000000000000.json"
+
+// maxNestedScriptDepth limits how deep scripts passed to shells are parsed.
+const maxNestedScriptDepth = 4
+
+// userOnlyExceptionCommands are the klaudiush exception subcommands that only
+// the user may run, as they mint tokens or resolve approval requests.
+var userOnlyExceptionCommands = []string{"grant", "approve", "deny"}
+
+// userOnlyExceptionPattern matches a user-only exception command inside a
+// single word, such as a script passed to python -c.
+var userOnlyExceptionPattern = regexp.MustCompile(
+	`klaudiush.*\bexception\s+["']?(grant|approve|deny)\b`,
+)
+
+// scriptPrograms run the script passed with -c (or --command for script).
+var scriptPrograms = []string{"bash", "sh", "zsh", "dash", "ksh", "fish", "su", "script"}
+
+// ExceptionGuard blocks tool calls through which the agent could issue its
+// own exceptions: running klaudiush exception commands reserved for the user,
+// reading the key that signs exception tokens and changing the approval
+// queue. Its errors cannot be bypassed with exception tokens.
+//
+// The guard is best effort: it inspects commands statically, so a command
+// that builds the key path or the klaudiush invocation at runtime can evade
+// it. The terminal confirmation of the user-only commands does not rely on it.
+type ExceptionGuard struct {
+	keyReads       *filevalidators.SensitiveReadValidator
+	signingKeyFile string
+	approvalDir    string
+	homeDir        string
+}
+
+// NewExceptionGuard creates an exception guard protecting the signing key
+// and the approval queue of cfg.
+func NewExceptionGuard(cfg *config.ExceptionsConfig, log logger.Logger) *ExceptionGuard {
+	homeDir, _ := os.UserHomeDir()
+	signingKeyFile := cfg.GetSigningKeyFile()
+
+	var approvalCfg *config.ExceptionApprovalConfig
+	if cfg != nil {
+		approvalCfg = cfg.Approval
+	}
+
+	g := &ExceptionGuard{
+		keyReads: filevalidators.NewSensitiveReadValidator(
+			log,
+			&config.SensitiveReadValidatorConfig{Patterns: []string{signingKeyFile}},
+			nil,
+		),
+		homeDir: homeDir,
+	}
+
+	g.signingKeyFile = g.expandHome(signingKeyFile)
+	g.approvalDir = g.expandHome(approvalCfg.GetDir())
+
+	return g
+}
+
+// Check returns the errors of the tool call, if it runs a user-only exception
+// command, reads the signing key or changes the approval queue.
+func (g *ExceptionGuard) Check(ctx context.Context, hookCtx *hook.Context) []*ValidationError {
+	if hookCtx.EventType != hook.EventTypePreToolUse {
+		return nil
+	}
+
+	var validationErrors []*ValidationError
+
+	if hookCtx.ToolName == hook.ToolTypeBash {
+		if subcommand := userOnlyExceptionCommand(hookCtx.GetCommand()); subcommand != "" {
+			validationErrors = append(validationErrors, &ValidationError{
+				Validator: exceptionGuardValidator,
+				Message: fmt.Sprintf(
+					"Blocked: klaudiush exception %s can only be run by the user",
+					subcommand,
+				),
+				ShouldBlock: true,
+				Reference:   validator.RefExceptionUserOnly,
+				FixHint:     "Ask the user to run the command in their terminal",
+			})
+		}
+	}
+
+	if g.readsSigningKey(ctx, hookCtx) {
+		validationErrors = append(validationErrors, &ValidationError{
+			Validator:   exceptionGuardValidator,
+			Message:     "Blocked: the exception signing key can only be read by klaudiush",
+			ShouldBlock: true,
+			Reference:   validator.RefExceptionUserOnly,
+		})
+	}
+
+	if g.changesApprovalQueue(hookCtx) {
+		validationErrors = append(validationErrors, &ValidationError{
+			Validator:   exceptionGuardValidator,
+			Message:     "Blocked: the exception approval queue can only be changed by klaudiush",
+			ShouldBlock: true,
+			Reference:   validator.RefExceptionUserOnly,
+			FixHint:     "Run klaudiush exception list to see the queued requests",
+		})
+	}
+
+	return validationErrors
+}
+
+// changesApprovalQueue reports whether the tool call may change the approval
+// queue: writing a file in it, or running a Bash command that mentions it.
+func (g *ExceptionGuard) changesApprovalQueue(hookCtx *hook.Context) bool {
+	switch {
+	case hookCtx.IsFileTool():
+		path := g.expandHome(hookCtx.GetFilePath())
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(cwdOf(hookCtx), path)
+		}
+
+		rel, err := filepath.Rel(g.approvalDir, path)
+
+		return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+	case hookCtx.IsBashTool():
+		return g.mentionsPath(
+			hookCtx.GetCommand(),
+			g.approvalDir,
+			filepath.Join(g.approvalDir, approvalFileExample),
+		)
+	default:
+		return false
+	}
+}
+
+// readsSigningKey reports whether the tool call may read the signing key:
+// reading it with Read, Grep or file reader commands, searching a directory
+// containing it with Grep, or running a Bash command that mentions it.
+func (g *ExceptionGuard) readsSigningKey(ctx context.Context, hookCtx *hook.Context) bool {
+	if !g.keyReads.Validate(ctx, hookCtx).Passed {
+		return true
+	}
+
+	switch hookCtx.ToolName {
+	case hook.ToolTypeGrep:
+		return g.searchesSigningKey(hookCtx)
+	case hook.ToolTypeBash:
+		return g.mentionsPath(hookCtx.GetCommand(), g.signingKeyFile)
+	default:
+		return false
+	}
+}
+
+// searchesSigningKey reports whether Grep searches the signing key without a
+// glob filter, because the key lies below the searched path. Like Grep, it
+// does not descend into hidden directories. Glob filters are checked with the
+// other reads of the key.
+func (g *ExceptionGuard) searchesSigningKey(hookCtx *hook.Context) bool {
+	if hookCtx.ToolInput.Glob != "" {
+		return false
+	}
+
+	searched := g.expandHome(cmp.Or(hookCtx.GetFilePath(), "."))
+	if !filepath.IsAbs(searched) {
+		searched = filepath.Join(cwdOf(hookCtx), searched)
+	}
+
+	rel, err := filepath.Rel(searched, filepath.Dir(g.signingKeyFile))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+
+	return rel == "." || !slices.ContainsFunc(
+		strings.Split(rel, string(filepath.Separator)),
+		func(dir string) bool { return strings.HasPrefix(dir, ".") },
+	)
+}
+
+// cwdOf returns the working directory of the hook, or of the process if the
+// hook has none.
+func cwdOf(hookCtx *hook.Context) string {
+	if hookCtx.CWD != "" {
+		return hookCtx.CWD
+	}
+
+	cwd, _ := os.Getwd()
+
+	return cwd
+}
+
+// mentionsPath reports whether command mentions the file name of the first
+// path, or has a word whose wildcards select any of the paths.
+func (g *ExceptionGuard) mentionsPath(command string, paths ...string) bool {
+	if strings.Contains(command, filepath.Base(paths[0])) {
+		return true
+	}
+
+	return anyCommand(command, 0, func(name string, args []string) bool {
+		return slices.ContainsFunc(append([]string{name}, args...), func(word string) bool {
+			word = g.expandHome(word)
+
+			if !strings.ContainsAny(word, "*?[{") {
+				return false
+			}
+
+			return slices.ContainsFunc(paths, func(path string) bool {
+				matched, _ := doublestar.Match(word, path)
+
+				return matched
+			})
+		})
+	})
+}
+
+// expandHome replaces a leading ~, $HOME or ${HOME} with the home directory.
+func (g *ExceptionGuard) expandHome(path string) string {
+	if g.homeDir == "" {
+		return path
+	}
+
+	for _, prefix := range []string{"~", "$HOME", "${HOME}"} {
+		if path == prefix {
+			return g.homeDir
+		}
+
+		if rest, ok := strings.CutPrefix(path, prefix+"/"); ok {
+			return filepath.Join(g.homeDir, rest)
+		}
+	}
+
+	return path
+}
+
+// userOnlyExceptionCommand returns the user-only klaudiush exception
+// subcommand run by command, or "" if there is none. Any command with the
+// words "exception grant", "exception approve" or "exception deny" counts,
+// so that klaudiush cannot be hidden behind a variable or a wrapper such as
+// env or xargs, and scripts passed to shells, script and eval are checked too.
+func userOnlyExceptionCommand(command string) string {
+	var subcommand string
+
+	anyCommand(command, 0, func(name string, args []string) bool {
+		words := append([]string{name}, args...)
+
+		for i, word := range words {
+			if match := userOnlyExceptionPattern.FindStringSubmatch(word); match != nil {
+				subcommand = match[1]
+
+				return true
+			}
+
+			if i+1 < len(words) && word == "exception" &&
+				slices.Contains(userOnlyExceptionCommands, words[i+1]) {
+				subcommand = words[i+1]
+
+				return true
+			}
+		}
+
+		return false
+	})
+
+	return subcommand
+}
+
+// anyCommand parses command and reports whether fn returns true for the name
+// and unquoted arguments of any of its commands, including the commands of
+// scripts passed to shells, script and eval. Commands that cannot be parsed
+// are checked as a single command.
+func anyCommand(command string, depth int, fn func(name string, args []string) bool) bool {
+	if command == "" || depth > maxNestedScriptDepth {
+		return false
+	}
+
+	parseResult, err := parser.NewBashParser().Parse(command)
+	if err != nil {
+		return fn("", strings.Fields(command))
+	}
+
+	for _, cmd := range parseResult.Commands {
+		name, rawArgs := cmd.UnwrapSudo()
+
+		args := make([]string, 0, len(rawArgs))
+		for _, arg := range rawArgs {
+			args = append(args, parser.UnquoteArg(arg))
+		}
+
+		if fn(name, args) {
+			return true
+		}
+
+		for _, script := range nestedScripts(name, args) {
+			if anyCommand(script, depth+1, fn) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// nestedScripts returns the scripts a command runs: the -c argument of shells
+// and script, and the arguments of eval.
+func nestedScripts(name string, args []string) []string {
+	program := filepath.Base(name)
+
+	if program == "eval" {
+		return []string{strings.Join(args, " ")}
+	}
+
+	if !slices.Contains(scriptPrograms, program) {
+		return nil
+	}
+
+	var scripts []string
+
+	for i, arg := range args {
+		if script, ok := strings.CutPrefix(arg, "--command="); ok {
+			scripts = append(scripts, script)
+
+			continue
+		}
+
+		takesScript := arg == "--command" ||
+			(strings.HasPrefix(arg, "-") && !strings.HasPrefix(arg, "--") &&
+				strings.Contains(arg, "c"))
+
+		if takesScript && i+1 < len(args) {
+			scripts = append(scripts, args[i+1])
+		}
+	}
+
+	return scripts
+}
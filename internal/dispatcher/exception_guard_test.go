@@ -0,0 +1,164 @@
+package dispatcher_test
+
+import (
+	"context"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/dispatcher"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+var _ = Describe("ExceptionGuard", func() {
+	var (
+		guard       *dispatcher.ExceptionGuard
+		keyFile     string
+		approvalDir string
+	)
+
+	BeforeEach(func() {
+		dir := filepath.Join(GinkgoT().TempDir(), ".klaudiush")
+		keyFile = filepath.Join(dir, "exception_signing.key")
+		approvalDir = filepath.Join(dir, "exception_approvals")
+		guard = dispatcher.NewExceptionGuard(&config.ExceptionsConfig{
+			SigningKeyFile: keyFile,
+			Approval:       &config.ExceptionApprovalConfig{Dir: approvalDir},
+		}, logger.NewNoOpLogger())
+	})
+
+	check := func(tool hook.ToolType, input hook.ToolInput) []*dispatcher.ValidationError {
+		return guard.Check(context.Background(), &hook.Context{
+			EventType: hook.EventTypePreToolUse,
+			ToolName:  tool,
+			ToolInput: input,
+		})
+	}
+
+	DescribeTable("Bash commands",
+		func(command string, blocked bool) {
+			errs := check(hook.ToolTypeBash, hook.ToolInput{Command: command})
+			if !blocked {
+				Expect(errs).To(BeEmpty())
+
+				return
+			}
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].ShouldBlock).To(BeTrue())
+			Expect(errs[0].Reference).To(Equal(validator.RefExceptionUserOnly))
+		},
+		Entry("grant", "klaudiush exception grant SEC001 --ttl 15m", true),
+		Entry("grant by path", "/usr/local/bin/klaudiush exception grant SEC001", true),
+		Entry("grant in a pipeline", "cd /tmp && klaudiush exception 'grant' SEC001", true),
+		Entry("grant with sudo", "sudo klaudiush exception grant SEC001", true),
+		Entry("approve", "klaudiush exception approve 3f2a9c0d1e8b", true),
+		Entry("deny", "klaudiush --debug exception deny 3f2a9c0d1e8b", true),
+		Entry("other programs", "echo klaudiush exception grant", true),
+		Entry("dynamic name", "k=klaudiush; $k exception grant SEC001", true),
+		Entry("bash -c", "bash -c 'klaudiush exception grant SEC001'", true),
+		Entry("nested shells", `sh -c "bash -lc 'klaudiush exception approve 3f2a'"`, true),
+		Entry("script -qc", "script -qc 'klaudiush exception grant SEC001' /dev/null", true),
+		Entry("script --command", "script --command='klaudiush exception deny 3f2a'", true),
+		Entry("eval", `eval "klaudiush exception grant SEC001"`, true),
+		Entry("python -c", `python3 -c 'os.system("klaudiush exception grant X")'`, true),
+		Entry("list", "klaudiush exception list", false),
+		Entry("exception in a message", "git commit -m 'Handle the exception'", false),
+	)
+
+	DescribeTable("reads of the signing key",
+		func(tool hook.ToolType, input func() hook.ToolInput, blocked bool) {
+			errs := check(tool, input())
+			if !blocked {
+				Expect(errs).To(BeEmpty())
+
+				return
+			}
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Message).To(ContainSubstring("signing key"))
+		},
+		Entry("Read", hook.ToolTypeRead,
+			func() hook.ToolInput { return hook.ToolInput{FilePath: keyFile} }, true),
+		Entry("cat", hook.ToolTypeBash,
+			func() hook.ToolInput { return hook.ToolInput{Command: "cat " + keyFile} }, true),
+		Entry("grep", hook.ToolTypeBash,
+			func() hook.ToolInput { return hook.ToolInput{Command: "grep . " + keyFile} }, true),
+		Entry("python", hook.ToolTypeBash,
+			func() hook.ToolInput {
+				return hook.ToolInput{Command: `python3 -c 'open("` + keyFile + `").read()'`}
+			}, true),
+		Entry("wildcards", hook.ToolTypeBash,
+			func() hook.ToolInput {
+				return hook.ToolInput{Command: "head " + filepath.Dir(keyFile) + "/*.key"}
+			}, true),
+		Entry("wildcards in bash -c", hook.ToolTypeBash,
+			func() hook.ToolInput {
+				return hook.ToolInput{Command: "bash -c 'od " + filepath.Dir(keyFile) + "/*.key'"}
+			}, true),
+		Entry("Grep of the key directory", hook.ToolTypeGrep,
+			func() hook.ToolInput { return hook.ToolInput{FilePath: filepath.Dir(keyFile)} }, true),
+		Entry("Grep with a glob", hook.ToolTypeGrep,
+			func() hook.ToolInput {
+				return hook.ToolInput{FilePath: filepath.Dir(keyFile), Glob: "*.key"}
+			}, true),
+		Entry("Grep with another glob", hook.ToolTypeGrep,
+			func() hook.ToolInput {
+				return hook.ToolInput{FilePath: filepath.Dir(keyFile), Glob: "*.go"}
+			}, false),
+		Entry("Grep above a hidden directory", hook.ToolTypeGrep,
+			func() hook.ToolInput {
+				return hook.ToolInput{FilePath: filepath.Dir(filepath.Dir(keyFile))}
+			}, false),
+		Entry("other files", hook.ToolTypeRead,
+			func() hook.ToolInput { return hook.ToolInput{FilePath: keyFile + ".pub"} }, false),
+	)
+
+	DescribeTable("changes of the approval queue",
+		func(tool hook.ToolType, input func() hook.ToolInput, blocked bool) {
+			errs := check(tool, input())
+			if !blocked {
+				Expect(errs).To(BeEmpty())
+
+				return
+			}
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Message).To(ContainSubstring("approval queue"))
+		},
+		Entry("Write", hook.ToolTypeWrite,
+			func() hook.ToolInput {
+				return hook.ToolInput{FilePath: filepath.Join(approvalDir, "3f2a9c0d1e8b.json")}
+			}, true),
+		Entry("Edit", hook.ToolTypeEdit,
+			func() hook.ToolInput {
+				return hook.ToolInput{FilePath: filepath.Join(approvalDir, "3f2a9c0d1e8b.json")}
+			}, true),
+		Entry("sed -i", hook.ToolTypeBash,
+			func() hook.ToolInput {
+				return hook.ToolInput{
+					Command: "sed -i s/pending/approved/ " + approvalDir + "/3f2a9c0d1e8b.json",
+				}
+			}, true),
+		Entry("wildcards", hook.ToolTypeBash,
+			func() hook.ToolInput {
+				return hook.ToolInput{Command: "rm " + filepath.Dir(approvalDir) + "/*/*.json"}
+			}, true),
+		Entry("Write elsewhere", hook.ToolTypeWrite,
+			func() hook.ToolInput {
+				return hook.ToolInput{FilePath: filepath.Join(approvalDir+"2", "notes.md")}
+			}, false),
+	)
+
+	It("ignores other events", func() {
+		Expect(guard.Check(context.Background(), &hook.Context{
+			EventType: hook.EventTypePostToolUse,
+			ToolName:  hook.ToolTypeBash,
+			ToolInput: hook.ToolInput{Command: "klaudiush exception grant SEC001"},
+		})).To(BeEmpty())
+	})
+})
@@ -133,6 +133,51 @@ var _ = Describe("ExceptionChecker", func() {
 			})
 		})
 
+		Context("with policy requiring approval", func() {
+			BeforeEach(func() {
+				requireApproval := true
+
+				handler := exceptions.NewHandler(&config.ExceptionsConfig{
+					Policies: map[string]*config.ExceptionPolicyConfig{
+						"GIT022": {RequireApproval: &requireApproval},
+					},
+					RateLimit: &config.ExceptionRateLimitConfig{
+						StateFile: filepath.Join(tempDir, "state.json"),
+					},
+					Audit: &config.ExceptionAuditConfig{
+						LogFile: filepath.Join(tempDir, "audit.jsonl"),
+					},
+					Approval: &config.ExceptionApprovalConfig{
+						Dir: filepath.Join(tempDir, "approvals"),
+					},
+				})
+				checker = dispatcher.NewExceptionChecker(handler)
+			})
+
+			It("tells how to approve the pending request", func() {
+				verr := &dispatcher.ValidationError{
+					Validator:   "git.push",
+					Message:     "cannot push to protected branch",
+					ShouldBlock: true,
+					Reference:   "https://klaudiu.sh/GIT022",
+					Details:     map[string]string{"branch": "main"},
+				}
+				hookCtx := &hook.Context{
+					SessionID: "session-1",
+					ToolInput: hook.ToolInput{
+						Command: "git push origin main # EXC:GIT022:Emergency+hotfix",
+					},
+				}
+
+				result, bypassed := checker.CheckException(hookCtx, verr)
+				Expect(bypassed).To(BeFalse())
+				Expect(result.ShouldBlock).To(BeTrue())
+				Expect(result.Details).To(HaveKey("branch"))
+				Expect(result.Details["approval"]).To(ContainSubstring("klaudiush exception approve"))
+				Expect(verr.Details).NotTo(HaveKey("approval"))
+			})
+		})
+
 		Context("with valid exception token", func() {
 			BeforeEach(func() {
 				stateFile := filepath.Join(tempDir, "state.json")
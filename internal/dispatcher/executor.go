@@ -194,11 +194,13 @@ func (e *ParallelExecutor) poolFor(category validator.ValidatorCategory) *semaph
 // toValidationError converts a validator and result to a ValidationError.
 func toValidationError(v validator.Validator, result *validator.Result) *ValidationError {
 	return &ValidationError{
-		Validator:   v.Name(),
-		Message:     result.Message,
-		Details:     result.Details,
-		ShouldBlock: result.ShouldBlock,
-		Reference:   result.Reference,
-		FixHint:     result.FixHint,
+		Validator:    v.Name(),
+		Message:      result.Message,
+		Details:      result.Details,
+		ShouldBlock:  result.ShouldBlock,
+		ShouldAsk:    result.ShouldAsk,
+		ShouldPoison: result.ShouldPoison,
+		Reference:    result.Reference,
+		FixHint:      result.FixHint,
 	}
 }
@@ -20,6 +20,7 @@ import (
 type mockBlockingValidator struct {
 	name      string
 	reference validator.Reference
+	poison    bool
 }
 
 func (v *mockBlockingValidator) Name() string {
@@ -28,10 +29,11 @@ func (v *mockBlockingValidator) Name() string {
 
 func (v *mockBlockingValidator) Validate(_ context.Context, _ *hook.Context) *validator.Result {
 	return &validator.Result{
-		Passed:      false,
-		Message:     "validation blocked",
-		ShouldBlock: true,
-		Reference:   v.reference,
+		Passed:       false,
+		Message:      "validation blocked",
+		ShouldBlock:  true,
+		ShouldPoison: v.poison,
+		Reference:    v.reference,
 	}
 }
 
@@ -364,6 +366,31 @@ var _ = Describe("Dispatcher Exception Integration", func() {
 		})
 	})
 
+	Context("ShouldAsk helper", func() {
+		It("returns true when an error asks and none block", func() {
+			errors := []*dispatcher.ValidationError{
+				{Message: "warning", ShouldBlock: false},
+				{Message: "confirm", ShouldAsk: true},
+			}
+			Expect(dispatcher.ShouldAsk(errors)).To(BeTrue())
+		})
+
+		It("returns false when an error blocks", func() {
+			errors := []*dispatcher.ValidationError{
+				{Message: "confirm", ShouldAsk: true},
+				{Message: "error", ShouldBlock: true},
+			}
+			Expect(dispatcher.ShouldAsk(errors)).To(BeFalse())
+		})
+
+		It("returns false for warnings only", func() {
+			errors := []*dispatcher.ValidationError{
+				{Message: "warning", ShouldBlock: false},
+			}
+			Expect(dispatcher.ShouldAsk(errors)).To(BeFalse())
+		})
+	})
+
 	Context("FormatErrors helper", func() {
 		It("formats blocking errors with red emoji", func() {
 			errors := []*dispatcher.ValidationError{
@@ -391,6 +418,20 @@ var _ = Describe("Dispatcher Exception Integration", func() {
 			Expect(formatted).To(ContainSubstring("warning message"))
 		})
 
+		It("formats confirmation requests with question emoji", func() {
+			errors := []*dispatcher.ValidationError{
+				{
+					Validator: "git.push",
+					Message:   "please confirm",
+					ShouldAsk: true,
+				},
+			}
+			formatted := dispatcher.FormatErrors(errors)
+			Expect(formatted).To(ContainSubstring("❓ Confirmation Required:"))
+			Expect(formatted).To(ContainSubstring("please confirm"))
+			Expect(formatted).NotTo(ContainSubstring("⚠️"))
+		})
+
 		It("returns empty string for no errors", func() {
 			Expect(dispatcher.FormatErrors(nil)).To(BeEmpty())
 			Expect(dispatcher.FormatErrors([]*dispatcher.ValidationError{})).To(BeEmpty())
@@ -0,0 +1,161 @@
+package dispatcher
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/internal/templates"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// MessageDataFunc builds the template data of message overrides for a hook
+// context.
+type MessageDataFunc func(hookCtx *hook.Context) *templates.ContextData
+
+// messageOverride holds the compiled templates of a message override.
+type messageOverride struct {
+	message *template.Template
+	fixHint *template.Template
+}
+
+// MessageOverrides replaces the message and fix hint of validation errors
+// by their reference code, so users can point fix hints at their own
+// documentation.
+type MessageOverrides struct {
+	overrides map[string]*messageOverride
+	dataFunc  MessageDataFunc
+	logger    logger.Logger
+}
+
+// MessageOverridesOption configures the MessageOverrides.
+type MessageOverridesOption func(*MessageOverrides)
+
+// WithMessageOverridesLogger sets the logger.
+func WithMessageOverridesLogger(log logger.Logger) MessageOverridesOption {
+	return func(m *MessageOverrides) {
+		if log != nil {
+			m.logger = log
+		}
+	}
+}
+
+// WithMessageDataFunc sets the function building the template data from the
+// hook context. It is only called when an override applies.
+func WithMessageDataFunc(fn MessageDataFunc) MessageOverridesOption {
+	return func(m *MessageOverrides) {
+		if fn != nil {
+			m.dataFunc = fn
+		}
+	}
+}
+
+// NewMessageOverrides compiles the message overrides of cfg, keyed by
+// reference code (case-insensitive).
+func NewMessageOverrides(
+	cfg map[string]config.MessageOverrideConfig,
+	opts ...MessageOverridesOption,
+) (*MessageOverrides, error) {
+	m := &MessageOverrides{
+		overrides: make(map[string]*messageOverride, len(cfg)),
+		dataFunc:  defaultMessageData,
+		logger:    logger.NewNoOpLogger(),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	for _, code := range slices.Sorted(maps.Keys(cfg)) {
+		override := &messageOverride{}
+
+		var err error
+
+		if msg := cfg[code].Message; msg != "" {
+			override.message, err = templates.Compile(code, msg)
+			if err != nil {
+				return nil, errors.Wrapf(err, "compiling message override of %s", code)
+			}
+		}
+
+		if hint := cfg[code].FixHint; hint != "" {
+			override.fixHint, err = templates.Compile(code, hint)
+			if err != nil {
+				return nil, errors.Wrapf(err, "compiling fix hint override of %s", code)
+			}
+		}
+
+		m.overrides[strings.ToUpper(code)] = override
+	}
+
+	return m, nil
+}
+
+// defaultMessageData builds the template data without git data.
+func defaultMessageData(hookCtx *hook.Context) *templates.ContextData {
+	return &templates.ContextData{
+		Command:  hookCtx.GetCommand(),
+		File:     hookCtx.GetFilePath(),
+		FilePath: hookCtx.GetFilePath(),
+		ToolName: hookCtx.ToolName.String(),
+	}
+}
+
+// Apply replaces the message and fix hint of the errors with an override for
+// their reference code. If a template fails, the original text is kept.
+func (m *MessageOverrides) Apply(
+	hookCtx *hook.Context,
+	errs []*ValidationError,
+) []*ValidationError {
+	var data *templates.ContextData
+
+	for _, verr := range errs {
+		code := strings.ToUpper(extractErrorCode(verr.Reference))
+
+		override, ok := m.overrides[code]
+		if !ok {
+			continue
+		}
+
+		if data == nil {
+			data = m.dataFunc(hookCtx)
+		}
+
+		data.Code = code
+		data.Message = verr.Message
+		data.FixHint = verr.FixHint
+
+		verr.Message = m.render(override.message, data, verr.Message)
+		verr.FixHint = m.render(override.fixHint, data, verr.FixHint)
+	}
+
+	return errs
+}
+
+// render executes tmpl with data, returning fallback if tmpl is nil or fails.
+func (m *MessageOverrides) render(
+	tmpl *template.Template,
+	data *templates.ContextData,
+	fallback string,
+) string {
+	if tmpl == nil {
+		return fallback
+	}
+
+	text, err := templates.Execute(tmpl, data)
+	if err != nil {
+		m.logger.Error("failed to render message override",
+			"code", data.Code,
+			"error", err,
+		)
+
+		return fallback
+	}
+
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,115 @@
+package dispatcher_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/dispatcher"
+	"github.com/smykla-labs/klaudiush/internal/templates"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+var _ = Describe("MessageOverrides", func() {
+	hookCtx := &hook.Context{
+		EventType: hook.EventTypePreToolUse,
+		ToolName:  hook.ToolTypeBash,
+		ToolInput: hook.ToolInput{Command: "git push origin main"},
+	}
+
+	newError := func(ref validator.Reference) *dispatcher.ValidationError {
+		return &dispatcher.ValidationError{
+			Validator:   "validate-git-push",
+			Message:     "original message",
+			FixHint:     "original hint",
+			ShouldBlock: true,
+			Reference:   ref,
+		}
+	}
+
+	It("replaces the fix hint by reference code", func() {
+		overrides, err := dispatcher.NewMessageOverrides(map[string]config.MessageOverrideConfig{
+			"git019": {FixHint: "{{.FixHint}}. See https://runbooks.example.com/{{.Code}}"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		errs := overrides.Apply(hookCtx, []*dispatcher.ValidationError{
+			newError("https://klaudiu.sh/GIT019"),
+			newError("https://klaudiu.sh/GIT001"),
+		})
+
+		Expect(errs[0].Message).To(Equal("original message"))
+		Expect(errs[0].FixHint).To(Equal(
+			"original hint. See https://runbooks.example.com/GIT019",
+		))
+		Expect(errs[1].FixHint).To(Equal("original hint"))
+	})
+
+	It("renders the hook context", func() {
+		overrides, err := dispatcher.NewMessageOverrides(
+			map[string]config.MessageOverrideConfig{
+				"GIT019": {Message: "{{.Message}} ({{.Command}} on {{.Branch}})"},
+			},
+			dispatcher.WithMessageDataFunc(func(hookCtx *hook.Context) *templates.ContextData {
+				return &templates.ContextData{Command: hookCtx.GetCommand(), Branch: "main"}
+			}),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		errs := overrides.Apply(hookCtx, []*dispatcher.ValidationError{
+			newError("https://klaudiu.sh/GIT019"),
+		})
+
+		Expect(errs[0].Message).To(Equal("original message (git push origin main on main)"))
+		Expect(errs[0].FixHint).To(Equal("original hint"))
+	})
+
+	It("keeps the original text when rendering fails", func() {
+		overrides, err := dispatcher.NewMessageOverrides(map[string]config.MessageOverrideConfig{
+			"GIT019": {Message: "{{.Message.Nope}}"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		errs := overrides.Apply(hookCtx, []*dispatcher.ValidationError{
+			newError("https://klaudiu.sh/GIT019"),
+		})
+
+		Expect(errs[0].Message).To(Equal("original message"))
+	})
+
+	It("rejects invalid templates", func() {
+		_, err := dispatcher.NewMessageOverrides(map[string]config.MessageOverrideConfig{
+			"GIT019": {FixHint: "{{.FixHint"},
+		})
+		Expect(err).To(MatchError(ContainSubstring("compiling fix hint override of GIT019")))
+	})
+
+	It("is applied by the dispatcher", func() {
+		log := logger.NewNoOpLogger()
+		reg := validator.NewRegistry()
+		reg.Register(
+			&mockBlockingValidator{name: "git.push", reference: "https://klaudiu.sh/GIT022"},
+			validator.ToolTypeIs(hook.ToolTypeBash),
+		)
+
+		overrides, err := dispatcher.NewMessageOverrides(map[string]config.MessageOverrideConfig{
+			"GIT022": {Message: "Pushes are reviewed: {{.Command}}"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		disp := dispatcher.NewDispatcherWithOptions(
+			reg,
+			log,
+			dispatcher.NewSequentialExecutor(log),
+			dispatcher.WithMessageOverrides(overrides),
+		)
+
+		errs := disp.Dispatch(context.Background(), hookCtx)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Message).To(Equal("Pushes are reviewed: git push origin main"))
+	})
+})
@@ -0,0 +1,140 @@
+package dispatcher
+
+import (
+	"strings"
+
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+)
+
+// ResponseOptions controls how validation errors are converted to a hook response.
+type ResponseOptions struct {
+	// SuppressOutput hides the hook output from the Claude Code transcript.
+	SuppressOutput bool
+
+	// StopOnBlock stops Claude entirely when an operation is blocked.
+	StopOnBlock bool
+}
+
+// BuildResponse converts validation errors into a structured Claude Code hook response.
+//
+// For PreToolUse events blocking errors map to a "deny" permission decision and
+// confirmation requests map to "ask". Other blockable events (PostToolUse,
+// UserPromptSubmit, Stop, SubagentStop) have no permission decision, so both are
+// reported as a top-level "block" decision. Events that cannot be blocked only
+// get a system message. Warnings never change
+// the decision; they are surfaced as a system message and as additional context
+// for the model. An explicit "allow" is never emitted because it would bypass
+// the Claude Code permission system.
+func BuildResponse(
+	eventType hook.EventType,
+	errs []*ValidationError,
+	opts ResponseOptions,
+) *hook.Response {
+	resp := &hook.Response{
+		SuppressOutput: opts.SuppressOutput,
+	}
+
+	if len(errs) == 0 {
+		return resp
+	}
+
+	blocking := ShouldBlock(errs)
+	ask := ShouldAsk(errs)
+	message := strings.TrimSpace(FormatErrors(errs))
+
+	switch {
+	case blocking || ask:
+		applyDecision(resp, eventType, blocking, message)
+	default:
+		applyWarnings(resp, eventType, message)
+	}
+
+	if blocking && opts.StopOnBlock {
+		stop := false
+		resp.Continue = &stop
+		resp.StopReason = stopReason(errs)
+	}
+
+	return resp
+}
+
+// applyDecision sets the blocking or confirmation decision on the response.
+func applyDecision(resp *hook.Response, eventType hook.EventType, blocking bool, message string) {
+	if eventType == hook.EventTypePreToolUse {
+		decision := hook.PermissionDecisionAsk
+		if blocking {
+			decision = hook.PermissionDecisionDeny
+		}
+
+		resp.HookSpecificOutput = &hook.HookSpecificOutput{
+			HookEventName:            eventType.String(),
+			PermissionDecision:       decision,
+			PermissionDecisionReason: message,
+		}
+
+		return
+	}
+
+	if !supportsBlockDecision(eventType) {
+		resp.SystemMessage = message
+
+		return
+	}
+
+	resp.Decision = hook.DecisionBlock
+	resp.Reason = message
+}
+
+// applyWarnings surfaces non-blocking warnings to both the user and the model.
+func applyWarnings(resp *hook.Response, eventType hook.EventType, message string) {
+	resp.SystemMessage = message
+
+	if !supportsAdditionalContext(eventType) {
+		return
+	}
+
+	resp.HookSpecificOutput = &hook.HookSpecificOutput{
+		HookEventName:     eventType.String(),
+		AdditionalContext: message,
+	}
+}
+
+// supportsBlockDecision reports whether the event accepts a top-level "block" decision.
+func supportsBlockDecision(eventType hook.EventType) bool {
+	switch eventType {
+	case hook.EventTypePostToolUse,
+		hook.EventTypeUserPromptSubmit,
+		hook.EventTypeStop,
+		hook.EventTypeSubagentStop:
+		return true
+	default:
+		return false
+	}
+}
+
+// supportsAdditionalContext reports whether the event accepts hook-specific
+// additional context for the model.
+func supportsAdditionalContext(eventType hook.EventType) bool {
+	switch eventType {
+	case hook.EventTypePreToolUse,
+		hook.EventTypePostToolUse,
+		hook.EventTypeUserPromptSubmit,
+		hook.EventTypeSessionStart:
+		return true
+	default:
+		return false
+	}
+}
+
+// stopReason builds a short stop reason listing the blocking validators.
+func stopReason(errs []*ValidationError) string {
+	names := make([]string, 0, len(errs))
+
+	for _, err := range errs {
+		if err.ShouldBlock {
+			names = append(names, shortName(err.Validator))
+		}
+	}
+
+	return "Blocked by klaudiush: " + strings.Join(names, ", ")
+}
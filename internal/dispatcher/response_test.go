@@ -0,0 +1,131 @@
+package dispatcher_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/dispatcher"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+)
+
+var _ = Describe("BuildResponse", func() {
+	blocking := &dispatcher.ValidationError{
+		Validator:   "validate-commit",
+		Message:     "missing signoff",
+		ShouldBlock: true,
+	}
+	asking := &dispatcher.ValidationError{
+		Validator: "validate-push",
+		Message:   "confirm push",
+		ShouldAsk: true,
+	}
+	warning := &dispatcher.ValidationError{
+		Validator: "validate-markdown",
+		Message:   "style issue",
+	}
+
+	It("returns an empty response when there are no errors", func() {
+		resp := dispatcher.BuildResponse(hook.EventTypePreToolUse, nil, dispatcher.ResponseOptions{})
+		Expect(resp.HookSpecificOutput).To(BeNil())
+		Expect(resp.Continue).To(BeNil())
+		Expect(resp.SystemMessage).To(BeEmpty())
+	})
+
+	It("denies PreToolUse when an error blocks", func() {
+		resp := dispatcher.BuildResponse(
+			hook.EventTypePreToolUse,
+			[]*dispatcher.ValidationError{blocking, asking},
+			dispatcher.ResponseOptions{},
+		)
+		Expect(resp.HookSpecificOutput).NotTo(BeNil())
+		Expect(resp.HookSpecificOutput.HookEventName).To(Equal("PreToolUse"))
+		Expect(resp.HookSpecificOutput.PermissionDecision).To(Equal(hook.PermissionDecisionDeny))
+		Expect(resp.HookSpecificOutput.PermissionDecisionReason).To(ContainSubstring("missing signoff"))
+		Expect(resp.Continue).To(BeNil())
+	})
+
+	It("asks on PreToolUse when an error requests confirmation", func() {
+		resp := dispatcher.BuildResponse(
+			hook.EventTypePreToolUse,
+			[]*dispatcher.ValidationError{asking, warning},
+			dispatcher.ResponseOptions{},
+		)
+		Expect(resp.HookSpecificOutput.PermissionDecision).To(Equal(hook.PermissionDecisionAsk))
+		Expect(resp.HookSpecificOutput.PermissionDecisionReason).To(ContainSubstring("confirm push"))
+		Expect(resp.HookSpecificOutput.PermissionDecisionReason).To(ContainSubstring("style issue"))
+	})
+
+	It("passes warnings to the model as additional context", func() {
+		resp := dispatcher.BuildResponse(
+			hook.EventTypePreToolUse,
+			[]*dispatcher.ValidationError{warning},
+			dispatcher.ResponseOptions{},
+		)
+		Expect(resp.HookSpecificOutput.PermissionDecision).To(BeEmpty())
+		Expect(resp.HookSpecificOutput.AdditionalContext).To(ContainSubstring("style issue"))
+		Expect(resp.SystemMessage).To(ContainSubstring("style issue"))
+	})
+
+	It("uses a top-level block decision for PostToolUse", func() {
+		resp := dispatcher.BuildResponse(
+			hook.EventTypePostToolUse,
+			[]*dispatcher.ValidationError{blocking},
+			dispatcher.ResponseOptions{},
+		)
+		Expect(resp.Decision).To(Equal(hook.DecisionBlock))
+		Expect(resp.Reason).To(ContainSubstring("missing signoff"))
+		Expect(resp.HookSpecificOutput).To(BeNil())
+	})
+
+	It("blocks Stop with a top-level decision", func() {
+		resp := dispatcher.BuildResponse(
+			hook.EventTypeStop,
+			[]*dispatcher.ValidationError{blocking},
+			dispatcher.ResponseOptions{},
+		)
+		Expect(resp.Decision).To(Equal(hook.DecisionBlock))
+		Expect(resp.Reason).To(ContainSubstring("missing signoff"))
+	})
+
+	It("only reports a system message for events that cannot be blocked", func() {
+		resp := dispatcher.BuildResponse(
+			hook.EventTypePreCompact,
+			[]*dispatcher.ValidationError{blocking},
+			dispatcher.ResponseOptions{},
+		)
+		Expect(resp.Decision).To(BeEmpty())
+		Expect(resp.HookSpecificOutput).To(BeNil())
+		Expect(resp.SystemMessage).To(ContainSubstring("missing signoff"))
+	})
+
+	It("omits hook-specific output for Notification warnings", func() {
+		resp := dispatcher.BuildResponse(
+			hook.EventTypeNotification,
+			[]*dispatcher.ValidationError{warning},
+			dispatcher.ResponseOptions{},
+		)
+		Expect(resp.HookSpecificOutput).To(BeNil())
+		Expect(resp.SystemMessage).To(ContainSubstring("style issue"))
+	})
+
+	It("stops Claude when configured and an error blocks", func() {
+		resp := dispatcher.BuildResponse(
+			hook.EventTypePreToolUse,
+			[]*dispatcher.ValidationError{blocking},
+			dispatcher.ResponseOptions{StopOnBlock: true, SuppressOutput: true},
+		)
+		Expect(resp.Continue).NotTo(BeNil())
+		Expect(*resp.Continue).To(BeFalse())
+		Expect(resp.StopReason).To(Equal("Blocked by klaudiush: commit"))
+		Expect(resp.SuppressOutput).To(BeTrue())
+	})
+
+	It("does not stop Claude for confirmation requests", func() {
+		resp := dispatcher.BuildResponse(
+			hook.EventTypePreToolUse,
+			[]*dispatcher.ValidationError{asking},
+			dispatcher.ResponseOptions{StopOnBlock: true},
+		)
+		Expect(resp.Continue).To(BeNil())
+	})
+})
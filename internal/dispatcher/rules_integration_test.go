@@ -0,0 +1,84 @@
+package dispatcher_test
+
+import (
+	"context"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/config/factory"
+	"github.com/smykla-labs/klaudiush/internal/dispatcher"
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+var _ = Describe("Dispatcher Rules Integration", func() {
+	It("records rule statistics once per hook invocation", func() {
+		log := logger.NewNoOpLogger()
+		tempDir := GinkgoT().TempDir()
+		enabled := true
+
+		cfg := &config.Config{
+			Validators: &config.ValidatorsConfig{
+				Git:          &config.GitConfig{},
+				File:         &config.FileConfig{},
+				Notification: &config.NotificationConfig{},
+				Secrets:      &config.SecretsConfig{},
+				Shell: &config.ShellConfig{
+					Backtick: &config.BacktickValidatorConfig{
+						ValidatorConfig: config.ValidatorConfig{Enabled: &enabled},
+					},
+					Destructive: &config.DestructiveValidatorConfig{
+						ValidatorConfig: config.ValidatorConfig{Enabled: &enabled},
+					},
+				},
+			},
+			Rules: &config.RulesConfig{
+				AuditLogFile: filepath.Join(tempDir, "audit.jsonl"),
+				Rules: []config.RuleConfig{
+					{
+						Name:     "block-draft-prs",
+						Priority: 100,
+						Match:    &config.RuleMatchConfig{CommandPattern: "gh pr create --draft*"},
+						Action:   &config.RuleActionConfig{Type: "block", Message: "no drafts"},
+					},
+					{
+						Name:     "block-prs",
+						Priority: 10,
+						Match:    &config.RuleMatchConfig{CommandPattern: "gh pr create*"},
+						Action:   &config.RuleActionConfig{Type: "block", Message: "no PRs"},
+					},
+				},
+			},
+		}
+
+		registry, engine, err := factory.NewRegistryBuilder(log).BuildWithRuleEngine(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(engine).NotTo(BeNil())
+
+		recorder := rules.NewStatsRecorder(filepath.Join(tempDir, "rule_stats.json"))
+		engine.SetStatsRecorder(recorder)
+
+		disp := dispatcher.NewDispatcher(registry, log)
+		errs := disp.Dispatch(context.Background(), &hook.Context{
+			EventType: hook.EventTypePreToolUse,
+			ToolName:  hook.ToolTypeBash,
+			ToolInput: hook.ToolInput{Command: "gh pr create --draft --title wip"},
+		})
+		Expect(errs).NotTo(BeEmpty())
+		Expect(recorder.Save()).To(Succeed())
+
+		state, err := recorder.Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.Rules).To(HaveKey("block-draft-prs"))
+		Expect(state.Rules["block-draft-prs"].Matches).To(Equal(1))
+		Expect(state.Rules).To(HaveKey("block-prs"))
+		Expect(state.Rules["block-prs"].Matches).To(BeZero())
+		Expect(state.Rules["block-prs"].ShadowedBy).To(
+			Equal(map[string]int{"block-draft-prs": 1}),
+		)
+	})
+})
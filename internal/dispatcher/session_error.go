@@ -11,6 +11,10 @@ import (
 const (
 	// poisonedSessionValidator is the validator name for poisoned session errors.
 	poisonedSessionValidator = "session-poisoned"
+
+	// sessionEscalationValidator is the validator name for session escalation
+	// warnings.
+	sessionEscalationValidator = "session-escalation"
 )
 
 // createPoisonedSessionError creates a validation error for a poisoned session.
@@ -30,6 +34,10 @@ func createPoisonedSessionError(info *session.SessionInfo) *ValidationError {
 	// Format codes for display (comma-separated with spaces)
 	codesDisplay := strings.Join(info.PoisonCodes, ", ")
 
+	if info.IsHardPoisoned() {
+		return createHardPoisonedSessionError(info, codesDisplay, timestamp)
+	}
+
 	// Format codes for token (comma-separated without spaces)
 	codesToken := strings.Join(info.PoisonCodes, ",")
 
@@ -43,6 +51,10 @@ func createPoisonedSessionError(info *session.SessionInfo) *ValidationError {
 		details["original_error"] = "Original error: " + info.PoisonMessage
 	}
 
+	if info.PoisonExpiresAt != nil {
+		details["expires"] = "Poisoning expires at " + info.PoisonExpiresAt.Format("2006-01-02 15:04:05")
+	}
+
 	// Add machine-parseable unpoison instructions
 	details["unpoison"] = fmt.Sprintf(
 		"To unpoison: KLACK=\"SESS:%s\" command  # or comment: # SESS:%s",
@@ -66,6 +78,58 @@ func createPoisonedSessionError(info *session.SessionInfo) *ValidationError {
 	}
 }
 
+// createHardPoisonedSessionError creates a validation error for a session
+// hard-poisoned by repeated blocked attempts. Acknowledgment tokens are not
+// accepted, so the instructions point to the user instead.
+func createHardPoisonedSessionError(
+	info *session.SessionInfo,
+	codesDisplay string,
+	timestamp string,
+) *ValidationError {
+	msg := fmt.Sprintf(
+		"Blocked: session hard-poisoned by %s at %s after repeated blocked attempts",
+		codesDisplay,
+		timestamp,
+	)
+
+	details := make(map[string]string)
+
+	if info.PoisonMessage != "" {
+		details["original_error"] = "Original error: " + info.PoisonMessage
+	}
+
+	if info.PoisonExpiresAt != nil {
+		details["expires"] = "Poisoning expires at " + info.PoisonExpiresAt.Format("2006-01-02 15:04:05")
+	}
+
+	details["unpoison"] = fmt.Sprintf(
+		"Acknowledgment tokens are not accepted. To unpoison: klaudiush session unpoison %s",
+		info.SessionID,
+	)
+
+	return &ValidationError{
+		Validator:   poisonedSessionValidator,
+		Message:     msg,
+		Details:     details,
+		ShouldBlock: true,
+		Reference:   validator.RefSessionPoisoned,
+		FixHint:     "Stop retrying the blocked command and ask the user to unpoison the session",
+	}
+}
+
+// createSessionWarningError creates a warning for a session with repeated
+// blocked attempts below the acknowledgment threshold.
+func createSessionWarningError(transition session.Transition) *ValidationError {
+	return &ValidationError{
+		Validator: sessionEscalationValidator,
+		Message: fmt.Sprintf(
+			"Session warned: %d blocked attempt(s) of %s, repeating them will poison the session",
+			transition.Attempts,
+			strings.Join(transition.Codes, ", "),
+		),
+	}
+}
+
 // extractSessionPoisonCodes extracts all error codes from blocking validation errors.
 // Returns a slice of codes from all blocking errors with references.
 func extractSessionPoisonCodes(errors []*ValidationError) []string {
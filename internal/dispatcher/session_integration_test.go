@@ -2,6 +2,7 @@ package dispatcher_test
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -226,6 +227,56 @@ var _ = Describe("Dispatcher Session Integration", func() {
 			Expect(errs).To(HaveLen(1))
 			Expect(errs[0].Reference.Code()).To(Equal("GIT001"))
 		})
+
+		It("should not poison session for blocking PostToolUse results", func() {
+			reg.Register(
+				&mockBlockingValidator{
+					name:      "test-post-blocker",
+					reference: validator.RefShellTestFailure,
+				},
+				validator.EventTypeIs(hook.EventTypePostToolUse),
+			)
+
+			hookCtx := &hook.Context{
+				EventType:    hook.EventTypePostToolUse,
+				ToolName:     hook.ToolTypeBash,
+				SessionID:    "test-session-post",
+				ToolInput:    hook.ToolInput{Command: "go test ./..."},
+				ToolResponse: &hook.ToolResponse{Stdout: "FAIL"},
+			}
+
+			errs := disp.Dispatch(ctx, hookCtx)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].ShouldBlock).To(BeTrue())
+
+			poisoned, _ := tracker.IsPoisoned("test-session-post")
+			Expect(poisoned).To(BeFalse())
+		})
+
+		It("should poison session for PostToolUse results that request it", func() {
+			reg.Register(
+				&mockBlockingValidator{
+					name:      "test-post-poisoner",
+					reference: validator.RefSecretsAPIKey,
+					poison:    true,
+				},
+				validator.EventTypeIs(hook.EventTypePostToolUse),
+			)
+
+			hookCtx := &hook.Context{
+				EventType:    hook.EventTypePostToolUse,
+				ToolName:     hook.ToolTypeBash,
+				SessionID:    "test-session-post-poison",
+				ToolInput:    hook.ToolInput{Command: "env"},
+				ToolResponse: &hook.ToolResponse{Stdout: "TOKEN=secret"},
+			}
+
+			Expect(disp.Dispatch(ctx, hookCtx)).To(HaveLen(1))
+
+			poisoned, info := tracker.IsPoisoned("test-session-post-poison")
+			Expect(poisoned).To(BeTrue())
+			Expect(info.PoisonCodes).To(ConsistOf(validator.RefSecretsAPIKey.Code()))
+		})
 	})
 
 	Context("with session tracking disabled", func() {
@@ -1041,6 +1092,152 @@ EOF`,
 })
 
 // mockSessionAuditLogger is a mock implementation of SessionAuditLogger.
+var _ = Describe("Dispatcher Session Escalation", func() {
+	var (
+		disp        *dispatcher.Dispatcher
+		tracker     *session.Tracker
+		auditLogger *mockSessionAuditLogger
+		now         time.Time
+		ctx         context.Context
+	)
+
+	const sessionID = "escalation-test"
+
+	dispatch := func(command string) []*dispatcher.ValidationError {
+		return disp.Dispatch(ctx, &hook.Context{
+			EventType: hook.EventTypePreToolUse,
+			ToolName:  hook.ToolTypeBash,
+			SessionID: sessionID,
+			ToolInput: hook.ToolInput{Command: command},
+		})
+	}
+
+	status := func() session.Status {
+		return tracker.GetInfo(sessionID).Status
+	}
+
+	BeforeEach(func() {
+		log := logger.NewNoOpLogger()
+		reg := validator.NewRegistry()
+		ctx = context.Background()
+		now = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		enabled := true
+		cfg := &config.SessionConfig{
+			Enabled: &enabled,
+			Escalation: &config.SessionEscalationConfig{
+				PoisonCooldown: config.Duration(30 * time.Minute),
+			},
+		}
+		tracker = session.NewTracker(
+			cfg,
+			session.WithLogger(log),
+			session.WithTimeFunc(func() time.Time { return now }),
+		)
+		auditLogger = &mockSessionAuditLogger{enabled: true}
+
+		reg.Register(
+			&mockBlockingValidator{
+				name:      "test-blocker",
+				reference: validator.RefGitNoSignoff,
+			},
+			validator.And(
+				validator.EventTypeIs(hook.EventTypePreToolUse),
+				validator.ToolTypeIs(hook.ToolTypeBash),
+				validator.CommandContains("git commit"),
+			),
+		)
+
+		disp = dispatcher.NewDispatcherWithOptions(
+			reg,
+			log,
+			dispatcher.NewSequentialExecutor(log),
+			dispatcher.WithSessionTracker(tracker),
+			dispatcher.WithSessionAuditLogger(auditLogger),
+		)
+	})
+
+	It("escalates repeated blocked attempts through warn, acknowledgment and hard poison", func() {
+		By("warning on the first blocked attempt")
+
+		errs := dispatch("git commit")
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0].ShouldBlock).To(BeTrue())
+		Expect(errs[1].Validator).To(Equal("session-escalation"))
+		Expect(errs[1].ShouldBlock).To(BeFalse())
+		Expect(status()).To(Equal(session.StatusWarned))
+
+		Expect(auditLogger.entries).To(HaveLen(1))
+		Expect(auditLogger.entries[0].Action).To(Equal(session.AuditActionWarn))
+		Expect(auditLogger.entries[0].PreviousStatus).To(Equal(session.StatusClean))
+		Expect(auditLogger.entries[0].Status).To(Equal(session.StatusWarned))
+		Expect(auditLogger.entries[0].Attempts).To(Equal(1))
+
+		By("requiring acknowledgment on the second blocked attempt")
+
+		now = now.Add(time.Minute)
+		errs = dispatch("git commit")
+		Expect(errs).To(HaveLen(1))
+		Expect(status()).To(Equal(session.StatusPoisoned))
+		Expect(auditLogger.entries).To(HaveLen(2))
+		Expect(auditLogger.entries[1].Action).To(Equal(session.AuditActionPoison))
+		Expect(auditLogger.entries[1].Status).To(Equal(session.StatusPoisoned))
+
+		errs = dispatch("git status")
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Reference).To(Equal(validator.RefSessionPoisoned))
+
+		By("hard-poisoning when the acknowledged command is blocked again")
+
+		now = now.Add(time.Minute)
+		errs = dispatch(`KLACK="SESS:GIT001" git commit`)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Reference.Code()).To(Equal("GIT001"))
+		Expect(status()).To(Equal(session.StatusHardPoisoned))
+
+		Expect(auditLogger.entries).To(HaveLen(4))
+		Expect(auditLogger.entries[2].Action).To(Equal(session.AuditActionUnpoison))
+		Expect(auditLogger.entries[2].PoisonCodes).To(Equal([]string{"GIT001"}))
+		Expect(auditLogger.entries[2].PreviousStatus).To(Equal(session.StatusPoisoned))
+		Expect(auditLogger.entries[3].Action).To(Equal(session.AuditActionPoison))
+		Expect(auditLogger.entries[3].PreviousStatus).To(Equal(session.StatusClean))
+		Expect(auditLogger.entries[3].Status).To(Equal(session.StatusHardPoisoned))
+		Expect(auditLogger.entries[3].Attempts).To(Equal(3))
+
+		By("rejecting acknowledgment tokens while hard-poisoned")
+
+		errs = dispatch(`KLACK="SESS:GIT001" echo ok`)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Reference).To(Equal(validator.RefSessionPoisoned))
+		Expect(errs[0].Message).To(ContainSubstring("hard-poisoned"))
+		Expect(errs[0].Details["unpoison"]).To(ContainSubstring("klaudiush session unpoison " + sessionID))
+		Expect(status()).To(Equal(session.StatusHardPoisoned))
+	})
+
+	It("expires poisoning after the cooldown", func() {
+		dispatch("git commit")
+		dispatch("git commit")
+		Expect(status()).To(Equal(session.StatusPoisoned))
+
+		errs := dispatch("git status")
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Details["expires"]).To(ContainSubstring("2026-01-01 12:30:00"))
+
+		now = now.Add(30 * time.Minute)
+		auditLogger.entries = nil
+
+		Expect(dispatch("git status")).To(BeEmpty())
+		Expect(status()).To(Equal(session.StatusClean))
+		Expect(tracker.GetInfo(sessionID).BlockedAttempts).To(BeEmpty())
+
+		Expect(auditLogger.entries).To(HaveLen(1))
+		Expect(auditLogger.entries[0].Action).To(Equal(session.AuditActionExpire))
+		Expect(auditLogger.entries[0].PreviousStatus).To(Equal(session.StatusPoisoned))
+		Expect(auditLogger.entries[0].Status).To(Equal(session.StatusClean))
+		Expect(auditLogger.entries[0].PoisonCodes).To(Equal([]string{"GIT001"}))
+	})
+})
+
 type mockSessionAuditLogger struct {
 	entries []*session.AuditEntry
 	enabled bool
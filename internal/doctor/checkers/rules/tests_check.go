@@ -0,0 +1,114 @@
+package ruleschecker
+
+import (
+	"context"
+	"fmt"
+
+	internalconfig "github.com/smykla-labs/klaudiush/internal/config"
+	"github.com/smykla-labs/klaudiush/internal/config/factory"
+	"github.com/smykla-labs/klaudiush/internal/doctor"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// RuleTestsChecker runs the tests embedded in rule configuration.
+type RuleTestsChecker struct {
+	loader    ConfigLoader
+	loaderErr error
+}
+
+// NewRuleTestsChecker creates a new rule tests checker.
+func NewRuleTestsChecker() *RuleTestsChecker {
+	loader, err := internalconfig.NewKoanfLoader()
+	if err != nil {
+		return &RuleTestsChecker{
+			loaderErr: err,
+		}
+	}
+
+	return &RuleTestsChecker{
+		loader: loader,
+	}
+}
+
+// NewRuleTestsCheckerWithLoader creates a RuleTestsChecker with a custom loader (for testing).
+func NewRuleTestsCheckerWithLoader(loader ConfigLoader) *RuleTestsChecker {
+	return &RuleTestsChecker{
+		loader: loader,
+	}
+}
+
+// Name returns the name of the check.
+func (*RuleTestsChecker) Name() string {
+	return "Rule tests"
+}
+
+// Category returns the category of the check.
+func (*RuleTestsChecker) Category() doctor.Category {
+	return doctor.CategoryConfig
+}
+
+// Check runs all rule tests.
+func (c *RuleTestsChecker) Check(_ context.Context) doctor.CheckResult {
+	if c.loaderErr != nil {
+		return doctor.FailError("Rule tests",
+			fmt.Sprintf("config loader initialization failed: %v", c.loaderErr))
+	}
+
+	cfg, err := c.loader.Load(nil)
+	if err != nil {
+		// Config loading errors are handled by config checker
+		return doctor.Skip("Rule tests", "Config load failed (see config check)")
+	}
+
+	engine, err := factory.NewRulesFactory(logger.NewNoOpLogger()).CreateRuleEngine(cfg)
+	if err != nil {
+		return doctor.FailError("Rule tests", fmt.Sprintf("failed to create rule engine: %v", err))
+	}
+
+	if engine == nil {
+		return doctor.Skip("Rule tests", "No rules configured")
+	}
+
+	results := engine.RunTests()
+	if len(results) == 0 {
+		return doctor.Skip("Rule tests", "No rule tests defined")
+	}
+
+	var details []string
+
+	for _, result := range results {
+		if result.Passed() {
+			continue
+		}
+
+		details = append(details, fmt.Sprintf(
+			"Rule %q, test %q: expected %s, got %s",
+			result.Rule.Name,
+			result.Test.DisplayName(),
+			describeOutcome(result.Test.Matched, string(result.Test.Action)),
+			describeOutcome(result.Matched, string(result.Action)),
+		))
+	}
+
+	if len(details) == 0 {
+		return doctor.Pass("Rule tests", fmt.Sprintf("%d rule test(s) passed", len(results)))
+	}
+
+	return doctor.FailError("Rule tests",
+		fmt.Sprintf("%d of %d rule test(s) failed", len(details), len(results))).
+		WithDetails(details...)
+}
+
+// describeOutcome formats a test outcome as "matched (block)" or "not matched".
+func describeOutcome(matched bool, action string) string {
+	outcome := "not matched"
+	if matched {
+		outcome = "matched"
+	}
+
+	if action != "" {
+		outcome += " (" + action + ")"
+	}
+
+	return outcome
+}
@@ -0,0 +1,112 @@
+package ruleschecker
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/smykla-labs/klaudiush/internal/doctor"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+)
+
+var _ = Describe("RuleTestsChecker", func() {
+	var (
+		ctrl       *gomock.Controller
+		mockLoader *MockConfigLoader
+		checker    *RuleTestsChecker
+		ctx        context.Context
+	)
+
+	rulesConfig := func(tests ...config.RuleTestConfig) *config.Config {
+		return &config.Config{
+			Rules: &config.RulesConfig{
+				Rules: []config.RuleConfig{
+					{
+						Name:   "block-main",
+						Match:  &config.RuleMatchConfig{BranchPattern: "main"},
+						Action: &config.RuleActionConfig{Type: "block"},
+						Tests:  tests,
+					},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockLoader = NewMockConfigLoader(ctrl)
+		checker = NewRuleTestsCheckerWithLoader(mockLoader)
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should return correct name and category", func() {
+		Expect(checker.Name()).To(Equal("Rule tests"))
+		Expect(checker.Category()).To(Equal(doctor.CategoryConfig))
+	})
+
+	It("should skip when config load fails", func() {
+		mockLoader.EXPECT().Load(nil).Return(nil, context.DeadlineExceeded)
+
+		result := checker.Check(ctx)
+
+		Expect(result.Status).To(Equal(doctor.StatusSkipped))
+		Expect(result.Message).To(ContainSubstring("Config load failed"))
+	})
+
+	It("should skip when no rules are configured", func() {
+		mockLoader.EXPECT().Load(nil).Return(&config.Config{}, nil)
+
+		result := checker.Check(ctx)
+
+		Expect(result.Status).To(Equal(doctor.StatusSkipped))
+		Expect(result.Message).To(Equal("No rules configured"))
+	})
+
+	It("should skip when no rule tests are defined", func() {
+		mockLoader.EXPECT().Load(nil).Return(rulesConfig(), nil)
+
+		result := checker.Check(ctx)
+
+		Expect(result.Status).To(Equal(doctor.StatusSkipped))
+		Expect(result.Message).To(Equal("No rule tests defined"))
+	})
+
+	It("should pass when all rule tests pass", func() {
+		mockLoader.EXPECT().Load(nil).Return(rulesConfig(
+			config.RuleTestConfig{Branch: "main", Matched: true, Action: "block"},
+			config.RuleTestConfig{Branch: "feat/x"},
+		), nil)
+
+		result := checker.Check(ctx)
+
+		Expect(result.Status).To(Equal(doctor.StatusPass))
+		Expect(result.Message).To(Equal("2 rule test(s) passed"))
+	})
+
+	It("should fail with details when rule tests fail", func() {
+		mockLoader.EXPECT().Load(nil).Return(rulesConfig(
+			config.RuleTestConfig{Branch: "main", Matched: true},
+			config.RuleTestConfig{
+				Name:    "typo",
+				Command: "git push origin master",
+				Branch:  "master",
+				Matched: true,
+				Action:  "block",
+			},
+		), nil)
+
+		result := checker.Check(ctx)
+
+		Expect(result.Status).To(Equal(doctor.StatusFail))
+		Expect(result.Message).To(Equal("1 of 2 rule test(s) failed"))
+		Expect(result.Details).To(ConsistOf(
+			`Rule "block-main", test "typo": expected matched (block), got not matched (allow)`,
+		))
+	})
+})
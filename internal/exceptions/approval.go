@@ -0,0 +1,464 @@
+// Package exceptions provides the exception workflow system for klaudiush.
+package exceptions
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/internal/statestore"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+const (
+	// approvalIDLength is the number of hex characters of an approval ID.
+	approvalIDLength = 12
+
+	// approvalFileSuffix is the file extension of approval request files.
+	approvalFileSuffix = ".json"
+
+	// approvalLockName names the state file whose lock guards the queue.
+	approvalLockName = "queue"
+
+	// approvalAuditSource is the audit entry source of approval resolutions.
+	approvalAuditSource = "approval"
+)
+
+// ErrApprovalNotFound is returned when an approval request does not exist.
+var ErrApprovalNotFound = errors.New("approval request not found")
+
+// ApprovalStatus is the status of an approval request.
+type ApprovalStatus string
+
+const (
+	// ApprovalStatusPending indicates the request waits for a decision.
+	ApprovalStatusPending ApprovalStatus = "pending"
+
+	// ApprovalStatusApproved indicates the request was approved. The next
+	// retry of the command is allowed and uses up the approval.
+	ApprovalStatusApproved ApprovalStatus = "approved"
+
+	// ApprovalStatusDenied indicates the request was denied. Retries of the
+	// command are denied until the request expires.
+	ApprovalStatusDenied ApprovalStatus = "denied"
+)
+
+// ApprovalRequest is an exception request queued for approval by a human.
+type ApprovalRequest struct {
+	// ID identifies the request. It is derived from the session, the error
+	// code and the command hash, so retries of a command find their request.
+	ID string `json:"id"`
+
+	// Status is the status of the request.
+	Status ApprovalStatus `json:"status"`
+
+	// ErrorCode is the validator error code to bypass.
+	ErrorCode string `json:"error_code"`
+
+	// ValidatorName is the name of the validator that blocked the command.
+	ValidatorName string `json:"validator_name,omitempty"`
+
+	// Command is the full command to approve.
+	Command string `json:"command"`
+
+	// CommandHash is the hash of the normalized command.
+	CommandHash string `json:"command_hash"`
+
+	// Reason is the justification reason of the exception token.
+	Reason string `json:"reason,omitempty"`
+
+	// SessionID is the session the command runs in, if known.
+	SessionID string `json:"session_id,omitempty"`
+
+	// WorkingDir is the working directory of the command.
+	WorkingDir string `json:"working_dir,omitempty"`
+
+	// RequestedAt is when the request was queued.
+	RequestedAt time.Time `json:"requested_at"`
+
+	// ResolvedAt is when the request was approved or denied.
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+
+	// Signature signs the resolution with the exception signing key, so it
+	// cannot be forged by editing the queue.
+	Signature string `json:"signature,omitempty"`
+}
+
+// ApprovalID returns the ID of the approval request for a command.
+func ApprovalID(sessionID, errorCode, commandHash string) string {
+	sum := sha256.Sum256([]byte(sessionID + "\n" + errorCode + "\n" + commandHash))
+
+	return hex.EncodeToString(sum[:])[:approvalIDLength]
+}
+
+// isExpired returns true if the request is older than maxAge at now. Resolved
+// requests age from their resolution.
+func (r *ApprovalRequest) isExpired(now time.Time, maxAge time.Duration) bool {
+	since := r.RequestedAt
+	if r.ResolvedAt != nil {
+		since = *r.ResolvedAt
+	}
+
+	return now.Sub(since) > maxAge
+}
+
+// AuditEntry returns the audit entry recording the resolution of the request.
+func (r *ApprovalRequest) AuditEntry() *AuditEntry {
+	entry := &AuditEntry{
+		Timestamp:      time.Now(),
+		ErrorCode:      r.ErrorCode,
+		ValidatorName:  r.ValidatorName,
+		Allowed:        r.Status == ApprovalStatusApproved,
+		Reason:         r.Reason,
+		Source:         approvalAuditSource,
+		Command:        truncateCommand(r.Command),
+		WorkingDir:     r.WorkingDir,
+		SessionID:      r.SessionID,
+		ApprovalID:     r.ID,
+		ApprovalStatus: r.Status,
+	}
+
+	if r.ResolvedAt != nil {
+		entry.Timestamp = *r.ResolvedAt
+	}
+
+	if r.Status == ApprovalStatusDenied {
+		entry.DenialReason = "exception approval denied by user"
+	}
+
+	return entry
+}
+
+// ApprovalQueue is a directory of approval requests shared between processes.
+// The hook queues requests and the CLI resolves them.
+type ApprovalQueue struct {
+	dir            string
+	signingKeyFile string
+	maxAge         time.Duration
+	preview        bool
+	timeFunc       func() time.Time
+	logger         logger.Logger
+}
+
+// ApprovalQueueOption configures the ApprovalQueue.
+type ApprovalQueueOption func(*ApprovalQueue)
+
+// WithApprovalDir sets the queue directory.
+func WithApprovalDir(dir string) ApprovalQueueOption {
+	return func(q *ApprovalQueue) {
+		if dir != "" {
+			q.dir = dir
+		}
+	}
+}
+
+// WithApprovalSigningKeyFile sets the key file resolutions are signed with.
+func WithApprovalSigningKeyFile(path string) ApprovalQueueOption {
+	return func(q *ApprovalQueue) {
+		if path != "" {
+			q.signingKeyFile = path
+		}
+	}
+}
+
+// WithApprovalTimeFunc sets the time function (for testing).
+func WithApprovalTimeFunc(fn func() time.Time) ApprovalQueueOption {
+	return func(q *ApprovalQueue) {
+		if fn != nil {
+			q.timeFunc = fn
+		}
+	}
+}
+
+// WithApprovalQueueLogger sets the logger for the approval queue.
+func WithApprovalQueueLogger(log logger.Logger) ApprovalQueueOption {
+	return func(q *ApprovalQueue) {
+		if log != nil {
+			q.logger = log
+		}
+	}
+}
+
+// WithApprovalPreview makes Request report what it would do without queueing
+// or using up requests.
+func WithApprovalPreview() ApprovalQueueOption {
+	return func(q *ApprovalQueue) {
+		q.preview = true
+	}
+}
+
+// NewApprovalQueue creates a new approval queue.
+func NewApprovalQueue(
+	cfg *config.ExceptionApprovalConfig,
+	opts ...ApprovalQueueOption,
+) *ApprovalQueue {
+	q := &ApprovalQueue{
+		dir:            cfg.GetDir(),
+		signingKeyFile: config.DefaultSigningKeyFile,
+		maxAge:         cfg.GetMaxAge(),
+		timeFunc:       time.Now,
+		logger:         logger.NewNoOpLogger(),
+	}
+
+	for _, opt := range opts {
+		opt(q)
+	}
+
+	q.dir = resolveHomePath(q.dir)
+
+	return q
+}
+
+// Request returns the approval request for the given request's command and
+// session, queueing it as pending if it is not queued yet or expired. An
+// approved request is used up and returned with the approved status, so only
+// one retry is allowed per approval. Approvals without a valid signature are
+// returned as pending.
+func (q *ApprovalQueue) Request(req *ApprovalRequest) (*ApprovalRequest, error) {
+	var result *ApprovalRequest
+
+	request := func() error {
+		now := q.timeFunc()
+		id := ApprovalID(req.SessionID, req.ErrorCode, req.CommandHash)
+
+		current, err := q.read(id)
+		if err != nil {
+			return err
+		}
+
+		if current != nil && !current.isExpired(now, q.maxAge) {
+			result = current
+
+			if current.Status == ApprovalStatusApproved && !q.isSigned(current) {
+				q.logger.Info("ignoring approval without a valid signature", "id", id)
+
+				current.Status = ApprovalStatusPending
+
+				return nil
+			}
+
+			if current.Status != ApprovalStatusApproved || q.preview {
+				return nil
+			}
+
+			return q.remove(id)
+		}
+
+		queued := *req
+		queued.ID = id
+		queued.Status = ApprovalStatusPending
+		queued.RequestedAt = now
+		queued.ResolvedAt = nil
+		result = &queued
+
+		if q.preview {
+			return nil
+		}
+
+		q.pruneExpired(now)
+
+		return q.write(&queued)
+	}
+
+	// Previews only read, so they do not need the lock
+	var err error
+	if q.preview {
+		err = request()
+	} else {
+		err = q.withLock(request)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Resolve approves or denies the request with the given ID and signs the
+// resolution, creating the signing key if needed. Requests can be resolved
+// again until they are used up or expire.
+func (q *ApprovalQueue) Resolve(id string, status ApprovalStatus) (*ApprovalRequest, error) {
+	signer, err := LoadOrCreateSigner(q.signingKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *ApprovalRequest
+
+	err = q.withLock(func() error {
+		now := q.timeFunc()
+
+		current, err := q.read(id)
+		if err != nil {
+			return err
+		}
+
+		if current == nil || current.isExpired(now, q.maxAge) {
+			return errors.Wrapf(ErrApprovalNotFound, "%s", id)
+		}
+
+		current.Status = status
+		current.ResolvedAt = &now
+		current.Signature = signer.signApproval(current)
+		result = current
+
+		return q.write(current)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Get returns the request with the given ID.
+// Returns ErrApprovalNotFound if it does not exist or expired.
+func (q *ApprovalQueue) Get(id string) (*ApprovalRequest, error) {
+	request, err := q.read(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request == nil || request.isExpired(q.timeFunc(), q.maxAge) {
+		return nil, errors.Wrapf(ErrApprovalNotFound, "%s", id)
+	}
+
+	return request, nil
+}
+
+// List returns the requests that did not expire, oldest first.
+func (q *ApprovalQueue) List() ([]*ApprovalRequest, error) {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "reading approval queue")
+	}
+
+	now := q.timeFunc()
+	requests := make([]*ApprovalRequest, 0, len(entries))
+
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), approvalFileSuffix)
+		if !ok || entry.IsDir() {
+			continue
+		}
+
+		request, err := q.read(id)
+		if err != nil {
+			q.logger.Debug("skipping unreadable approval request", "id", id, "error", err.Error())
+
+			continue
+		}
+
+		if request != nil && !request.isExpired(now, q.maxAge) {
+			requests = append(requests, request)
+		}
+	}
+
+	slices.SortFunc(requests, func(a, b *ApprovalRequest) int {
+		return a.RequestedAt.Compare(b.RequestedAt)
+	})
+
+	return requests, nil
+}
+
+// isSigned returns true if the resolution of the request is signed with the
+// signing key. Without a key no resolution is signed.
+func (q *ApprovalQueue) isSigned(request *ApprovalRequest) bool {
+	signer, err := LoadSigner(q.signingKeyFile)
+	if err != nil {
+		q.logger.Debug("failed to load signing key", "error", err.Error())
+
+		return false
+	}
+
+	return signer.verifyApproval(request)
+}
+
+// withLock runs fn while holding the lock of the queue.
+func (q *ApprovalQueue) withLock(fn func() error) error {
+	return statestore.New(filepath.Join(q.dir, approvalLockName)).WithLock(fn)
+}
+
+// store returns the state store of the request with the given ID.
+func (q *ApprovalQueue) store(id string) *statestore.Store {
+	return statestore.New(filepath.Join(q.dir, id+approvalFileSuffix))
+}
+
+// read reads the request with the given ID, or nil if it does not exist.
+func (q *ApprovalQueue) read(id string) (*ApprovalRequest, error) {
+	// IDs are hex, reject anything that could escape the queue directory
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return nil, nil
+	}
+
+	data, err := q.store(id).Read()
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	var request ApprovalRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		return nil, errors.Wrapf(err, "parsing approval request %s", id)
+	}
+
+	return &request, nil
+}
+
+// write writes the request. Must be called while holding the queue lock.
+func (q *ApprovalQueue) write(request *ApprovalRequest) error {
+	data, err := json.MarshalIndent(request, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshaling approval request")
+	}
+
+	return q.store(request.ID).WriteLocked(data)
+}
+
+// remove removes the request with the given ID. Must be called while holding
+// the queue lock.
+func (q *ApprovalQueue) remove(id string) error {
+	err := os.Remove(q.store(id).Path())
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "removing approval request")
+	}
+
+	return nil
+}
+
+// pruneExpired removes expired requests. Must be called while holding the
+// queue lock. Failures are logged, as pruning is best effort.
+func (q *ApprovalQueue) pruneExpired(now time.Time) {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), approvalFileSuffix)
+		if !ok {
+			continue
+		}
+
+		request, err := q.read(id)
+		if err != nil || request == nil || !request.isExpired(now, q.maxAge) {
+			continue
+		}
+
+		if err := q.remove(id); err != nil {
+			q.logger.Debug("failed to prune approval request", "id", id, "error", err.Error())
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package exceptions_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/exceptions"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+)
+
+var _ = Describe("ApprovalQueue", func() {
+	var (
+		dir     string
+		keyFile string
+		now     time.Time
+		queue   *exceptions.ApprovalQueue
+	)
+
+	newRequest := func() *exceptions.ApprovalRequest {
+		return &exceptions.ApprovalRequest{
+			ErrorCode:   "SEC001",
+			Command:     "cat .env",
+			CommandHash: "0123456789abcdef",
+			Reason:      "Debugging",
+			SessionID:   "session-1",
+		}
+	}
+
+	BeforeEach(func() {
+		tempDir := GinkgoT().TempDir()
+		dir = filepath.Join(tempDir, "approvals")
+		keyFile = filepath.Join(tempDir, "exception_signing.key")
+		now = time.Unix(1765000000, 0).UTC()
+		queue = exceptions.NewApprovalQueue(
+			&config.ExceptionApprovalConfig{MaxAge: config.Duration(time.Hour)},
+			exceptions.WithApprovalDir(dir),
+			exceptions.WithApprovalSigningKeyFile(keyFile),
+			exceptions.WithApprovalTimeFunc(func() time.Time { return now }),
+		)
+	})
+
+	It("queues a pending request once per session and command", func() {
+		first, err := queue.Request(newRequest())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first.Status).To(Equal(exceptions.ApprovalStatusPending))
+		Expect(first.ID).To(Equal(exceptions.ApprovalID("session-1", "SEC001", "0123456789abcdef")))
+
+		now = now.Add(time.Minute)
+
+		retry, err := queue.Request(newRequest())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retry.RequestedAt).To(Equal(first.RequestedAt))
+
+		other := newRequest()
+		other.SessionID = "session-2"
+		Expect(queue.Request(other)).NotTo(HaveField("ID", first.ID))
+
+		requests, err := queue.List()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests).To(HaveLen(2))
+		Expect(requests[0].ID).To(Equal(first.ID))
+	})
+
+	It("uses up approved requests", func() {
+		request, err := queue.Request(newRequest())
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = queue.Resolve(request.ID, exceptions.ApprovalStatusApproved)
+		Expect(err).NotTo(HaveOccurred())
+
+		approved, err := queue.Request(newRequest())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(approved.Status).To(Equal(exceptions.ApprovalStatusApproved))
+
+		again, err := queue.Request(newRequest())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again.Status).To(Equal(exceptions.ApprovalStatusPending))
+	})
+
+	It("treats approvals without a valid signature as pending", func() {
+		request, err := queue.Request(newRequest())
+		Expect(err).NotTo(HaveOccurred())
+
+		denied, err := queue.Resolve(request.ID, exceptions.ApprovalStatusDenied)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(denied.Signature).NotTo(BeEmpty())
+		Expect(keyFile).To(BeAnExistingFile())
+
+		path := filepath.Join(dir, request.ID+".json")
+		content, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		forged := strings.Replace(string(content), `"denied"`, `"approved"`, 1)
+		Expect(os.WriteFile(path, []byte(forged), 0o600)).To(Succeed())
+
+		for range 2 {
+			retry, err := queue.Request(newRequest())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retry.Status).To(Equal(exceptions.ApprovalStatusPending))
+		}
+	})
+
+	It("expires requests after the maximum age", func() {
+		request, err := queue.Request(newRequest())
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = queue.Resolve(request.ID, exceptions.ApprovalStatusDenied)
+		Expect(err).NotTo(HaveOccurred())
+
+		now = now.Add(2 * time.Hour)
+
+		_, err = queue.Get(request.ID)
+		Expect(errors.Is(err, exceptions.ErrApprovalNotFound)).To(BeTrue())
+
+		_, err = queue.Resolve(request.ID, exceptions.ApprovalStatusApproved)
+		Expect(errors.Is(err, exceptions.ErrApprovalNotFound)).To(BeTrue())
+
+		renewed, err := queue.Request(newRequest())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(renewed.Status).To(Equal(exceptions.ApprovalStatusPending))
+	})
+
+	It("does not write the queue in preview mode", func() {
+		preview := exceptions.NewApprovalQueue(nil, exceptions.WithApprovalDir(dir), exceptions.WithApprovalPreview())
+
+		request, err := preview.Request(newRequest())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(request.Status).To(Equal(exceptions.ApprovalStatusPending))
+
+		_, err = os.Stat(dir)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("rejects IDs outside the queue", func() {
+		_, err := queue.Resolve("../state", exceptions.ApprovalStatusApproved)
+		Expect(errors.Is(err, exceptions.ErrApprovalNotFound)).To(BeTrue())
+	})
+
+	It("builds audit entries of resolutions", func() {
+		request, err := queue.Request(newRequest())
+		Expect(err).NotTo(HaveOccurred())
+
+		denied, err := queue.Resolve(request.ID, exceptions.ApprovalStatusDenied)
+		Expect(err).NotTo(HaveOccurred())
+
+		entry := denied.AuditEntry()
+		Expect(entry.Timestamp).To(Equal(now))
+		Expect(entry.Allowed).To(BeFalse())
+		Expect(entry.ApprovalID).To(Equal(request.ID))
+		Expect(entry.ApprovalStatus).To(Equal(exceptions.ApprovalStatusDenied))
+		Expect(entry.DenialReason).NotTo(BeEmpty())
+	})
+})
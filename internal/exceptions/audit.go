@@ -14,6 +14,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 
+	"github.com/smykla-labs/klaudiush/internal/statestore"
 	"github.com/smykla-labs/klaudiush/pkg/config"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
 )
@@ -118,28 +119,32 @@ func (a *AuditLogger) Log(entry *AuditEntry) error {
 		return nil
 	}
 
-	a.mu.Lock()
-	defer a.mu.Unlock()
-
-	// Check and perform rotation if needed
-	if rotateErr := a.rotateIfNeededLocked(); rotateErr != nil {
-		a.logger.Error("failed to rotate audit log",
-			"error", rotateErr.Error(),
-		)
-		// Continue to log even if rotation fails
-	}
-
 	// Marshal entry to JSON
 	data, err := json.Marshal(entry)
 	if err != nil {
 		return errors.Wrap(err, "marshaling audit entry")
 	}
 
-	return a.writeEntryLocked(data)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Lock the log file, so entries and rotations of concurrent invocations
+	// do not interleave
+	return a.store().WithLock(func() error {
+		// Check and perform rotation if needed
+		if rotateErr := a.rotateIfNeededLocked(); rotateErr != nil {
+			a.logger.Error("failed to rotate audit log",
+				"error", rotateErr.Error(),
+			)
+			// Continue to log even if rotation fails
+		}
+
+		return a.writeEntryLocked(data)
+	})
 }
 
 // writeEntryLocked writes the JSON data to the log file.
-// Must be called with mu held.
+// Must be called with mu and the log file lock held.
 func (a *AuditLogger) writeEntryLocked(data []byte) error {
 	path := a.resolveLogPath()
 
@@ -249,7 +254,7 @@ func (a *AuditLogger) Rotate() error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	return a.rotateLocked()
+	return a.store().WithLock(a.rotateLocked)
 }
 
 // Cleanup removes old backup files and entries exceeding retention.
@@ -257,13 +262,25 @@ func (a *AuditLogger) Cleanup() error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	// Clean up old backup files
-	if err := a.cleanupBackupsLocked(); err != nil {
-		return err
-	}
+	return a.store().WithLock(func() error {
+		// Clean up old backup files
+		if err := a.cleanupBackupsLocked(); err != nil {
+			return err
+		}
+
+		// Clean up old entries from current file
+		return a.cleanupOldEntriesLocked()
+	})
+}
 
-	// Clean up old entries from current file
-	return a.cleanupOldEntriesLocked()
+// store returns the state store guarding the log file against concurrent
+// writes of other processes.
+func (a *AuditLogger) store() *statestore.Store {
+	return statestore.New(
+		a.resolveLogPath(),
+		statestore.WithFilePermissions(auditFilePermissions),
+		statestore.WithDirPermissions(auditDirPermissions),
+	)
 }
 
 // GetLogPath returns the resolved log file path.
@@ -272,7 +289,7 @@ func (a *AuditLogger) GetLogPath() string {
 }
 
 // rotateIfNeededLocked checks if rotation is needed and performs it.
-// Must be called with mu held.
+// Must be called with mu and the log file lock held.
 func (a *AuditLogger) rotateIfNeededLocked() error {
 	path := a.resolveLogPath()
 
@@ -299,7 +316,7 @@ func (a *AuditLogger) rotateIfNeededLocked() error {
 }
 
 // rotateLocked rotates the audit log file.
-// Must be called with mu held.
+// Must be called with mu and the log file lock held.
 func (a *AuditLogger) rotateLocked() error {
 	path := a.resolveLogPath()
 
@@ -329,7 +346,7 @@ func (a *AuditLogger) rotateLocked() error {
 }
 
 // cleanupBackupsLocked removes excess backup files.
-// Must be called with mu held.
+// Must be called with mu and the log file lock held.
 func (a *AuditLogger) cleanupBackupsLocked() error {
 	path := a.resolveLogPath()
 	dir := filepath.Dir(path)
@@ -404,7 +421,7 @@ func (a *AuditLogger) removeExcessBackups(backups []string) {
 }
 
 // cleanupOldEntriesLocked removes entries older than max age.
-// Must be called with mu held.
+// Must be called with mu and the log file lock held.
 func (a *AuditLogger) cleanupOldEntriesLocked() error {
 	path := a.resolveLogPath()
 
@@ -86,6 +86,10 @@ type EvaluateRequest struct {
 
 	// Repository is the git repository path (for audit).
 	Repository string
+
+	// SessionID is the Claude Code session identifier (for audit and
+	// session-bound tokens).
+	SessionID string
 }
 
 // Evaluate evaluates a command for exception tokens and returns the result.
@@ -153,6 +157,17 @@ func (e *Engine) Evaluate(req *EvaluateRequest) *ExceptionResult {
 		ValidatorName: req.ValidatorName,
 		ErrorCode:     token.ErrorCode,
 		RequestTime:   time.Now(),
+		SessionID:     req.SessionID,
+	}
+
+	// Hash the command for command-bound tokens
+	if token.CommandHash != "" {
+		commandHash, err := e.parser.CommandHash(req.Command)
+		if err != nil {
+			e.logger.Debug("failed to hash command", "error", err.Error())
+		}
+
+		exceptionReq.CommandHash = commandHash
 	}
 
 	// Evaluate against policy
@@ -178,6 +193,9 @@ func (e *Engine) Evaluate(req *EvaluateRequest) *ExceptionResult {
 			Command:       truncateCommand(req.Command),
 			WorkingDir:    req.WorkingDir,
 			Repository:    req.Repository,
+			SessionID:     req.SessionID,
+			// Signatures present are verified, so allowed signed tokens are valid
+			Signed: decision.Allowed && token.Signature != "",
 		},
 	}
 
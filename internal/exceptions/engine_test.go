@@ -173,11 +173,13 @@ var _ = Describe("Engine", func() {
 					ErrorCode:     "GIT022",
 					WorkingDir:    "/path/to/repo",
 					Repository:    "my-repo",
+					SessionID:     "session-1",
 				})
 				Expect(result.AuditEntry).NotTo(BeNil())
 				Expect(result.AuditEntry.Timestamp).NotTo(BeZero())
 				Expect(result.AuditEntry.WorkingDir).To(Equal("/path/to/repo"))
 				Expect(result.AuditEntry.Repository).To(Equal("my-repo"))
+				Expect(result.AuditEntry.SessionID).To(Equal("session-1"))
 				Expect(result.AuditEntry.Allowed).To(BeTrue())
 				Expect(result.AuditEntry.DenialReason).To(BeEmpty())
 			})
@@ -239,6 +241,63 @@ var _ = Describe("Engine", func() {
 		})
 	})
 
+	Describe("Evaluate with scoped tokens", func() {
+		var signer *exceptions.Signer
+
+		BeforeEach(func() {
+			signer = exceptions.NewSigner([]byte("secret"))
+			engine = exceptions.NewEngine(nil, exceptions.WithMatcher(
+				exceptions.NewPolicyMatcher(nil, exceptions.WithSigner(signer)),
+			))
+		})
+
+		sign := func(command string) string {
+			hash, err := exceptions.NewParser().CommandHash(command)
+			Expect(err).NotTo(HaveOccurred())
+
+			token := &exceptions.Token{
+				Prefix:      "EXC",
+				ErrorCode:   "GIT022",
+				SessionID:   "session-1",
+				CommandHash: hash,
+			}
+			token.Signature = signer.Sign(token)
+
+			return token.String()
+		}
+
+		It("allows the bound command in the bound session", func() {
+			result := engine.Evaluate(&exceptions.EvaluateRequest{
+				Command:   "git push origin main # " + sign("git push origin main"),
+				ErrorCode: "GIT022",
+				SessionID: "session-1",
+			})
+			Expect(result.Allowed).To(BeTrue())
+			Expect(result.AuditEntry.Signed).To(BeTrue())
+		})
+
+		It("denies other commands", func() {
+			result := engine.Evaluate(&exceptions.EvaluateRequest{
+				Command:   "git push --force origin main # " + sign("git push origin main"),
+				ErrorCode: "GIT022",
+				SessionID: "session-1",
+			})
+			Expect(result.Allowed).To(BeFalse())
+			Expect(result.AuditEntry.DenialReason).To(Equal("token is bound to another command"))
+			Expect(result.AuditEntry.Signed).To(BeFalse())
+		})
+
+		It("denies other sessions", func() {
+			result := engine.Evaluate(&exceptions.EvaluateRequest{
+				Command:   "git push origin main # " + sign("git push origin main"),
+				ErrorCode: "GIT022",
+				SessionID: "session-2",
+			})
+			Expect(result.Allowed).To(BeFalse())
+			Expect(result.Reason).To(Equal("token is bound to another session"))
+		})
+	})
+
 	Describe("EvaluateForErrorCode", func() {
 		BeforeEach(func() {
 			engine = exceptions.NewEngine(nil)
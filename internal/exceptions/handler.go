@@ -18,6 +18,7 @@ type Handler struct {
 	engine      *Engine
 	rateLimiter *RateLimiter
 	auditLogger *AuditLogger
+	approvals   *ApprovalQueue
 	config      *config.ExceptionsConfig
 	logger      logger.Logger
 }
@@ -61,6 +62,15 @@ func WithAuditLogger(a *AuditLogger) HandlerOption {
 	}
 }
 
+// WithApprovalQueue sets a custom approval queue.
+func WithApprovalQueue(q *ApprovalQueue) HandlerOption {
+	return func(h *Handler) {
+		if q != nil {
+			h.approvals = q
+		}
+	}
+}
+
 // NewHandler creates a new exception handler.
 func NewHandler(cfg *config.ExceptionsConfig, opts ...HandlerOption) *Handler {
 	log := logger.NewNoOpLogger()
@@ -98,6 +108,19 @@ func NewHandler(cfg *config.ExceptionsConfig, opts ...HandlerOption) *Handler {
 		h.auditLogger = NewAuditLogger(auditCfg, WithAuditLoggerLogger(h.logger))
 	}
 
+	if h.approvals == nil {
+		var approvalCfg *config.ExceptionApprovalConfig
+		if cfg != nil {
+			approvalCfg = cfg.Approval
+		}
+
+		h.approvals = NewApprovalQueue(
+			approvalCfg,
+			WithApprovalQueueLogger(h.logger),
+			WithApprovalSigningKeyFile(cfg.GetSigningKeyFile()),
+		)
+	}
+
 	return h
 }
 
@@ -132,6 +155,10 @@ type CheckResponse struct {
 
 	// RateLimitInfo contains rate limit quota information.
 	RateLimitInfo *CheckResult
+
+	// Approval is the approval request of the command, if its policy
+	// requires approval.
+	Approval *ApprovalRequest
 }
 
 // Check evaluates whether a validation error should be bypassed due to an
@@ -162,8 +189,21 @@ func (h *Handler) Check(req *CheckRequest) *CheckResponse {
 		return h.handleRateLimitDenial(evalResult, rateLimitResult)
 	}
 
+	// Check human approval
+	var approval *ApprovalRequest
+
+	if h.isApprovalRequired(evalResult.AuditEntry.ErrorCode) {
+		approval = h.requestApproval(req, command, evalResult)
+		if approval == nil || approval.Status != ApprovalStatusApproved {
+			return h.handleApprovalDenial(evalResult, approval)
+		}
+	}
+
 	// Record and log successful exception
-	return h.handleAllowedExeption(req, evalResult, rateLimitResult)
+	resp := h.handleAllowedExeption(req, evalResult, rateLimitResult)
+	resp.Approval = approval
+
+	return resp
 }
 
 // validateRequest validates the check request and returns an error response if invalid.
@@ -189,6 +229,7 @@ func (h *Handler) evaluateToken(req *CheckRequest, command string) *ExceptionRes
 		ErrorCode:     req.ErrorCode,
 		WorkingDir:    h.getWorkingDir(),
 		Repository:    h.getRepository(req.HookContext),
+		SessionID:     req.HookContext.SessionID,
 	})
 }
 
@@ -235,6 +276,80 @@ func (h *Handler) handleRateLimitDenial(
 	}
 }
 
+// isApprovalRequired returns true if the policy of the error code requires
+// human approval.
+func (h *Handler) isApprovalRequired(errorCode string) bool {
+	if h.config == nil {
+		return false
+	}
+
+	return h.config.GetPolicy(errorCode).IsApprovalRequired()
+}
+
+// requestApproval returns the approval request of the command, queueing it
+// if needed. Returns nil if the queue is unavailable.
+func (h *Handler) requestApproval(
+	req *CheckRequest,
+	command string,
+	evalResult *ExceptionResult,
+) *ApprovalRequest {
+	entry := evalResult.AuditEntry
+
+	commandHash, err := h.engine.parser.CommandHash(command)
+	if err != nil {
+		h.logger.Error("failed to hash command for approval", "error", err.Error())
+
+		return nil
+	}
+
+	approval, err := h.approvals.Request(&ApprovalRequest{
+		ErrorCode:     entry.ErrorCode,
+		ValidatorName: req.ValidatorName,
+		Command:       command,
+		CommandHash:   commandHash,
+		Reason:        entry.Reason,
+		SessionID:     entry.SessionID,
+		WorkingDir:    entry.WorkingDir,
+	})
+	if err != nil {
+		h.logger.Error("failed to request exception approval", "error", err.Error())
+
+		return nil
+	}
+
+	entry.ApprovalID = approval.ID
+	entry.ApprovalStatus = approval.Status
+
+	return approval
+}
+
+// handleApprovalDenial handles an exception that is not approved (yet).
+func (h *Handler) handleApprovalDenial(
+	evalResult *ExceptionResult,
+	approval *ApprovalRequest,
+) *CheckResponse {
+	reason := "exception approval is unavailable"
+	if approval != nil {
+		reason = "exception approval " + approval.ID + " is " + string(approval.Status)
+	}
+
+	h.logger.Debug("exception awaiting approval",
+		"error_code", evalResult.AuditEntry.ErrorCode,
+		"reason", reason,
+	)
+
+	evalResult.AuditEntry.Allowed = false
+	evalResult.AuditEntry.DenialReason = reason
+	h.logAuditEntry(evalResult.AuditEntry, "unapproved exception")
+
+	return &CheckResponse{
+		Bypassed:  false,
+		Reason:    reason,
+		ErrorCode: evalResult.AuditEntry.ErrorCode,
+		Approval:  approval,
+	}
+}
+
 // handleAllowedExeption handles a successful exception bypass.
 func (h *Handler) handleAllowedExeption(
 	req *CheckRequest,
@@ -287,7 +402,7 @@ func (h *Handler) LoadState() error {
 	return h.rateLimiter.Load()
 }
 
-// SaveState persists rate limit state.
+// SaveState persists rate limit state if it was modified.
 func (h *Handler) SaveState() error {
 	return h.rateLimiter.Save()
 }
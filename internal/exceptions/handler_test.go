@@ -273,6 +273,90 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("with policy requiring approval", func() {
+			var queue *exceptions.ApprovalQueue
+
+			requireApproval := true
+
+			check := func() *exceptions.CheckResponse {
+				return handler.Check(&exceptions.CheckRequest{
+					HookContext: &hook.Context{
+						SessionID: "session-1",
+						ToolInput: hook.ToolInput{
+							Command: "git push origin main # EXC:GIT022:Emergency+hotfix",
+						},
+					},
+					ValidatorName: "git.push",
+					ErrorCode:     "GIT022",
+				})
+			}
+
+			BeforeEach(func() {
+				cfg := &config.ExceptionsConfig{
+					Policies: map[string]*config.ExceptionPolicyConfig{
+						"GIT022": {RequireApproval: &requireApproval},
+					},
+					RateLimit: &config.ExceptionRateLimitConfig{
+						StateFile: filepath.Join(tempDir, "state.json"),
+					},
+					Audit: &config.ExceptionAuditConfig{
+						LogFile: filepath.Join(tempDir, "audit.jsonl"),
+					},
+					Approval: &config.ExceptionApprovalConfig{
+						Dir: filepath.Join(tempDir, "approvals"),
+					},
+				}
+
+				queue = exceptions.NewApprovalQueue(
+					cfg.Approval,
+					exceptions.WithApprovalSigningKeyFile(filepath.Join(tempDir, "signing.key")),
+				)
+				handler = exceptions.NewHandler(cfg, exceptions.WithApprovalQueue(queue))
+			})
+
+			It("queues a pending approval request", func() {
+				result := check()
+				Expect(result.Bypassed).To(BeFalse())
+				Expect(result.Approval).NotTo(BeNil())
+				Expect(result.Approval.Status).To(Equal(exceptions.ApprovalStatusPending))
+				Expect(result.Reason).To(ContainSubstring("is pending"))
+
+				requests, err := queue.List()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(requests).To(HaveLen(1))
+				Expect(requests[0].SessionID).To(Equal("session-1"))
+				Expect(requests[0].Reason).To(Equal("Emergency hotfix"))
+			})
+
+			It("allows one retry once approved", func() {
+				id := check().Approval.ID
+
+				_, err := queue.Resolve(id, exceptions.ApprovalStatusApproved)
+				Expect(err).NotTo(HaveOccurred())
+
+				result := check()
+				Expect(result.Bypassed).To(BeTrue())
+				Expect(result.Approval.ID).To(Equal(id))
+
+				Expect(check().Bypassed).To(BeFalse())
+			})
+
+			It("denies retries once denied", func() {
+				id := check().Approval.ID
+
+				_, err := queue.Resolve(id, exceptions.ApprovalStatusDenied)
+				Expect(err).NotTo(HaveOccurred())
+
+				result := check()
+				Expect(result.Bypassed).To(BeFalse())
+				Expect(result.Reason).To(ContainSubstring("is denied"))
+
+				content, err := os.ReadFile(filepath.Join(tempDir, "audit.jsonl"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(ContainSubstring(`"approval_status":"denied"`))
+			})
+		})
+
 		Context("with policy not allowing exception", func() {
 			BeforeEach(func() {
 				allowException := false
@@ -5,6 +5,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/smykla-labs/klaudiush/pkg/config"
 )
@@ -15,13 +16,33 @@ var DefaultPolicy = &config.ExceptionPolicyConfig{}
 // PolicyMatcher evaluates exception policies against requests.
 type PolicyMatcher struct {
 	config *config.ExceptionsConfig
+	signer *Signer
+}
+
+// PolicyMatcherOption configures the PolicyMatcher.
+type PolicyMatcherOption func(*PolicyMatcher)
+
+// WithSigner sets the signer used to verify token signatures. By default the
+// signing key is loaded from the configured key file when needed.
+func WithSigner(s *Signer) PolicyMatcherOption {
+	return func(m *PolicyMatcher) {
+		if s != nil {
+			m.signer = s
+		}
+	}
 }
 
 // NewPolicyMatcher creates a new policy matcher.
-func NewPolicyMatcher(cfg *config.ExceptionsConfig) *PolicyMatcher {
-	return &PolicyMatcher{
+func NewPolicyMatcher(cfg *config.ExceptionsConfig, opts ...PolicyMatcherOption) *PolicyMatcher {
+	m := &PolicyMatcher{
 		config: cfg,
 	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
 }
 
 // Match evaluates a request against the configured policies.
@@ -69,6 +90,11 @@ func (m *PolicyMatcher) Match(req *ExceptionRequest) *PolicyDecision {
 		}
 	}
 
+	// Validate token scopes
+	if decision := m.validateScope(policy, req); decision != nil {
+		return decision
+	}
+
 	return &PolicyDecision{
 		Allowed:        true,
 		Reason:         "policy allows exception for " + req.Token.ErrorCode,
@@ -137,6 +163,115 @@ func (m *PolicyMatcher) validateReason(
 	}
 }
 
+// validateScope validates the scopes of the token against the request and
+// the policy. Scopes carried by the token are always enforced, the policy
+// decides which of them are mandatory. Returns nil if the scopes are valid.
+func (m *PolicyMatcher) validateScope(
+	policy *config.ExceptionPolicyConfig,
+	req *ExceptionRequest,
+) *PolicyDecision {
+	token := req.Token
+	code := token.ErrorCode
+
+	deny := func(reason string) *PolicyDecision {
+		return &PolicyDecision{
+			Allowed:        false,
+			Reason:         reason,
+			RequiredReason: policy.IsReasonRequired(),
+			ProvidedReason: token.Reason,
+		}
+	}
+
+	if decision := m.validateSignature(policy, token, deny); decision != nil {
+		return decision
+	}
+
+	switch {
+	case token.SessionID == "" && policy.IsSessionBound():
+		return deny("token for " + code + " must be bound to a session")
+	case token.SessionID != "" && token.SessionID != req.SessionID:
+		return deny("token is bound to another session")
+	case token.CommandHash == "" && policy.IsCommandBound():
+		return deny("token for " + code + " must be bound to a command")
+	case token.CommandHash != "" && token.CommandHash != req.CommandHash:
+		return deny("token is bound to another command")
+	}
+
+	return m.validateExpiry(policy, req, deny)
+}
+
+// validateSignature validates the token signature. Returns nil if the token
+// is validly signed, or unsigned and the policy does not require a signature.
+func (m *PolicyMatcher) validateSignature(
+	policy *config.ExceptionPolicyConfig,
+	token *Token,
+	deny func(string) *PolicyDecision,
+) *PolicyDecision {
+	if token.Signature == "" {
+		if policy.IsSignatureRequired() {
+			return deny("token for " + token.ErrorCode + " must be signed with klaudiush exception grant")
+		}
+
+		return nil
+	}
+
+	signer, err := m.getSigner()
+	if err != nil {
+		return deny("cannot verify token signature: " + err.Error())
+	}
+
+	if !signer.Verify(token) {
+		return deny("invalid token signature")
+	}
+
+	return nil
+}
+
+// validateExpiry validates the token expiry. A maximum token lifetime makes
+// the expiry mandatory. Returns nil if the expiry is valid.
+func (*PolicyMatcher) validateExpiry(
+	policy *config.ExceptionPolicyConfig,
+	req *ExceptionRequest,
+	deny func(string) *PolicyDecision,
+) *PolicyDecision {
+	expiresAt := req.Token.ExpiresAt
+	maxTTL := policy.GetMaxTokenTTL()
+
+	if expiresAt == nil {
+		if policy.IsExpiryRequired() || maxTTL > 0 {
+			return deny("token for " + req.Token.ErrorCode + " must carry an expiry")
+		}
+
+		return nil
+	}
+
+	if !req.RequestTime.Before(*expiresAt) {
+		return deny("token expired at " + expiresAt.Format(time.RFC3339))
+	}
+
+	if maxTTL > 0 && expiresAt.Sub(req.RequestTime) > maxTTL {
+		return deny("token expiry exceeds the maximum lifetime of " + maxTTL.String())
+	}
+
+	return nil
+}
+
+// getSigner returns the signer, loading the configured signing key on first use.
+func (m *PolicyMatcher) getSigner() (*Signer, error) {
+	if m.signer != nil {
+		return m.signer, nil
+	}
+
+	signer, err := LoadSigner(m.config.GetSigningKeyFile())
+	if err != nil {
+		return nil, err
+	}
+
+	m.signer = signer
+
+	return signer, nil
+}
+
 // isValidReason checks if the reason matches any of the valid reasons.
 // Comparison is case-insensitive and supports prefix matching.
 func (*PolicyMatcher) isValidReason(validReasons []string, reason string) bool {
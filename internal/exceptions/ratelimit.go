@@ -11,6 +11,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 
+	"github.com/smykla-labs/klaudiush/internal/statestore"
 	"github.com/smykla-labs/klaudiush/pkg/config"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
 )
@@ -37,6 +38,12 @@ type RateLimiter struct {
 	// stateFile is the resolved path for state persistence.
 	stateFile string
 
+	// recorded are the error codes recorded since the last save, and reset
+	// whether the state was reset since. Save merges them into the state file,
+	// so usage recorded by other processes is kept.
+	recorded []string
+	reset    bool
+
 	// now is a function that returns the current time.
 	// Used for testing to control time.
 	now func() time.Time
@@ -97,10 +104,7 @@ func NewRateLimiter(
 
 	// Reinitialize state window times using the (possibly custom) time function.
 	// This ensures tests with custom time functions get correct initial windows.
-	now := r.now()
-	r.state.HourStartTime = now.Truncate(time.Hour)
-	r.state.DayStartTime = now.Truncate(hoursPerDay * time.Hour)
-	r.state.LastUpdated = now
+	r.state = r.newState()
 
 	return r
 }
@@ -133,7 +137,7 @@ func (r *RateLimiter) Check(errorCode string) *CheckResult {
 	defer r.mu.Unlock()
 
 	// First, ensure windows are current
-	r.resetIfExpiredLocked()
+	r.resetIfExpired(r.state)
 
 	// Check if rate limiting is enabled
 	if r.config != nil && !r.config.IsRateLimitEnabled() {
@@ -237,15 +241,8 @@ func (r *RateLimiter) Record(errorCode string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	// Ensure windows are current
-	r.resetIfExpiredLocked()
-
-	// Increment counters
-	r.state.GlobalHourlyCount++
-	r.state.GlobalDailyCount++
-	r.state.HourlyUsage[errorCode]++
-	r.state.DailyUsage[errorCode]++
-	r.state.LastUpdated = r.now()
+	r.recordUsage(r.state, errorCode)
+	r.recorded = append(r.recorded, errorCode)
 
 	r.logger.Debug("recorded exception usage",
 		"error_code", errorCode,
@@ -256,48 +253,28 @@ func (r *RateLimiter) Record(errorCode string) error {
 	return nil
 }
 
-// Load loads the rate limit state from the configured state file.
+// Load loads the rate limit state from the configured state file. Usage not
+// saved yet is applied on top of the loaded state.
 func (r *RateLimiter) Load() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	path := r.resolveStatePath()
 
-	// Path comes from trusted configuration, not user input.
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from config
+	data, err := r.store().Read()
 	if err != nil {
-		if os.IsNotExist(err) {
-			r.logger.Debug("state file does not exist, using fresh state",
-				"path", path,
-			)
-
-			return nil
-		}
-
 		return errors.Wrap(err, "reading state file")
 	}
 
-	var state RateLimitState
-	if err := json.Unmarshal(data, &state); err != nil {
-		r.logger.Debug("failed to parse state file, using fresh state",
+	if data == nil {
+		r.logger.Debug("state file does not exist, using fresh state",
 			"path", path,
-			"error", err.Error(),
 		)
 
 		return nil
 	}
 
-	// Initialize maps if nil (could happen with corrupted/old state files)
-	if state.HourlyUsage == nil {
-		state.HourlyUsage = make(map[string]int)
-	}
-
-	if state.DailyUsage == nil {
-		state.DailyUsage = make(map[string]int)
-	}
-
-	r.state = &state
-	r.resetIfExpiredLocked()
+	r.state = r.mergeState(path, data)
 
 	r.logger.Debug("loaded state from file",
 		"path", path,
@@ -308,38 +285,43 @@ func (r *RateLimiter) Load() error {
 	return nil
 }
 
-// Save persists the current rate limit state to the configured state file.
+// Save persists the rate limit state to the configured state file. The state
+// file is locked while the usage recorded since the last load or save is
+// merged into its current content, so concurrent invocations sharing the file
+// do not lose each other's usage. Nothing is written if no usage was recorded
+// and the state was not reset.
 func (r *RateLimiter) Save() error {
-	r.mu.RLock()
-	state := r.state
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	path := r.resolveStatePath()
-	r.mu.RUnlock()
 
-	// Ensure directory exists
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, stateDirPermissions); err != nil {
-		return errors.Wrap(err, "creating state directory")
-	}
+	if len(r.recorded) == 0 && !r.reset {
+		r.logger.Debug("state unchanged, skipping save", "path", path)
 
-	data, err := json.MarshalIndent(state, "", "  ")
-	if err != nil {
-		return errors.Wrap(err, "marshaling state")
+		return nil
 	}
 
-	// Write to temp file first for atomic operation
-	tmpPath := path + ".tmp"
-	if err := os.WriteFile(tmpPath, data, stateFilePermissions); err != nil {
-		return errors.Wrap(err, "writing temp state file")
-	}
+	var merged *RateLimitState
 
-	// Rename for atomic replace
-	if err := os.Rename(tmpPath, path); err != nil {
-		// Clean up temp file on error
-		_ = os.Remove(tmpPath)
+	err := r.store().Update(func(current []byte) ([]byte, error) {
+		merged = r.mergeState(path, current)
 
-		return errors.Wrap(err, "renaming state file")
+		data, err := json.MarshalIndent(merged, "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "marshaling state")
+		}
+
+		return data, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "saving state file")
 	}
 
+	r.state = merged
+	r.recorded = nil
+	r.reset = false
+
 	r.logger.Debug("saved state to file",
 		"path", path,
 	)
@@ -347,18 +329,83 @@ func (r *RateLimiter) Save() error {
 	return nil
 }
 
+// store returns the state store of the configured state file.
+func (r *RateLimiter) store() *statestore.Store {
+	return statestore.New(
+		r.resolveStatePath(),
+		statestore.WithFilePermissions(stateFilePermissions),
+		statestore.WithDirPermissions(stateDirPermissions),
+	)
+}
+
+// mergeState parses the state file content and applies the reset and usage
+// not saved yet. A missing or corrupted state file yields a fresh state.
+// Must be called with mu held.
+func (r *RateLimiter) mergeState(path string, data []byte) *RateLimitState {
+	state := r.newState()
+
+	if data != nil && !r.reset {
+		var loaded RateLimitState
+		if err := json.Unmarshal(data, &loaded); err != nil {
+			r.logger.Debug("failed to parse state file, using fresh state",
+				"path", path,
+				"error", err.Error(),
+			)
+		} else {
+			state = &loaded
+		}
+	}
+
+	// Initialize maps if nil (could happen with corrupted/old state files)
+	if state.HourlyUsage == nil {
+		state.HourlyUsage = make(map[string]int)
+	}
+
+	if state.DailyUsage == nil {
+		state.DailyUsage = make(map[string]int)
+	}
+
+	r.resetIfExpired(state)
+
+	for _, errorCode := range r.recorded {
+		r.recordUsage(state, errorCode)
+	}
+
+	return state
+}
+
+// newState returns a fresh state with windows starting at the current time.
+func (r *RateLimiter) newState() *RateLimitState {
+	state := NewRateLimitState()
+
+	now := r.now()
+	state.HourStartTime = now.Truncate(time.Hour)
+	state.DayStartTime = now.Truncate(hoursPerDay * time.Hour)
+	state.LastUpdated = now
+
+	return state
+}
+
+// recordUsage increments the counters of errorCode in state.
+func (r *RateLimiter) recordUsage(state *RateLimitState, errorCode string) {
+	// Ensure windows are current
+	r.resetIfExpired(state)
+
+	state.GlobalHourlyCount++
+	state.GlobalDailyCount++
+	state.HourlyUsage[errorCode]++
+	state.DailyUsage[errorCode]++
+	state.LastUpdated = r.now()
+}
+
 // Reset clears all rate limit state.
 func (r *RateLimiter) Reset() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.state = NewRateLimitState()
-
-	// Update times with the current time function
-	now := r.now()
-	r.state.HourStartTime = now.Truncate(time.Hour)
-	r.state.DayStartTime = now.Truncate(hoursPerDay * time.Hour)
-	r.state.LastUpdated = now
+	r.state = r.newState()
+	r.recorded = nil
+	r.reset = true
 
 	r.logger.Debug("rate limit state reset")
 }
@@ -378,35 +425,34 @@ func (r *RateLimiter) GetState() RateLimitState {
 	return state
 }
 
-// resetIfExpiredLocked resets counters if time windows have expired.
-// Must be called with mu held.
-func (r *RateLimiter) resetIfExpiredLocked() {
+// resetIfExpired resets counters of state if time windows have expired.
+func (r *RateLimiter) resetIfExpired(state *RateLimitState) {
 	now := r.now()
 	currentHour := now.Truncate(time.Hour)
 	currentDay := now.Truncate(hoursPerDay * time.Hour)
 
 	// Reset hourly counters if hour has changed
-	if currentHour.After(r.state.HourStartTime) {
+	if currentHour.After(state.HourStartTime) {
 		r.logger.Debug("resetting hourly counters",
-			"old_hour", r.state.HourStartTime.Format(time.RFC3339),
+			"old_hour", state.HourStartTime.Format(time.RFC3339),
 			"new_hour", currentHour.Format(time.RFC3339),
 		)
 
-		r.state.GlobalHourlyCount = 0
-		r.state.HourlyUsage = make(map[string]int)
-		r.state.HourStartTime = currentHour
+		state.GlobalHourlyCount = 0
+		state.HourlyUsage = make(map[string]int)
+		state.HourStartTime = currentHour
 	}
 
 	// Reset daily counters if day has changed
-	if currentDay.After(r.state.DayStartTime) {
+	if currentDay.After(state.DayStartTime) {
 		r.logger.Debug("resetting daily counters",
-			"old_day", r.state.DayStartTime.Format(time.RFC3339),
+			"old_day", state.DayStartTime.Format(time.RFC3339),
 			"new_day", currentDay.Format(time.RFC3339),
 		)
 
-		r.state.GlobalDailyCount = 0
-		r.state.DailyUsage = make(map[string]int)
-		r.state.DayStartTime = currentDay
+		state.GlobalDailyCount = 0
+		state.DailyUsage = make(map[string]int)
+		state.DayStartTime = currentDay
 	}
 }
 
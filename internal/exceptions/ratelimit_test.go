@@ -282,6 +282,17 @@ var _ = Describe("RateLimiter", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("does not write the state file when nothing was recorded", func() {
+			Expect(limiter.Load()).To(Succeed())
+			Expect(limiter.Save()).To(Succeed())
+
+			_, err := os.Stat(stateFile)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+
+			_, err = os.Stat(stateFile + ".lock")
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
 		It("loads previously saved state", func() {
 			_ = limiter.Record("GIT022")
 			_ = limiter.Record("SEC001")
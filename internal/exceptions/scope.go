@@ -0,0 +1,188 @@
+// Package exceptions provides the exception workflow system for klaudiush.
+package exceptions
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"mvdan.cc/sh/v3/syntax"
+)
+
+// Token scope keys.
+const (
+	// ScopeSession binds the token to a session ID.
+	ScopeSession = "sid"
+
+	// ScopeCommand binds the token to the hash of the normalized command.
+	ScopeCommand = "cmd"
+
+	// ScopeExpiry binds the token to an expiry unix timestamp.
+	ScopeExpiry = "exp"
+
+	// ScopeSignature carries the HMAC signature of the token.
+	ScopeSignature = "sig"
+
+	// commandHashLength is the number of hex characters of a command hash.
+	commandHashLength = 16
+)
+
+// scopeKeys lists the scope keys in the order they are encoded.
+var scopeKeys = []string{ScopeSession, ScopeCommand, ScopeExpiry, ScopeSignature}
+
+// IsScoped returns true if the token carries any scope.
+func (t *Token) IsScoped() bool {
+	return t.SessionID != "" || t.CommandHash != "" || t.ExpiresAt != nil || t.Signature != ""
+}
+
+// String encodes the token in its textual form. The reason is URL-encoded and
+// scopes are appended after it.
+func (t *Token) String() string {
+	var builder strings.Builder
+
+	builder.WriteString(t.Prefix)
+	builder.WriteString(":")
+	builder.WriteString(t.ErrorCode)
+
+	if t.Reason != "" || t.IsScoped() {
+		builder.WriteString(":")
+		builder.WriteString(url.QueryEscape(t.Reason))
+	}
+
+	for _, key := range scopeKeys {
+		if value := t.scopeValue(key); value != "" {
+			builder.WriteString(":" + key + "=" + value)
+		}
+	}
+
+	return builder.String()
+}
+
+// scopeValue returns the encoded value of a scope, or empty if it is not set.
+func (t *Token) scopeValue(key string) string {
+	switch key {
+	case ScopeSession:
+		return t.SessionID
+	case ScopeCommand:
+		return t.CommandHash
+	case ScopeExpiry:
+		if t.ExpiresAt == nil {
+			return ""
+		}
+
+		return strconv.FormatInt(t.ExpiresAt.Unix(), 10)
+	case ScopeSignature:
+		return t.Signature
+	default:
+		return ""
+	}
+}
+
+// setScope sets a scope of the token from its encoded value.
+func (t *Token) setScope(key, value string) error {
+	if t.scopeValue(key) != "" {
+		return errors.Wrapf(ErrInvalidToken, "duplicate scope %q", key)
+	}
+
+	if value == "" {
+		return errors.Wrapf(ErrInvalidToken, "empty scope %q", key)
+	}
+
+	switch key {
+	case ScopeSession:
+		t.SessionID = value
+	case ScopeCommand:
+		t.CommandHash = value
+	case ScopeExpiry:
+		unix, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return errors.Wrapf(ErrInvalidToken, "invalid expiry %q", value)
+		}
+
+		expiresAt := time.Unix(unix, 0).UTC()
+		t.ExpiresAt = &expiresAt
+	case ScopeSignature:
+		t.Signature = value
+	}
+
+	return nil
+}
+
+// splitScopes removes the trailing scope parts (key=value with a known key)
+// of a token split on colons and applies them to the token. The error code
+// part is never treated as a scope.
+func splitScopes(token *Token, parts []string) ([]string, error) {
+	for len(parts) > minTokenParts {
+		key, value, ok := strings.Cut(parts[len(parts)-1], "=")
+		if !ok || !slices.Contains(scopeKeys, key) {
+			break
+		}
+
+		if err := token.setScope(key, value); err != nil {
+			return nil, err
+		}
+
+		parts = parts[:len(parts)-1]
+	}
+
+	return parts, nil
+}
+
+// NormalizeCommand returns the canonical form of a command used for command
+// binding: comments and exception token assignments are removed and the
+// command is reformatted, so whitespace differences do not change it.
+func (p *Parser) NormalizeCommand(command string) (string, error) {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return "", ErrEmptyCommand
+	}
+
+	file, err := syntax.NewParser().Parse(strings.NewReader(command), "")
+	if err != nil {
+		return "", errors.Wrap(ErrParseFailed, err.Error())
+	}
+
+	syntax.Walk(file, func(node syntax.Node) bool {
+		call, ok := node.(*syntax.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+
+		assigns := call.Assigns[:0]
+
+		for _, assign := range call.Assigns {
+			if assign.Name == nil || assign.Name.Value != p.envVarName {
+				assigns = append(assigns, assign)
+			}
+		}
+
+		call.Assigns = assigns
+
+		return true
+	})
+
+	var buf bytes.Buffer
+	if err := syntax.NewPrinter().Print(&buf, file); err != nil {
+		return "", errors.Wrap(ErrParseFailed, err.Error())
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// CommandHash returns the hash of the normalized command.
+func (p *Parser) CommandHash(command string) (string, error) {
+	normalized, err := p.NormalizeCommand(command)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(normalized))
+
+	return hex.EncodeToString(sum[:])[:commandHashLength], nil
+}
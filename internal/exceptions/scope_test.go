@@ -0,0 +1,205 @@
+package exceptions_test
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/exceptions"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+)
+
+var _ = Describe("Token scopes", func() {
+	var parser *exceptions.Parser
+
+	BeforeEach(func() {
+		parser = exceptions.NewParser()
+	})
+
+	Describe("ParseToken", func() {
+		It("parses all scopes", func() {
+			token, err := parser.ParseToken(
+				"EXC:SEC001:Test+fixture:sid=abc-123:cmd=0123456789abcdef:exp=1765000000:sig=4f2a9c0d1e8b7a63",
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token.Reason).To(Equal("Test fixture"))
+			Expect(token.SessionID).To(Equal("abc-123"))
+			Expect(token.CommandHash).To(Equal("0123456789abcdef"))
+			Expect(token.ExpiresAt.Unix()).To(Equal(int64(1765000000)))
+			Expect(token.Signature).To(Equal("4f2a9c0d1e8b7a63"))
+		})
+
+		It("parses scopes without a reason", func() {
+			token, err := parser.ParseToken("EXC:SEC001::sid=abc-123")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token.Reason).To(BeEmpty())
+			Expect(token.SessionID).To(Equal("abc-123"))
+		})
+
+		It("keeps colons of unscoped reasons", func() {
+			token, err := parser.ParseToken("EXC:GIT022:reason:extra:parts")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token.Reason).To(Equal("reason:extra:parts"))
+			Expect(token.IsScoped()).To(BeFalse())
+		})
+
+		It("rejects an invalid expiry", func() {
+			_, err := parser.ParseToken("EXC:SEC001:reason:exp=tomorrow")
+			Expect(errors.Is(err, exceptions.ErrInvalidToken)).To(BeTrue())
+		})
+
+		It("rejects duplicate scopes", func() {
+			_, err := parser.ParseToken("EXC:SEC001:reason:sid=a:sid=b")
+			Expect(errors.Is(err, exceptions.ErrInvalidToken)).To(BeTrue())
+		})
+	})
+
+	Describe("String", func() {
+		It("encodes an unscoped token", func() {
+			token := &exceptions.Token{Prefix: "EXC", ErrorCode: "GIT022", Reason: "Emergency hotfix"}
+			Expect(token.String()).To(Equal("EXC:GIT022:Emergency+hotfix"))
+		})
+
+		It("round-trips a scoped token", func() {
+			expiresAt := time.Unix(1765000000, 0).UTC()
+			token := &exceptions.Token{
+				Prefix:      "EXC",
+				ErrorCode:   "SEC001",
+				Reason:      "a: b",
+				SessionID:   "abc-123",
+				CommandHash: "0123456789abcdef",
+				ExpiresAt:   &expiresAt,
+				Signature:   "4f2a9c0d1e8b7a63",
+			}
+
+			parsed, err := parser.ParseToken(token.String())
+			Expect(err).NotTo(HaveOccurred())
+
+			parsed.Raw = ""
+			Expect(parsed).To(Equal(token))
+		})
+	})
+
+	Describe("CommandHash", func() {
+		It("ignores tokens, comments and whitespace", func() {
+			hash, err := parser.CommandHash("git push origin main")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hash).To(HaveLen(16))
+
+			for _, command := range []string{
+				"git push origin main  # EXC:GIT022:reason:cmd=" + hash,
+				`KLACK="EXC:GIT022:reason" git   push origin main`,
+			} {
+				Expect(parser.CommandHash(command)).To(Equal(hash), command)
+			}
+		})
+
+		It("differs for other commands", func() {
+			hash, err := parser.CommandHash("git push origin main")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parser.CommandHash("git push --force origin main")).NotTo(Equal(hash))
+		})
+	})
+
+	Describe("PolicyMatcher", func() {
+		var (
+			signer  *exceptions.Signer
+			policy  *config.ExceptionPolicyConfig
+			matcher *exceptions.PolicyMatcher
+			now     time.Time
+		)
+
+		enabled := true
+
+		match := func(token *exceptions.Token) *exceptions.PolicyDecision {
+			return matcher.Match(&exceptions.ExceptionRequest{
+				Token:       token,
+				RequestTime: now,
+				SessionID:   "session-1",
+				CommandHash: "0123456789abcdef",
+			})
+		}
+
+		BeforeEach(func() {
+			now = time.Now()
+			signer = exceptions.NewSigner([]byte("secret"))
+			policy = &config.ExceptionPolicyConfig{}
+			matcher = exceptions.NewPolicyMatcher(&config.ExceptionsConfig{
+				Policies: map[string]*config.ExceptionPolicyConfig{"SEC001": policy},
+			}, exceptions.WithSigner(signer))
+		})
+
+		It("enforces scopes carried by the token", func() {
+			token := &exceptions.Token{ErrorCode: "SEC001", SessionID: "session-2"}
+			Expect(match(token).Reason).To(Equal("token is bound to another session"))
+
+			token = &exceptions.Token{ErrorCode: "SEC001", CommandHash: "fedcba9876543210"}
+			Expect(match(token).Reason).To(Equal("token is bound to another command"))
+
+			expiresAt := now.Add(-time.Second)
+			token = &exceptions.Token{ErrorCode: "SEC001", ExpiresAt: &expiresAt}
+			Expect(match(token).Reason).To(HavePrefix("token expired at"))
+
+			token = &exceptions.Token{
+				ErrorCode:   "SEC001",
+				SessionID:   "session-1",
+				CommandHash: "0123456789abcdef",
+			}
+			Expect(match(token).Allowed).To(BeTrue())
+		})
+
+		It("requires scopes configured by the policy", func() {
+			policy.BindSession = &enabled
+			policy.BindCommand = &enabled
+			policy.RequireExpiry = &enabled
+
+			token := &exceptions.Token{ErrorCode: "SEC001"}
+			Expect(match(token).Reason).To(Equal("token for SEC001 must be bound to a session"))
+
+			token.SessionID = "session-1"
+			Expect(match(token).Reason).To(Equal("token for SEC001 must be bound to a command"))
+
+			token.CommandHash = "0123456789abcdef"
+			Expect(match(token).Reason).To(Equal("token for SEC001 must carry an expiry"))
+
+			expiresAt := now.Add(time.Hour)
+			token.ExpiresAt = &expiresAt
+			Expect(match(token).Allowed).To(BeTrue())
+		})
+
+		It("limits the token lifetime", func() {
+			policy.MaxTokenTTL = config.Duration(time.Hour)
+
+			token := &exceptions.Token{ErrorCode: "SEC001"}
+			Expect(match(token).Reason).To(Equal("token for SEC001 must carry an expiry"))
+
+			expiresAt := now.Add(2 * time.Hour)
+			token.ExpiresAt = &expiresAt
+			Expect(match(token).Reason).To(ContainSubstring("maximum lifetime of 1h0m0s"))
+		})
+
+		It("requires a valid signature", func() {
+			policy.RequireSignature = &enabled
+
+			token := &exceptions.Token{Prefix: "EXC", ErrorCode: "SEC001", Reason: "Test fixture"}
+			Expect(match(token).Reason).To(ContainSubstring("must be signed"))
+
+			token.Signature = signer.Sign(token)
+			Expect(match(token).Allowed).To(BeTrue())
+
+			token.Reason = "Other reason"
+			Expect(match(token).Reason).To(Equal("invalid token signature"))
+		})
+
+		It("denies signed tokens without a signing key", func() {
+			matcher = exceptions.NewPolicyMatcher(&config.ExceptionsConfig{
+				SigningKeyFile: "/nonexistent/exception_signing.key",
+			})
+
+			token := &exceptions.Token{ErrorCode: "SEC001", Signature: "4f2a9c0d1e8b7a63"}
+			Expect(match(token).Reason).To(ContainSubstring("signing key not found"))
+		})
+	})
+})
@@ -0,0 +1,177 @@
+// Package exceptions provides the exception workflow system for klaudiush.
+package exceptions
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/internal/statestore"
+)
+
+const (
+	// signingKeyBytes is the number of random bytes of a signing key.
+	signingKeyBytes = 32
+
+	// signatureLength is the number of hex characters of a token signature.
+	signatureLength = 16
+)
+
+var (
+	// ErrSigningKeyNotFound is returned when the signing key file does not exist.
+	ErrSigningKeyNotFound = errors.New("signing key not found")
+
+	// ErrInvalidSigningKey is returned when the signing key file is malformed.
+	ErrInvalidSigningKey = errors.New("invalid signing key")
+)
+
+// Signer signs and verifies exception tokens with an HMAC key, so tokens can
+// only be minted by whoever can read the key.
+type Signer struct {
+	key []byte
+}
+
+// NewSigner creates a signer with the given key.
+func NewSigner(key []byte) *Signer {
+	return &Signer{key: key}
+}
+
+// LoadSigner loads the signing key from path.
+// Returns ErrSigningKeyNotFound if the key file does not exist.
+func LoadSigner(path string) (*Signer, error) {
+	data, err := statestore.New(resolveHomePath(path)).Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading signing key")
+	}
+
+	if data == nil {
+		return nil, errors.Wrapf(ErrSigningKeyNotFound, "no key at %s", path)
+	}
+
+	return parseSigningKey(data)
+}
+
+// LoadOrCreateSigner loads the signing key from path, creating a random key
+// readable only by the current user if it does not exist.
+func LoadOrCreateSigner(path string) (*Signer, error) {
+	var key []byte
+
+	err := statestore.New(resolveHomePath(path)).Update(func(current []byte) ([]byte, error) {
+		if current != nil {
+			key = current
+
+			return nil, nil
+		}
+
+		random := make([]byte, signingKeyBytes)
+		if _, err := rand.Read(random); err != nil {
+			return nil, errors.Wrap(err, "generating signing key")
+		}
+
+		key = []byte(hex.EncodeToString(random) + "\n")
+
+		return key, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "creating signing key")
+	}
+
+	return parseSigningKey(key)
+}
+
+// parseSigningKey decodes a hex-encoded signing key.
+func parseSigningKey(data []byte) (*Signer, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSigningKey
+	}
+
+	return NewSigner(key), nil
+}
+
+// Sign returns the signature of the token. It covers the prefix, error code,
+// reason and all scopes except the signature itself.
+func (s *Signer) Sign(token *Token) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(signingPayload(token))
+
+	return hex.EncodeToString(mac.Sum(nil))[:signatureLength]
+}
+
+// Verify returns true if the token carries a valid signature.
+func (s *Signer) Verify(token *Token) bool {
+	if token.Signature == "" {
+		return false
+	}
+
+	return hmac.Equal([]byte(s.Sign(token)), []byte(token.Signature))
+}
+
+// signApproval returns the signature of the resolution of the approval
+// request. It covers the ID, status, command hash and session.
+func (s *Signer) signApproval(request *ApprovalRequest) string {
+	fields := []string{
+		"approval",
+		request.ID,
+		string(request.Status),
+		request.CommandHash,
+		request.SessionID,
+	}
+
+	for i, field := range fields {
+		fields[i] = url.QueryEscape(field)
+	}
+
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(strings.Join(fields, "\n")))
+
+	return hex.EncodeToString(mac.Sum(nil))[:signatureLength]
+}
+
+// verifyApproval returns true if the approval request carries a valid
+// signature of its resolution.
+func (s *Signer) verifyApproval(request *ApprovalRequest) bool {
+	if request.Signature == "" {
+		return false
+	}
+
+	return hmac.Equal([]byte(s.signApproval(request)), []byte(request.Signature))
+}
+
+// signingPayload returns the signed representation of the token. Fields are
+// escaped so their separators cannot be forged by a crafted reason.
+func signingPayload(token *Token) []byte {
+	fields := []string{
+		token.Prefix,
+		token.ErrorCode,
+		token.Reason,
+		token.SessionID,
+		token.CommandHash,
+		token.scopeValue(ScopeExpiry),
+	}
+
+	for i, field := range fields {
+		fields[i] = url.QueryEscape(field)
+	}
+
+	return []byte(strings.Join(fields, "\n"))
+}
+
+// resolveHomePath expands ~ in a path.
+func resolveHomePath(path string) string {
+	if len(path) > 1 && path[0] == '~' && path[1] == '/' {
+		home, err := os.UserHomeDir()
+		if err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+
+	return path
+}
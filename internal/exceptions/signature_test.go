@@ -0,0 +1,97 @@
+package exceptions_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/exceptions"
+)
+
+var _ = Describe("Signer", func() {
+	var (
+		signer *exceptions.Signer
+		token  *exceptions.Token
+	)
+
+	BeforeEach(func() {
+		expiresAt := time.Unix(1765000000, 0).UTC()
+		signer = exceptions.NewSigner([]byte("secret"))
+		token = &exceptions.Token{
+			Prefix:    "EXC",
+			ErrorCode: "SEC001",
+			Reason:    "Test fixture",
+			SessionID: "session-1",
+			ExpiresAt: &expiresAt,
+		}
+	})
+
+	It("verifies tokens it signed", func() {
+		token.Signature = signer.Sign(token)
+		Expect(token.Signature).To(HaveLen(16))
+		Expect(signer.Verify(token)).To(BeTrue())
+	})
+
+	It("rejects unsigned tokens", func() {
+		Expect(signer.Verify(token)).To(BeFalse())
+	})
+
+	It("rejects tokens signed with another key", func() {
+		token.Signature = exceptions.NewSigner([]byte("other")).Sign(token)
+		Expect(signer.Verify(token)).To(BeFalse())
+	})
+
+	It("rejects tokens with changed scopes", func() {
+		token.Signature = signer.Sign(token)
+
+		expiresAt := token.ExpiresAt.Add(time.Hour)
+		token.ExpiresAt = &expiresAt
+		Expect(signer.Verify(token)).To(BeFalse())
+	})
+
+	It("does not let the reason forge other scopes", func() {
+		token.Signature = signer.Sign(token)
+
+		// Moving the session into the reason must not keep the signature valid
+		token.Reason += "\nsession-1"
+		token.SessionID = ""
+		Expect(signer.Verify(token)).To(BeFalse())
+	})
+
+	Describe("LoadOrCreateSigner", func() {
+		var path string
+
+		BeforeEach(func() {
+			path = filepath.Join(GinkgoT().TempDir(), "exception_signing.key")
+		})
+
+		It("creates a key readable only by the user and reuses it", func() {
+			created, err := exceptions.LoadOrCreateSigner(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			info, err := os.Stat(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+
+			loaded, err := exceptions.LoadSigner(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded.Sign(token)).To(Equal(created.Sign(token)))
+		})
+
+		It("fails to load a missing key", func() {
+			_, err := exceptions.LoadSigner(path)
+			Expect(errors.Is(err, exceptions.ErrSigningKeyNotFound)).To(BeTrue())
+		})
+
+		It("fails to load a malformed key", func() {
+			Expect(os.WriteFile(path, []byte("not hex"), 0o600)).To(Succeed())
+
+			_, err := exceptions.LoadSigner(path)
+			Expect(errors.Is(err, exceptions.ErrInvalidSigningKey)).To(BeTrue())
+		})
+	})
+})
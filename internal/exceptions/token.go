@@ -18,9 +18,6 @@ const (
 	// DefaultEnvVarName is the default environment variable name for tokens.
 	DefaultEnvVarName = "KLACK"
 
-	// tokenParts is the expected number of parts in a token (prefix:code:reason).
-	tokenParts = 3
-
 	// minTokenParts is the minimum parts (prefix:code) without reason.
 	minTokenParts = 2
 )
@@ -232,6 +229,11 @@ func (p *Parser) findTokenInComments(file *syntax.File) *ParseResult {
 	return result
 }
 
+// ParseToken parses a raw token string, e.g. "EXC:GIT022:reason".
+func (p *Parser) ParseToken(raw string) (*Token, error) {
+	return p.parseToken(raw)
+}
+
 // parseTokenFromComment extracts a token from a comment string.
 func (p *Parser) parseTokenFromComment(text string) (*Token, error) {
 	// Find the token pattern in the comment
@@ -253,14 +255,14 @@ func (p *Parser) parseTokenFromComment(text string) (*Token, error) {
 }
 
 // parseToken parses a raw token string into a Token struct.
-// Expected format: PREFIX:ERROR_CODE[:URL_ENCODED_REASON]
+// Expected format: PREFIX:ERROR_CODE[:URL_ENCODED_REASON][:SCOPE=VALUE...]
 func (p *Parser) parseToken(raw string) (*Token, error) {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
 		return nil, ErrInvalidToken
 	}
 
-	parts := strings.SplitN(raw, ":", tokenParts)
+	parts := strings.Split(raw, ":")
 	if len(parts) < minTokenParts {
 		return nil, errors.Wrapf(
 			ErrInvalidToken,
@@ -285,25 +287,32 @@ func (p *Parser) parseToken(raw string) (*Token, error) {
 		return nil, errors.Wrapf(ErrInvalidErrorCode, "invalid error code format: %q", errorCode)
 	}
 
-	var reason string
+	token := &Token{
+		Prefix:    prefix,
+		ErrorCode: errorCode,
+		Raw:       raw,
+	}
+
+	parts, err := splitScopes(token, parts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(parts) > minTokenParts {
+		// The reason keeps unencoded colons
+		encoded := strings.Join(parts[minTokenParts:], ":")
 
-	if len(parts) == tokenParts {
 		// URL-decode the reason
-		decoded, err := url.QueryUnescape(parts[2])
+		decoded, err := url.QueryUnescape(encoded)
 		if err != nil {
 			// If decoding fails, use the raw value
-			reason = parts[2]
+			token.Reason = encoded
 		} else {
-			reason = decoded
+			token.Reason = decoded
 		}
 	}
 
-	return &Token{
-		Prefix:    prefix,
-		ErrorCode: errorCode,
-		Reason:    reason,
-		Raw:       raw,
-	}, nil
+	return token, nil
 }
 
 // isValidErrorCode checks if an error code matches the expected format.
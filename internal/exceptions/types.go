@@ -13,8 +13,9 @@ const (
 )
 
 // Token represents a parsed exception acknowledgment token.
-// Token format: EXC:<ERROR_CODE>:<URL_ENCODED_REASON>
+// Token format: EXC:<ERROR_CODE>:<URL_ENCODED_REASON>[:<SCOPE>=<VALUE>...]
 // Example: EXC:GIT022:Emergency+hotfix
+// Scoped example: EXC:SEC001:Test+fixture:sid=abc-123:exp=1765000000:sig=4f2a9c0d1e8b7a63
 type Token struct {
 	// Prefix is the token prefix (e.g., "EXC").
 	Prefix string
@@ -26,6 +27,22 @@ type Token struct {
 	// May be empty if no reason was provided.
 	Reason string
 
+	// SessionID is the session the token is bound to (scope "sid").
+	// Empty if the token is not bound to a session.
+	SessionID string
+
+	// CommandHash is the hash of the normalized command the token is bound
+	// to (scope "cmd"). Empty if the token is not bound to a command.
+	CommandHash string
+
+	// ExpiresAt is when the token expires (scope "exp").
+	// Nil if the token does not expire.
+	ExpiresAt *time.Time
+
+	// Signature is the HMAC signature of the token (scope "sig").
+	// Empty if the token is not signed.
+	Signature string
+
 	// Raw is the original unparsed token string.
 	Raw string
 }
@@ -77,6 +94,13 @@ type ExceptionRequest struct {
 
 	// RequestTime is when the exception was requested.
 	RequestTime time.Time
+
+	// SessionID is the session the command runs in, if known.
+	SessionID string
+
+	// CommandHash is the hash of the normalized command.
+	// Empty if the command could not be normalized.
+	CommandHash string
 }
 
 // ExceptionResult represents the result of evaluating an exception request.
@@ -124,6 +148,19 @@ type AuditEntry struct {
 
 	// Repository is the git repository path.
 	Repository string `json:"repository,omitempty"`
+
+	// SessionID is the Claude Code session identifier, if known.
+	SessionID string `json:"session_id,omitempty"`
+
+	// Signed indicates whether the token carried a valid signature.
+	Signed bool `json:"signed,omitempty"`
+
+	// ApprovalID is the approval request of the exception, if its policy
+	// requires approval.
+	ApprovalID string `json:"approval_id,omitempty"`
+
+	// ApprovalStatus is the status of the approval request.
+	ApprovalStatus ApprovalStatus `json:"approval_status,omitempty"`
 }
 
 // RateLimitState represents the current rate limit state.
@@ -0,0 +1,447 @@
+// Package explain traces how a hook payload is validated: which validators
+// are selected, which rules are evaluated, whether exceptions or session
+// poisoning change the outcome, and the final decision.
+package explain
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/internal/config/factory"
+	"github.com/smykla-labs/klaudiush/internal/dispatcher"
+	"github.com/smykla-labs/klaudiush/internal/fixtures"
+	"github.com/smykla-labs/klaudiush/internal/parser"
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/internal/session"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// Trace is the full decision trace for a single hook invocation.
+type Trace struct {
+	// Event is the hook event type.
+	Event string `json:"event"`
+
+	// Tool is the tool name, if any.
+	Tool string `json:"tool,omitempty"`
+
+	// Command is the Bash command, if any.
+	Command string `json:"command,omitempty"`
+
+	// FilePath is the target file path, if any.
+	FilePath string `json:"file_path,omitempty"`
+
+	// SessionID is the Claude Code session ID, if any.
+	SessionID string `json:"session_id,omitempty"`
+
+	// Validators lists every registered validator and whether it was selected.
+	Validators []ValidatorTrace `json:"validators"`
+
+	// Rules lists every rule evaluation, in evaluation order.
+	Rules []RuleTrace `json:"rules"`
+
+	// Findings lists the errors, warnings and confirmation requests reported
+	// by validators, after exceptions were applied.
+	Findings []Finding `json:"findings"`
+
+	// Exceptions lists the exception checks made for blocking errors.
+	Exceptions []ExceptionTrace `json:"exceptions,omitempty"`
+
+	// Session describes session poisoning. Nil when session tracking is
+	// disabled or the payload has no session ID.
+	Session *SessionTrace `json:"session,omitempty"`
+
+	// Decision is the final decision.
+	Decision fixtures.Decision `json:"decision"`
+}
+
+// ValidatorTrace describes the selection of a single validator.
+type ValidatorTrace struct {
+	// Name is the validator name.
+	Name string `json:"name"`
+
+	// Category is the validator category (cpu, io, git).
+	Category string `json:"category"`
+
+	// Predicate describes the condition that selects the validator.
+	Predicate string `json:"predicate,omitempty"`
+
+	// Selected is true if the predicate matched.
+	Selected bool `json:"selected"`
+}
+
+// RuleTrace describes the evaluation of a single rule for a validator.
+type RuleTrace struct {
+	// Rule is the rule name.
+	Rule string `json:"rule"`
+
+	// Validator is the validator type the rule was evaluated for.
+	Validator string `json:"validator"`
+
+	// Matched indicates whether the rule matched.
+	Matched bool `json:"matched"`
+
+	// FailedMatcher names the condition that did not match.
+	FailedMatcher string `json:"failed_matcher,omitempty"`
+
+	// Action is the rule action, set when the rule matched.
+	Action string `json:"action,omitempty"`
+}
+
+// Finding is a single error, warning or confirmation request.
+type Finding struct {
+	// Validator is the validator that reported the finding.
+	Validator string `json:"validator"`
+
+	// Message is the finding message.
+	Message string `json:"message"`
+
+	// Reference is the reference code (e.g., "GIT026"), if any.
+	Reference string `json:"reference,omitempty"`
+
+	// Block indicates whether the finding blocks the operation.
+	Block bool `json:"block"`
+
+	// Ask indicates whether the finding asks the user for confirmation.
+	Ask bool `json:"ask"`
+}
+
+// ExceptionTrace describes an exception token check for a blocking error.
+type ExceptionTrace struct {
+	// Validator is the validator that reported the blocking error.
+	Validator string `json:"validator"`
+
+	// Reference is the reference code of the blocking error.
+	Reference string `json:"reference,omitempty"`
+
+	// Bypassed is true if an exception token turned the error into a warning.
+	Bypassed bool `json:"bypassed"`
+}
+
+// SessionTrace describes how session poisoning affected the decision.
+type SessionTrace struct {
+	// Poisoned is true if the session was poisoned before this invocation.
+	Poisoned bool `json:"poisoned"`
+
+	// PoisonCodes are the codes that poisoned the session.
+	PoisonCodes []string `json:"poison_codes,omitempty"`
+
+	// Unpoisoned is true if the payload acknowledged the poison codes.
+	Unpoisoned bool `json:"unpoisoned"`
+
+	// WouldPoison lists the codes that would poison the session after this
+	// invocation.
+	WouldPoison []string `json:"would_poison,omitempty"`
+
+	// WouldStatus is the status the session would escalate to after this
+	// invocation, if it would change.
+	WouldStatus string `json:"would_status,omitempty"`
+}
+
+// Explainer runs hook payloads through the validator pipeline and records a
+// trace. State is never persisted: session changes are recorded, not applied.
+type Explainer struct {
+	registry         *validator.Registry
+	log              logger.Logger
+	exceptionChecker dispatcher.ExceptionChecker
+	exceptionGuard   *dispatcher.ExceptionGuard
+	sessionTracker   dispatcher.SessionTracker
+}
+
+// Option configures an Explainer.
+type Option func(*Explainer)
+
+// WithExceptionChecker enables exception token checking.
+func WithExceptionChecker(checker dispatcher.ExceptionChecker) Option {
+	return func(e *Explainer) {
+		e.exceptionChecker = checker
+	}
+}
+
+// WithSessionTracker enables session poisoning checks. The tracker is only
+// read; poison and unpoison calls are recorded in the trace instead.
+func WithSessionTracker(tracker dispatcher.SessionTracker) Option {
+	return func(e *Explainer) {
+		e.sessionTracker = tracker
+	}
+}
+
+// New builds the validator registry and rule engine from cfg and creates an
+// Explainer.
+func New(cfg *config.Config, log logger.Logger, opts ...Option) (*Explainer, error) {
+	registry, _, err := factory.NewRegistryBuilder(log).BuildWithRuleEngine(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build validator registry")
+	}
+
+	e := &Explainer{
+		registry:       registry,
+		log:            log,
+		exceptionGuard: dispatcher.NewExceptionGuard(cfg.GetExceptions(), log),
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e, nil
+}
+
+// Explain dispatches hookCtx and returns the decision trace. Validators run
+// sequentially so that rule evaluations are reported in a stable order.
+func (e *Explainer) Explain(ctx context.Context, hookCtx *hook.Context) *Trace {
+	trace := &Trace{
+		Event:     hookCtx.EventType.String(),
+		Command:   hookCtx.GetCommand(),
+		FilePath:  hookCtx.GetFilePath(),
+		SessionID: hookCtx.SessionID,
+		Rules:     []RuleTrace{},
+		Findings:  []Finding{},
+	}
+
+	if hookCtx.ToolName != hook.ToolTypeUnknown {
+		trace.Tool = hookCtx.ToolName.String()
+	}
+
+	for _, selection := range e.registry.Explain(hookCtx) {
+		trace.Validators = append(trace.Validators, ValidatorTrace{
+			Name:      selection.Validator.Name(),
+			Category:  selection.Validator.Category().String(),
+			Predicate: selection.Predicate.String(),
+			Selected:  selection.Selected,
+		})
+	}
+
+	rec := &recorder{trace: trace}
+
+	opts := []dispatcher.DispatcherOption{dispatcher.WithExceptionGuard(e.exceptionGuard)}
+
+	if e.exceptionChecker != nil {
+		opts = append(opts, dispatcher.WithExceptionChecker(
+			&exceptionRecorder{checker: e.exceptionChecker, recorder: rec},
+		))
+	}
+
+	if e.sessionTracker != nil && e.sessionTracker.IsEnabled() && hookCtx.HasSessionID() {
+		trace.Session = &SessionTrace{}
+		opts = append(opts, dispatcher.WithSessionTracker(
+			&sessionRecorder{tracker: e.sessionTracker, session: trace.Session},
+		))
+	}
+
+	d := dispatcher.NewDispatcherWithOptions(
+		e.registry,
+		e.log,
+		dispatcher.NewSequentialExecutor(e.log),
+		opts...,
+	)
+
+	errs := d.Dispatch(rules.WithTracer(ctx, rec), hookCtx)
+
+	for _, verr := range errs {
+		finding := Finding{
+			Validator: verr.Validator,
+			Message:   verr.Message,
+			Block:     verr.ShouldBlock,
+			Ask:       verr.ShouldAsk,
+		}
+
+		if verr.Reference != "" {
+			finding.Reference = verr.Reference.Code()
+		}
+
+		trace.Findings = append(trace.Findings, finding)
+	}
+
+	trace.Decision = fixtures.DecisionFor(errs)
+
+	return trace
+}
+
+// ParsePayload parses a hook payload the same way the hook entry point does.
+// Payloads without hook_event_name are treated as PreToolUse.
+func ParsePayload(r io.Reader) (*hook.Context, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read payload")
+	}
+
+	hookCtx, err := parser.NewJSONParser(bytes.NewReader(data)).Parse(hook.EventTypeUnknown)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse payload")
+	}
+
+	if hookCtx.EventType == hook.EventTypeUnknown {
+		hookCtx.EventType = hook.EventTypePreToolUse
+	}
+
+	return hookCtx, nil
+}
+
+// CommandContext returns a PreToolUse Bash context for command.
+func CommandContext(command, cwd string) *hook.Context {
+	return &hook.Context{
+		EventType: hook.EventTypePreToolUse,
+		ToolName:  hook.ToolTypeBash,
+		CWD:       cwd,
+		ToolInput: hook.ToolInput{Command: command},
+	}
+}
+
+// FileContext returns a PreToolUse Write context for path. If the file
+// exists, its current content is used as the written content.
+func FileContext(path, cwd string) (*hook.Context, error) {
+	content, err := os.ReadFile(path) //nolint:gosec // path comes from the user
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, errors.Wrapf(err, "reading %s", path)
+	}
+
+	return &hook.Context{
+		EventType: hook.EventTypePreToolUse,
+		ToolName:  hook.ToolTypeWrite,
+		CWD:       cwd,
+		ToolInput: hook.ToolInput{
+			FilePath: path,
+			Content:  string(content),
+		},
+	}, nil
+}
+
+// recorder collects rule evaluations and exception checks into a trace.
+type recorder struct {
+	mu    sync.Mutex
+	trace *Trace
+}
+
+// TraceRule implements rules.Tracer.
+func (r *recorder) TraceRule(evaluation *rules.Evaluation) {
+	ruleTrace := RuleTrace{
+		Rule:          evaluation.Rule.Name,
+		Validator:     string(evaluation.ValidatorType),
+		Matched:       evaluation.Matched,
+		FailedMatcher: evaluation.FailedMatcher,
+	}
+
+	if evaluation.Matched && evaluation.Rule.Action != nil {
+		ruleTrace.Action = string(evaluation.Rule.Action.Type)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.trace.Rules = append(r.trace.Rules, ruleTrace)
+}
+
+// addException records an exception check.
+func (r *recorder) addException(exception ExceptionTrace) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.trace.Exceptions = append(r.trace.Exceptions, exception)
+}
+
+// exceptionRecorder records exception checks for blocking errors.
+type exceptionRecorder struct {
+	checker  dispatcher.ExceptionChecker
+	recorder *recorder
+}
+
+// CheckException implements dispatcher.ExceptionChecker.
+func (c *exceptionRecorder) CheckException(
+	hookCtx *hook.Context,
+	verr *dispatcher.ValidationError,
+) (*dispatcher.ValidationError, bool) {
+	modified, bypassed := c.checker.CheckException(hookCtx, verr)
+
+	if verr.ShouldBlock {
+		c.recorder.addException(ExceptionTrace{
+			Validator: verr.Validator,
+			Reference: verr.Reference.Code(),
+			Bypassed:  bypassed,
+		})
+	}
+
+	return modified, bypassed
+}
+
+// IsEnabled implements dispatcher.ExceptionChecker.
+func (c *exceptionRecorder) IsEnabled() bool {
+	return c.checker.IsEnabled()
+}
+
+// sessionRecorder reads session state and records changes without applying them.
+type sessionRecorder struct {
+	tracker dispatcher.SessionTracker
+	session *SessionTrace
+}
+
+// IsPoisoned implements dispatcher.SessionTracker.
+func (s *sessionRecorder) IsPoisoned(sessionID string) (bool, *session.SessionInfo) {
+	poisoned, info := s.tracker.IsPoisoned(sessionID)
+	if poisoned {
+		s.session.Poisoned = true
+		s.session.PoisonCodes = info.PoisonCodes
+	}
+
+	return poisoned, info
+}
+
+// Poison implements dispatcher.SessionTracker.
+func (s *sessionRecorder) Poison(_ string, codes []string, _ string) {
+	s.session.WouldPoison = codes
+	s.session.WouldStatus = session.StatusPoisoned.String()
+}
+
+// Unpoison implements dispatcher.SessionTracker.
+func (s *sessionRecorder) Unpoison(string) {
+	s.session.Unpoisoned = true
+}
+
+// RecordCommand implements dispatcher.SessionTracker.
+func (*sessionRecorder) RecordCommand(string) {}
+
+// RecordBlocked implements dispatcher.SessionTracker.
+func (s *sessionRecorder) RecordBlocked(sessionID string, codes []string, _ string) session.Transition {
+	transition := s.tracker.PreviewBlocked(sessionID, codes)
+	if !transition.Changed() {
+		return transition
+	}
+
+	s.session.WouldStatus = transition.To.String()
+
+	if transition.To == session.StatusPoisoned || transition.To == session.StatusHardPoisoned {
+		s.session.WouldPoison = codes
+	}
+
+	return transition
+}
+
+// PreviewBlocked implements dispatcher.SessionTracker.
+func (s *sessionRecorder) PreviewBlocked(sessionID string, codes []string) session.Transition {
+	return s.tracker.PreviewBlocked(sessionID, codes)
+}
+
+// ExpireCooldown implements dispatcher.SessionTracker. The tracker does not
+// report expired poisoning as poisoned, so there is nothing to record.
+func (*sessionRecorder) ExpireCooldown(string) session.Transition {
+	return session.Transition{}
+}
+
+// IsEnabled implements dispatcher.SessionTracker.
+func (s *sessionRecorder) IsEnabled() bool {
+	return s.tracker.IsEnabled()
+}
+
+// Verify interface compliance.
+var (
+	_ rules.Tracer                = (*recorder)(nil)
+	_ dispatcher.ExceptionChecker = (*exceptionRecorder)(nil)
+	_ dispatcher.SessionTracker   = (*sessionRecorder)(nil)
+)
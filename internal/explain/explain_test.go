@@ -0,0 +1,262 @@
+package explain_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	internalconfig "github.com/smykla-labs/klaudiush/internal/config"
+	"github.com/smykla-labs/klaudiush/internal/dispatcher"
+	"github.com/smykla-labs/klaudiush/internal/explain"
+	"github.com/smykla-labs/klaudiush/internal/fixtures"
+	"github.com/smykla-labs/klaudiush/internal/session"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// bypassingChecker bypasses every blocking error.
+type bypassingChecker struct{}
+
+func (*bypassingChecker) CheckException(
+	_ *hook.Context,
+	verr *dispatcher.ValidationError,
+) (*dispatcher.ValidationError, bool) {
+	bypassed := *verr
+	bypassed.ShouldBlock = false
+
+	return &bypassed, true
+}
+
+func (*bypassingChecker) IsEnabled() bool { return true }
+
+// fakeTracker is a session tracker with a fixed poisoned state.
+type fakeTracker struct {
+	poisonCodes []string
+	poisonCalls int
+}
+
+func (t *fakeTracker) IsPoisoned(sessionID string) (bool, *session.SessionInfo) {
+	if len(t.poisonCodes) == 0 {
+		return false, nil
+	}
+
+	return true, &session.SessionInfo{
+		SessionID:   sessionID,
+		Status:      session.StatusPoisoned,
+		PoisonCodes: t.poisonCodes,
+	}
+}
+
+func (t *fakeTracker) Poison(string, []string, string) { t.poisonCalls++ }
+
+func (*fakeTracker) Unpoison(string) {}
+
+func (*fakeTracker) RecordCommand(string) {}
+
+func (t *fakeTracker) RecordBlocked(sessionID string, codes []string, _ string) session.Transition {
+	t.poisonCalls++
+
+	return t.PreviewBlocked(sessionID, codes)
+}
+
+// PreviewBlocked escalates like the default policy: the first blocked
+// attempt poisons the session.
+func (*fakeTracker) PreviewBlocked(_ string, codes []string) session.Transition {
+	return session.Transition{From: session.StatusClean, To: session.StatusPoisoned, Codes: codes}
+}
+
+func (*fakeTracker) ExpireCooldown(string) session.Transition { return session.Transition{} }
+
+func (*fakeTracker) IsEnabled() bool { return true }
+
+var _ = Describe("Explainer", func() {
+	var (
+		cfg *config.Config
+		ctx context.Context
+	)
+
+	newExplainer := func(opts ...explain.Option) *explain.Explainer {
+		explainer, err := explain.New(cfg, logger.NewNoOpLogger(), opts...)
+		Expect(err).NotTo(HaveOccurred())
+
+		return explainer
+	}
+
+	findValidator := func(trace *explain.Trace, name string) explain.ValidatorTrace {
+		for _, v := range trace.Validators {
+			if v.Name == name {
+				return v
+			}
+		}
+
+		Fail("validator " + name + " not in trace")
+
+		return explain.ValidatorTrace{}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		cfg = internalconfig.DefaultConfig()
+		enabled := true
+		cfg.Validators.Shell = &config.ShellConfig{
+			Destructive: &config.DestructiveValidatorConfig{
+				ValidatorConfig: config.ValidatorConfig{Enabled: &enabled},
+			},
+		}
+	})
+
+	It("reports selected validators with their predicates", func() {
+		trace := newExplainer().Explain(ctx, explain.CommandContext("rm -rf /", "/tmp"))
+
+		destructive := findValidator(trace, "validate-destructive")
+		Expect(destructive.Selected).To(BeTrue())
+		Expect(destructive.Predicate).To(Equal("PreToolUse and Bash"))
+		Expect(destructive.Category).To(Equal("Git"))
+
+		Expect(findValidator(trace, "validate-git-push").Selected).To(BeFalse())
+
+		Expect(trace.Event).To(Equal("PreToolUse"))
+		Expect(trace.Tool).To(Equal("Bash"))
+		Expect(trace.Decision).To(Equal(fixtures.DecisionDeny))
+		Expect(trace.Findings).To(HaveLen(1))
+		Expect(trace.Findings[0].Validator).To(Equal("validate-destructive"))
+		Expect(trace.Findings[0].Reference).To(Equal("SHELL003"))
+		Expect(trace.Findings[0].Block).To(BeTrue())
+	})
+
+	It("reports rule evaluations and the failing matcher", func() {
+		cfg.Rules = &config.RulesConfig{
+			Rules: []config.RuleConfig{
+				{
+					Name:     "push-only",
+					Priority: 20,
+					Match:    &config.RuleMatchConfig{ValidatorType: "git.push"},
+					Action:   &config.RuleActionConfig{Type: "block"},
+				},
+				{
+					Name:     "warn-ls",
+					Priority: 10,
+					Match: &config.RuleMatchConfig{
+						ValidatorType:  "shell.destructive",
+						CommandPattern: "ls*",
+					},
+					Action: &config.RuleActionConfig{Type: "warn", Message: "ls again"},
+				},
+			},
+		}
+
+		trace := newExplainer().Explain(ctx, explain.CommandContext("ls -la", "/tmp"))
+
+		Expect(trace.Rules).To(ConsistOf(
+			explain.RuleTrace{
+				Rule:          "push-only",
+				Validator:     "shell.destructive",
+				FailedMatcher: "validator_type:git.push",
+			},
+			explain.RuleTrace{
+				Rule:      "warn-ls",
+				Validator: "shell.destructive",
+				Matched:   true,
+				Action:    "warn",
+			},
+			explain.RuleTrace{
+				Rule:          "push-only",
+				Validator:     "rules",
+				FailedMatcher: "validator_type:git.push",
+			},
+			explain.RuleTrace{
+				Rule:          "warn-ls",
+				Validator:     "rules",
+				FailedMatcher: "validator_type:shell.destructive",
+			},
+		))
+		Expect(trace.Decision).To(Equal(fixtures.DecisionWarn))
+	})
+
+	It("reports bypassed blocking errors", func() {
+		trace := newExplainer(explain.WithExceptionChecker(&bypassingChecker{})).
+			Explain(ctx, explain.CommandContext("rm -rf /", "/tmp"))
+
+		Expect(trace.Exceptions).To(Equal([]explain.ExceptionTrace{
+			{Validator: "validate-destructive", Reference: "SHELL003", Bypassed: true},
+		}))
+		Expect(trace.Decision).To(Equal(fixtures.DecisionWarn))
+	})
+
+	Describe("session poisoning", func() {
+		It("reports a poisoned session without running validators", func() {
+			tracker := &fakeTracker{poisonCodes: []string{"GIT001"}}
+			hookCtx := explain.CommandContext("ls", "/tmp")
+			hookCtx.SessionID = "session-1"
+
+			trace := newExplainer(explain.WithSessionTracker(tracker)).Explain(ctx, hookCtx)
+
+			Expect(trace.Session).NotTo(BeNil())
+			Expect(trace.Session.Poisoned).To(BeTrue())
+			Expect(trace.Session.PoisonCodes).To(Equal([]string{"GIT001"}))
+			Expect(trace.Decision).To(Equal(fixtures.DecisionDeny))
+		})
+
+		It("records the codes that would poison the session without applying them", func() {
+			tracker := &fakeTracker{}
+			hookCtx := explain.CommandContext("rm -rf /", "/tmp")
+			hookCtx.SessionID = "session-1"
+
+			trace := newExplainer(explain.WithSessionTracker(tracker)).Explain(ctx, hookCtx)
+
+			Expect(trace.Session.Poisoned).To(BeFalse())
+			Expect(trace.Session.WouldPoison).To(Equal([]string{"SHELL003"}))
+			Expect(trace.Session.WouldStatus).To(Equal(session.StatusPoisoned.String()))
+			Expect(tracker.poisonCalls).To(BeZero())
+		})
+
+		It("omits the session without a session ID", func() {
+			trace := newExplainer(explain.WithSessionTracker(&fakeTracker{})).
+				Explain(ctx, explain.CommandContext("ls", "/tmp"))
+
+			Expect(trace.Session).To(BeNil())
+		})
+	})
+
+	Describe("inputs", func() {
+		It("parses payloads and defaults to PreToolUse", func() {
+			hookCtx, err := explain.ParsePayload(strings.NewReader(
+				`{"tool_name": "Bash", "tool_input": {"command": "git status"}}`,
+			))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hookCtx.EventType).To(Equal(hook.EventTypePreToolUse))
+			Expect(hookCtx.GetCommand()).To(Equal("git status"))
+		})
+
+		It("rejects malformed payloads", func() {
+			_, err := explain.ParsePayload(strings.NewReader("{"))
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("uses the current file content for file contexts", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "README.md")
+			Expect(os.WriteFile(path, []byte("# Title\n"), 0o600)).To(Succeed())
+
+			hookCtx, err := explain.FileContext(path, "/tmp")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hookCtx.ToolName).To(Equal(hook.ToolTypeWrite))
+			Expect(hookCtx.ToolInput.Content).To(Equal("# Title\n"))
+		})
+
+		It("allows file contexts for files that do not exist yet", func() {
+			hookCtx, err := explain.FileContext("/nonexistent/new.md", "/tmp")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hookCtx.GetFilePath()).To(Equal("/nonexistent/new.md"))
+			Expect(hookCtx.ToolInput.Content).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,207 @@
+// Package fixtures replays recorded hook payloads through the validator
+// pipeline and checks the resulting decisions against expectations.
+package fixtures
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// DefaultDir is the default fixture directory, relative to the project root.
+const DefaultDir = ".klaudiush/tests"
+
+// fixtureExt is the file extension of fixture files.
+const fixtureExt = ".json"
+
+var (
+	// ErrNoFixtures is returned when a directory contains no fixture files.
+	ErrNoFixtures = errors.New("no fixtures found")
+
+	// ErrInvalidFixture is returned when a fixture file is malformed.
+	ErrInvalidFixture = errors.New("invalid fixture")
+)
+
+// Decision is the outcome of a hook invocation.
+type Decision string
+
+const (
+	// DecisionAllow means no validator reported an error.
+	DecisionAllow Decision = "allow"
+
+	// DecisionWarn means validators reported only non-blocking warnings.
+	DecisionWarn Decision = "warn"
+
+	// DecisionAsk means the user is asked to confirm the operation.
+	DecisionAsk Decision = "ask"
+
+	// DecisionDeny means the operation is blocked.
+	DecisionDeny Decision = "deny"
+)
+
+// ValidDecisions lists all valid decisions.
+var ValidDecisions = []Decision{DecisionAllow, DecisionWarn, DecisionAsk, DecisionDeny}
+
+// Fixture is a recorded hook payload with the expected outcome.
+//
+// Example fixture file:
+//
+//	{
+//	  "name": "blocks force push to main",
+//	  "hook_type": "PreToolUse",
+//	  "input": {
+//	    "tool_name": "Bash",
+//	    "tool_input": {"command": "git push --force origin main"}
+//	  },
+//	  "expect": {
+//	    "decision": "deny",
+//	    "validators": ["validate-git-push"],
+//	    "references": ["GIT026"]
+//	  }
+//	}
+type Fixture struct {
+	// Name identifies the fixture in reports. Defaults to the file path
+	// relative to the fixture directory, without extension.
+	Name string `json:"name,omitempty"`
+
+	// Description is an optional human-readable description.
+	Description string `json:"description,omitempty"`
+
+	// HookType is the hook event type (e.g., "PreToolUse"). When empty, the
+	// hook_event_name field of the input is used, then PreToolUse.
+	HookType string `json:"hook_type,omitempty"`
+
+	// Input is the hook payload as sent by Claude Code.
+	Input json.RawMessage `json:"input"`
+
+	// Expect is the expected outcome.
+	Expect Expectation `json:"expect"`
+
+	// Path is the file the fixture was loaded from.
+	Path string `json:"-"`
+}
+
+// Expectation describes the expected outcome of a fixture.
+type Expectation struct {
+	// Decision is the expected decision: allow, warn, ask or deny ("block" is
+	// accepted as an alias for deny).
+	Decision Decision `json:"decision"`
+
+	// Validators lists validator names that must report an error.
+	// Other validators may report errors too.
+	Validators []string `json:"validators,omitempty"`
+
+	// References lists reference codes (e.g., "GIT026") that must be reported.
+	// Other references may be reported too.
+	References []string `json:"references,omitempty"`
+}
+
+// Load reads all fixture files (*.json) under dir, recursively, sorted by path.
+// dir may also point to a single fixture file.
+func Load(dir string) ([]*Fixture, error) {
+	var paths []string
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		if !entry.IsDir() && filepath.Ext(path) == fixtureExt {
+			paths = append(paths, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading fixture directory %s", dir)
+	}
+
+	if len(paths) == 0 {
+		return nil, errors.Wrapf(ErrNoFixtures, "in %s", dir)
+	}
+
+	slices.Sort(paths)
+
+	fixtures := make([]*Fixture, 0, len(paths))
+
+	for _, path := range paths {
+		fixture, err := LoadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if fixture.Name == "" {
+			fixture.Name = fixtureName(dir, path)
+		}
+
+		fixtures = append(fixtures, fixture)
+	}
+
+	return fixtures, nil
+}
+
+// LoadFile reads and validates a single fixture file.
+func LoadFile(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // fixture paths come from the user
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading fixture %s", path)
+	}
+
+	var fixture Fixture
+
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, errors.Wrapf(
+			errors.CombineErrors(ErrInvalidFixture, err),
+			"parsing fixture %s",
+			path,
+		)
+	}
+
+	fixture.Path = path
+
+	if err := fixture.validate(); err != nil {
+		return nil, errors.Wrapf(err, "fixture %s", path)
+	}
+
+	return &fixture, nil
+}
+
+// validate checks required fields and normalizes the expected decision.
+func (f *Fixture) validate() error {
+	if len(f.Input) == 0 {
+		return errors.Wrap(ErrInvalidFixture, "input is required")
+	}
+
+	decision := Decision(strings.ToLower(string(f.Expect.Decision)))
+	if decision == "block" {
+		decision = DecisionDeny
+	}
+
+	if !slices.Contains(ValidDecisions, decision) {
+		return errors.Wrapf(
+			ErrInvalidFixture,
+			"expect.decision %q is invalid (valid: %v)",
+			f.Expect.Decision,
+			ValidDecisions,
+		)
+	}
+
+	f.Expect.Decision = decision
+
+	return nil
+}
+
+// fixtureName derives a fixture name from its path relative to dir.
+func fixtureName(dir, path string) string {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == "." {
+		rel = filepath.Base(path)
+	}
+
+	return strings.TrimSuffix(filepath.ToSlash(rel), fixtureExt)
+}
@@ -0,0 +1,88 @@
+package fixtures_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/fixtures"
+)
+
+var _ = Describe("Load", func() {
+	var dir string
+
+	writeFixture := func(name, content string) {
+		path := filepath.Join(dir, name)
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("loads fixtures recursively sorted by path", func() {
+		writeFixture("b.json", `{"input": {"tool_name": "Bash"}, "expect": {"decision": "allow"}}`)
+		writeFixture("a/nested.json", `{"input": {"tool_name": "Bash"}, "expect": {"decision": "deny"}}`)
+		writeFixture("notes.txt", "ignored")
+
+		loaded, err := fixtures.Load(dir)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(HaveLen(2))
+		Expect(loaded[0].Name).To(Equal("a/nested"))
+		Expect(loaded[0].Expect.Decision).To(Equal(fixtures.DecisionDeny))
+		Expect(loaded[1].Name).To(Equal("b"))
+		Expect(loaded[1].Path).To(Equal(filepath.Join(dir, "b.json")))
+	})
+
+	It("keeps explicit fixture names", func() {
+		writeFixture("x.json", `{"name": "custom", "input": {}, "expect": {"decision": "allow"}}`)
+
+		loaded, err := fixtures.Load(dir)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded[0].Name).To(Equal("custom"))
+	})
+
+	It("loads a single fixture file", func() {
+		writeFixture("single.json", `{"input": {}, "expect": {"decision": "warn"}}`)
+
+		loaded, err := fixtures.Load(filepath.Join(dir, "single.json"))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(HaveLen(1))
+		Expect(loaded[0].Name).To(Equal("single"))
+	})
+
+	It("normalizes block to deny", func() {
+		writeFixture("block.json", `{"input": {}, "expect": {"decision": "Block"}}`)
+
+		loaded, err := fixtures.Load(dir)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded[0].Expect.Decision).To(Equal(fixtures.DecisionDeny))
+	})
+
+	It("returns ErrNoFixtures for an empty directory", func() {
+		_, err := fixtures.Load(dir)
+
+		Expect(err).To(MatchError(fixtures.ErrNoFixtures))
+	})
+
+	DescribeTable("rejects invalid fixtures",
+		func(content string) {
+			writeFixture("bad.json", content)
+
+			_, err := fixtures.Load(dir)
+
+			Expect(err).To(MatchError(fixtures.ErrInvalidFixture))
+		},
+		Entry("malformed JSON", `{"input": `),
+		Entry("missing input", `{"expect": {"decision": "allow"}}`),
+		Entry("missing decision", `{"input": {}}`),
+		Entry("unknown decision", `{"input": {}, "expect": {"decision": "maybe"}}`),
+	)
+})
@@ -0,0 +1,197 @@
+package fixtures
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/internal/config/factory"
+	"github.com/smykla-labs/klaudiush/internal/dispatcher"
+	"github.com/smykla-labs/klaudiush/internal/parser"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// Result is the outcome of running a single fixture.
+type Result struct {
+	// Fixture is the fixture that was run.
+	Fixture *Fixture
+
+	// Decision is the actual decision.
+	Decision Decision
+
+	// Validators lists the validators that reported errors, in report order.
+	Validators []string
+
+	// References lists the reported reference codes, in report order.
+	References []string
+
+	// Failures lists the expectations that were not met.
+	Failures []string
+
+	// Err is set when the fixture could not be run.
+	Err error
+}
+
+// Passed returns true if the fixture ran and met all expectations.
+func (r *Result) Passed() bool {
+	return r.Err == nil && len(r.Failures) == 0
+}
+
+// Runner replays fixtures through the validator registry and dispatcher.
+// Session tracking and exceptions are not wired in, so runs have no side
+// effects on persisted state.
+type Runner struct {
+	dispatcher *dispatcher.Dispatcher
+}
+
+// NewRunner builds the validator registry and rule engine from cfg and
+// creates a Runner that dispatches fixtures in-process.
+func NewRunner(cfg *config.Config, log logger.Logger) (*Runner, error) {
+	registry, _, err := factory.NewRegistryBuilder(log).BuildWithRuleEngine(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build validator registry")
+	}
+
+	return &Runner{
+		dispatcher: dispatcher.NewDispatcherWithOptions(
+			registry,
+			log,
+			dispatcher.NewSequentialExecutor(log),
+		),
+	}, nil
+}
+
+// Run runs all fixtures and returns their results in order.
+func (r *Runner) Run(ctx context.Context, fixtures []*Fixture) []*Result {
+	results := make([]*Result, 0, len(fixtures))
+
+	for _, fixture := range fixtures {
+		results = append(results, r.RunFixture(ctx, fixture))
+	}
+
+	return results
+}
+
+// RunFixture runs a single fixture and compares the outcome with its expectations.
+func (r *Runner) RunFixture(ctx context.Context, fixture *Fixture) *Result {
+	result := &Result{Fixture: fixture}
+
+	hookCtx, err := parseInput(fixture)
+	if err != nil {
+		result.Err = err
+
+		return result
+	}
+
+	errs := r.dispatcher.Dispatch(ctx, hookCtx)
+
+	result.Decision = DecisionFor(errs)
+
+	for _, validationErr := range errs {
+		if !slices.Contains(result.Validators, validationErr.Validator) {
+			result.Validators = append(result.Validators, validationErr.Validator)
+		}
+
+		if validationErr.Reference == "" {
+			continue
+		}
+
+		if code := validationErr.Reference.Code(); !slices.Contains(result.References, code) {
+			result.References = append(result.References, code)
+		}
+	}
+
+	result.Failures = compare(fixture.Expect, result)
+
+	return result
+}
+
+// DecisionFor returns the decision the hook makes for the given validation errors.
+func DecisionFor(errs []*dispatcher.ValidationError) Decision {
+	switch {
+	case dispatcher.ShouldBlock(errs):
+		return DecisionDeny
+	case dispatcher.ShouldAsk(errs):
+		return DecisionAsk
+	case len(errs) > 0:
+		return DecisionWarn
+	default:
+		return DecisionAllow
+	}
+}
+
+// parseInput parses the fixture payload into a hook context the same way
+// the hook entry point does.
+func parseInput(fixture *Fixture) (*hook.Context, error) {
+	eventType := hook.EventTypeUnknown
+
+	if fixture.HookType != "" {
+		parsed, err := hook.EventTypeString(fixture.HookType)
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidFixture, "unknown hook_type %q", fixture.HookType)
+		}
+
+		eventType = parsed
+	}
+
+	hookCtx, err := parser.NewJSONParser(bytes.NewReader(fixture.Input)).Parse(eventType)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse input")
+	}
+
+	if hookCtx.EventType == hook.EventTypeUnknown {
+		hookCtx.EventType = hook.EventTypePreToolUse
+	}
+
+	return hookCtx, nil
+}
+
+// compare returns a description of every unmet expectation.
+func compare(expect Expectation, result *Result) []string {
+	var failures []string
+
+	if expect.Decision != result.Decision {
+		failures = append(failures, fmt.Sprintf(
+			"expected decision %s, got %s",
+			expect.Decision,
+			result.Decision,
+		))
+	}
+
+	if missing := missingFrom(expect.Validators, result.Validators); len(missing) > 0 {
+		failures = append(failures, fmt.Sprintf(
+			"expected validators [%s] not reported (got [%s])",
+			strings.Join(missing, ", "),
+			strings.Join(result.Validators, ", "),
+		))
+	}
+
+	if missing := missingFrom(expect.References, result.References); len(missing) > 0 {
+		failures = append(failures, fmt.Sprintf(
+			"expected references [%s] not reported (got [%s])",
+			strings.Join(missing, ", "),
+			strings.Join(result.References, ", "),
+		))
+	}
+
+	return failures
+}
+
+// missingFrom returns the expected values that are not in actual.
+func missingFrom(expected, actual []string) []string {
+	var missing []string
+
+	for _, value := range expected {
+		if !slices.ContainsFunc(actual, func(a string) bool { return strings.EqualFold(a, value) }) {
+			missing = append(missing, value)
+		}
+	}
+
+	return missing
+}
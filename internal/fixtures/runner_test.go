@@ -0,0 +1,123 @@
+package fixtures_test
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	internalconfig "github.com/smykla-labs/klaudiush/internal/config"
+	"github.com/smykla-labs/klaudiush/internal/dispatcher"
+	"github.com/smykla-labs/klaudiush/internal/fixtures"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+var _ = Describe("Runner", func() {
+	var runner *fixtures.Runner
+
+	newFixture := func(input string, expect fixtures.Expectation) *fixtures.Fixture {
+		return &fixtures.Fixture{
+			Name:   "fixture",
+			Input:  json.RawMessage(input),
+			Expect: expect,
+		}
+	}
+
+	BeforeEach(func() {
+		cfg := internalconfig.DefaultConfig()
+		enabled := true
+		cfg.Validators.Shell = &config.ShellConfig{
+			Destructive: &config.DestructiveValidatorConfig{
+				ValidatorConfig: config.ValidatorConfig{Enabled: &enabled},
+			},
+		}
+
+		var err error
+
+		runner, err = fixtures.NewRunner(cfg, logger.NewNoOpLogger())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("passes when the decision, validators and references match", func() {
+		result := runner.RunFixture(context.Background(), newFixture(
+			`{"tool_name": "Bash", "tool_input": {"command": "rm -rf /"}}`,
+			fixtures.Expectation{
+				Decision:   fixtures.DecisionDeny,
+				Validators: []string{"validate-destructive"},
+				References: []string{"SHELL003"},
+			},
+		))
+
+		Expect(result.Err).NotTo(HaveOccurred())
+		Expect(result.Passed()).To(BeTrue(), "failures: %v", result.Failures)
+		Expect(result.Decision).To(Equal(fixtures.DecisionDeny))
+		Expect(result.Validators).To(ContainElement("validate-destructive"))
+		Expect(result.References).To(ContainElement("SHELL003"))
+	})
+
+	It("reports every unmet expectation", func() {
+		result := runner.RunFixture(context.Background(), newFixture(
+			`{"tool_name": "Bash", "tool_input": {"command": "rm -rf /"}}`,
+			fixtures.Expectation{
+				Decision:   fixtures.DecisionAllow,
+				Validators: []string{"validate-git-push"},
+				References: []string{"GIT026"},
+			},
+		))
+
+		Expect(result.Passed()).To(BeFalse())
+		Expect(result.Failures).To(HaveLen(3))
+		Expect(result.Failures[0]).To(Equal("expected decision allow, got deny"))
+		Expect(result.Failures[1]).To(ContainSubstring("validate-git-push"))
+		Expect(result.Failures[2]).To(ContainSubstring("GIT026"))
+	})
+
+	It("allows payloads no validator handles", func() {
+		result := runner.RunFixture(context.Background(), newFixture(
+			`{"tool_name": "Glob", "tool_input": {"pattern": "*.go"}}`,
+			fixtures.Expectation{Decision: fixtures.DecisionAllow},
+		))
+
+		Expect(result.Passed()).To(BeTrue())
+		Expect(result.Validators).To(BeEmpty())
+	})
+
+	It("fails fixtures with an unknown hook type", func() {
+		fixture := newFixture(`{}`, fixtures.Expectation{Decision: fixtures.DecisionAllow})
+		fixture.HookType = "BeforeEverything"
+
+		result := runner.RunFixture(context.Background(), fixture)
+
+		Expect(result.Passed()).To(BeFalse())
+		Expect(result.Err).To(MatchError(fixtures.ErrInvalidFixture))
+	})
+
+	It("runs fixtures in order", func() {
+		results := runner.Run(context.Background(), []*fixtures.Fixture{
+			newFixture(`{"tool_name": "Glob"}`, fixtures.Expectation{Decision: fixtures.DecisionAllow}),
+			newFixture(`{"tool_name": "Glob"}`, fixtures.Expectation{Decision: fixtures.DecisionDeny}),
+		})
+
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].Passed()).To(BeTrue())
+		Expect(results[1].Passed()).To(BeFalse())
+	})
+})
+
+var _ = Describe("DecisionFor", func() {
+	DescribeTable("maps validation errors to decisions",
+		func(errs []*dispatcher.ValidationError, expected fixtures.Decision) {
+			Expect(fixtures.DecisionFor(errs)).To(Equal(expected))
+		},
+		Entry("no errors", nil, fixtures.DecisionAllow),
+		Entry("warning", []*dispatcher.ValidationError{{Validator: "v"}}, fixtures.DecisionWarn),
+		Entry("ask", []*dispatcher.ValidationError{{Validator: "v", ShouldAsk: true}}, fixtures.DecisionAsk),
+		Entry("block wins over ask", []*dispatcher.ValidationError{
+			{Validator: "a", ShouldAsk: true},
+			{Validator: "b", ShouldBlock: true, Reference: validator.RefGitNoSignoff},
+		}, fixtures.DecisionDeny),
+	)
+})
@@ -80,3 +80,9 @@ func (a *RepositoryAdapter) GetBranchRemote(branch string) (string, error) {
 func (a *RepositoryAdapter) GetRemotes() (map[string]string, error) {
 	return a.repo.GetRemotes()
 }
+
+// GetCommitsAhead returns the number of commits on the given branch that are
+// not on its upstream branch
+func (a *RepositoryAdapter) GetCommitsAhead(branch string) (int, error) {
+	return a.repo.GetCommitsAhead(branch)
+}
@@ -123,6 +123,16 @@ var _ = Describe("RepositoryAdapter", func() {
 			Expect(mockRepo.getRemotesCalled).To(BeTrue())
 		})
 	})
+
+	Describe("GetCommitsAhead", func() {
+		It("should delegate to repository", func() {
+			mockRepo.commitsAhead = 3
+			ahead, err := adapter.GetCommitsAhead("main")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ahead).To(Equal(3))
+			Expect(mockRepo.getCommitsAheadCalled).To(BeTrue())
+		})
+	})
 })
 
 // mockRepository is a mock implementation of the Repository interface for testing
@@ -172,6 +182,11 @@ type mockRepository struct {
 	remotes          map[string]string
 	remotesErr       error
 	getRemotesCalled bool
+
+	// GetCommitsAhead
+	commitsAhead          int
+	commitsAheadErr       error
+	getCommitsAheadCalled bool
 }
 
 func (m *mockRepository) IsInRepo() bool {
@@ -231,6 +246,11 @@ func (m *mockRepository) GetRemotes() (map[string]string, error) {
 	return m.remotes, m.remotesErr
 }
 
+func (m *mockRepository) GetCommitsAhead(string) (int, error) {
+	m.getCommitsAheadCalled = true
+	return m.commitsAhead, m.commitsAheadErr
+}
+
 var _ = Describe("NewSDKRunnerForPath", func() {
 	var (
 		tempDir string
@@ -45,6 +45,10 @@ type CachedRunner struct {
 	// Branch remote cache (per branch name)
 	branchRemoteMu    sync.RWMutex
 	branchRemoteCache map[string]branchRemoteCacheEntry
+
+	// Commits ahead cache (per branch name)
+	commitsAheadMu    sync.Mutex
+	commitsAheadCache map[string]commitsAheadCacheEntry
 }
 
 type remoteURLCacheEntry struct {
@@ -57,6 +61,11 @@ type branchRemoteCacheEntry struct {
 	err    error
 }
 
+type commitsAheadCacheEntry struct {
+	ahead int
+	err   error
+}
+
 // NewCachedRunner creates a new CachedRunner that wraps the given Runner.
 // The cached runner memoizes results for the duration of its lifetime.
 //
@@ -66,6 +75,7 @@ func NewCachedRunner(delegate Runner) Runner {
 		delegate:          delegate,
 		remoteURLCache:    make(map[string]remoteURLCacheEntry),
 		branchRemoteCache: make(map[string]branchRemoteCacheEntry),
+		commitsAheadCache: make(map[string]commitsAheadCacheEntry),
 	}
 }
 
@@ -223,5 +233,21 @@ func (c *CachedRunner) GetRemotes() (map[string]string, error) {
 	return c.remotes, c.remotesErr
 }
 
+// GetCommitsAhead returns the number of commits the branch is ahead of its upstream.
+// Results are cached per branch name.
+func (c *CachedRunner) GetCommitsAhead(branch string) (int, error) {
+	c.commitsAheadMu.Lock()
+	defer c.commitsAheadMu.Unlock()
+
+	if entry, ok := c.commitsAheadCache[branch]; ok {
+		return entry.ahead, entry.err
+	}
+
+	ahead, err := c.delegate.GetCommitsAhead(branch)
+	c.commitsAheadCache[branch] = commitsAheadCacheEntry{ahead: ahead, err: err}
+
+	return ahead, err
+}
+
 // Ensure CachedRunner implements Runner.
 var _ Runner = (*CachedRunner)(nil)
@@ -342,6 +342,23 @@ var _ = Describe("CachedRunner", func() {
 		})
 	})
 
+	Describe("GetCommitsAhead", func() {
+		It("caches results per branch name", func() {
+			mockRunner.EXPECT().GetCommitsAhead("main").Return(2, nil).Times(1)
+			mockRunner.EXPECT().GetCommitsAhead("local-only").
+				Return(0, errors.New("no tracking branch")).Times(1)
+
+			for range 2 {
+				ahead, err := cached.GetCommitsAhead("main")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ahead).To(Equal(2))
+
+				_, err = cached.GetCommitsAhead("local-only")
+				Expect(err).To(MatchError("no tracking branch"))
+			}
+		})
+	})
+
 	Describe("Concurrent access", func() {
 		It("handles concurrent calls to IsInRepo", func() {
 			mockRunner.EXPECT().IsInRepo().Return(true).Times(1)
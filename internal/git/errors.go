@@ -20,4 +20,7 @@ var (
 
 	// ErrNoTracking is returned when a branch has no tracking configuration
 	ErrNoTracking = errors.New("branch has no tracking remote")
+
+	// ErrInvalidRemoteURL is returned when a remote URL has no host or repository path
+	ErrInvalidRemoteURL = errors.New("invalid remote URL")
 )
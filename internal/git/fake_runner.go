@@ -12,6 +12,7 @@ type FakeRunner struct {
 	Remotes        map[string]string
 	CurrentBranch  string
 	BranchRemotes  map[string]string
+	CommitsAhead   map[string]int
 	Err            error
 }
 
@@ -31,6 +32,9 @@ func NewFakeRunner() *FakeRunner {
 		BranchRemotes: map[string]string{
 			"main": "origin",
 		},
+		CommitsAhead: map[string]int{
+			"main": 0,
+		},
 		Err: nil,
 	}
 }
@@ -120,6 +124,19 @@ func (f *FakeRunner) GetRemotes() (map[string]string, error) {
 	return f.Remotes, nil
 }
 
+// GetCommitsAhead returns the number of commits the branch is ahead of its upstream.
+func (f *FakeRunner) GetCommitsAhead(branch string) (int, error) {
+	if f.Err != nil {
+		return 0, f.Err
+	}
+
+	if ahead, ok := f.CommitsAhead[branch]; ok {
+		return ahead, nil
+	}
+
+	return 0, &FakeRunnerError{Msg: "branch upstream not found"}
+}
+
 // FakeRunnerError is a simple error type for testing.
 type FakeRunnerError struct {
 	Msg string
@@ -0,0 +1,68 @@
+package git
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// RemoteURL is a remote URL split into host, owner and repository name
+type RemoteURL struct {
+	// Host is the lowercase host name without port (e.g., "github.com")
+	Host string
+
+	// Owner is the repository owner (e.g., "smykla-labs"). For hosts with
+	// nested groups it contains all groups (e.g., "group/subgroup").
+	Owner string
+
+	// Repo is the repository name without the ".git" suffix
+	Repo string
+}
+
+// ParseRemoteURL parses HTTP(S), SSH, git protocol and SCP-like
+// ("git@github.com:owner/repo.git") remote URLs
+func ParseRemoteURL(rawURL string) (*RemoteURL, error) {
+	host, path, err := splitRemoteURL(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, err
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || path == "" {
+		return nil, errors.Wrapf(ErrInvalidRemoteURL, "%q", rawURL)
+	}
+
+	remote := &RemoteURL{Host: strings.ToLower(host), Repo: path}
+
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		remote.Owner = path[:i]
+		remote.Repo = path[i+1:]
+	}
+
+	return remote, nil
+}
+
+// splitRemoteURL splits a remote URL into host and path
+func splitRemoteURL(rawURL string) (host, path string, err error) {
+	if strings.Contains(rawURL, "://") {
+		parsed, parseErr := url.Parse(rawURL)
+		if parseErr != nil {
+			return "", "", errors.Wrapf(ErrInvalidRemoteURL, "%q: %v", rawURL, parseErr)
+		}
+
+		return parsed.Hostname(), parsed.Path, nil
+	}
+
+	// SCP-like syntax: [user@]host:path, where host contains no slash
+	hostPart, pathPart, ok := strings.Cut(rawURL, ":")
+	if !ok || strings.Contains(hostPart, "/") {
+		return "", "", errors.Wrapf(ErrInvalidRemoteURL, "%q", rawURL)
+	}
+
+	if _, after, found := strings.Cut(hostPart, "@"); found {
+		hostPart = after
+	}
+
+	return hostPart, pathPart, nil
+}
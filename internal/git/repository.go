@@ -8,6 +8,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/go-git/go-git/v6"
 	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/object"
 )
 
 // gitEnvVarsToUnset lists git environment variables that must be cleared before
@@ -66,6 +67,10 @@ type Repository interface {
 
 	// GetRemotes returns the list of all remotes with their URLs
 	GetRemotes() (map[string]string, error)
+
+	// GetCommitsAhead returns the number of commits on the given branch
+	// that are not on its upstream branch
+	GetCommitsAhead(branch string) (int, error)
 }
 
 // SDKRepository implements Repository using go-git SDK
@@ -304,3 +309,109 @@ func (r *SDKRepository) GetRemotes() (map[string]string, error) {
 
 	return result, nil
 }
+
+// GetCommitsAhead returns the number of commits on the given branch that are
+// not on its upstream branch
+func (r *SDKRepository) GetCommitsAhead(branch string) (int, error) {
+	branchRef, err := r.repo.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return 0, errors.Wrapf(ErrBranchNotFound, "branch %q", branch)
+		}
+
+		return 0, errors.Wrap(err, "failed to lookup branch")
+	}
+
+	cfg, err := r.repo.Config()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get config")
+	}
+
+	branchCfg, ok := cfg.Branches[branch]
+	if !ok || branchCfg.Remote == "" || branchCfg.Merge == "" {
+		return 0, errors.Wrapf(ErrNoTracking, "branch %q", branch)
+	}
+
+	upstreamName := branchCfg.Merge
+	if branchCfg.Remote != "." {
+		upstreamName = plumbing.NewRemoteReferenceName(branchCfg.Remote, branchCfg.Merge.Short())
+	}
+
+	upstreamRef, err := r.repo.Reference(upstreamName, true)
+	if err != nil {
+		return 0, errors.Wrapf(ErrNoTracking, "upstream %q of branch %q", upstreamName.Short(), branch)
+	}
+
+	local, err := r.repo.CommitObject(branchRef.Hash())
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get branch commit")
+	}
+
+	upstream, err := r.repo.CommitObject(upstreamRef.Hash())
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get upstream commit")
+	}
+
+	return countCommitsAhead(local, upstream)
+}
+
+// countCommitsAhead counts the commits reachable from local but not from
+// upstream, like "git rev-list --count upstream..local". Commit dates are not
+// relied on, as they may be skewed.
+func countCommitsAhead(local, upstream *object.Commit) (int, error) {
+	inUpstream := make(map[plumbing.Hash]bool)
+
+	if err := walkAncestors(upstream, func(c *object.Commit) bool {
+		if inUpstream[c.Hash] {
+			return false
+		}
+
+		inUpstream[c.Hash] = true
+
+		return true
+	}); err != nil {
+		return 0, err
+	}
+
+	inLocal := make(map[plumbing.Hash]bool)
+
+	if err := walkAncestors(local, func(c *object.Commit) bool {
+		if inUpstream[c.Hash] || inLocal[c.Hash] {
+			return false
+		}
+
+		inLocal[c.Hash] = true
+
+		return true
+	}); err != nil {
+		return 0, err
+	}
+
+	return len(inLocal), nil
+}
+
+// walkAncestors visits commit and its ancestors, descending into the parents
+// of a commit only if visit returns true for it.
+func walkAncestors(commit *object.Commit, visit func(*object.Commit) bool) error {
+	stack := []*object.Commit{commit}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if !visit(current) {
+			continue
+		}
+
+		for i := range current.NumParents() {
+			parent, err := current.Parent(i)
+			if err != nil {
+				return errors.Wrap(err, "failed to get parent commit")
+			}
+
+			stack = append(stack, parent)
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"time"
 
 	"github.com/go-git/go-git/v6"
 	"github.com/go-git/go-git/v6/config"
@@ -549,6 +550,134 @@ var _ = Describe("SDKRepository", func() {
 			})
 		})
 	})
+
+	Describe("GetCommitsAhead", func() {
+		var (
+			worktree *git.Worktree
+			commitAt time.Time
+		)
+
+		commit := func() plumbing.Hash {
+			commitAt = commitAt.Add(time.Minute)
+
+			hash, err := worktree.Commit("commit", &git.CommitOptions{ //nolint:govet // shadow
+				Author:            &object.Signature{Name: testAuthor.Name, Email: testAuthor.Email, When: commitAt},
+				AllowEmptyCommits: true,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			return hash
+		}
+
+		setUpstream := func(hash plumbing.Hash) {
+			err := repo.Storer.SetReference( //nolint:govet // shadow
+				plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", "master"), hash),
+			)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			sdkRepo, err = internalgit.DiscoverRepository()
+			Expect(err).NotTo(HaveOccurred())
+
+			worktree, err = repo.Worktree()
+			Expect(err).NotTo(HaveOccurred())
+
+			commitAt = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		})
+
+		Context("when branch tracks an upstream branch", func() {
+			var base plumbing.Hash
+
+			BeforeEach(func() {
+				base = commit()
+				setUpstream(base)
+
+				cfg, err := repo.Config() //nolint:govet // shadow
+				Expect(err).NotTo(HaveOccurred())
+
+				cfg.Branches["master"] = &config.Branch{
+					Name:   "master",
+					Remote: "origin",
+					Merge:  "refs/heads/master",
+				}
+
+				Expect(repo.SetConfig(cfg)).To(Succeed())
+			})
+
+			It("should return zero when up to date", func() {
+				ahead, err := sdkRepo.GetCommitsAhead("master") //nolint:govet // shadow
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ahead).To(BeZero())
+			})
+
+			It("should count local commits", func() {
+				commit()
+				commit()
+
+				ahead, err := sdkRepo.GetCommitsAhead("master") //nolint:govet // shadow
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ahead).To(Equal(2))
+			})
+
+			It("should not count upstream commits when diverged", func() {
+				Expect(worktree.Checkout(&git.CheckoutOptions{
+					Branch: plumbing.NewBranchReferenceName("other"),
+					Create: true,
+				})).To(Succeed())
+
+				commit()
+				commit()
+				commit()
+				setUpstream(commit())
+
+				Expect(worktree.Checkout(&git.CheckoutOptions{
+					Branch: plumbing.NewBranchReferenceName("master"),
+				})).To(Succeed())
+
+				commit()
+
+				ahead, err := sdkRepo.GetCommitsAhead("master") //nolint:govet // shadow
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ahead).To(Equal(1))
+			})
+
+			It("should not count upstream commits with older commit dates", func() {
+				commit()
+
+				Expect(worktree.Checkout(&git.CheckoutOptions{
+					Branch: plumbing.NewBranchReferenceName("other"),
+					Create: true,
+				})).To(Succeed())
+
+				commitAt = commitAt.Add(-time.Hour)
+
+				commit()
+				setUpstream(commit())
+
+				Expect(worktree.Checkout(&git.CheckoutOptions{
+					Branch: plumbing.NewBranchReferenceName("master"),
+				})).To(Succeed())
+
+				commitAt = commitAt.Add(2 * time.Hour)
+
+				commit()
+
+				ahead, err := sdkRepo.GetCommitsAhead("master") //nolint:govet // shadow
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ahead).To(Equal(1))
+			})
+		})
+
+		Context("when branch has no upstream", func() {
+			It("should return ErrNoTracking", func() {
+				commit()
+
+				_, err := sdkRepo.GetCommitsAhead("master") //nolint:govet // shadow
+				Expect(err).To(MatchError(internalgit.ErrNoTracking))
+			})
+		})
+	})
 })
 
 var _ = Describe("DiscoverRepository with linked worktrees", func() {
@@ -30,4 +30,8 @@ type Runner interface {
 
 	// GetRemotes returns the list of all remotes with their URLs
 	GetRemotes() (map[string]string, error)
+
+	// GetCommitsAhead returns the number of commits on the given branch
+	// that are not on its upstream branch
+	GetCommitsAhead(branch string) (int, error)
 }
@@ -54,6 +54,21 @@ func (mr *MockRunnerMockRecorder) GetBranchRemote(branch any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranchRemote", reflect.TypeOf((*MockRunner)(nil).GetBranchRemote), branch)
 }
 
+// GetCommitsAhead mocks base method.
+func (m *MockRunner) GetCommitsAhead(branch string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommitsAhead", branch)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommitsAhead indicates an expected call of GetCommitsAhead.
+func (mr *MockRunnerMockRecorder) GetCommitsAhead(branch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitsAhead", reflect.TypeOf((*MockRunner)(nil).GetCommitsAhead), branch)
+}
+
 // GetCurrentBranch mocks base method.
 func (m *MockRunner) GetCurrentBranch() (string, error) {
 	m.ctrl.T.Helper()
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io"
 	"os"
+	"path/filepath"
 
 	"github.com/cockroachdb/errors"
 
@@ -24,11 +25,19 @@ type JSONInput struct {
 	ToolName         string          `json:"tool_name,omitempty"`
 	Tool             string          `json:"tool,omitempty"`
 	ToolInput        json.RawMessage `json:"tool_input,omitempty"`
+	ToolResponse     json.RawMessage `json:"tool_response,omitempty"`
 	Command          string          `json:"command,omitempty"`
 	NotificationType string          `json:"notification_type,omitempty"`
 	SessionID        string          `json:"session_id,omitempty"`
 	ToolUseID        string          `json:"tool_use_id,omitempty"`
 	TranscriptPath   string          `json:"transcript_path,omitempty"`
+	HookEventName    string          `json:"hook_event_name,omitempty"`
+	CWD              string          `json:"cwd,omitempty"`
+	Prompt           string          `json:"prompt,omitempty"`
+	StopHookActive   bool            `json:"stop_hook_active,omitempty"`
+	Source           string          `json:"source,omitempty"`
+	Reason           string          `json:"reason,omitempty"`
+	Trigger          string          `json:"trigger,omitempty"`
 }
 
 // JSONParser parses JSON input from stdin or environment variable.
@@ -44,6 +53,8 @@ func NewJSONParser(reader io.Reader) *JSONParser {
 }
 
 // Parse parses the JSON input and extracts the hook context.
+// If eventType is EventTypeUnknown, the event type is taken from the
+// hook_event_name field of the payload.
 func (p *JSONParser) Parse(eventType hook.EventType) (*hook.Context, error) {
 	// Try reading from stdin
 	jsonBytes, err := io.ReadAll(p.reader)
@@ -94,6 +105,12 @@ func (p *JSONParser) Parse(eventType hook.EventType) (*hook.Context, error) {
 		parsedToolType = hook.ToolTypeUnknown
 	}
 
+	if eventType == hook.EventTypeUnknown && input.HookEventName != "" {
+		if parsedEventType, eventErr := hook.EventTypeString(input.HookEventName); eventErr == nil {
+			eventType = parsedEventType
+		}
+	}
+
 	ctx := &hook.Context{
 		EventType:        eventType,
 		ToolName:         parsedToolType,
@@ -103,7 +120,109 @@ func (p *JSONParser) Parse(eventType hook.EventType) (*hook.Context, error) {
 		SessionID:        input.SessionID,
 		ToolUseID:        input.ToolUseID,
 		TranscriptPath:   input.TranscriptPath,
+		CWD:              input.CWD,
+		Prompt:           input.Prompt,
+		StopHookActive:   input.StopHookActive,
+		Source:           input.Source,
+		Reason:           input.Reason,
+		Trigger:          input.Trigger,
+	}
+
+	if len(input.ToolResponse) > 0 {
+		ctx.ToolResponse = parseToolResponse(input.ToolResponse)
+
+		if ctx.EventType == hook.EventTypePostToolUse && ctx.IsFileTool() {
+			readWrittenFile(ctx)
+		}
 	}
 
 	return ctx, nil
 }
+
+// toolResponseJSON is the raw tool_response structure. Field names differ
+// between tools (e.g., Bash reports stdout/stderr, Write reports filePath).
+type toolResponseJSON struct {
+	Stdout          string `json:"stdout"`
+	Stderr          string `json:"stderr"`
+	ExitCode        *int   `json:"exit_code"`
+	ExitCodeCamel   *int   `json:"exitCode"`
+	Interrupted     bool   `json:"interrupted"`
+	FilePath        string `json:"filePath"`
+	FilePathSnake   string `json:"file_path"`
+	Content         string `json:"content"`
+	Success         *bool  `json:"success"`
+	ReadFileContent *struct {
+		FilePath string `json:"filePath"`
+		Content  string `json:"content"`
+	} `json:"file"`
+}
+
+// parseToolResponse parses tool_response, which is an object for most tools
+// and a plain string for some.
+func parseToolResponse(raw json.RawMessage) *hook.ToolResponse {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return &hook.ToolResponse{Stdout: text}
+	}
+
+	var input toolResponseJSON
+	if err := json.Unmarshal(raw, &input); err != nil {
+		return &hook.ToolResponse{}
+	}
+
+	resp := &hook.ToolResponse{
+		Stdout:      input.Stdout,
+		Stderr:      input.Stderr,
+		ExitCode:    input.ExitCode,
+		Interrupted: input.Interrupted,
+		FilePath:    input.FilePath,
+		Content:     input.Content,
+		Success:     input.Success,
+	}
+
+	if resp.ExitCode == nil {
+		resp.ExitCode = input.ExitCodeCamel
+	}
+
+	if resp.FilePath == "" {
+		resp.FilePath = input.FilePathSnake
+	}
+
+	if input.ReadFileContent != nil {
+		if resp.FilePath == "" {
+			resp.FilePath = input.ReadFileContent.FilePath
+		}
+
+		if resp.Content == "" {
+			resp.Content = input.ReadFileContent.Content
+		}
+	}
+
+	return resp
+}
+
+// readWrittenFile sets the tool response content to the file contents on disk,
+// so validators see the whole file after a PostToolUse Write, Edit or MultiEdit.
+// The reported content is kept if the file cannot be read.
+func readWrittenFile(ctx *hook.Context) {
+	path := ctx.ToolResponse.FilePath
+	if path == "" {
+		path = ctx.GetFilePath()
+	}
+
+	if path == "" {
+		return
+	}
+
+	if !filepath.IsAbs(path) && ctx.CWD != "" {
+		path = filepath.Join(ctx.CWD, path)
+	}
+
+	//nolint:gosec // path comes from the hook payload of a file the tool just wrote
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	ctx.ToolResponse.Content = string(content)
+}
@@ -2,6 +2,8 @@ package parser_test
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -103,6 +105,208 @@ var _ = Describe("JSONParser", func() {
 		})
 	})
 
+	Describe("Parse with MultiEdit input", func() {
+		It("parses every entry of the edits array in order", func() {
+			input := `{
+				"tool_name": "MultiEdit",
+				"tool_input": {
+					"file_path": "/tmp/README.md",
+					"edits": [
+						{"old_string": "foo", "new_string": "bar"},
+						{"old_string": "baz", "new_string": "qux", "replace_all": true}
+					]
+				}
+			}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypePreToolUse)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.ToolName).To(Equal(hook.ToolTypeMultiEdit))
+			Expect(ctx.IsMultiEdit()).To(BeTrue())
+			Expect(ctx.GetFilePath()).To(Equal("/tmp/README.md"))
+			Expect(ctx.ToolInput.Edits).To(Equal([]hook.EditOp{
+				{OldString: "foo", NewString: "bar"},
+				{OldString: "baz", NewString: "qux", ReplaceAll: true},
+			}))
+		})
+
+		It("is not a multi-edit without edits", func() {
+			input := `{
+				"tool_name": "MultiEdit",
+				"tool_input": {"file_path": "/tmp/README.md"}
+			}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypePreToolUse)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.IsMultiEdit()).To(BeFalse())
+		})
+	})
+
+	Describe("Parse with lifecycle event input", func() {
+		It("parses UserPromptSubmit payload fields", func() {
+			input := `{
+				"hook_event_name": "UserPromptSubmit",
+				"session_id": "abc123",
+				"cwd": "/home/user/project",
+				"prompt": "Write a function to calculate factorial"
+			}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypeUserPromptSubmit)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.EventType).To(Equal(hook.EventTypeUserPromptSubmit))
+			Expect(ctx.ToolName).To(Equal(hook.ToolTypeUnknown))
+			Expect(ctx.Prompt).To(Equal("Write a function to calculate factorial"))
+			Expect(ctx.CWD).To(Equal("/home/user/project"))
+		})
+
+		It("parses Stop, SessionStart, SessionEnd and PreCompact payload fields", func() {
+			input := `{
+				"stop_hook_active": true,
+				"source": "resume",
+				"reason": "logout",
+				"trigger": "auto"
+			}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypeStop)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.StopHookActive).To(BeTrue())
+			Expect(ctx.Source).To(Equal("resume"))
+			Expect(ctx.Reason).To(Equal("logout"))
+			Expect(ctx.Trigger).To(Equal("auto"))
+		})
+
+		It("uses hook_event_name when the event type is unknown", func() {
+			input := `{"hook_event_name": "PreCompact", "trigger": "manual"}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypeUnknown)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.EventType).To(Equal(hook.EventTypePreCompact))
+		})
+
+		It("prefers the given event type over hook_event_name", func() {
+			input := `{"hook_event_name": "Stop"}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypeSubagentStop)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.EventType).To(Equal(hook.EventTypeSubagentStop))
+		})
+	})
+
+	Describe("Parse with PostToolUse tool response", func() {
+		It("parses Bash stdout, stderr and exit code", func() {
+			input := `{
+				"hook_event_name": "PostToolUse",
+				"tool_name": "Bash",
+				"tool_input": {"command": "go test ./..."},
+				"tool_response": {"stdout": "FAIL", "stderr": "exit status 1", "exit_code": 1}
+			}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypeUnknown)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.EventType).To(Equal(hook.EventTypePostToolUse))
+			Expect(ctx.ToolResponse).NotTo(BeNil())
+			Expect(ctx.ToolResponse.Stdout).To(Equal("FAIL"))
+			Expect(ctx.ToolResponse.Stderr).To(Equal("exit status 1"))
+			Expect(ctx.ToolResponse.Failed()).To(BeTrue())
+			Expect(ctx.GetToolOutput()).To(Equal("FAIL\nexit status 1"))
+		})
+
+		It("accepts camelCase exit codes and plain string responses", func() {
+			p := parser.NewJSONParser(bytes.NewReader([]byte(
+				`{"tool_name": "Bash", "tool_response": {"exitCode": 0}}`,
+			)))
+			ctx, err := p.Parse(hook.EventTypePostToolUse)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*ctx.ToolResponse.ExitCode).To(Equal(0))
+			Expect(ctx.ToolResponse.Failed()).To(BeFalse())
+
+			p = parser.NewJSONParser(bytes.NewReader([]byte(
+				`{"tool_name": "WebFetch", "tool_response": "page text"}`,
+			)))
+			ctx, err = p.Parse(hook.EventTypePostToolUse)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.GetToolOutput()).To(Equal("page text"))
+		})
+
+		It("reads the written file contents from disk", func() {
+			dir := GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o600)).
+				To(Succeed())
+
+			input := `{
+				"cwd": "` + dir + `",
+				"tool_name": "Edit",
+				"tool_input": {"file_path": "main.go", "old_string": "a", "new_string": "b"},
+				"tool_response": {"filePath": "main.go", "oldString": "a", "newString": "b"}
+			}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypePostToolUse)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.GetWrittenContent()).To(Equal("package main\n"))
+		})
+
+		It("does not read files from disk for Read responses", func() {
+			dir := GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(dir, ".env"), []byte("TOKEN=on-disk\n"), 0o600)).
+				To(Succeed())
+
+			input := `{
+				"cwd": "` + dir + `",
+				"tool_name": "Read",
+				"tool_input": {"file_path": ".env"},
+				"tool_response": {"type": "text", "file": {"filePath": ".env", "content": "TOKEN=reported"}}
+			}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypePostToolUse)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.ToolResponse.Content).To(Equal("TOKEN=reported"))
+		})
+
+		It("keeps the reported content when the file cannot be read", func() {
+			input := `{
+				"tool_name": "Write",
+				"tool_input": {"file_path": "/nonexistent/file.txt", "content": "hello"},
+				"tool_response": {"type": "create", "filePath": "/nonexistent/file.txt", "content": "hello"}
+			}`
+
+			p := parser.NewJSONParser(bytes.NewReader([]byte(input)))
+			ctx, err := p.Parse(hook.EventTypePostToolUse)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.GetWrittenContent()).To(Equal("hello"))
+		})
+
+		It("has no tool response for PreToolUse payloads", func() {
+			p := parser.NewJSONParser(bytes.NewReader([]byte(
+				`{"tool_name": "Bash", "tool_input": {"command": "ls"}}`,
+			)))
+			ctx, err := p.Parse(hook.EventTypePreToolUse)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctx.ToolResponse).To(BeNil())
+			Expect(ctx.GetToolOutput()).To(BeEmpty())
+		})
+	})
+
 	Describe("Backward compatibility", func() {
 		It("works with inputs without session fields", func() {
 			input := `{
@@ -269,9 +269,11 @@ func (p *PredicateMatcher) Matches(hookCtx *hook.Context) bool {
 }
 
 // matchesEventType returns whether the event type matches.
+// Without an event type filter only PreToolUse events match, so plugins are not
+// invoked for lifecycle events (Stop, SessionStart, ...) unless they opt in.
 func (p *PredicateMatcher) matchesEventType(hookCtx *hook.Context) bool {
 	if len(p.eventTypes) == 0 {
-		return true
+		return hookCtx.EventType == hook.EventTypePreToolUse
 	}
 
 	eventTypeStr := hookCtx.EventType.String()
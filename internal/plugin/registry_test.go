@@ -372,6 +372,31 @@ var _ = Describe("PredicateMatcher", func() {
 			})
 		})
 
+		Context("with lifecycle event type filter", func() {
+			It("should match the configured lifecycle event", func() {
+				matcher, err := plugin.NewPredicateMatcher(&config.PluginPredicate{
+					EventTypes: []string{"Stop"},
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(matcher.Matches(&hook.Context{EventType: hook.EventTypeStop})).To(BeTrue())
+				Expect(matcher.Matches(&hook.Context{EventType: hook.EventTypePreToolUse})).To(BeFalse())
+			})
+		})
+
+		Context("without event type filter", func() {
+			It("should only match PreToolUse", func() {
+				matcher, err := plugin.NewPredicateMatcher(&config.PluginPredicate{})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(matcher.Matches(&hook.Context{
+					EventType: hook.EventTypePreToolUse,
+					ToolName:  hook.ToolTypeBash,
+				})).To(BeTrue())
+				Expect(matcher.Matches(&hook.Context{EventType: hook.EventTypeSessionStart})).To(BeFalse())
+			})
+		})
+
 		Context("with tool type filter", func() {
 			var matcher *plugin.PredicateMatcher
 
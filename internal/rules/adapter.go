@@ -75,6 +75,16 @@ func NewRuleValidatorAdapter(
 func (a *RuleValidatorAdapter) CheckRules(
 	ctx context.Context,
 	hookCtx *hook.Context,
+) *validator.Result {
+	return a.checkRules(ctx, hookCtx, false)
+}
+
+// checkRules implements CheckRules. If scopedOnly is set, rules without a
+// validator_type or with "*" are skipped.
+func (a *RuleValidatorAdapter) checkRules(
+	ctx context.Context,
+	hookCtx *hook.Context,
+	scopedOnly bool,
 ) *validator.Result {
 	if a.engine == nil {
 		return nil
@@ -84,6 +94,7 @@ func (a *RuleValidatorAdapter) CheckRules(
 	matchCtx := &MatchContext{
 		HookContext:   hookCtx,
 		ValidatorType: a.validatorType,
+		scopedOnly:    scopedOnly,
 	}
 
 	if hookCtx != nil {
@@ -152,24 +163,42 @@ func (a *RuleValidatorAdapter) CheckRulesWithContext(
 func (*RuleValidatorAdapter) convertResult(result *RuleResult) *validator.Result {
 	switch result.Action {
 	case ActionBlock:
+		return blockResult(result)
+
+	case ActionSuggest:
+		blocked := blockResult(result)
+
+		if result.Suggestion != "" {
+			blocked.FixHint = "Run instead: " + result.Suggestion
+		}
+
+		return blocked
+
+	case ActionPoison:
+		blocked := blockResult(result)
+		blocked.ShouldPoison = true
+
+		return blocked
+
+	case ActionWarn:
 		if result.Reference != "" {
-			return validator.FailWithRef(
+			return validator.WarnWithRef(
 				validator.Reference(result.Reference),
 				result.Message,
 			)
 		}
 
-		return validator.Fail(result.Message)
+		return validator.Warn(result.Message)
 
-	case ActionWarn:
+	case ActionAsk:
 		if result.Reference != "" {
-			return validator.WarnWithRef(
+			return validator.AskWithRef(
 				validator.Reference(result.Reference),
 				result.Message,
 			)
 		}
 
-		return validator.Warn(result.Message)
+		return validator.Ask(result.Message)
 
 	case ActionAllow:
 		return validator.Pass()
@@ -179,6 +208,18 @@ func (*RuleValidatorAdapter) convertResult(result *RuleResult) *validator.Result
 	}
 }
 
+// blockResult creates a blocking validator result for a rule result.
+func blockResult(result *RuleResult) *validator.Result {
+	if result.Reference != "" {
+		return validator.FailWithRef(
+			validator.Reference(result.Reference),
+			result.Message,
+		)
+	}
+
+	return validator.Fail(result.Message)
+}
+
 // HasRulesForValidator returns true if there are any rules for this validator type.
 func (a *RuleValidatorAdapter) HasRulesForValidator() bool {
 	if a.engine == nil {
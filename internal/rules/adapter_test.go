@@ -131,6 +131,52 @@ var _ = Describe("RuleValidatorAdapter", func() {
 			})
 		})
 
+		Context("with ask rule", func() {
+			BeforeEach(func() {
+				ruleList := []*rules.Rule{
+					{
+						Name:    "ask-upstream",
+						Enabled: true,
+						Match: &rules.RuleMatch{
+							Remote: "upstream",
+						},
+						Action: &rules.RuleAction{
+							Type:      rules.ActionAsk,
+							Message:   "confirm push to upstream",
+							Reference: "GIT020",
+						},
+					},
+				}
+
+				var err error
+				engine, err = rules.NewRuleEngine(ruleList)
+				Expect(err).NotTo(HaveOccurred())
+
+				adapter = rules.NewRuleValidatorAdapter(
+					engine,
+					rules.ValidatorGitPush,
+				)
+			})
+
+			It("should return ask result when rule asks", func() {
+				hookCtx := &hook.Context{}
+
+				adapter.GitContextProvider = func() *rules.GitContext {
+					return &rules.GitContext{
+						Remote: "upstream",
+					}
+				}
+
+				result := adapter.CheckRules(ctx, hookCtx)
+				Expect(result).NotTo(BeNil())
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.ShouldBlock).To(BeFalse())
+				Expect(result.ShouldAsk).To(BeTrue())
+				Expect(result.Message).To(Equal("confirm push to upstream"))
+				Expect(string(result.Reference)).To(Equal("GIT020"))
+			})
+		})
+
 		Context("with allow rule", func() {
 			BeforeEach(func() {
 				ruleList := []*rules.Rule{
@@ -528,6 +574,80 @@ var _ = Describe("RuleValidatorAdapter", func() {
 		})
 	})
 
+	Describe("Suggest action", func() {
+		BeforeEach(func() {
+			var err error
+
+			engine, err = rules.NewRuleEngine([]*rules.Rule{
+				{
+					Name:    "force-with-lease",
+					Enabled: true,
+					Match:   &rules.RuleMatch{Program: "git", Flags: []string{"--force"}},
+					Action: &rules.RuleAction{
+						Type:       rules.ActionSuggest,
+						Message:    "Force pushes can overwrite remote work",
+						Suggestion: `{{replace "--force" "--force-with-lease" .Command}}`,
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			adapter = rules.NewRuleValidatorAdapter(engine, rules.ValidatorGitPush)
+		})
+
+		It("should block with the rendered replacement command", func() {
+			result := adapter.CheckRules(ctx, &hook.Context{
+				ToolName:  hook.ToolTypeBash,
+				ToolInput: hook.ToolInput{Command: "git push --force origin feat"},
+			})
+			Expect(result).NotTo(BeNil())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Message).To(Equal("Force pushes can overwrite remote work"))
+			Expect(result.FixHint).To(Equal("Run instead: git push --force-with-lease origin feat"))
+		})
+
+		It("should reject rules without a suggestion", func() {
+			_, err := rules.NewRuleEngine([]*rules.Rule{
+				{Name: "empty", Enabled: true, Action: &rules.RuleAction{Type: rules.ActionSuggest}},
+			})
+			Expect(err).To(MatchError(ContainSubstring("requires a suggestion")))
+		})
+
+		It("should reject invalid suggestion templates", func() {
+			_, err := rules.NewRuleEngine([]*rules.Rule{
+				{
+					Name:    "broken",
+					Enabled: true,
+					Action:  &rules.RuleAction{Type: rules.ActionSuggest, Suggestion: "{{.Command"},
+				},
+			})
+			Expect(err).To(MatchError(ContainSubstring("failed to compile rule suggestion")))
+		})
+	})
+
+	Describe("Poison action", func() {
+		It("should block and request session poisoning", func() {
+			engine, _ = rules.NewRuleEngine([]*rules.Rule{
+				{
+					Name:    "no-prod-creds",
+					Enabled: true,
+					Action: &rules.RuleAction{
+						Type:      rules.ActionPoison,
+						Message:   "Production credentials were used",
+						Reference: "SEC001",
+					},
+				},
+			})
+			adapter = rules.NewRuleValidatorAdapter(engine, rules.ValidatorRules)
+
+			result := adapter.CheckRules(ctx, &hook.Context{})
+			Expect(result).NotTo(BeNil())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.ShouldPoison).To(BeTrue())
+			Expect(result.Reference.Code()).To(Equal("SEC001"))
+		})
+	})
+
 	Describe("AdapterOption functions", func() {
 		It("should apply WithAdapterLogger option", func() {
 			ruleList := []*rules.Rule{
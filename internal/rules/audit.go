@@ -0,0 +1,235 @@
+package rules
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/internal/statestore"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// Audit file permission constants.
+const (
+	// auditFilePermissions is the permission mode for the audit log file.
+	auditFilePermissions = 0o600
+
+	// auditDirPermissions is the permission mode for the audit directory.
+	auditDirPermissions = 0o700
+
+	// maxAuditCommandLength is the maximum length of commands in audit entries.
+	maxAuditCommandLength = 500
+)
+
+// AuditEntry is an audit log entry for a rule with the log action.
+type AuditEntry struct {
+	// Timestamp is when the rule matched.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Rule is the name of the rule that matched.
+	Rule string `json:"rule"`
+
+	// ValidatorType is the validator the rule was evaluated for.
+	ValidatorType ValidatorType `json:"validator_type"`
+
+	// Event is the hook event type.
+	Event string `json:"event,omitempty"`
+
+	// Tool is the name of the tool being invoked.
+	Tool string `json:"tool,omitempty"`
+
+	// Command is the bash command, truncated to limit log size.
+	Command string `json:"command,omitempty"`
+
+	// FilePath is the file path being operated on.
+	FilePath string `json:"file_path,omitempty"`
+
+	// Message is the rule's message.
+	Message string `json:"message,omitempty"`
+
+	// Reference is the rule's reference code.
+	Reference string `json:"reference,omitempty"`
+
+	// SessionID is the Claude Code session identifier.
+	SessionID string `json:"session_id,omitempty"`
+
+	// WorkingDir is the working directory of the session.
+	WorkingDir string `json:"working_dir,omitempty"`
+}
+
+// AuditLogger writes matches of rules with the log action to a JSONL file.
+type AuditLogger struct {
+	mu      sync.Mutex
+	logFile string
+	logger  logger.Logger
+
+	// now is a function that returns the current time.
+	// Used for testing to control time.
+	now func() time.Time
+}
+
+// AuditLoggerOption configures the AuditLogger.
+type AuditLoggerOption func(*AuditLogger)
+
+// WithAuditLoggerLogger sets the logger.
+func WithAuditLoggerLogger(log logger.Logger) AuditLoggerOption {
+	return func(a *AuditLogger) {
+		if log != nil {
+			a.logger = log
+		}
+	}
+}
+
+// WithAuditTimeFunc sets a custom time function for testing.
+func WithAuditTimeFunc(fn func() time.Time) AuditLoggerOption {
+	return func(a *AuditLogger) {
+		if fn != nil {
+			a.now = fn
+		}
+	}
+}
+
+// NewAuditLogger creates a new rules audit logger writing to logFile.
+// A leading "~/" in logFile is expanded to the home directory.
+func NewAuditLogger(logFile string, opts ...AuditLoggerOption) *AuditLogger {
+	a := &AuditLogger{
+		logFile: logFile,
+		logger:  logger.NewNoOpLogger(),
+		now:     time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+// Record writes an audit entry for rule matching in ctx.
+func (a *AuditLogger) Record(rule *Rule, ctx *MatchContext) error {
+	entry := &AuditEntry{
+		Timestamp:     a.now(),
+		Rule:          rule.Name,
+		ValidatorType: ctx.ValidatorType,
+		Command:       truncate(ctx.Command, maxAuditCommandLength),
+	}
+
+	if rule.Action != nil {
+		entry.Message = rule.Action.Message
+		entry.Reference = rule.Action.Reference
+	}
+
+	if hookCtx := ctx.HookContext; hookCtx != nil {
+		entry.Event = hookCtx.EventType.String()
+		entry.Tool = hookCtx.ToolName.String()
+		entry.FilePath = hookCtx.GetFilePath()
+		entry.SessionID = hookCtx.SessionID
+		entry.WorkingDir = hookCtx.CWD
+	}
+
+	return a.Log(entry)
+}
+
+// Log writes an audit entry to the log file.
+func (a *AuditLogger) Log(entry *AuditEntry) error {
+	if entry == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "marshaling rules audit entry")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	store := statestore.New(
+		a.GetLogPath(),
+		statestore.WithFilePermissions(auditFilePermissions),
+		statestore.WithDirPermissions(auditDirPermissions),
+	)
+
+	if err := store.Append(append(data, '\n')); err != nil {
+		return errors.Wrap(err, "writing rules audit entry")
+	}
+
+	return nil
+}
+
+// Read reads all audit entries from the log file.
+// Returns an empty slice if the file does not exist.
+func (a *AuditLogger) Read() ([]*AuditEntry, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Path comes from trusted configuration, not user input.
+	file, err := os.Open(a.GetLogPath()) //nolint:gosec // G304: path is from config
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*AuditEntry{}, nil
+		}
+
+		return nil, errors.Wrap(err, "opening rules audit file")
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var entries []*AuditEntry
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		var entry AuditEntry
+
+		if unmarshalErr := json.Unmarshal([]byte(line), &entry); unmarshalErr != nil {
+			a.logger.Debug("skipping malformed rules audit entry",
+				"error", unmarshalErr.Error(),
+			)
+
+			continue
+		}
+
+		entries = append(entries, &entry)
+	}
+
+	if scanErr := scanner.Err(); scanErr != nil {
+		return nil, errors.Wrap(scanErr, "scanning rules audit file")
+	}
+
+	return entries, nil
+}
+
+// GetLogPath returns the log file path with "~/" expanded.
+func (a *AuditLogger) GetLogPath() string {
+	path := a.logFile
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+
+	return path
+}
+
+// truncate shortens s to at most maxLen bytes.
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+
+	return s[:maxLen] + "..."
+}
@@ -0,0 +1,166 @@
+package rules_test
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+)
+
+var _ = Describe("AuditLogger", func() {
+	var (
+		auditLogger *rules.AuditLogger
+		now         time.Time
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		auditLogger = rules.NewAuditLogger(
+			filepath.Join(GinkgoT().TempDir(), "audit", "rules_audit.jsonl"),
+			rules.WithAuditTimeFunc(func() time.Time { return now }),
+		)
+	})
+
+	It("returns no entries before anything is logged", func() {
+		entries, err := auditLogger.Read()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(BeEmpty())
+	})
+
+	It("records log rule matches without affecting the decision", func() {
+		engine, err := rules.NewRuleEngine([]*rules.Rule{
+			{
+				Name:     "log-kubectl",
+				Priority: 100,
+				Enabled:  true,
+				Match:    &rules.RuleMatch{Program: "kubectl"},
+				Action: &rules.RuleAction{
+					Type:      rules.ActionLog,
+					Message:   "kubectl used",
+					Reference: "K8S001",
+				},
+			},
+			{
+				Name:    "warn-delete",
+				Enabled: true,
+				Match:   &rules.RuleMatch{Subcommand: "delete"},
+				Action:  &rules.RuleAction{Type: rules.ActionWarn, Message: "deleting"},
+			},
+		}, rules.WithAuditLogger(auditLogger))
+		Expect(err).NotTo(HaveOccurred())
+
+		result := engine.EvaluateHook(context.Background(), &hook.Context{
+			EventType: hook.EventTypePreToolUse,
+			ToolName:  hook.ToolTypeBash,
+			ToolInput: hook.ToolInput{Command: "kubectl delete pod x"},
+			SessionID: "session-1",
+		}, rules.ValidatorRules, nil, nil)
+
+		Expect(result.Matched).To(BeTrue())
+		Expect(result.Rule.Name).To(Equal("warn-delete"))
+		Expect(result.Logged).To(HaveLen(1))
+
+		entries, err := auditLogger.Read()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(*entries[0]).To(Equal(rules.AuditEntry{
+			Timestamp:     now,
+			Rule:          "log-kubectl",
+			ValidatorType: rules.ValidatorRules,
+			Event:         "PreToolUse",
+			Tool:          "Bash",
+			Command:       "kubectl delete pod x",
+			Message:       "kubectl used",
+			Reference:     "K8S001",
+			SessionID:     "session-1",
+		}))
+	})
+
+	It("does not match when only log rules match", func() {
+		engine, err := rules.NewRuleEngine([]*rules.Rule{
+			{
+				Name:    "log-all",
+				Enabled: true,
+				Action:  &rules.RuleAction{Type: rules.ActionLog},
+			},
+		}, rules.WithAuditLogger(auditLogger))
+		Expect(err).NotTo(HaveOccurred())
+
+		adapter := rules.NewRuleValidatorAdapter(engine, rules.ValidatorRules)
+		Expect(adapter.CheckRules(context.Background(), &hook.Context{})).To(BeNil())
+
+		entries, err := auditLogger.Read()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+
+	It("records log rules below the deciding rule", func() {
+		engine, err := rules.NewRuleEngine([]*rules.Rule{
+			{
+				Name:     "block-delete",
+				Priority: 100,
+				Enabled:  true,
+				Match:    &rules.RuleMatch{Subcommand: "delete"},
+				Action:   &rules.RuleAction{Type: rules.ActionBlock, Message: "no deletes"},
+			},
+			{
+				Name:    "log-kubectl",
+				Enabled: true,
+				Match:   &rules.RuleMatch{Program: "kubectl"},
+				Action:  &rules.RuleAction{Type: rules.ActionLog},
+			},
+		}, rules.WithAuditLogger(auditLogger), rules.WithEngineStopOnFirstMatch(true))
+		Expect(err).NotTo(HaveOccurred())
+
+		result := engine.EvaluateHook(context.Background(), &hook.Context{
+			ToolName:  hook.ToolTypeBash,
+			ToolInput: hook.ToolInput{Command: "kubectl delete pod x"},
+		}, rules.ValidatorRules, nil, nil)
+
+		Expect(result.Rule.Name).To(Equal("block-delete"))
+		Expect(result.Logged).To(HaveLen(1))
+
+		entries, err := auditLogger.Read()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Rule).To(Equal("log-kubectl"))
+	})
+
+	It("records a log rule once per hook invocation", func() {
+		engine, err := rules.NewRuleEngine([]*rules.Rule{
+			{
+				Name:    "log-all",
+				Enabled: true,
+				Action:  &rules.RuleAction{Type: rules.ActionLog},
+			},
+		}, rules.WithAuditLogger(auditLogger))
+		Expect(err).NotTo(HaveOccurred())
+
+		hookCtx := &hook.Context{ToolName: hook.ToolTypeBash}
+		ctx := rules.WithInvocation(context.Background())
+
+		for _, validatorType := range []rules.ValidatorType{
+			rules.ValidatorShellDestructive,
+			rules.ValidatorGitPush,
+		} {
+			adapter := rules.NewRuleValidatorAdapter(engine, validatorType)
+			Expect(adapter.CheckRules(ctx, hookCtx)).To(BeNil())
+		}
+
+		entries, err := auditLogger.Read()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+
+		adapter := rules.NewRuleValidatorAdapter(engine, rules.ValidatorGitPush)
+		Expect(adapter.CheckRules(rules.WithInvocation(context.Background()), hookCtx)).To(BeNil())
+
+		entries, err = auditLogger.Read()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(2))
+	})
+})
@@ -0,0 +1,372 @@
+package rules
+
+import (
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/pkg/parser"
+)
+
+// Pipeline positions for ParsedCommandMatcher.
+const (
+	PipelinePositionFirst  = "first"
+	PipelinePositionMiddle = "middle"
+	PipelinePositionLast   = "last"
+)
+
+// ErrInvalidPipelinePosition is returned for unknown pipeline positions.
+var ErrInvalidPipelinePosition = errors.New("invalid pipeline position")
+
+// ParsedCommandMatcher matches against the individual commands extracted
+// from a bash command by the parser. It matches if any single extracted
+// command satisfies all of its conditions, so "cd x && curl ... | sh" is
+// caught by a rule on the "sh" program regardless of what surrounds it.
+type ParsedCommandMatcher struct {
+	program          Pattern
+	subcommand       Pattern
+	flags            []string
+	withoutFlags     []string
+	args             []Pattern
+	inPipeline       *bool
+	inSubshell       *bool
+	inCmdSubst       *bool
+	pipelinePosition string
+}
+
+// NewParsedCommandMatcher creates a matcher for the parsed command conditions
+// of match. Returns nil if match has no parsed command conditions.
+func NewParsedCommandMatcher(
+	match *RuleMatch,
+	opts PatternOptions,
+) (*ParsedCommandMatcher, error) {
+	if !hasParsedCommandConditions(match) {
+		return nil, nil //nolint:nilnil // no conditions is valid
+	}
+
+	switch match.PipelinePosition {
+	case "", PipelinePositionFirst, PipelinePositionMiddle, PipelinePositionLast:
+	default:
+		return nil, errors.Wrapf(ErrInvalidPipelinePosition, "%q", match.PipelinePosition)
+	}
+
+	m := &ParsedCommandMatcher{
+		flags:            match.Flags,
+		withoutFlags:     match.WithoutFlags,
+		inPipeline:       match.InPipeline,
+		inSubshell:       match.InSubshell,
+		inCmdSubst:       match.InCommandSubstitution,
+		pipelinePosition: match.PipelinePosition,
+	}
+
+	var err error
+
+	if match.Program != "" {
+		if m.program, err = CompilePatternWithOptions(match.Program, opts); err != nil {
+			return nil, errors.Wrap(err, "program")
+		}
+	}
+
+	if match.Subcommand != "" {
+		if m.subcommand, err = CompilePatternWithOptions(match.Subcommand, opts); err != nil {
+			return nil, errors.Wrap(err, "subcommand")
+		}
+	}
+
+	for _, arg := range match.Args {
+		pattern, err := CompilePatternWithOptions(arg, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "args")
+		}
+
+		m.args = append(m.args, pattern)
+	}
+
+	return m, nil
+}
+
+// hasParsedCommandConditions reports whether match has any condition that
+// is evaluated against parsed commands.
+func hasParsedCommandConditions(match *RuleMatch) bool {
+	return match.Program != "" ||
+		match.Subcommand != "" ||
+		len(match.Flags) > 0 ||
+		len(match.WithoutFlags) > 0 ||
+		len(match.Args) > 0 ||
+		match.InPipeline != nil ||
+		match.InSubshell != nil ||
+		match.InCommandSubstitution != nil ||
+		match.PipelinePosition != ""
+}
+
+// Match returns true if any parsed command satisfies all conditions.
+func (m *ParsedCommandMatcher) Match(ctx *MatchContext) bool {
+	commands := ctx.ParsedCommands()
+
+	for i := range commands {
+		if m.matchCommand(&commands[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchCommand checks a single parsed command against all conditions.
+func (m *ParsedCommandMatcher) matchCommand(cmd *parser.Command) bool {
+	if !m.matchContext(cmd) {
+		return false
+	}
+
+	if m.program != nil && !m.program.Match(filepath.Base(cmd.Name)) {
+		return false
+	}
+
+	words := splitCommandWords(cmd)
+
+	if m.subcommand != nil && (words.subcommand == "" || !m.subcommand.Match(words.subcommand)) {
+		return false
+	}
+
+	for _, flag := range m.flags {
+		if !words.hasFlag(flag) {
+			return false
+		}
+	}
+
+	for _, flag := range m.withoutFlags {
+		if words.hasFlag(flag) {
+			return false
+		}
+	}
+
+	for _, pattern := range m.args {
+		if !matchAnyArg(pattern, words.args) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchContext checks the pipeline, subshell and command substitution
+// conditions.
+func (m *ParsedCommandMatcher) matchContext(cmd *parser.Command) bool {
+	if m.inPipeline != nil && *m.inPipeline != cmd.InPipeline() {
+		return false
+	}
+
+	if m.inSubshell != nil && *m.inSubshell != cmd.InSubshell {
+		return false
+	}
+
+	if m.inCmdSubst != nil && *m.inCmdSubst != cmd.InCmdSubst {
+		return false
+	}
+
+	switch m.pipelinePosition {
+	case PipelinePositionFirst:
+		return cmd.InPipeline() && cmd.PipelineStage == 1
+	case PipelinePositionMiddle:
+		return cmd.InPipeline() && cmd.PipelineStage > 1 && cmd.PipelineStage < cmd.PipelineLength
+	case PipelinePositionLast:
+		return cmd.InPipeline() && cmd.PipelineStage == cmd.PipelineLength
+	default:
+		return true
+	}
+}
+
+// Name returns the matcher name.
+func (m *ParsedCommandMatcher) Name() string {
+	var parts []string
+
+	if m.program != nil {
+		parts = append(parts, "program="+m.program.String())
+	}
+
+	if m.subcommand != nil {
+		parts = append(parts, "subcommand="+m.subcommand.String())
+	}
+
+	if len(m.flags) > 0 {
+		parts = append(parts, "flags="+strings.Join(m.flags, ","))
+	}
+
+	if len(m.withoutFlags) > 0 {
+		parts = append(parts, "without_flags="+strings.Join(m.withoutFlags, ","))
+	}
+
+	for _, arg := range m.args {
+		parts = append(parts, "arg="+arg.String())
+	}
+
+	if m.inPipeline != nil {
+		parts = append(parts, "in_pipeline="+strconv.FormatBool(*m.inPipeline))
+	}
+
+	if m.inSubshell != nil {
+		parts = append(parts, "in_subshell="+strconv.FormatBool(*m.inSubshell))
+	}
+
+	if m.inCmdSubst != nil {
+		parts = append(parts, "in_command_substitution="+strconv.FormatBool(*m.inCmdSubst))
+	}
+
+	if m.pipelinePosition != "" {
+		parts = append(parts, "pipeline_position="+m.pipelinePosition)
+	}
+
+	return "command(" + strings.Join(parts, " ") + ")"
+}
+
+// commandWords is a parsed command split into subcommand, flags and
+// positional arguments.
+type commandWords struct {
+	subcommand string
+	flags      []string
+	args       []string
+	git        *parser.GitCommand
+
+	// combinesShortFlags is false for programs with single-dash long options.
+	combinesShortFlags bool
+}
+
+// singleDashOptionPrograms lists programs whose options are single-dash words
+// such as "find -name" or "go -race", so their flags are never combined short
+// flags.
+var singleDashOptionPrograms = []string{
+	"cc", "clang", "ffmpeg", "find", "g++", "gcc", "go", "java", "javac",
+	"openssl", "terraform", "tofu",
+}
+
+// valueFlags lists the global options that take their value as a separate
+// word, per program. Their values are not positional arguments, so
+// "kubectl --context prod delete" has the subcommand "delete". Options of
+// other programs are assumed not to take a separate value.
+var valueFlags = map[string][]string{
+	"kubectl": {
+		"--as", "--as-group", "--cache-dir", "--certificate-authority",
+		"--client-certificate", "--client-key", "--cluster", "--context",
+		"--kubeconfig", "--namespace", "-n", "--request-timeout", "--server",
+		"-s", "--token", "--user",
+	},
+	"helm": {
+		"--kube-context", "--kubeconfig", "--namespace", "-n",
+		"--registry-config", "--repository-cache", "--repository-config",
+	},
+	"gh":     {"--hostname", "--repo", "-R"},
+	"docker": {"--config", "--context", "-c", "--host", "-H", "--log-level", "-l"},
+	"aws":    {"--endpoint-url", "--output", "--profile", "--region"},
+}
+
+// splitCommandWords splits the arguments of cmd. Git commands are parsed
+// with parser.ParseGitCommand so that global options such as -C are skipped
+// and args holds the arguments after the subcommand. For other programs the
+// subcommand is the first positional argument and args holds all of them;
+// the values of the options in valueFlags are skipped.
+func splitCommandWords(cmd *parser.Command) commandWords {
+	if gitCmd, err := parser.ParseGitCommand(*cmd); err == nil {
+		return commandWords{
+			subcommand: gitCmd.Subcommand,
+			flags:      gitCmd.Flags,
+			args:       gitCmd.Args,
+			git:        gitCmd,
+		}
+	}
+
+	program := filepath.Base(cmd.Name)
+
+	words := commandWords{
+		combinesShortFlags: !slices.Contains(singleDashOptionPrograms, program),
+	}
+
+	takesValue := valueFlags[program]
+	endOfFlags := false
+	skipValue := false
+
+	for _, arg := range cmd.Args {
+		switch {
+		case skipValue:
+			skipValue = false
+		case endOfFlags || arg == "-" || !strings.HasPrefix(arg, "-"):
+			words.args = append(words.args, arg)
+		case arg == "--":
+			endOfFlags = true
+		default:
+			words.flags = append(words.flags, arg)
+			skipValue = slices.Contains(takesValue, arg)
+		}
+	}
+
+	if len(words.args) > 0 {
+		words.subcommand = words.args[0]
+	}
+
+	return words
+}
+
+// hasFlag reports whether the command has flag. Long flags also match
+// their "--flag=value" form and short flags match combined short flags
+// (e.g., "-f" matches "-rf"), like parser.GitCommand.HasFlag. Only words of
+// two to five letters of programs that are not in singleDashOptionPrograms
+// are treated as combined short flags.
+func (w commandWords) hasFlag(flag string) bool {
+	if w.git != nil && w.git.HasFlag(flag) {
+		return true
+	}
+
+	for _, f := range w.flags {
+		if f == flag {
+			return true
+		}
+
+		if strings.HasPrefix(flag, "--") {
+			if strings.HasPrefix(f, flag+"=") {
+				return true
+			}
+
+			continue
+		}
+
+		// Short flag like "-f" within combined flags like "-rf"
+		if len(flag) == 2 && flag[0] == '-' && w.combinesShortFlags &&
+			isCombinedShortFlags(f) && strings.ContainsRune(f[1:], rune(flag[1])) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isCombinedShortFlags reports whether word looks like combined short flags:
+// a dash followed by two to five letters.
+func isCombinedShortFlags(word string) bool {
+	const minLetters, maxLetters = 2, 5
+
+	letters := strings.TrimPrefix(word, "-")
+	if letters == word || len(letters) < minLetters || len(letters) > maxLetters {
+		return false
+	}
+
+	for _, r := range letters {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchAnyArg reports whether pattern matches any of args.
+func matchAnyArg(pattern Pattern, args []string) bool {
+	for _, arg := range args {
+		if pattern.Match(arg) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,231 @@
+package rules
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// ErrInvalidTimeWindow is returned for time windows that cannot be parsed.
+var ErrInvalidTimeWindow = errors.New("invalid time window")
+
+// timeOfDayLayout is the layout of time window start and end times.
+const timeOfDayLayout = "15:04"
+
+// minutesPerHour is the number of minutes in an hour.
+const minutesPerHour = 60
+
+// weekdays maps lowercase day abbreviations to weekdays.
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// EnvMatcher matches the value of an environment variable.
+type EnvMatcher struct {
+	name    string
+	pattern Pattern
+}
+
+// NewEnvMatcher creates a matcher for the environment variable name.
+func NewEnvMatcher(name, pattern string, opts PatternOptions) (*EnvMatcher, error) {
+	p, err := CompilePatternWithOptions(pattern, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "env %s", name)
+	}
+
+	return &EnvMatcher{name: name, pattern: p}, nil
+}
+
+// Match returns true if the variable is set and its value matches.
+func (m *EnvMatcher) Match(ctx *MatchContext) bool {
+	value, ok := ctx.lookupEnv(m.name)
+
+	return ok && m.pattern.Match(value)
+}
+
+// Name returns the matcher name.
+func (m *EnvMatcher) Name() string {
+	return "env:" + m.name + "=" + m.pattern.String()
+}
+
+// CWDMatcher matches the working directory of the hook.
+type CWDMatcher struct {
+	pattern Pattern
+}
+
+// NewCWDMatcher creates a matcher for the working directory.
+func NewCWDMatcher(pattern string, opts PatternOptions) (*CWDMatcher, error) {
+	p, err := CompilePatternWithOptions(pattern, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "cwd_pattern")
+	}
+
+	return &CWDMatcher{pattern: p}, nil
+}
+
+// Match returns true if the working directory matches the pattern.
+func (m *CWDMatcher) Match(ctx *MatchContext) bool {
+	dir := ctx.WorkingDir()
+
+	return dir != "" && m.pattern.Match(dir)
+}
+
+// Name returns the matcher name.
+func (m *CWDMatcher) Name() string {
+	return "cwd:" + m.pattern.String()
+}
+
+// TimeWindowMatcher matches the current time against a time window.
+type TimeWindowMatcher struct {
+	window   *TimeWindow
+	start    int
+	end      int
+	days     []time.Weekday
+	location *time.Location
+}
+
+// NewTimeWindowMatcher creates a matcher for the time window.
+func NewTimeWindowMatcher(window *TimeWindow) (*TimeWindowMatcher, error) {
+	m := &TimeWindowMatcher{
+		window:   window,
+		start:    -1,
+		end:      -1,
+		location: time.Local,
+	}
+
+	var err error
+
+	if m.start, err = parseTimeOfDay(window.Start); err != nil {
+		return nil, errors.Wrap(err, "start")
+	}
+
+	if m.end, err = parseTimeOfDay(window.End); err != nil {
+		return nil, errors.Wrap(err, "end")
+	}
+
+	if m.start >= 0 && m.start == m.end {
+		return nil, errors.Wrapf(ErrInvalidTimeWindow, "start and end are both %q", window.Start)
+	}
+
+	for _, day := range window.Days {
+		weekday, ok := weekdays[strings.ToLower(day)]
+		if !ok {
+			return nil, errors.Wrapf(ErrInvalidTimeWindow, "unknown day %q", day)
+		}
+
+		m.days = append(m.days, weekday)
+	}
+
+	if window.Timezone != "" {
+		if m.location, err = time.LoadLocation(window.Timezone); err != nil {
+			return nil, errors.Wrapf(ErrInvalidTimeWindow, "timezone %q: %v", window.Timezone, err)
+		}
+	}
+
+	return m, nil
+}
+
+// parseTimeOfDay parses "HH:MM" into minutes since midnight, or -1 if empty.
+func parseTimeOfDay(value string) (int, error) {
+	if value == "" {
+		return -1, nil
+	}
+
+	t, err := time.Parse(timeOfDayLayout, value)
+	if err != nil {
+		return 0, errors.Wrapf(ErrInvalidTimeWindow, "%q is not in HH:MM format", value)
+	}
+
+	return t.Hour()*minutesPerHour + t.Minute(), nil
+}
+
+// Match returns true if the current time is within the window.
+func (m *TimeWindowMatcher) Match(ctx *MatchContext) bool {
+	now := ctx.now().In(m.location)
+	minute := now.Hour()*minutesPerHour + now.Minute()
+	day := now.Weekday()
+
+	switch {
+	case m.start >= 0 && m.end >= 0 && m.end < m.start:
+		// Window spans midnight, times after midnight belong to the previous day.
+		switch {
+		case minute >= m.start:
+		case minute < m.end:
+			day = (day + 6) % 7 //nolint:mnd // previous weekday
+		default:
+			return false
+		}
+	case m.start >= 0 && minute < m.start:
+		return false
+	case m.end >= 0 && minute >= m.end:
+		return false
+	}
+
+	return len(m.days) == 0 || slices.Contains(m.days, day)
+}
+
+// Name returns the matcher name.
+func (m *TimeWindowMatcher) Name() string {
+	parts := []string{m.window.Start + "-" + m.window.End}
+
+	if len(m.window.Days) > 0 {
+		parts = append(parts, strings.Join(m.window.Days, ","))
+	}
+
+	if m.window.Timezone != "" {
+		parts = append(parts, m.window.Timezone)
+	}
+
+	return "time(" + strings.Join(parts, " ") + ")"
+}
+
+// SessionCommandsMatcher matches the number of commands recorded in the
+// current session. It never matches without session data.
+type SessionCommandsMatcher struct {
+	min *int
+	max *int
+}
+
+// NewSessionCommandsMatcher creates a matcher for the session command count.
+func NewSessionCommandsMatcher(minCommands, maxCommands *int) *SessionCommandsMatcher {
+	return &SessionCommandsMatcher{min: minCommands, max: maxCommands}
+}
+
+// Match returns true if the session command count is within the bounds.
+func (m *SessionCommandsMatcher) Match(ctx *MatchContext) bool {
+	if ctx.Session == nil {
+		return false
+	}
+
+	count := ctx.Session.CommandCount
+
+	if m.min != nil && count < *m.min {
+		return false
+	}
+
+	return m.max == nil || count <= *m.max
+}
+
+// Name returns the matcher name.
+func (m *SessionCommandsMatcher) Name() string {
+	var parts []string
+
+	if m.min != nil {
+		parts = append(parts, "min="+strconv.Itoa(*m.min))
+	}
+
+	if m.max != nil {
+		parts = append(parts, "max="+strconv.Itoa(*m.max))
+	}
+
+	return "session_commands(" + strings.Join(parts, " ") + ")"
+}
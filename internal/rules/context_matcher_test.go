@@ -0,0 +1,182 @@
+package rules_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+)
+
+var _ = Describe("Context matchers", func() {
+	build := func(match *rules.RuleMatch) rules.Matcher {
+		matcher, err := rules.BuildMatcher(match)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matcher).NotTo(BeNil())
+
+		return matcher
+	}
+
+	Describe("env", func() {
+		env := func(vars map[string]string) func(string) (string, bool) {
+			return func(key string) (string, bool) {
+				value, ok := vars[key]
+
+				return value, ok
+			}
+		}
+
+		DescribeTable("matches environment variables",
+			func(vars map[string]string, expected bool) {
+				matcher := build(&rules.RuleMatch{
+					Env: map[string]string{"AWS_PROFILE": "prod-*", "CI": "!true"},
+				})
+
+				Expect(matcher.Match(&rules.MatchContext{LookupEnv: env(vars)})).To(Equal(expected))
+			},
+			Entry("all match", map[string]string{"AWS_PROFILE": "prod-eu", "CI": "false"}, true),
+			Entry("value does not match", map[string]string{"AWS_PROFILE": "dev", "CI": "false"}, false),
+			Entry("negated value", map[string]string{"AWS_PROFILE": "prod-eu", "CI": "true"}, false),
+			Entry("unset variable", map[string]string{"AWS_PROFILE": "prod-eu"}, false),
+		)
+
+		It("describes its condition", func() {
+			matcher := build(&rules.RuleMatch{Env: map[string]string{"AWS_PROFILE": "prod-*"}})
+			Expect(matcher.Name()).To(Equal("env:AWS_PROFILE=prod-*"))
+		})
+	})
+
+	Describe("cwd_pattern", func() {
+		It("matches the hook working directory", func() {
+			matcher := build(&rules.RuleMatch{CWDPattern: "**/prod-infra/**"})
+
+			inDir := func(dir string) bool {
+				return matcher.Match(&rules.MatchContext{
+					HookContext: &hook.Context{CWD: dir},
+				})
+			}
+
+			Expect(inDir("/home/dev/prod-infra/modules")).To(BeTrue())
+			Expect(inDir("/home/dev/staging-infra/modules")).To(BeFalse())
+		})
+	})
+
+	Describe("time_window", func() {
+		// 2026-03-02 is a Monday.
+		at := func(value string) *rules.MatchContext {
+			t, err := time.Parse(time.RFC3339, value)
+			Expect(err).NotTo(HaveOccurred())
+
+			return &rules.MatchContext{Now: func() time.Time { return t }}
+		}
+
+		DescribeTable("working hours",
+			func(now string, expected bool) {
+				matcher := build(&rules.RuleMatch{TimeWindow: &rules.TimeWindow{
+					Start:    "09:00",
+					End:      "18:00",
+					Days:     []string{"mon", "tue", "wed", "thu", "fri"},
+					Timezone: "UTC",
+				}})
+
+				Expect(matcher.Match(at(now))).To(Equal(expected))
+			},
+			Entry("start is inclusive", "2026-03-02T09:00:00Z", true),
+			Entry("end is exclusive", "2026-03-02T18:00:00Z", false),
+			Entry("before start", "2026-03-02T08:59:00Z", false),
+			Entry("weekend", "2026-03-07T12:00:00Z", false),
+			Entry("converted to the window timezone", "2026-03-02T08:00:00-05:00", true),
+			Entry("outside the window in its timezone", "2026-03-02T12:00:00+05:00", false),
+		)
+
+		DescribeTable("windows spanning midnight",
+			func(now string, expected bool) {
+				matcher := build(&rules.RuleMatch{TimeWindow: &rules.TimeWindow{
+					Start:    "22:00",
+					End:      "06:00",
+					Days:     []string{"fri"},
+					Timezone: "UTC",
+				}})
+
+				Expect(matcher.Match(at(now))).To(Equal(expected))
+			},
+			Entry("friday night", "2026-03-06T23:00:00Z", true),
+			Entry("early saturday belongs to friday", "2026-03-07T05:59:00Z", true),
+			Entry("early friday belongs to thursday", "2026-03-06T03:00:00Z", false),
+			Entry("friday afternoon", "2026-03-06T15:00:00Z", false),
+		)
+
+		It("matches whole days without start and end", func() {
+			matcher := build(&rules.RuleMatch{TimeWindow: &rules.TimeWindow{
+				Days:     []string{"Sat", "sun"},
+				Timezone: "UTC",
+			}})
+
+			Expect(matcher.Match(at("2026-03-07T00:00:00Z"))).To(BeTrue())
+			Expect(matcher.Match(at("2026-03-02T12:00:00Z"))).To(BeFalse())
+		})
+
+		DescribeTable("rejects invalid windows",
+			func(window *rules.TimeWindow) {
+				_, err := rules.BuildMatcher(&rules.RuleMatch{TimeWindow: window})
+				Expect(err).To(MatchError(rules.ErrInvalidTimeWindow))
+			},
+			Entry("bad start", &rules.TimeWindow{Start: "9am"}),
+			Entry("bad end", &rules.TimeWindow{End: "25:00"}),
+			Entry("empty window", &rules.TimeWindow{Start: "09:00", End: "09:00"}),
+			Entry("unknown day", &rules.TimeWindow{Days: []string{"monday"}}),
+			Entry("unknown timezone", &rules.TimeWindow{Timezone: "Mars/Olympus"}),
+		)
+	})
+
+	Describe("session commands", func() {
+		minCommands, maxCommands := 2, 5
+
+		DescribeTable("matches the session command count",
+			func(session *rules.SessionContext, expected bool) {
+				matcher := build(&rules.RuleMatch{
+					MinSessionCommands: &minCommands,
+					MaxSessionCommands: &maxCommands,
+				})
+
+				Expect(matcher.Match(&rules.MatchContext{Session: session})).To(Equal(expected))
+			},
+			Entry("within bounds", &rules.SessionContext{CommandCount: 3}, true),
+			Entry("at minimum", &rules.SessionContext{CommandCount: 2}, true),
+			Entry("at maximum", &rules.SessionContext{CommandCount: 5}, true),
+			Entry("below minimum", &rules.SessionContext{CommandCount: 1}, false),
+			Entry("above maximum", &rules.SessionContext{CommandCount: 6}, false),
+			Entry("without session data", nil, false),
+		)
+
+		It("takes session data from the engine's session provider", func() {
+			engine, err := rules.NewRuleEngine([]*rules.Rule{{
+				Name:    "fresh-session",
+				Enabled: true,
+				Match:   &rules.RuleMatch{MaxSessionCommands: new(int)},
+				Action:  &rules.RuleAction{Type: rules.ActionWarn},
+			}}, rules.WithSessionProvider(func(id string) *rules.SessionContext {
+				return &rules.SessionContext{ID: id, CommandCount: len(id)}
+			}))
+			Expect(err).NotTo(HaveOccurred())
+
+			evaluate := func(sessionID string) bool {
+				return engine.Evaluate(context.Background(), &rules.MatchContext{
+					HookContext: &hook.Context{SessionID: sessionID},
+				}).Matched
+			}
+
+			Expect(evaluate("")).To(BeFalse())
+			Expect(evaluate("abc")).To(BeFalse())
+
+			engine.SetSessionProvider(func(id string) *rules.SessionContext {
+				return &rules.SessionContext{ID: id}
+			})
+
+			Expect(evaluate("abc")).To(BeTrue())
+		})
+	})
+})
@@ -9,9 +9,16 @@ import (
 
 // RuleEngine is the main implementation of the Engine interface.
 type RuleEngine struct {
-	registry  *Registry
-	evaluator *Evaluator
-	logger    logger.Logger
+	registry    *Registry
+	evaluator   *Evaluator
+	logger      logger.Logger
+	auditLogger *AuditLogger
+
+	// sessionProvider supplies session data for session conditions.
+	sessionProvider SessionProvider
+
+	// gitProvider loads git data for git conditions.
+	gitProvider GitProvider
 
 	// Configuration options.
 	stopOnFirstMatch bool
@@ -28,6 +35,30 @@ func WithLogger(log logger.Logger) EngineOption {
 	}
 }
 
+// WithAuditLogger sets the audit logger that records matches of rules with
+// the log action. Without it, log rules only produce debug log lines.
+func WithAuditLogger(auditLogger *AuditLogger) EngineOption {
+	return func(e *RuleEngine) {
+		e.auditLogger = auditLogger
+	}
+}
+
+// WithSessionProvider sets the provider of session data for rules with
+// session conditions.
+func WithSessionProvider(provider SessionProvider) EngineOption {
+	return func(e *RuleEngine) {
+		e.sessionProvider = provider
+	}
+}
+
+// WithGitProvider sets the provider of git data for rules with git
+// conditions. Git data is only loaded when such a rule is evaluated.
+func WithGitProvider(provider GitProvider) EngineOption {
+	return func(e *RuleEngine) {
+		e.gitProvider = provider
+	}
+}
+
 // WithEngineStopOnFirstMatch configures the engine to stop after the first match.
 func WithEngineStopOnFirstMatch(stop bool) EngineOption {
 	return func(e *RuleEngine) {
@@ -76,8 +107,17 @@ func NewRuleEngine(rules []*Rule, opts ...EngineOption) (*RuleEngine, error) {
 }
 
 // Evaluate evaluates rules against the given match context.
-func (e *RuleEngine) Evaluate(_ context.Context, matchCtx *MatchContext) *RuleResult {
-	result := e.evaluator.Evaluate(matchCtx)
+// If ctx carries a Tracer (see WithTracer), every evaluated rule is reported to it.
+func (e *RuleEngine) Evaluate(ctx context.Context, matchCtx *MatchContext) *RuleResult {
+	e.populateSession(matchCtx)
+
+	if matchCtx.gitProvider == nil {
+		matchCtx.gitProvider = e.gitProvider
+	}
+
+	result := e.evaluator.evaluate(matchCtx, tracerFrom(ctx), invocationFrom(ctx))
+
+	e.recordLogged(invocationFrom(ctx), matchCtx, result.Logged)
 
 	if result.Matched {
 		e.logger.Debug("rule matched",
@@ -85,11 +125,117 @@ func (e *RuleEngine) Evaluate(_ context.Context, matchCtx *MatchContext) *RuleRe
 			"action", result.Action,
 			"validator", matchCtx.ValidatorType,
 		)
+
+		result.Message = e.renderMessage(result.Rule, matchCtx)
+	}
+
+	if result.Matched && result.Action == ActionSuggest {
+		result.Suggestion = e.renderSuggestion(result.Rule, matchCtx)
 	}
 
 	return result
 }
 
+// SetSessionProvider sets the provider of session data. It must be called
+// before rules are evaluated, as the engine may be used concurrently.
+func (e *RuleEngine) SetSessionProvider(provider SessionProvider) {
+	e.sessionProvider = provider
+}
+
+// SetAuditLogger sets the audit logger that records matches of rules with the
+// log action. It must be called before rules are evaluated, as the engine may
+// be used concurrently.
+func (e *RuleEngine) SetAuditLogger(auditLogger *AuditLogger) {
+	e.auditLogger = auditLogger
+}
+
+// SetStatsRecorder sets the recorder of rule hit statistics and tracks the
+// enabled rules in it. It must be called before rules are evaluated, as the
+// engine may be used concurrently.
+func (e *RuleEngine) SetStatsRecorder(recorder *StatsRecorder) {
+	for _, compiled := range e.registry.GetEnabled() {
+		recorder.Track(compiled.Rule.Name)
+	}
+
+	e.evaluator.stats = recorder
+}
+
+// populateSession sets the session data of matchCtx from the session provider.
+func (e *RuleEngine) populateSession(matchCtx *MatchContext) {
+	if matchCtx.Session != nil || e.sessionProvider == nil ||
+		matchCtx.HookContext == nil || !matchCtx.HookContext.HasSessionID() {
+		return
+	}
+
+	matchCtx.Session = e.sessionProvider(matchCtx.HookContext.SessionID)
+}
+
+// recordLogged writes the matches of rules with the log action to the audit
+// log, once per hook invocation.
+func (e *RuleEngine) recordLogged(inv *invocation, matchCtx *MatchContext, logged []*Rule) {
+	for _, rule := range logged {
+		if !inv.first("log:" + rule.Name) {
+			continue
+		}
+
+		e.logger.Debug("rule logged",
+			"rule", rule.Name,
+			"validator", matchCtx.ValidatorType,
+		)
+
+		if e.auditLogger == nil {
+			continue
+		}
+
+		if err := e.auditLogger.Record(rule, matchCtx); err != nil {
+			e.logger.Error("failed to write rules audit entry",
+				"rule", rule.Name,
+				"error", err,
+			)
+		}
+	}
+}
+
+// renderSuggestion renders the suggestion of a rule with the suggest action.
+// Returns an empty string if the template fails, so the rule still blocks.
+func (e *RuleEngine) renderSuggestion(rule *Rule, matchCtx *MatchContext) string {
+	compiled := e.registry.Get(rule.Name)
+	if compiled == nil {
+		return ""
+	}
+
+	suggestion, err := compiled.renderSuggestion(matchCtx)
+	if err != nil {
+		e.logger.Error("failed to render rule suggestion",
+			"rule", rule.Name,
+			"error", err,
+		)
+	}
+
+	return suggestion
+}
+
+// renderMessage renders the message of a matched rule. Returns the raw
+// message if the template fails, so the rule still takes its action.
+func (e *RuleEngine) renderMessage(rule *Rule, matchCtx *MatchContext) string {
+	compiled := e.registry.Get(rule.Name)
+	if compiled == nil {
+		return rule.Action.Message
+	}
+
+	message, err := compiled.renderMessage(matchCtx)
+	if err != nil {
+		e.logger.Error("failed to render rule message",
+			"rule", rule.Name,
+			"error", err,
+		)
+
+		return rule.Action.Message
+	}
+
+	return message
+}
+
 // EvaluateHook evaluates rules for a hook context with additional git/file context.
 // This is a convenience method that builds the match context from hook context.
 func (e *RuleEngine) EvaluateHook(
@@ -157,6 +303,12 @@ func (e *RuleEngine) GetEnabledRules() []*Rule {
 	return rules
 }
 
+// RunTests runs the tests of all enabled rules. Rule tests are evaluated
+// without the git and session providers, using only their own input.
+func (e *RuleEngine) RunTests() []*RuleTestResult {
+	return e.evaluator.RunTests()
+}
+
 // Size returns the number of rules.
 func (e *RuleEngine) Size() int {
 	return e.registry.Size()
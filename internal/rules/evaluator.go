@@ -10,6 +10,9 @@ type Evaluator struct {
 
 	// defaultAction is the action to take when no rules match.
 	defaultAction ActionType
+
+	// stats records rule hit statistics, if set.
+	stats *StatsRecorder
 }
 
 // EvaluatorOption configures an Evaluator.
@@ -29,6 +32,13 @@ func WithDefaultAction(action ActionType) EvaluatorOption {
 	}
 }
 
+// WithStatsRecorder sets the recorder of rule hit statistics.
+func WithStatsRecorder(recorder *StatsRecorder) EvaluatorOption {
+	return func(e *Evaluator) {
+		e.stats = recorder
+	}
+}
+
 // NewEvaluator creates a new rule evaluator.
 func NewEvaluator(registry *Registry, opts ...EvaluatorOption) *Evaluator {
 	e := &Evaluator{
@@ -48,6 +58,12 @@ func NewEvaluator(registry *Registry, opts ...EvaluatorOption) *Evaluator {
 // Returns the result of the first matching rule (if stopOnFirstMatch is true)
 // or the highest priority matching rule.
 func (e *Evaluator) Evaluate(ctx *MatchContext) *RuleResult {
+	return e.evaluate(ctx, nil, nil)
+}
+
+// evaluate implements Evaluate, reporting every evaluated rule to tracer if
+// set. Statistics are recorded once per invocation if inv is set.
+func (e *Evaluator) evaluate(ctx *MatchContext, tracer Tracer, inv *invocation) *RuleResult {
 	if e.registry == nil {
 		return &RuleResult{
 			Matched: false,
@@ -63,23 +79,103 @@ func (e *Evaluator) Evaluate(ctx *MatchContext) *RuleResult {
 		}
 	}
 
-	// Rules are already sorted by priority (highest first).
+	var (
+		logged    []*Rule
+		decidedBy *Rule
+	)
+
+	// Rules are already sorted by priority (highest first). Log rules do not
+	// affect the decision, so they are evaluated even after a rule decided.
 	for _, compiled := range rules {
-		if compiled.Matcher.Match(ctx) {
-			return &RuleResult{
-				Matched:   true,
-				Rule:      compiled.Rule,
-				Action:    compiled.Rule.Action.Type,
-				Message:   compiled.Rule.Action.Message,
-				Reference: compiled.Rule.Action.Reference,
-			}
+		if ctx.scopedOnly && !compiled.Rule.isScoped() {
+			continue
+		}
+
+		isLog := compiled.Rule.Action.Type == ActionLog
+
+		if decidedBy != nil && !isLog {
+			e.recordShadowed(inv, ctx, compiled, decidedBy)
+
+			continue
+		}
+
+		if !e.match(ctx, compiled, tracer) {
+			continue
+		}
+
+		// Log rules are recorded without affecting the decision.
+		if isLog {
+			logged = append(logged, compiled.Rule)
+			e.recordMatch(inv, compiled.Rule)
+
+			continue
+		}
+
+		decidedBy = compiled.Rule
+		e.recordMatch(inv, decidedBy)
+	}
+
+	if decidedBy == nil {
+		return &RuleResult{
+			Matched: false,
+			Action:  e.defaultAction,
+			Logged:  logged,
 		}
 	}
 
-	// No rules matched.
 	return &RuleResult{
-		Matched: false,
-		Action:  e.defaultAction,
+		Matched:   true,
+		Rule:      decidedBy,
+		Action:    decidedBy.Action.Type,
+		Message:   decidedBy.Action.Message,
+		Reference: decidedBy.Action.Reference,
+		Logged:    logged,
+	}
+}
+
+// match reports whether the compiled rule matches ctx, reporting the
+// evaluation to tracer if set.
+func (*Evaluator) match(ctx *MatchContext, compiled *CompiledRule, tracer Tracer) bool {
+	matched := compiled.Matcher.Match(ctx)
+
+	if tracer != nil {
+		evaluation := &Evaluation{
+			Rule:          compiled.Rule,
+			ValidatorType: ctx.ValidatorType,
+			Matched:       matched,
+		}
+
+		if !matched {
+			evaluation.FailedMatcher = FailedMatcher(compiled.Matcher, ctx)
+		}
+
+		tracer.TraceRule(evaluation)
+	}
+
+	return matched
+}
+
+// recordMatch records a match of rule in the statistics, once per invocation.
+func (e *Evaluator) recordMatch(inv *invocation, rule *Rule) {
+	if e.stats != nil && inv.first("match:"+rule.Name) {
+		e.stats.RecordMatch(rule, rule.Action.Type)
+	}
+}
+
+// recordShadowed records compiled as shadowed by the deciding rule if it
+// also matches ctx, once per invocation.
+func (e *Evaluator) recordShadowed(
+	inv *invocation,
+	ctx *MatchContext,
+	compiled *CompiledRule,
+	decidedBy *Rule,
+) {
+	if e.stats == nil || !compiled.Matcher.Match(ctx) {
+		return
+	}
+
+	if inv.first("shadowed:" + compiled.Rule.Name + "\x00" + decidedBy.Name) {
+		e.stats.RecordShadowed(compiled.Rule, decidedBy)
 	}
 }
 
@@ -171,3 +267,9 @@ func (e *Evaluator) FilterByValidator(validatorType ValidatorType) []*CompiledRu
 
 	return filtered
 }
+
+// isScoped reports whether the rule is restricted to specific validators,
+// i.e., has a validator_type other than "*".
+func (r *Rule) isScoped() bool {
+	return r.Match != nil && r.Match.ValidatorType != "" && r.Match.ValidatorType != ValidatorAll
+}
@@ -0,0 +1,182 @@
+package rules
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/internal/git"
+)
+
+// StagedFilesMatcher matches staged files against file patterns.
+type StagedFilesMatcher struct {
+	patterns []Pattern
+}
+
+// NewStagedFilesMatcher creates a matcher that requires at least one staged
+// file to match one of the patterns.
+func NewStagedFilesMatcher(patterns []string, opts PatternOptions) (*StagedFilesMatcher, error) {
+	m := &StagedFilesMatcher{}
+
+	for _, pattern := range patterns {
+		p, err := CompilePatternWithOptions(pattern, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "staged_files")
+		}
+
+		m.patterns = append(m.patterns, p)
+	}
+
+	return m, nil
+}
+
+// Match returns true if a staged file matches one of the patterns.
+func (m *StagedFilesMatcher) Match(ctx *MatchContext) bool {
+	state := ctx.gitState()
+	if state == nil {
+		return false
+	}
+
+	for _, file := range state.StagedFiles {
+		for _, p := range m.patterns {
+			if p.Match(file) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Name returns the matcher name.
+func (m *StagedFilesMatcher) Name() string {
+	names := make([]string, 0, len(m.patterns))
+	for _, p := range m.patterns {
+		names = append(names, p.String())
+	}
+
+	return "staged_files:" + strings.Join(names, ",")
+}
+
+// DirtyMatcher matches whether the working tree has uncommitted changes.
+type DirtyMatcher struct {
+	dirty bool
+}
+
+// NewDirtyMatcher creates a matcher for a dirty (true) or clean (false) tree.
+func NewDirtyMatcher(dirty bool) *DirtyMatcher {
+	return &DirtyMatcher{dirty: dirty}
+}
+
+// Match returns true if the tree state is as required.
+func (m *DirtyMatcher) Match(ctx *MatchContext) bool {
+	state := ctx.gitState()
+
+	return state != nil && state.IsDirty() == m.dirty
+}
+
+// Name returns the matcher name.
+func (m *DirtyMatcher) Name() string {
+	return "dirty:" + strconv.FormatBool(m.dirty)
+}
+
+// CommitsAheadMatcher matches the number of commits the current branch is
+// ahead of its upstream. It never matches branches without an upstream.
+type CommitsAheadMatcher struct {
+	min *int
+	max *int
+}
+
+// NewCommitsAheadMatcher creates a matcher for the commits ahead count.
+func NewCommitsAheadMatcher(minAhead, maxAhead *int) *CommitsAheadMatcher {
+	return &CommitsAheadMatcher{min: minAhead, max: maxAhead}
+}
+
+// Match returns true if the commits ahead count is within the bounds.
+func (m *CommitsAheadMatcher) Match(ctx *MatchContext) bool {
+	state := ctx.gitState()
+	if state == nil || !state.HasUpstream {
+		return false
+	}
+
+	if m.min != nil && state.CommitsAhead < *m.min {
+		return false
+	}
+
+	return m.max == nil || state.CommitsAhead <= *m.max
+}
+
+// Name returns the matcher name.
+func (m *CommitsAheadMatcher) Name() string {
+	var parts []string
+
+	if m.min != nil {
+		parts = append(parts, "min="+strconv.Itoa(*m.min))
+	}
+
+	if m.max != nil {
+		parts = append(parts, "max="+strconv.Itoa(*m.max))
+	}
+
+	return "commits_ahead(" + strings.Join(parts, " ") + ")"
+}
+
+// RemoteURLPart is a part of a remote URL matched by RemoteURLMatcher.
+type RemoteURLPart string
+
+// Remote URL parts.
+const (
+	RemoteURLHost  RemoteURLPart = "host"
+	RemoteURLOwner RemoteURLPart = "owner"
+	RemoteURLRepo  RemoteURLPart = "repo"
+)
+
+// RemoteURLMatcher matches a part of the remote URL.
+type RemoteURLMatcher struct {
+	part    RemoteURLPart
+	pattern Pattern
+}
+
+// NewRemoteURLMatcher creates a matcher for a part of the remote URL.
+func NewRemoteURLMatcher(
+	part RemoteURLPart,
+	pattern string,
+	opts PatternOptions,
+) (*RemoteURLMatcher, error) {
+	p, err := CompilePatternWithOptions(pattern, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "remote_%s", part)
+	}
+
+	return &RemoteURLMatcher{part: part, pattern: p}, nil
+}
+
+// Match returns true if the remote URL part matches the pattern.
+func (m *RemoteURLMatcher) Match(ctx *MatchContext) bool {
+	gitCtx := ctx.git()
+	if gitCtx == nil || gitCtx.RemoteURL == "" {
+		return false
+	}
+
+	remote, err := git.ParseRemoteURL(gitCtx.RemoteURL)
+	if err != nil {
+		return false
+	}
+
+	switch m.part {
+	case RemoteURLHost:
+		return m.pattern.Match(remote.Host)
+	case RemoteURLOwner:
+		return m.pattern.Match(remote.Owner)
+	case RemoteURLRepo:
+		return m.pattern.Match(remote.Repo)
+	default:
+		return false
+	}
+}
+
+// Name returns the matcher name.
+func (m *RemoteURLMatcher) Name() string {
+	return "remote_" + string(m.part) + ":" + m.pattern.String()
+}
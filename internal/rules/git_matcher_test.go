@@ -0,0 +1,234 @@
+package rules_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/git"
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+)
+
+var _ = Describe("Git state matchers", func() {
+	build := func(match *rules.RuleMatch) rules.Matcher {
+		matcher, err := rules.BuildMatcher(match)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matcher).NotTo(BeNil())
+
+		return matcher
+	}
+
+	withState := func(state *rules.GitState) *rules.MatchContext {
+		return &rules.MatchContext{GitState: state}
+	}
+
+	Describe("staged_files", func() {
+		DescribeTable("matches staged files",
+			func(staged []string, expected bool) {
+				matcher := build(&rules.RuleMatch{StagedFiles: []string{"**/*.tf", "go.sum"}})
+				Expect(matcher.Match(withState(&rules.GitState{StagedFiles: staged}))).
+					To(Equal(expected))
+			},
+			Entry("glob match", []string{"README.md", "infra/main.tf"}, true),
+			Entry("exact match", []string{"go.sum"}, true),
+			Entry("no match", []string{"README.md"}, false),
+			Entry("nothing staged", nil, false),
+		)
+
+		It("does not match without git state", func() {
+			matcher := build(&rules.RuleMatch{StagedFiles: []string{"*"}})
+			Expect(matcher.Match(&rules.MatchContext{})).To(BeFalse())
+		})
+	})
+
+	Describe("dirty", func() {
+		DescribeTable("matches the working tree state",
+			func(dirty bool, state *rules.GitState, expected bool) {
+				matcher := build(&rules.RuleMatch{Dirty: &dirty})
+				Expect(matcher.Match(withState(state))).To(Equal(expected))
+			},
+			Entry("dirty with staged files", true, &rules.GitState{StagedFiles: []string{"a"}}, true),
+			Entry("dirty with modified files", true, &rules.GitState{ModifiedFiles: []string{"a"}}, true),
+			Entry("dirty with untracked files", true, &rules.GitState{UntrackedFiles: []string{"a"}}, true),
+			Entry("dirty on clean tree", true, &rules.GitState{}, false),
+			Entry("clean on clean tree", false, &rules.GitState{}, true),
+			Entry("clean on dirty tree", false, &rules.GitState{ModifiedFiles: []string{"a"}}, false),
+			Entry("unknown state", false, nil, false),
+		)
+	})
+
+	Describe("commits ahead", func() {
+		minAhead, maxAhead := 1, 3
+
+		DescribeTable("matches the commits ahead count",
+			func(state *rules.GitState, expected bool) {
+				matcher := build(&rules.RuleMatch{
+					MinCommitsAhead: &minAhead,
+					MaxCommitsAhead: &maxAhead,
+				})
+				Expect(matcher.Match(withState(state))).To(Equal(expected))
+			},
+			Entry("within bounds", &rules.GitState{HasUpstream: true, CommitsAhead: 2}, true),
+			Entry("below minimum", &rules.GitState{HasUpstream: true, CommitsAhead: 0}, false),
+			Entry("above maximum", &rules.GitState{HasUpstream: true, CommitsAhead: 4}, false),
+			Entry("no upstream", &rules.GitState{CommitsAhead: 2}, false),
+		)
+
+		It("describes its condition", func() {
+			matcher := build(&rules.RuleMatch{MinCommitsAhead: &minAhead})
+			Expect(matcher.Name()).To(Equal("commits_ahead(min=1)"))
+		})
+	})
+
+	Describe("remote URL", func() {
+		DescribeTable("matches parts of the remote URL",
+			func(match *rules.RuleMatch, remoteURL string, expected bool) {
+				matcher := build(match)
+				Expect(matcher.Match(&rules.MatchContext{
+					GitContext: &rules.GitContext{IsInRepo: true, RemoteURL: remoteURL},
+				})).To(Equal(expected))
+			},
+			Entry("host of SCP-like URL",
+				&rules.RuleMatch{RemoteHost: "github.com"}, "git@github.com:Kong/kong.git", true),
+			Entry("owner of HTTPS URL",
+				&rules.RuleMatch{RemoteOwner: "Kong"}, "https://github.com/Kong/kong.git", true),
+			Entry("owner mismatch",
+				&rules.RuleMatch{RemoteOwner: "Kong"}, "https://github.com/smykla-labs/kong.git", false),
+			Entry("repo without .git suffix",
+				&rules.RuleMatch{RemoteRepo: "kong"}, "ssh://git@github.com/Kong/kong.git", true),
+			Entry("all parts",
+				&rules.RuleMatch{RemoteHost: "github.com", RemoteOwner: "Kong", RemoteRepo: "k*"},
+				"git@github.com:Kong/kong.git", true),
+			Entry("no remote URL",
+				&rules.RuleMatch{RemoteHost: "github.com"}, "", false),
+			Entry("unparseable remote URL",
+				&rules.RuleMatch{RemoteHost: "github.com"}, "not a url", false),
+		)
+	})
+
+	Describe("git provider", func() {
+		var runner *git.FakeRunner
+
+		BeforeEach(func() {
+			runner = git.NewFakeRunner()
+			runner.Remotes["upstream"] = "git@github.com:Kong/kong.git"
+		})
+
+		evaluate := func(match *rules.RuleMatch, command string) bool {
+			engine, err := rules.NewRuleEngine([]*rules.Rule{{
+				Name:    "git-rule",
+				Enabled: true,
+				Match:   match,
+				Action:  &rules.RuleAction{Type: rules.ActionBlock},
+			}}, rules.WithGitProvider(rules.NewGitProvider(runner)))
+			Expect(err).NotTo(HaveOccurred())
+
+			return engine.Evaluate(context.Background(), &rules.MatchContext{
+				HookContext: &hook.Context{
+					ToolName:  hook.ToolTypeBash,
+					ToolInput: hook.ToolInput{Command: command},
+				},
+				Command: command,
+			}).Matched
+		}
+
+		It("uses the remote named by the command", func() {
+			match := &rules.RuleMatch{RemoteOwner: "Kong"}
+
+			Expect(evaluate(match, "git push upstream main")).To(BeTrue())
+			Expect(evaluate(match, "git push -u origin main")).To(BeFalse())
+		})
+
+		It("falls back to the tracking remote of the current branch", func() {
+			runner.BranchRemotes["main"] = "upstream"
+
+			Expect(evaluate(&rules.RuleMatch{RemoteOwner: "Kong"}, "git push")).To(BeTrue())
+		})
+
+		It("fills the branch for branch patterns", func() {
+			runner.CurrentBranch = "release-1.0"
+			runner.BranchRemotes["release-1.0"] = "origin"
+
+			Expect(evaluate(&rules.RuleMatch{BranchPattern: "release-*"}, "git push")).To(BeTrue())
+		})
+
+		It("loads the working tree state", func() {
+			runner.StagedFiles = []string{"secrets/prod.env"}
+			runner.CommitsAhead["main"] = 2
+
+			dirty := true
+			minAhead := 2
+
+			Expect(evaluate(&rules.RuleMatch{
+				StagedFiles:     []string{"secrets/**"},
+				Dirty:           &dirty,
+				MinCommitsAhead: &minAhead,
+			}, "git commit -m wip")).To(BeTrue())
+		})
+
+		It("does not match outside a repository", func() {
+			runner.InRepo = false
+
+			dirty := false
+
+			Expect(evaluate(&rules.RuleMatch{Dirty: &dirty}, "ls")).To(BeFalse())
+			Expect(evaluate(&rules.RuleMatch{RemoteHost: "*"}, "ls")).To(BeFalse())
+		})
+
+		It("uses a runner for the working directory of the hook", func() {
+			repoRunner := git.NewFakeRunner()
+			repoRunner.CurrentBranch = "release-1.0"
+			runner.CurrentBranch = "main"
+
+			var dirs []string
+
+			engine, err := rules.NewRuleEngine([]*rules.Rule{{
+				Name:    "release",
+				Enabled: true,
+				Match:   &rules.RuleMatch{BranchPattern: "release-*"},
+				Action:  &rules.RuleAction{Type: rules.ActionBlock},
+			}}, rules.WithGitProvider(rules.NewGitProvider(
+				runner,
+				rules.WithRunnerForDir(func(dir string) git.Runner {
+					dirs = append(dirs, dir)
+
+					return repoRunner
+				}),
+			)))
+			Expect(err).NotTo(HaveOccurred())
+
+			evaluateIn := func(cwd string) bool {
+				return engine.Evaluate(context.Background(), &rules.MatchContext{
+					HookContext: &hook.Context{
+						CWD:       cwd,
+						ToolName:  hook.ToolTypeBash,
+						ToolInput: hook.ToolInput{Command: "git push"},
+					},
+					Command: "git push",
+				}).Matched
+			}
+
+			Expect(evaluateIn("/repos/release")).To(BeTrue())
+			Expect(evaluateIn("/repos/release")).To(BeTrue())
+			Expect(evaluateIn("")).To(BeFalse())
+			Expect(dirs).To(Equal([]string{"/repos/release"}))
+		})
+
+		It("prefers git data set on the match context", func() {
+			engine, err := rules.NewRuleEngine([]*rules.Rule{{
+				Name:    "preset",
+				Enabled: true,
+				Match:   &rules.RuleMatch{BranchPattern: "feature/*"},
+				Action:  &rules.RuleAction{Type: rules.ActionBlock},
+			}}, rules.WithGitProvider(rules.NewGitProvider(runner)))
+			Expect(err).NotTo(HaveOccurred())
+
+			result := engine.Evaluate(context.Background(), &rules.MatchContext{
+				GitContext: &rules.GitContext{IsInRepo: true, Branch: "feature/x"},
+			})
+			Expect(result.Matched).To(BeTrue())
+		})
+	})
+})
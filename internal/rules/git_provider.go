@@ -0,0 +1,170 @@
+package rules
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/smykla-labs/klaudiush/internal/git"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
+)
+
+// defaultRemote is the remote assumed when neither the command nor the
+// branch configuration names one.
+const defaultRemote = "origin"
+
+// remoteSubcommands are git subcommands whose first positional argument is
+// a remote name.
+var remoteSubcommands = map[string]bool{
+	"push":  true,
+	"fetch": true,
+	"pull":  true,
+}
+
+// RunnerGitProvider loads git data for rule matching using a git.Runner.
+type RunnerGitProvider struct {
+	runner git.Runner
+
+	// runnerForDir creates a runner for the repository of a directory. Nil
+	// when runner is used for every directory.
+	runnerForDir func(dir string) git.Runner
+
+	mu         sync.Mutex
+	dirRunners map[string]git.Runner
+}
+
+// GitProviderOption configures a RunnerGitProvider.
+type GitProviderOption func(*RunnerGitProvider)
+
+// WithRunnerForDir loads git data from the working directory of the hook,
+// using a runner created by runnerForDir for each directory. The runner
+// passed to NewGitProvider is used when the hook has no working directory.
+func WithRunnerForDir(runnerForDir func(dir string) git.Runner) GitProviderOption {
+	return func(p *RunnerGitProvider) {
+		p.runnerForDir = runnerForDir
+	}
+}
+
+// NewGitProvider creates a GitProvider backed by runner. Use a cached runner
+// (see git.NewCachedRunner) to share results between validators.
+func NewGitProvider(runner git.Runner, opts ...GitProviderOption) *RunnerGitProvider {
+	p := &RunnerGitProvider{
+		runner:     runner,
+		dirRunners: make(map[string]git.Runner),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// runnerFor returns the runner for the working directory of the hook. Runners
+// are created once per directory.
+//
+//nolint:ireturn // Returns interface for flexibility between injected and path-specific runners
+func (p *RunnerGitProvider) runnerFor(ctx *MatchContext) git.Runner {
+	if p.runnerForDir == nil || ctx.HookContext == nil || ctx.HookContext.CWD == "" {
+		return p.runner
+	}
+
+	dir := ctx.WorkingDir()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	runner, ok := p.dirRunners[dir]
+	if !ok {
+		runner = p.runnerForDir(dir)
+		p.dirRunners[dir] = runner
+	}
+
+	return runner
+}
+
+// GitContext returns the repository, branch and remote data. The remote is
+// the one named by a git push, fetch or pull command, or else the tracking
+// remote of the current branch, or "origin".
+func (p *RunnerGitProvider) GitContext(ctx *MatchContext) *GitContext {
+	runner := p.runnerFor(ctx)
+	if !runner.IsInRepo() {
+		return nil
+	}
+
+	gitCtx := &GitContext{IsInRepo: true}
+	gitCtx.RepoRoot, _ = runner.GetRepoRoot()
+	gitCtx.Branch, _ = runner.GetCurrentBranch()
+	gitCtx.Remote = commandRemote(ctx)
+
+	if gitCtx.Remote == "" && gitCtx.Branch != "" {
+		gitCtx.Remote, _ = runner.GetBranchRemote(gitCtx.Branch)
+	}
+
+	if gitCtx.Remote == "" {
+		gitCtx.Remote = defaultRemote
+	}
+
+	gitCtx.RemoteURL, _ = runner.GetRemoteURL(gitCtx.Remote)
+
+	return gitCtx
+}
+
+// GitState returns the working tree status and the upstream of the current
+// branch. Returns nil if the status cannot be determined.
+func (p *RunnerGitProvider) GitState(ctx *MatchContext) *GitState {
+	runner := p.runnerFor(ctx)
+	if !runner.IsInRepo() {
+		return nil
+	}
+
+	state := &GitState{}
+
+	var err error
+
+	if state.StagedFiles, err = runner.GetStagedFiles(); err != nil {
+		return nil
+	}
+
+	if state.ModifiedFiles, err = runner.GetModifiedFiles(); err != nil {
+		return nil
+	}
+
+	if state.UntrackedFiles, err = runner.GetUntrackedFiles(); err != nil {
+		return nil
+	}
+
+	if branch, branchErr := runner.GetCurrentBranch(); branchErr == nil {
+		if ahead, aheadErr := runner.GetCommitsAhead(branch); aheadErr == nil {
+			state.HasUpstream = true
+			state.CommitsAhead = ahead
+		}
+	}
+
+	return state
+}
+
+// commandRemote returns the remote named by the first git push, fetch or
+// pull command, or "" if there is none.
+func commandRemote(ctx *MatchContext) string {
+	for _, cmd := range ctx.ParsedCommands() {
+		if cmd.Name != "git" {
+			continue
+		}
+
+		gitCmd, err := parser.ParseGitCommand(cmd)
+		if err != nil || !remoteSubcommands[gitCmd.Subcommand] {
+			continue
+		}
+
+		for _, arg := range gitCmd.Args {
+			if !strings.HasPrefix(arg, "-") {
+				return arg
+			}
+		}
+	}
+
+	return ""
+}
+
+// Verify interface compliance.
+var _ GitProvider = (*RunnerGitProvider)(nil)
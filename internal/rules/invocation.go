@@ -0,0 +1,54 @@
+package rules
+
+import (
+	"context"
+	"sync"
+)
+
+// invocationKey is the context key for the current hook invocation.
+type invocationKey struct{}
+
+// invocation tracks what was recorded for rules during a single hook
+// invocation. Several validators can evaluate the same rule for one hook
+// invocation, so its matches are recorded only once.
+type invocation struct {
+	mu       sync.Mutex
+	recorded map[string]bool
+}
+
+// WithInvocation returns a context for a single hook invocation. Rule
+// matches evaluated with it are written to the audit log and statistics once
+// per invocation, however many validators evaluate the rule.
+func WithInvocation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, invocationKey{}, &invocation{recorded: make(map[string]bool)})
+}
+
+// invocationFrom returns the invocation stored in ctx, or nil.
+func invocationFrom(ctx context.Context) *invocation {
+	if ctx == nil {
+		return nil
+	}
+
+	inv, _ := ctx.Value(invocationKey{}).(*invocation)
+
+	return inv
+}
+
+// first reports whether key is recorded for the first time in the
+// invocation. Without an invocation, every record is the first.
+func (inv *invocation) first(key string) bool {
+	if inv == nil {
+		return true
+	}
+
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	if inv.recorded[key] {
+		return false
+	}
+
+	inv.recorded[key] = true
+
+	return true
+}
@@ -1,8 +1,13 @@
 package rules
 
 import (
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/cockroachdb/errors"
+
 	"github.com/smykla-labs/klaudiush/pkg/hook"
 )
 
@@ -54,11 +59,12 @@ func NewRepoMultiPatternMatcher(
 
 // Match returns true if the repo root matches the pattern.
 func (m *RepoPatternMatcher) Match(ctx *MatchContext) bool {
-	if ctx.GitContext == nil || ctx.GitContext.RepoRoot == "" {
+	gitCtx := ctx.git()
+	if gitCtx == nil || gitCtx.RepoRoot == "" {
 		return false
 	}
 
-	return m.pattern.Match(ctx.GitContext.RepoRoot)
+	return m.pattern.Match(gitCtx.RepoRoot)
 }
 
 // Name returns the matcher name.
@@ -78,11 +84,12 @@ func NewRemoteMatcher(remote string) *RemoteMatcher {
 
 // Match returns true if the remote matches exactly.
 func (m *RemoteMatcher) Match(ctx *MatchContext) bool {
-	if ctx.GitContext == nil {
+	gitCtx := ctx.git()
+	if gitCtx == nil {
 		return false
 	}
 
-	return ctx.GitContext.Remote == m.remote
+	return gitCtx.Remote == m.remote
 }
 
 // Name returns the matcher name.
@@ -138,11 +145,12 @@ func NewBranchMultiPatternMatcher(
 
 // Match returns true if the branch matches the pattern.
 func (m *BranchPatternMatcher) Match(ctx *MatchContext) bool {
-	if ctx.GitContext == nil || ctx.GitContext.Branch == "" {
+	gitCtx := ctx.git()
+	if gitCtx == nil || gitCtx.Branch == "" {
 		return false
 	}
 
-	return m.pattern.Match(ctx.GitContext.Branch)
+	return m.pattern.Match(gitCtx.Branch)
 }
 
 // Name returns the matcher name.
@@ -662,6 +670,176 @@ func (b *matcherBuilder) addAdvancedPatternMatcher(
 	b.matchers = append(b.matchers, m)
 }
 
+// addParsedCommandMatcher adds a matcher for the parsed command conditions.
+func (b *matcherBuilder) addParsedCommandMatcher(match *RuleMatch) {
+	if b.err != nil {
+		return
+	}
+
+	m, err := NewParsedCommandMatcher(match, b.opts)
+	if err != nil {
+		b.err = err
+		return
+	}
+
+	if m != nil {
+		b.matchers = append(b.matchers, m)
+	}
+}
+
+// addContextMatchers adds matchers for the environment, working directory,
+// time window and session conditions.
+func (b *matcherBuilder) addContextMatchers(match *RuleMatch) {
+	if b.err != nil {
+		return
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(match.Env)) {
+		m, err := NewEnvMatcher(name, match.Env[name], b.opts)
+		if err != nil {
+			b.err = err
+			return
+		}
+
+		b.matchers = append(b.matchers, m)
+	}
+
+	if match.CWDPattern != "" {
+		m, err := NewCWDMatcher(match.CWDPattern, b.opts)
+		if err != nil {
+			b.err = err
+			return
+		}
+
+		b.matchers = append(b.matchers, m)
+	}
+
+	if match.TimeWindow != nil {
+		m, err := NewTimeWindowMatcher(match.TimeWindow)
+		if err != nil {
+			b.err = errors.Wrap(err, "time_window")
+			return
+		}
+
+		b.matchers = append(b.matchers, m)
+	}
+
+	if match.MinSessionCommands != nil || match.MaxSessionCommands != nil {
+		b.matchers = append(b.matchers,
+			NewSessionCommandsMatcher(match.MinSessionCommands, match.MaxSessionCommands))
+	}
+}
+
+// addGitMatchers adds matchers for the remote URL, staged files, dirty tree
+// and commits ahead conditions.
+func (b *matcherBuilder) addGitMatchers(match *RuleMatch) {
+	if b.err != nil {
+		return
+	}
+
+	remoteParts := []struct {
+		part    RemoteURLPart
+		pattern string
+	}{
+		{RemoteURLHost, match.RemoteHost},
+		{RemoteURLOwner, match.RemoteOwner},
+		{RemoteURLRepo, match.RemoteRepo},
+	}
+
+	for _, remotePart := range remoteParts {
+		if remotePart.pattern == "" {
+			continue
+		}
+
+		m, err := NewRemoteURLMatcher(remotePart.part, remotePart.pattern, b.opts)
+		if err != nil {
+			b.err = err
+			return
+		}
+
+		b.matchers = append(b.matchers, m)
+	}
+
+	if len(match.StagedFiles) > 0 {
+		m, err := NewStagedFilesMatcher(match.StagedFiles, b.opts)
+		if err != nil {
+			b.err = err
+			return
+		}
+
+		b.matchers = append(b.matchers, m)
+	}
+
+	if match.Dirty != nil {
+		b.matchers = append(b.matchers, NewDirtyMatcher(*match.Dirty))
+	}
+
+	if match.MinCommitsAhead != nil || match.MaxCommitsAhead != nil {
+		b.matchers = append(b.matchers,
+			NewCommitsAheadMatcher(match.MinCommitsAhead, match.MaxCommitsAhead))
+	}
+}
+
+// addNested adds matchers for the nested all, any and not blocks.
+// Nested blocks without conditions are ignored.
+func (b *matcherBuilder) addNested(match *RuleMatch) {
+	if b.err != nil {
+		return
+	}
+
+	all, err := buildNestedMatchers("all", match.All)
+	if err != nil {
+		b.err = err
+		return
+	}
+
+	// All children must match, which is what the builder does already.
+	b.matchers = append(b.matchers, all...)
+
+	anyOf, err := buildNestedMatchers("any", match.Any)
+	if err != nil {
+		b.err = err
+		return
+	}
+
+	if len(anyOf) > 0 {
+		b.matchers = append(b.matchers, NewOrMatcher(anyOf...))
+	}
+
+	if match.Not == nil {
+		return
+	}
+
+	not, err := BuildMatcher(match.Not)
+	if err != nil {
+		b.err = errors.Wrap(err, "not")
+		return
+	}
+
+	if not != nil {
+		b.matchers = append(b.matchers, NewNotMatcher(not))
+	}
+}
+
+// buildNestedMatchers compiles a list of nested match blocks, skipping
+// blocks without conditions.
+func buildNestedMatchers(key string, blocks []*RuleMatch) ([]Matcher, error) {
+	matchers := make([]Matcher, 0, len(blocks))
+
+	for i, block := range blocks {
+		m, err := BuildMatcher(block)
+		if err != nil {
+			return nil, errors.Wrap(err, key+"["+strconv.Itoa(i)+"]")
+		}
+
+		if m != nil {
+			matchers = append(matchers, m)
+		}
+	}
+
+	return matchers, nil
+}
+
 // result returns the final matcher or error.
 //
 //nolint:nilnil,ireturn // returning nil, nil is intentional; interface for polymorphism
@@ -785,7 +963,8 @@ func parsePatternMode(mode string) MultiPatternMode {
 }
 
 // BuildMatcher creates a composite matcher from RuleMatch conditions.
-// Returns nil if no conditions are specified.
+// Nested all, any and not blocks are compiled recursively into a
+// CompositeMatcher tree. Returns nil if no conditions are specified.
 //
 //nolint:nilnil,ireturn // returning nil, nil is intentional; interface for polymorphism
 func BuildMatcher(match *RuleMatch) (Matcher, error) {
@@ -840,6 +1019,18 @@ func buildMatcherLegacy(match *RuleMatch) (Matcher, error) {
 	b.addPatternMatcher(match.ContentPattern, wrapContentMatcher)
 	b.addPatternMatcher(match.CommandPattern, wrapCommandMatcher)
 
+	// Add parsed command conditions.
+	b.addParsedCommandMatcher(match)
+
+	// Add environment, working directory, time and session conditions.
+	b.addContextMatchers(match)
+
+	// Add git remote URL and working tree conditions.
+	b.addGitMatchers(match)
+
+	// Add nested all/any/not blocks.
+	b.addNested(match)
+
 	return b.result()
 }
 
@@ -883,6 +1074,18 @@ func buildMatcherAdvanced(match *RuleMatch) (Matcher, error) {
 	b.addAdvancedPatternMatcher(match.CommandPattern, match.CommandPatterns,
 		wrapCommandMatcherWithOpts, wrapCommandMultiMatcher)
 
+	// Add parsed command conditions.
+	b.addParsedCommandMatcher(match)
+
+	// Add environment, working directory, time and session conditions.
+	b.addContextMatchers(match)
+
+	// Add git remote URL and working tree conditions.
+	b.addGitMatchers(match)
+
+	// Add nested all/any/not blocks.
+	b.addNested(match)
+
 	return b.result()
 }
 
@@ -923,6 +1126,15 @@ var (
 	_ Matcher = (*ValidatorTypeMatcher)(nil)
 	_ Matcher = (*ToolTypeMatcher)(nil)
 	_ Matcher = (*EventTypeMatcher)(nil)
+	_ Matcher = (*ParsedCommandMatcher)(nil)
+	_ Matcher = (*EnvMatcher)(nil)
+	_ Matcher = (*CWDMatcher)(nil)
+	_ Matcher = (*TimeWindowMatcher)(nil)
+	_ Matcher = (*SessionCommandsMatcher)(nil)
+	_ Matcher = (*RemoteURLMatcher)(nil)
+	_ Matcher = (*StagedFilesMatcher)(nil)
+	_ Matcher = (*DirtyMatcher)(nil)
+	_ Matcher = (*CommitsAheadMatcher)(nil)
 	_ Matcher = (*CompositeMatcher)(nil)
 	_ Matcher = (*AlwaysMatcher)(nil)
 	_ Matcher = (*NeverMatcher)(nil)
@@ -745,6 +745,20 @@ var _ = Describe("Matcher", func() {
 			}
 			Expect(matcher.Match(ctx)).To(BeTrue())
 		})
+
+		It("should match lifecycle events", func() {
+			matcher := rules.NewEventTypeMatcher("userpromptsubmit")
+
+			ctx := &rules.MatchContext{
+				HookContext: &hook.Context{
+					EventType: hook.EventTypeUserPromptSubmit,
+				},
+			}
+			Expect(matcher.Match(ctx)).To(BeTrue())
+
+			ctx.HookContext.EventType = hook.EventTypeStop
+			Expect(matcher.Match(ctx)).To(BeFalse())
+		})
 	})
 
 	Describe("CompositeMatcher", func() {
@@ -899,6 +913,81 @@ var _ = Describe("Matcher", func() {
 		})
 	})
 
+	Describe("BuildMatcher with nested blocks", func() {
+		// Block push to main unless the repo is a sandbox or the branch is a release.
+		match := &rules.RuleMatch{
+			ValidatorType: rules.ValidatorGitPush,
+			BranchPattern: "main",
+			Not: &rules.RuleMatch{
+				Any: []*rules.RuleMatch{
+					{RepoPattern: "/home/user/sandbox/**"},
+					{All: []*rules.RuleMatch{
+						{Remote: "origin"},
+						{RepoPattern: "**/release-*"},
+					}},
+				},
+			},
+		}
+
+		newCtx := func(repoRoot, remote string) *rules.MatchContext {
+			return &rules.MatchContext{
+				ValidatorType: rules.ValidatorGitPush,
+				GitContext: &rules.GitContext{
+					RepoRoot: repoRoot,
+					Remote:   remote,
+					Branch:   "main",
+				},
+			}
+		}
+
+		It("should compose nested all/any/not blocks", func() {
+			matcher, err := rules.BuildMatcher(match)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(matcher.Match(newCtx("/home/user/work/api", "origin"))).To(BeTrue())
+			Expect(matcher.Match(newCtx("/home/user/sandbox/api", "origin"))).To(BeFalse())
+			Expect(matcher.Match(newCtx("/home/user/work/release-1", "origin"))).To(BeFalse())
+			Expect(matcher.Match(newCtx("/home/user/work/release-1", "upstream"))).To(BeTrue())
+		})
+
+		It("should build a matcher from nested blocks only", func() {
+			matcher, err := rules.BuildMatcher(&rules.RuleMatch{
+				Any: []*rules.RuleMatch{
+					{Remote: "origin"},
+					{Remote: "upstream"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matcher.Name()).To(Equal("OR"))
+
+			Expect(matcher.Match(newCtx("/repo", "upstream"))).To(BeTrue())
+			Expect(matcher.Match(newCtx("/repo", "fork"))).To(BeFalse())
+		})
+
+		It("should ignore nested blocks without conditions", func() {
+			matcher, err := rules.BuildMatcher(&rules.RuleMatch{
+				Remote: "origin",
+				Any:    []*rules.RuleMatch{{}},
+				Not:    &rules.RuleMatch{},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matcher.Name()).To(Equal("remote:origin"))
+		})
+
+		It("should report the path of invalid nested patterns", func() {
+			_, err := rules.BuildMatcher(&rules.RuleMatch{
+				Not: &rules.RuleMatch{
+					Any: []*rules.RuleMatch{
+						{Remote: "origin"},
+						{RepoPattern: "[invalid"},
+					},
+				},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("not: any[1]: "))
+		})
+	})
+
 	Describe("AlwaysMatcher", func() {
 		It("should always match", func() {
 			matcher := &rules.AlwaysMatcher{}
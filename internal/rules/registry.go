@@ -4,8 +4,11 @@ import (
 	"cmp"
 	"slices"
 	"sync"
+	"text/template"
 
 	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/internal/templates"
 )
 
 // CompiledRule represents a rule with its pre-compiled matcher.
@@ -15,6 +18,15 @@ type CompiledRule struct {
 
 	// Matcher is the compiled matcher for this rule.
 	Matcher Matcher
+
+	// Suggestion is the compiled suggestion template (suggest action only).
+	Suggestion *template.Template
+
+	// Message is the compiled message template (nil for plain messages).
+	Message *template.Template
+
+	// captures are the rule's regex patterns with named capture groups.
+	captures []groupCapture
 }
 
 // Registry stores compiled rules sorted by priority.
@@ -56,16 +68,37 @@ func (r *Registry) Add(rule *Rule) error {
 		matcher = &AlwaysMatcher{}
 	}
 
+	compiled := &CompiledRule{
+		Rule:     rule,
+		Matcher:  matcher,
+		captures: compileGroupCaptures(rule.Match),
+	}
+
+	if isTemplate(rule.Action.Message) {
+		compiled.Message, err = templates.Compile(rule.Name, rule.Action.Message)
+		if err != nil {
+			return errors.Wrap(err, "failed to compile rule message")
+		}
+	}
+
+	if rule.Action.Type == ActionSuggest {
+		if rule.Action.Suggestion == "" {
+			return errors.New("suggest action requires a suggestion")
+		}
+
+		compiled.Suggestion, err = templates.Compile(rule.Name, rule.Action.Suggestion)
+		if err != nil {
+			return errors.Wrap(err, "failed to compile rule suggestion")
+		}
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	// Check for duplicate name and update if exists.
 	for i, existing := range r.rules {
 		if existing.Rule.Name == rule.Name {
-			r.rules[i] = &CompiledRule{
-				Rule:    rule,
-				Matcher: matcher,
-			}
+			r.rules[i] = compiled
 
 			r.sortRulesLocked()
 
@@ -74,10 +107,7 @@ func (r *Registry) Add(rule *Rule) error {
 	}
 
 	// Add new rule.
-	r.rules = append(r.rules, &CompiledRule{
-		Rule:    rule,
-		Matcher: matcher,
-	})
+	r.rules = append(r.rules, compiled)
 
 	r.sortRulesLocked()
 
@@ -0,0 +1,185 @@
+package rules
+
+import (
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+)
+
+// RuleTest is a test case for a rule: an input and the expected outcome of
+// evaluating it against all rules.
+type RuleTest struct {
+	// Name describes the test case.
+	Name string
+
+	// Input is the evaluated input.
+	Input RuleTestInput
+
+	// Matched is whether the rule is expected to match the input.
+	Matched bool
+
+	// Action is the expected action of the evaluation. Empty to not check it.
+	Action ActionType
+}
+
+// DisplayName returns the test name, or its command or file path if unnamed.
+func (t *RuleTest) DisplayName() string {
+	switch {
+	case t.Name != "":
+		return t.Name
+	case t.Input.Command != "":
+		return t.Input.Command
+	default:
+		return t.Input.FilePath
+	}
+}
+
+// RuleTestInput is the input of a rule test.
+type RuleTestInput struct {
+	// ValidatorType is the validator the input is evaluated for.
+	ValidatorType ValidatorType
+
+	// ToolType is the tool name. Defaults to "Bash" with a command and
+	// "Write" with a file path.
+	ToolType string
+
+	// EventType is the hook event type. Defaults to "PreToolUse".
+	EventType string
+
+	// Command is the bash command.
+	Command string
+
+	// FilePath is the file path being operated on.
+	FilePath string
+
+	// Content is the file content.
+	Content string
+
+	// Branch is the current git branch.
+	Branch string
+
+	// Remote is the git remote name.
+	Remote string
+}
+
+// MatchContext builds the match context for the input. Git data is limited
+// to the branch and remote of the input, so tests never depend on the
+// repository they run in.
+func (in *RuleTestInput) MatchContext() *MatchContext {
+	hookCtx := &hook.Context{
+		EventType: hook.EventTypePreToolUse,
+		ToolInput: hook.ToolInput{
+			Command:  in.Command,
+			FilePath: in.FilePath,
+			Content:  in.Content,
+		},
+	}
+
+	if eventType, err := hook.EventTypeString(in.EventType); err == nil {
+		hookCtx.EventType = eventType
+	}
+
+	toolType := in.ToolType
+
+	switch {
+	case toolType != "":
+	case in.Command != "":
+		toolType = hook.ToolTypeBash.String()
+	case in.FilePath != "":
+		toolType = hook.ToolTypeWrite.String()
+	}
+
+	if tool, err := hook.ToolTypeString(toolType); err == nil {
+		hookCtx.ToolName = tool
+	}
+
+	ctx := &MatchContext{
+		HookContext:   hookCtx,
+		ValidatorType: in.ValidatorType,
+		Command:       in.Command,
+		GitContext: &GitContext{
+			IsInRepo: true,
+			Branch:   in.Branch,
+			Remote:   in.Remote,
+		},
+		GitState: &GitState{},
+	}
+
+	if in.FilePath != "" || in.Content != "" {
+		ctx.FileContext = &FileContext{Path: in.FilePath, Content: in.Content}
+	}
+
+	return ctx
+}
+
+// RuleTestResult is the outcome of a rule test.
+type RuleTestResult struct {
+	// Rule is the rule the test belongs to.
+	Rule *Rule
+
+	// Test is the executed test.
+	Test *RuleTest
+
+	// Matched is whether the rule matched the input.
+	Matched bool
+
+	// Action is the action of the evaluation.
+	Action ActionType
+
+	// DecidedBy is the name of the rule that decided the evaluation, if any.
+	DecidedBy string
+}
+
+// Passed returns true if the outcome is as expected.
+func (r *RuleTestResult) Passed() bool {
+	if r.Matched != r.Test.Matched {
+		return false
+	}
+
+	return r.Test.Action == "" || r.Action == r.Test.Action
+}
+
+// RunTests runs the tests of all enabled rules through the evaluator, in
+// rule priority order. A rule matches the input if it decides the
+// evaluation, or for log rules, if it is logged. Test evaluations are not
+// recorded in rule statistics.
+func (e *Evaluator) RunTests() []*RuleTestResult {
+	if e.registry == nil {
+		return nil
+	}
+
+	tester := *e
+	tester.stats = nil
+
+	var results []*RuleTestResult
+
+	for _, compiled := range e.registry.GetEnabled() {
+		for _, test := range compiled.Rule.Tests {
+			results = append(results, tester.runTest(compiled.Rule, test))
+		}
+	}
+
+	return results
+}
+
+// runTest evaluates the input of a single test.
+func (e *Evaluator) runTest(rule *Rule, test *RuleTest) *RuleTestResult {
+	evaluation := e.Evaluate(test.Input.MatchContext())
+
+	result := &RuleTestResult{
+		Rule:   rule,
+		Test:   test,
+		Action: evaluation.Action,
+	}
+
+	if evaluation.Rule != nil {
+		result.DecidedBy = evaluation.Rule.Name
+		result.Matched = evaluation.Rule.Name == rule.Name
+	}
+
+	for _, logged := range evaluation.Logged {
+		if logged.Name == rule.Name {
+			result.Matched = true
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,160 @@
+package rules_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+)
+
+var _ = Describe("Rule tests", func() {
+	Describe("RuleTestInput.MatchContext", func() {
+		It("should default to a PreToolUse Bash invocation for commands", func() {
+			ctx := (&rules.RuleTestInput{
+				Command: "git push origin main",
+				Branch:  "main",
+				Remote:  "origin",
+			}).MatchContext()
+
+			Expect(ctx.HookContext.EventType).To(Equal(hook.EventTypePreToolUse))
+			Expect(ctx.HookContext.ToolName).To(Equal(hook.ToolTypeBash))
+			Expect(ctx.Command).To(Equal("git push origin main"))
+			Expect(ctx.GitContext.Branch).To(Equal("main"))
+			Expect(ctx.GitContext.Remote).To(Equal("origin"))
+			Expect(ctx.FileContext).To(BeNil())
+		})
+
+		It("should default to Write for file paths", func() {
+			ctx := (&rules.RuleTestInput{FilePath: "main.tf", Content: "x"}).MatchContext()
+
+			Expect(ctx.HookContext.ToolName).To(Equal(hook.ToolTypeWrite))
+			Expect(ctx.FileContext.Path).To(Equal("main.tf"))
+			Expect(ctx.FileContext.Content).To(Equal("x"))
+		})
+
+		It("should use the given tool and event types", func() {
+			ctx := (&rules.RuleTestInput{
+				FilePath:  "main.tf",
+				ToolType:  "Edit",
+				EventType: "PostToolUse",
+			}).MatchContext()
+
+			Expect(ctx.HookContext.ToolName).To(Equal(hook.ToolTypeEdit))
+			Expect(ctx.HookContext.EventType).To(Equal(hook.EventTypePostToolUse))
+		})
+	})
+
+	Describe("RunTests", func() {
+		pushTo := func(branch string) rules.RuleTestInput {
+			return rules.RuleTestInput{
+				ValidatorType: rules.ValidatorGitPush,
+				Command:       "git push origin " + branch,
+				Branch:        branch,
+			}
+		}
+
+		newEngine := func(ruleList ...*rules.Rule) *rules.RuleEngine {
+			engine, err := rules.NewRuleEngine(ruleList)
+			Expect(err).NotTo(HaveOccurred())
+
+			return engine
+		}
+
+		It("should report passing and failing tests", func() {
+			engine := newEngine(&rules.Rule{
+				Name:     "block-main",
+				Priority: 100,
+				Enabled:  true,
+				Match: &rules.RuleMatch{
+					ValidatorType: rules.ValidatorGitPush,
+					BranchPattern: "main",
+				},
+				Action: &rules.RuleAction{Type: rules.ActionBlock},
+				Tests: []*rules.RuleTest{
+					{Name: "main", Input: pushTo("main"), Matched: true, Action: rules.ActionBlock},
+					{Name: "feature", Input: pushTo("feat/x"), Matched: false},
+					{Name: "wrong action", Input: pushTo("main"), Matched: true, Action: rules.ActionWarn},
+					{Name: "typo", Input: pushTo("master"), Matched: true},
+				},
+			})
+
+			results := engine.RunTests()
+			Expect(results).To(HaveLen(4))
+
+			passed := make(map[string]bool, len(results))
+			for _, result := range results {
+				passed[result.Test.Name] = result.Passed()
+			}
+
+			Expect(passed).To(Equal(map[string]bool{
+				"main":         true,
+				"feature":      true,
+				"wrong action": false,
+				"typo":         false,
+			}))
+
+			Expect(results[0].DecidedBy).To(Equal("block-main"))
+			Expect(results[1].DecidedBy).To(BeEmpty())
+			Expect(results[1].Action).To(Equal(rules.ActionAllow))
+		})
+
+		It("should not count a match when another rule decides first", func() {
+			engine := newEngine(
+				&rules.Rule{
+					Name:     "allow-all-pushes",
+					Priority: 200,
+					Enabled:  true,
+					Match:    &rules.RuleMatch{ValidatorType: rules.ValidatorGitPush},
+					Action:   &rules.RuleAction{Type: rules.ActionAllow},
+				},
+				&rules.Rule{
+					Name:     "block-main",
+					Priority: 100,
+					Enabled:  true,
+					Match:    &rules.RuleMatch{BranchPattern: "main"},
+					Action:   &rules.RuleAction{Type: rules.ActionBlock},
+					Tests: []*rules.RuleTest{
+						{Input: pushTo("main"), Matched: true},
+					},
+				},
+			)
+
+			results := engine.RunTests()
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Passed()).To(BeFalse())
+			Expect(results[0].DecidedBy).To(Equal("allow-all-pushes"))
+			Expect(results[0].Test.DisplayName()).To(Equal("git push origin main"))
+		})
+
+		It("should count logged rules as matched", func() {
+			engine := newEngine(&rules.Rule{
+				Name:    "log-pushes",
+				Enabled: true,
+				Match:   &rules.RuleMatch{CommandPattern: "git push*"},
+				Action:  &rules.RuleAction{Type: rules.ActionLog},
+				Tests: []*rules.RuleTest{
+					{Input: pushTo("main"), Matched: true, Action: rules.ActionAllow},
+				},
+			})
+
+			results := engine.RunTests()
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Passed()).To(BeTrue())
+		})
+
+		It("should skip tests of disabled rules", func() {
+			engine := newEngine(&rules.Rule{
+				Name:    "disabled",
+				Enabled: false,
+				Match:   &rules.RuleMatch{CommandPattern: "*"},
+				Action:  &rules.RuleAction{Type: rules.ActionBlock},
+				Tests: []*rules.RuleTest{
+					{Input: pushTo("main"), Matched: true},
+				},
+			})
+
+			Expect(engine.RunTests()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,365 @@
+package rules
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/internal/statestore"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// Stats file permission constants.
+const (
+	// statsFilePermissions is the permission mode for the stats file.
+	statsFilePermissions = 0o600
+
+	// statsDirPermissions is the permission mode for the stats directory.
+	statsDirPermissions = 0o700
+)
+
+// RuleStatus classifies a rule by its hit statistics.
+type RuleStatus string
+
+const (
+	// RuleStatusActive is a rule that matched recently.
+	RuleStatusActive RuleStatus = "active"
+
+	// RuleStatusDead is a rule that has not matched for a long time.
+	RuleStatusDead RuleStatus = "dead"
+
+	// RuleStatusShadowed is a rule that only matched when a higher-priority
+	// rule decided the evaluation first.
+	RuleStatusShadowed RuleStatus = "shadowed"
+
+	// RuleStatusUntracked is a rule without recorded statistics.
+	RuleStatusUntracked RuleStatus = "untracked"
+)
+
+// RuleStats contains the hit statistics of a rule.
+type RuleStats struct {
+	// FirstSeen is when the rule was first evaluated with statistics enabled.
+	FirstSeen time.Time `json:"first_seen"`
+
+	// Matches is the number of hook invocations the rule decided, or for
+	// rules with the log action, was logged in.
+	Matches int `json:"matches"`
+
+	// LastMatch is when the rule last matched.
+	LastMatch time.Time `json:"last_match,omitzero"`
+
+	// LastAction is the action taken on the last match.
+	LastAction ActionType `json:"last_action,omitempty"`
+
+	// ShadowedBy counts the hook invocations in which the rule matched, but a
+	// higher-priority rule decided first, by the name of the deciding rule.
+	ShadowedBy map[string]int `json:"shadowed_by,omitempty"`
+}
+
+// ShadowedMatches returns the number of evaluations in which the rule
+// matched, but a higher-priority rule decided first.
+func (s *RuleStats) ShadowedMatches() int {
+	total := 0
+
+	for _, count := range s.ShadowedBy {
+		total += count
+	}
+
+	return total
+}
+
+// TopShadower returns the rule that most often decided evaluations the rule
+// also matched. Returns an empty string if the rule was never shadowed.
+func (s *RuleStats) TopShadower() string {
+	var (
+		top      string
+		topCount int
+	)
+
+	for name, count := range s.ShadowedBy {
+		if count > topCount || (count == topCount && name < top) {
+			top, topCount = name, count
+		}
+	}
+
+	return top
+}
+
+// Status returns the status of the rule at now. A rule is shadowed if it
+// matched only when a higher-priority rule decided first, and dead if it has
+// not matched within deadAfter, or, if it never matched, has been tracked
+// for longer than deadAfter.
+func (s *RuleStats) Status(now time.Time, deadAfter time.Duration) RuleStatus {
+	if s == nil {
+		return RuleStatusUntracked
+	}
+
+	if s.Matches == 0 && s.ShadowedMatches() > 0 {
+		return RuleStatusShadowed
+	}
+
+	since := s.LastMatch
+	if since.IsZero() {
+		since = s.FirstSeen
+	}
+
+	if now.Sub(since) > deadAfter {
+		return RuleStatusDead
+	}
+
+	return RuleStatusActive
+}
+
+// merge adds the statistics recorded in other.
+func (s *RuleStats) merge(other *RuleStats) {
+	if s.FirstSeen.IsZero() || (!other.FirstSeen.IsZero() && other.FirstSeen.Before(s.FirstSeen)) {
+		s.FirstSeen = other.FirstSeen
+	}
+
+	s.Matches += other.Matches
+
+	if other.LastMatch.After(s.LastMatch) {
+		s.LastMatch = other.LastMatch
+		s.LastAction = other.LastAction
+	}
+
+	for name, count := range other.ShadowedBy {
+		if s.ShadowedBy == nil {
+			s.ShadowedBy = make(map[string]int)
+		}
+
+		s.ShadowedBy[name] += count
+	}
+}
+
+// StatsState is the persisted rule hit statistics.
+type StatsState struct {
+	// Rules maps rule names to their statistics.
+	Rules map[string]*RuleStats `json:"rules"`
+
+	// LastUpdated is when the statistics were last saved.
+	LastUpdated time.Time `json:"last_updated,omitzero"`
+}
+
+// StatsRecorder collects rule hit statistics during evaluation and merges
+// them into a JSON state file.
+type StatsRecorder struct {
+	mu        sync.Mutex
+	stateFile string
+	logger    logger.Logger
+
+	// tracked are the names of the rules statistics are recorded for.
+	tracked []string
+
+	// pending are the statistics recorded since the last save.
+	pending map[string]*RuleStats
+
+	// now is a function that returns the current time.
+	// Used for testing to control time.
+	now func() time.Time
+}
+
+// StatsRecorderOption configures the StatsRecorder.
+type StatsRecorderOption func(*StatsRecorder)
+
+// WithStatsRecorderLogger sets the logger.
+func WithStatsRecorderLogger(log logger.Logger) StatsRecorderOption {
+	return func(r *StatsRecorder) {
+		if log != nil {
+			r.logger = log
+		}
+	}
+}
+
+// WithStatsTimeFunc sets a custom time function for testing.
+func WithStatsTimeFunc(fn func() time.Time) StatsRecorderOption {
+	return func(r *StatsRecorder) {
+		if fn != nil {
+			r.now = fn
+		}
+	}
+}
+
+// NewStatsRecorder creates a new rule statistics recorder saving to
+// stateFile. A leading "~/" in stateFile is expanded to the home directory.
+func NewStatsRecorder(stateFile string, opts ...StatsRecorderOption) *StatsRecorder {
+	r := &StatsRecorder{
+		stateFile: stateFile,
+		logger:    logger.NewNoOpLogger(),
+		pending:   make(map[string]*RuleStats),
+		now:       time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Track registers the rules statistics are recorded for, so that rules
+// which never match still get a first-seen time.
+func (r *StatsRecorder) Track(names ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tracked = append(r.tracked, names...)
+}
+
+// RecordMatch records that rule matched and the action was taken.
+func (r *StatsRecorder) RecordMatch(rule *Rule, action ActionType) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := r.pendingLocked(rule.Name)
+	stats.Matches++
+	stats.LastMatch = r.now()
+	stats.LastAction = action
+}
+
+// RecordShadowed records that rule matched, but decidedBy decided the
+// evaluation first.
+func (r *StatsRecorder) RecordShadowed(rule, decidedBy *Rule) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := r.pendingLocked(rule.Name)
+	if stats.ShadowedBy == nil {
+		stats.ShadowedBy = make(map[string]int)
+	}
+
+	stats.ShadowedBy[decidedBy.Name]++
+}
+
+// pendingLocked returns the pending statistics of the named rule.
+// Must be called with mu held.
+func (r *StatsRecorder) pendingLocked(name string) *RuleStats {
+	stats, ok := r.pending[name]
+	if !ok {
+		stats = &RuleStats{FirstSeen: r.now()}
+		r.pending[name] = stats
+	}
+
+	return stats
+}
+
+// Load reads the statistics from the state file. Returns empty statistics
+// if the file does not exist or cannot be parsed.
+func (r *StatsRecorder) Load() (*StatsState, error) {
+	data, err := r.store().Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading rule stats file")
+	}
+
+	return r.parseState(data), nil
+}
+
+// parseState parses the state file content. Returns empty statistics if the
+// content is missing or cannot be parsed.
+func (r *StatsRecorder) parseState(data []byte) *StatsState {
+	if data == nil {
+		return &StatsState{Rules: make(map[string]*RuleStats)}
+	}
+
+	var state StatsState
+	if err := json.Unmarshal(data, &state); err != nil {
+		r.logger.Debug("failed to parse rule stats file, using fresh state",
+			"path", r.GetStatePath(),
+			"error", err.Error(),
+		)
+
+		return &StatsState{Rules: make(map[string]*RuleStats)}
+	}
+
+	if state.Rules == nil {
+		state.Rules = make(map[string]*RuleStats)
+	}
+
+	return &state
+}
+
+// Save merges the statistics recorded since the last save into the state
+// file. The file is locked while merging, so concurrent invocations do not
+// lose each other's statistics, and only written if the statistics changed.
+func (r *StatsRecorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var changed bool
+
+	err := r.store().Update(func(current []byte) ([]byte, error) {
+		state := r.parseState(current)
+		now := r.now()
+		changed = len(r.pending) > 0
+
+		for name, pending := range r.pending {
+			if stats, ok := state.Rules[name]; ok {
+				stats.merge(pending)
+			} else {
+				state.Rules[name] = pending
+			}
+		}
+
+		for _, name := range r.tracked {
+			if _, ok := state.Rules[name]; !ok {
+				state.Rules[name] = &RuleStats{FirstSeen: now}
+				changed = true
+			}
+		}
+
+		if !changed {
+			return nil, nil
+		}
+
+		state.LastUpdated = now
+
+		data, err := json.MarshalIndent(state, "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "marshaling rule stats")
+		}
+
+		return data, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "saving rule stats file")
+	}
+
+	if !changed {
+		return nil
+	}
+
+	r.logger.Debug("saved rule stats",
+		"path", r.GetStatePath(),
+		"updated_rules", len(r.pending),
+	)
+
+	clear(r.pending)
+
+	return nil
+}
+
+// store returns the state store of the stats file.
+func (r *StatsRecorder) store() *statestore.Store {
+	return statestore.New(
+		r.GetStatePath(),
+		statestore.WithFilePermissions(statsFilePermissions),
+		statestore.WithDirPermissions(statsDirPermissions),
+	)
+}
+
+// GetStatePath returns the state file path with "~/" expanded.
+func (r *StatsRecorder) GetStatePath() string {
+	path := r.stateFile
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+
+	return path
+}
@@ -0,0 +1,230 @@
+package rules_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+)
+
+var _ = Describe("StatsRecorder", func() {
+	var (
+		statsFile string
+		now       time.Time
+		newEngine func(recorder *rules.StatsRecorder) *rules.RuleEngine
+	)
+
+	newRecorder := func() *rules.StatsRecorder {
+		return rules.NewStatsRecorder(
+			statsFile,
+			rules.WithStatsTimeFunc(func() time.Time { return now }),
+		)
+	}
+
+	evaluate := func(engine *rules.RuleEngine, command string) *rules.RuleResult {
+		return engine.EvaluateHook(context.Background(), &hook.Context{
+			EventType: hook.EventTypePreToolUse,
+			ToolName:  hook.ToolTypeBash,
+			ToolInput: hook.ToolInput{Command: command},
+		}, rules.ValidatorGitPush, nil, nil)
+	}
+
+	BeforeEach(func() {
+		statsFile = filepath.Join(GinkgoT().TempDir(), "state", "rule_stats.json")
+		now = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		newEngine = func(recorder *rules.StatsRecorder) *rules.RuleEngine {
+			engine, err := rules.NewRuleEngine([]*rules.Rule{
+				{
+					Name:     "block-force",
+					Priority: 100,
+					Enabled:  true,
+					Match:    &rules.RuleMatch{CommandPattern: "git push --force*"},
+					Action:   &rules.RuleAction{Type: rules.ActionBlock},
+				},
+				{
+					Name:     "warn-push",
+					Priority: 50,
+					Enabled:  true,
+					Match:    &rules.RuleMatch{CommandPattern: "git push*"},
+					Action:   &rules.RuleAction{Type: rules.ActionWarn},
+				},
+				{
+					Name:     "log-git",
+					Priority: 200,
+					Enabled:  true,
+					Match:    &rules.RuleMatch{CommandPattern: "git *"},
+					Action:   &rules.RuleAction{Type: rules.ActionLog},
+				},
+				{
+					Name:    "never-matches",
+					Enabled: true,
+					Match:   &rules.RuleMatch{CommandPattern: "svn *"},
+					Action:  &rules.RuleAction{Type: rules.ActionBlock},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			engine.SetStatsRecorder(recorder)
+
+			return engine
+		}
+	})
+
+	It("returns empty statistics without a state file", func() {
+		state, err := newRecorder().Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.Rules).To(BeEmpty())
+	})
+
+	It("records matches, actions and shadowed rules", func() {
+		recorder := newRecorder()
+		engine := newEngine(recorder)
+
+		Expect(evaluate(engine, "git push origin main").Action).To(Equal(rules.ActionWarn))
+		Expect(evaluate(engine, "git push --force origin main").Action).To(Equal(rules.ActionBlock))
+		Expect(recorder.Save()).To(Succeed())
+
+		state, err := newRecorder().Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.LastUpdated).To(Equal(now))
+
+		Expect(state.Rules["block-force"].Matches).To(Equal(1))
+		Expect(state.Rules["block-force"].LastAction).To(Equal(rules.ActionBlock))
+
+		// warn-push decided the first evaluation and was shadowed in the second
+		Expect(state.Rules["warn-push"].Matches).To(Equal(1))
+		Expect(state.Rules["warn-push"].ShadowedBy).To(Equal(map[string]int{"block-force": 1}))
+
+		// log-git is logged in both evaluations without deciding them
+		Expect(state.Rules["log-git"].Matches).To(Equal(2))
+		Expect(state.Rules["log-git"].LastAction).To(Equal(rules.ActionLog))
+		Expect(state.Rules["log-git"].ShadowedBy).To(BeEmpty())
+
+		// Tracked rules get a first-seen time without matching
+		Expect(state.Rules["never-matches"].Matches).To(BeZero())
+		Expect(state.Rules["never-matches"].FirstSeen).To(Equal(now))
+	})
+
+	It("merges statistics saved by other invocations", func() {
+		first := newRecorder()
+		evaluate(newEngine(first), "git push origin main")
+		Expect(first.Save()).To(Succeed())
+
+		firstSeen := now
+		now = now.Add(time.Hour)
+
+		second := newRecorder()
+		evaluate(newEngine(second), "git push origin feat")
+		Expect(second.Save()).To(Succeed())
+
+		state, err := newRecorder().Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.Rules["warn-push"].Matches).To(Equal(2))
+		Expect(state.Rules["warn-push"].FirstSeen).To(Equal(firstSeen))
+		Expect(state.Rules["warn-push"].LastMatch).To(Equal(now))
+	})
+
+	It("does not write the state file without changes", func() {
+		recorder := newRecorder()
+		newEngine(recorder)
+		Expect(recorder.Save()).To(Succeed())
+
+		info, err := os.Stat(statsFile)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(newRecorder().Save()).To(Succeed())
+
+		after, err := os.Stat(statsFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(after.ModTime()).To(Equal(info.ModTime()))
+	})
+
+	It("ignores a corrupted state file", func() {
+		Expect(os.MkdirAll(filepath.Dir(statsFile), 0o700)).To(Succeed())
+		Expect(os.WriteFile(statsFile, []byte("{not json"), 0o600)).To(Succeed())
+
+		state, err := newRecorder().Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.Rules).To(BeEmpty())
+	})
+
+	It("does not record rule test evaluations", func() {
+		recorder := newRecorder()
+		engine, err := rules.NewRuleEngine([]*rules.Rule{{
+			Name:    "tested",
+			Enabled: true,
+			Match:   &rules.RuleMatch{CommandPattern: "git *"},
+			Action:  &rules.RuleAction{Type: rules.ActionBlock},
+			Tests: []*rules.RuleTest{
+				{Input: rules.RuleTestInput{Command: "git status"}, Matched: true},
+			},
+		}})
+		Expect(err).NotTo(HaveOccurred())
+
+		engine.SetStatsRecorder(recorder)
+		Expect(engine.RunTests()[0].Passed()).To(BeTrue())
+		Expect(recorder.Save()).To(Succeed())
+
+		state, err := newRecorder().Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.Rules["tested"].Matches).To(BeZero())
+	})
+})
+
+var _ = Describe("RuleStats", func() {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	deadAfter := 30 * 24 * time.Hour
+	daysAgo := func(days int) time.Time {
+		return now.Add(-time.Duration(days) * 24 * time.Hour)
+	}
+
+	DescribeTable("Status",
+		func(stats *rules.RuleStats, expected rules.RuleStatus) {
+			Expect(stats.Status(now, deadAfter)).To(Equal(expected))
+		},
+		Entry("untracked", nil, rules.RuleStatusUntracked),
+		Entry("recent match",
+			&rules.RuleStats{FirstSeen: daysAgo(90), Matches: 3, LastMatch: daysAgo(2)},
+			rules.RuleStatusActive,
+		),
+		Entry("old match",
+			&rules.RuleStats{FirstSeen: daysAgo(90), Matches: 3, LastMatch: daysAgo(40)},
+			rules.RuleStatusDead,
+		),
+		Entry("never matched, recently added",
+			&rules.RuleStats{FirstSeen: daysAgo(5)},
+			rules.RuleStatusActive,
+		),
+		Entry("never matched, tracked for long",
+			&rules.RuleStats{FirstSeen: daysAgo(45)},
+			rules.RuleStatusDead,
+		),
+		Entry("only matched behind another rule",
+			&rules.RuleStats{FirstSeen: daysAgo(1), ShadowedBy: map[string]int{"a": 2}},
+			rules.RuleStatusShadowed,
+		),
+		Entry("sometimes matched behind another rule",
+			&rules.RuleStats{
+				FirstSeen:  daysAgo(1),
+				Matches:    1,
+				LastMatch:  daysAgo(1),
+				ShadowedBy: map[string]int{"a": 2},
+			},
+			rules.RuleStatusActive,
+		),
+	)
+
+	It("returns the rule that shadowed most often", func() {
+		stats := &rules.RuleStats{ShadowedBy: map[string]int{"b": 2, "a": 2, "c": 1}}
+		Expect(stats.TopShadower()).To(Equal("a"))
+		Expect(stats.ShadowedMatches()).To(Equal(5))
+		Expect((&rules.RuleStats{}).TopShadower()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,222 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/internal/templates"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+)
+
+// templateMarker indicates that a rule message is a template.
+const templateMarker = "{{"
+
+// captureSource identifies the value a regex pattern of a rule matched.
+type captureSource int
+
+const (
+	captureRepo captureSource = iota
+	captureBranch
+	captureFile
+	captureContent
+	captureCommand
+)
+
+// groupCapture is a regex pattern of a rule with named capture groups.
+type groupCapture struct {
+	source captureSource
+	regex  *regexp.Regexp
+}
+
+// compileGroupCaptures compiles the regex patterns of match, including those
+// of nested all/any conditions, that contain named capture groups. Negated
+// and glob patterns capture nothing and are skipped.
+func compileGroupCaptures(match *RuleMatch) []groupCapture {
+	if match == nil {
+		return nil
+	}
+
+	var captures []groupCapture
+
+	add := func(source captureSource, patterns ...string) {
+		for _, pattern := range patterns {
+			if pattern == "" || IsNegated(pattern) {
+				continue
+			}
+
+			if source != captureContent && DetectPatternType(pattern) != PatternTypeRegex {
+				continue
+			}
+
+			if match.CaseInsensitive && !strings.HasPrefix(pattern, "(?i)") {
+				pattern = "(?i)" + pattern
+			}
+
+			regex, err := regexp.Compile(pattern)
+			if err != nil || !hasNamedGroups(regex) {
+				continue
+			}
+
+			captures = append(captures, groupCapture{source: source, regex: regex})
+		}
+	}
+
+	add(captureRepo, match.RepoPattern)
+	add(captureRepo, match.RepoPatterns...)
+	add(captureBranch, match.BranchPattern)
+	add(captureBranch, match.BranchPatterns...)
+	add(captureFile, match.FilePattern)
+	add(captureFile, match.FilePatterns...)
+	add(captureContent, match.ContentPattern)
+	add(captureContent, match.ContentPatterns...)
+	add(captureCommand, match.CommandPattern)
+	add(captureCommand, match.CommandPatterns...)
+
+	for _, nested := range match.All {
+		captures = append(captures, compileGroupCaptures(nested)...)
+	}
+
+	for _, nested := range match.Any {
+		captures = append(captures, compileGroupCaptures(nested)...)
+	}
+
+	return captures
+}
+
+// hasNamedGroups reports whether regex contains named capture groups.
+func hasNamedGroups(regex *regexp.Regexp) bool {
+	for _, name := range regex.SubexpNames() {
+		if name != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newTemplateData builds the template data from a match context.
+func newTemplateData(ctx *MatchContext) *templates.ContextData {
+	data := &templates.ContextData{Command: ctx.Command}
+
+	if ctx.HookContext != nil {
+		data.File = ctx.HookContext.GetFilePath()
+		data.ToolName = ctx.HookContext.ToolName.String()
+
+		if data.Command == "" {
+			data.Command = ctx.HookContext.GetCommand()
+		}
+	}
+
+	if ctx.FileContext != nil && ctx.FileContext.Path != "" {
+		data.File = ctx.FileContext.Path
+	}
+
+	data.FilePath = data.File
+
+	if gitCtx := ctx.git(); gitCtx != nil {
+		data.Branch = gitCtx.Branch
+		data.Remote = gitCtx.Remote
+		data.RepoRoot = gitCtx.RepoRoot
+	}
+
+	return data
+}
+
+// TemplateData builds the template data for a hook context outside of rule
+// evaluation, loading git data from provider on first use. The provider may
+// be nil.
+func TemplateData(hookCtx *hook.Context, provider GitProvider) *templates.ContextData {
+	return newTemplateData(&MatchContext{
+		HookContext: hookCtx,
+		gitProvider: provider,
+	})
+}
+
+// templateData builds the template data of the rule from a match context,
+// including the named groups captured by the rule's regex patterns.
+func (c *CompiledRule) templateData(ctx *MatchContext) *templates.ContextData {
+	data := newTemplateData(ctx)
+	data.Groups = make(map[string]string)
+
+	for _, capture := range c.captures {
+		matches := capture.regex.FindStringSubmatch(captureValue(capture.source, ctx, data))
+		if matches == nil {
+			continue
+		}
+
+		for i, name := range capture.regex.SubexpNames() {
+			if name == "" || matches[i] == "" {
+				continue
+			}
+
+			// The first pattern capturing a group wins.
+			if _, ok := data.Groups[name]; !ok {
+				data.Groups[name] = matches[i]
+			}
+		}
+	}
+
+	return data
+}
+
+// captureValue returns the value the patterns of source are matched against.
+func captureValue(source captureSource, ctx *MatchContext, data *templates.ContextData) string {
+	switch source {
+	case captureRepo:
+		return data.RepoRoot
+	case captureBranch:
+		return data.Branch
+	case captureFile:
+		return data.File
+	case captureContent:
+		if ctx.FileContext != nil && ctx.FileContext.Content != "" {
+			return ctx.FileContext.Content
+		}
+
+		if ctx.HookContext != nil {
+			return ctx.HookContext.GetContent()
+		}
+
+		return ""
+	case captureCommand:
+		return data.Command
+	default:
+		return ""
+	}
+}
+
+// renderSuggestion executes the rule's suggestion template for ctx.
+func (c *CompiledRule) renderSuggestion(ctx *MatchContext) (string, error) {
+	if c.Suggestion == nil {
+		return "", nil
+	}
+
+	suggestion, err := templates.Execute(c.Suggestion, c.templateData(ctx))
+	if err != nil {
+		return "", errors.Wrapf(err, "rendering suggestion of rule %q", c.Rule.Name)
+	}
+
+	return strings.TrimSpace(suggestion), nil
+}
+
+// renderMessage executes the rule's message template for ctx. Returns the
+// message unchanged if it is not a template.
+func (c *CompiledRule) renderMessage(ctx *MatchContext) (string, error) {
+	if c.Message == nil {
+		return c.Rule.Action.Message, nil
+	}
+
+	message, err := templates.Execute(c.Message, c.templateData(ctx))
+	if err != nil {
+		return "", errors.Wrapf(err, "rendering message of rule %q", c.Rule.Name)
+	}
+
+	return strings.TrimSpace(message), nil
+}
+
+// isTemplate reports whether text uses template actions.
+func isTemplate(text string) bool {
+	return strings.Contains(text, templateMarker)
+}
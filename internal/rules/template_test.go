@@ -0,0 +1,117 @@
+package rules_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+)
+
+var _ = Describe("Message templates", func() {
+	evaluate := func(rule *rules.Rule, hookCtx *hook.Context, gitCtx *rules.GitContext) *rules.RuleResult {
+		engine, err := rules.NewRuleEngine([]*rules.Rule{rule})
+		Expect(err).NotTo(HaveOccurred())
+
+		return engine.EvaluateHook(context.Background(), hookCtx, rules.ValidatorGitPush, gitCtx, nil)
+	}
+
+	bashCtx := func(command string) *hook.Context {
+		return &hook.Context{
+			EventType: hook.EventTypePreToolUse,
+			ToolName:  hook.ToolTypeBash,
+			ToolInput: hook.ToolInput{Command: command},
+		}
+	}
+
+	It("should render the match context", func() {
+		result := evaluate(&rules.Rule{
+			Name:    "no-main",
+			Enabled: true,
+			Match:   &rules.RuleMatch{BranchPattern: "main"},
+			Action: &rules.RuleAction{
+				Type:    rules.ActionBlock,
+				Message: "Pushing {{.Branch}} to {{.Remote}} in {{.RepoRoot}} is not allowed: {{.Command}}",
+			},
+		}, bashCtx("git push origin main"), &rules.GitContext{
+			RepoRoot: "/repo",
+			Branch:   "main",
+			Remote:   "origin",
+		})
+
+		Expect(result.Matched).To(BeTrue())
+		Expect(result.Message).To(Equal(
+			"Pushing main to origin in /repo is not allowed: git push origin main",
+		))
+	})
+
+	It("should render named groups of regex patterns", func() {
+		result := evaluate(&rules.Rule{
+			Name:    "ticket-branches",
+			Enabled: true,
+			Match: &rules.RuleMatch{
+				BranchPattern:  `^(?P<type>feat|fix)/(?P<ticket>[A-Z]+-\d+)`,
+				CommandPattern: "git push*",
+			},
+			Action: &rules.RuleAction{
+				Type:    rules.ActionWarn,
+				Message: "{{.Groups.type}} branch for {{.Groups.ticket}}{{.Groups.missing}}",
+			},
+		}, bashCtx("git push"), &rules.GitContext{Branch: "feat/PROJ-123-login"})
+
+		Expect(result.Message).To(Equal("feat branch for PROJ-123"))
+	})
+
+	It("should capture groups of case-insensitive and nested patterns", func() {
+		result := evaluate(&rules.Rule{
+			Name:    "nested",
+			Enabled: true,
+			Match: &rules.RuleMatch{
+				All: []*rules.RuleMatch{
+					{CommandPattern: `^GIT PUSH (?P<remote>\w+)`, CaseInsensitive: true},
+				},
+			},
+			Action: &rules.RuleAction{Type: rules.ActionBlock, Message: "remote {{.Groups.remote}}"},
+		}, bashCtx("git push upstream main"), nil)
+
+		Expect(result.Message).To(Equal("remote upstream"))
+	})
+
+	It("should render the file path", func() {
+		result := evaluate(&rules.Rule{
+			Name:    "no-env",
+			Enabled: true,
+			Match:   &rules.RuleMatch{FilePattern: "**/.env"},
+			Action:  &rules.RuleAction{Type: rules.ActionBlock, Message: "Do not edit {{.File}}"},
+		}, &hook.Context{
+			EventType: hook.EventTypePreToolUse,
+			ToolName:  hook.ToolTypeWrite,
+			ToolInput: hook.ToolInput{FilePath: "/app/.env"},
+		}, nil)
+
+		Expect(result.Message).To(Equal("Do not edit /app/.env"))
+	})
+
+	It("should keep the raw message when rendering fails", func() {
+		result := evaluate(&rules.Rule{
+			Name:    "broken",
+			Enabled: true,
+			Match:   &rules.RuleMatch{CommandPattern: "git push*"},
+			Action:  &rules.RuleAction{Type: rules.ActionBlock, Message: "{{.Branch.Nope}}"},
+		}, bashCtx("git push"), nil)
+
+		Expect(result.Action).To(Equal(rules.ActionBlock))
+		Expect(result.Message).To(Equal("{{.Branch.Nope}}"))
+	})
+
+	It("should reject invalid message templates", func() {
+		_, err := rules.NewRuleEngine([]*rules.Rule{{
+			Name:    "invalid",
+			Enabled: true,
+			Action:  &rules.RuleAction{Type: rules.ActionBlock, Message: "{{.Branch"},
+		}})
+		Expect(err).To(MatchError(ContainSubstring("failed to compile rule message")))
+	})
+})
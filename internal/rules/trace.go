@@ -0,0 +1,88 @@
+package rules
+
+import (
+	"context"
+	"strings"
+)
+
+// Evaluation records the evaluation of a single rule against a match context.
+type Evaluation struct {
+	// Rule is the evaluated rule.
+	Rule *Rule
+
+	// ValidatorType is the validator the rule was evaluated for.
+	ValidatorType ValidatorType
+
+	// Matched indicates whether the rule matched.
+	Matched bool
+
+	// FailedMatcher names the condition that prevented the rule from matching
+	// (e.g., "command_pattern:git push"). Empty when the rule matched.
+	FailedMatcher string
+}
+
+// Tracer receives rule evaluations. Implementations must be safe for
+// concurrent use, since validators may run in parallel.
+type Tracer interface {
+	// TraceRule is called for every rule the engine evaluates.
+	TraceRule(evaluation *Evaluation)
+}
+
+// tracerKey is the context key for the rule tracer.
+type tracerKey struct{}
+
+// WithTracer returns a context that makes the engine report every rule
+// evaluation to tracer.
+func WithTracer(ctx context.Context, tracer Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, tracer)
+}
+
+// tracerFrom returns the tracer stored in ctx, or nil.
+//
+//nolint:ireturn // tracer implementations are provided by callers
+func tracerFrom(ctx context.Context) Tracer {
+	if ctx == nil {
+		return nil
+	}
+
+	tracer, _ := ctx.Value(tracerKey{}).(Tracer)
+
+	return tracer
+}
+
+// FailedMatcher returns the name of the innermost condition that prevents m
+// from matching ctx, or an empty string if m matches.
+//
+// For AND matchers this is the first failing child. For OR matchers, where
+// every child failed, all child failures are listed.
+func FailedMatcher(m Matcher, ctx *MatchContext) string {
+	if m == nil || m.Match(ctx) {
+		return ""
+	}
+
+	composite, ok := m.(*CompositeMatcher)
+	if !ok {
+		return m.Name()
+	}
+
+	switch composite.op {
+	case CompositeOpAND:
+		for _, child := range composite.matchers {
+			if failed := FailedMatcher(child, ctx); failed != "" {
+				return failed
+			}
+		}
+	case CompositeOpOR:
+		failures := make([]string, 0, len(composite.matchers))
+
+		for _, child := range composite.matchers {
+			failures = append(failures, FailedMatcher(child, ctx))
+		}
+
+		return "OR(" + strings.Join(failures, ", ") + ")"
+	case CompositeOpNOT:
+		return "NOT(" + composite.matchers[0].Name() + ")"
+	}
+
+	return composite.Name()
+}
@@ -0,0 +1,134 @@
+package rules_test
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+)
+
+// recordingTracer collects rule evaluations.
+type recordingTracer struct {
+	mu          sync.Mutex
+	evaluations []*rules.Evaluation
+}
+
+func (t *recordingTracer) TraceRule(evaluation *rules.Evaluation) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.evaluations = append(t.evaluations, evaluation)
+}
+
+var _ = Describe("Tracing", func() {
+	var (
+		engine *rules.RuleEngine
+		tracer *recordingTracer
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		engine, err = rules.NewRuleEngine([]*rules.Rule{
+			{
+				Name:     "block-main",
+				Enabled:  true,
+				Priority: 20,
+				Match: &rules.RuleMatch{
+					ValidatorType: rules.ValidatorGitPush,
+					BranchPattern: "main",
+				},
+				Action: &rules.RuleAction{Type: rules.ActionBlock},
+			},
+			{
+				Name:     "warn-origin",
+				Enabled:  true,
+				Priority: 10,
+				Match: &rules.RuleMatch{
+					ValidatorType: rules.ValidatorGitPush,
+					Remote:        "origin",
+				},
+				Action: &rules.RuleAction{Type: rules.ActionWarn},
+			},
+			{
+				Name:     "never-reached",
+				Enabled:  true,
+				Priority: 1,
+				Action:   &rules.RuleAction{Type: rules.ActionAllow},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		tracer = &recordingTracer{}
+	})
+
+	It("reports evaluated rules until the first match", func() {
+		result := engine.Evaluate(
+			rules.WithTracer(context.Background(), tracer),
+			&rules.MatchContext{
+				ValidatorType: rules.ValidatorGitPush,
+				GitContext:    &rules.GitContext{Remote: "origin", Branch: "feature"},
+			},
+		)
+
+		Expect(result.Matched).To(BeTrue())
+		Expect(tracer.evaluations).To(HaveLen(2))
+
+		Expect(tracer.evaluations[0].Rule.Name).To(Equal("block-main"))
+		Expect(tracer.evaluations[0].Matched).To(BeFalse())
+		Expect(tracer.evaluations[0].FailedMatcher).To(Equal("branch_pattern:main"))
+		Expect(tracer.evaluations[0].ValidatorType).To(Equal(rules.ValidatorGitPush))
+
+		Expect(tracer.evaluations[1].Rule.Name).To(Equal("warn-origin"))
+		Expect(tracer.evaluations[1].Matched).To(BeTrue())
+		Expect(tracer.evaluations[1].FailedMatcher).To(BeEmpty())
+	})
+
+	It("does not trace without a tracer in the context", func() {
+		engine.Evaluate(context.Background(), &rules.MatchContext{
+			ValidatorType: rules.ValidatorGitPush,
+		})
+
+		Expect(tracer.evaluations).To(BeEmpty())
+	})
+
+	Describe("FailedMatcher", func() {
+		ctx := &rules.MatchContext{
+			ValidatorType: rules.ValidatorGitPush,
+			GitContext:    &rules.GitContext{Remote: "upstream"},
+		}
+
+		It("returns an empty string for matching matchers", func() {
+			Expect(rules.FailedMatcher(rules.NewValidatorTypeMatcher(rules.ValidatorGitAll), ctx)).
+				To(BeEmpty())
+		})
+
+		It("returns the first failing child of an AND matcher", func() {
+			matcher := rules.NewAndMatcher(
+				rules.NewValidatorTypeMatcher(rules.ValidatorGitPush),
+				rules.NewRemoteMatcher("origin"),
+			)
+
+			Expect(rules.FailedMatcher(matcher, ctx)).To(Equal("remote:origin"))
+		})
+
+		It("lists every failing child of an OR matcher", func() {
+			matcher := rules.NewOrMatcher(
+				rules.NewRemoteMatcher("origin"),
+				rules.NewValidatorTypeMatcher(rules.ValidatorGitCommit),
+			)
+
+			Expect(rules.FailedMatcher(matcher, ctx)).
+				To(Equal("OR(remote:origin, validator_type:git.commit)"))
+		})
+
+		It("reports the inverted matcher of a NOT matcher", func() {
+			matcher := rules.NewNotMatcher(rules.NewRemoteMatcher("upstream"))
+
+			Expect(rules.FailedMatcher(matcher, ctx)).To(Equal("NOT(remote:upstream)"))
+		})
+	})
+})
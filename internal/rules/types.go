@@ -4,9 +4,12 @@ package rules
 
 import (
 	"context"
+	"os"
+	"time"
 
 	"github.com/smykla-labs/klaudiush/internal/validator"
 	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
 )
 
 // ActionType represents the action to take when a rule matches.
@@ -21,6 +24,20 @@ const (
 
 	// ActionAllow explicitly allows the operation.
 	ActionAllow ActionType = "allow"
+
+	// ActionAsk asks the user to confirm the operation.
+	ActionAsk ActionType = "ask"
+
+	// ActionSuggest blocks the operation and suggests a replacement command.
+	ActionSuggest ActionType = "suggest"
+
+	// ActionPoison blocks the operation and poisons the session with the
+	// rule's reference code.
+	ActionPoison ActionType = "poison"
+
+	// ActionLog records the match in the rules audit log without affecting
+	// the decision. Evaluation continues with the next rule.
+	ActionLog ActionType = "log"
 )
 
 // ValidatorType identifies a specific validator or group of validators.
@@ -30,30 +47,34 @@ type ValidatorType string
 
 // Common validator type constants.
 const (
-	ValidatorGitPush        ValidatorType = "git.push"
-	ValidatorGitFetch       ValidatorType = "git.fetch"
-	ValidatorGitCommit      ValidatorType = "git.commit"
-	ValidatorGitAdd         ValidatorType = "git.add"
-	ValidatorGitPR          ValidatorType = "git.pr"
-	ValidatorGitMerge       ValidatorType = "git.merge"
-	ValidatorGitBranch      ValidatorType = "git.branch"
-	ValidatorGitNoVerify    ValidatorType = "git.no_verify"
-	ValidatorGitAll         ValidatorType = "git.*"
-	ValidatorGitHubIssue    ValidatorType = "github.issue"
-	ValidatorGitHubAll      ValidatorType = "github.*"
-	ValidatorFileMarkdown   ValidatorType = "file.markdown"
-	ValidatorFileShell      ValidatorType = "file.shell"
-	ValidatorFileTerraform  ValidatorType = "file.terraform"
-	ValidatorFileWorkflow   ValidatorType = "file.workflow"
-	ValidatorFileGofumpt    ValidatorType = "file.gofumpt"
-	ValidatorFilePython     ValidatorType = "file.python"
-	ValidatorFileJavaScript ValidatorType = "file.javascript"
-	ValidatorFileRust       ValidatorType = "file.rust"
-	ValidatorFileAll        ValidatorType = "file.*"
-	ValidatorSecrets        ValidatorType = "secrets.secrets"
-	ValidatorShellBacktick  ValidatorType = "shell.backtick"
-	ValidatorNotification   ValidatorType = "notification.bell"
-	ValidatorAll            ValidatorType = "*"
+	ValidatorGitPush           ValidatorType = "git.push"
+	ValidatorGitFetch          ValidatorType = "git.fetch"
+	ValidatorGitCommit         ValidatorType = "git.commit"
+	ValidatorGitAdd            ValidatorType = "git.add"
+	ValidatorGitPR             ValidatorType = "git.pr"
+	ValidatorGitMerge          ValidatorType = "git.merge"
+	ValidatorGitBranch         ValidatorType = "git.branch"
+	ValidatorGitNoVerify       ValidatorType = "git.no_verify"
+	ValidatorGitAll            ValidatorType = "git.*"
+	ValidatorGitHubIssue       ValidatorType = "github.issue"
+	ValidatorGitHubAll         ValidatorType = "github.*"
+	ValidatorFileMarkdown      ValidatorType = "file.markdown"
+	ValidatorFileShell         ValidatorType = "file.shell"
+	ValidatorFileTerraform     ValidatorType = "file.terraform"
+	ValidatorFileWorkflow      ValidatorType = "file.workflow"
+	ValidatorFileGofumpt       ValidatorType = "file.gofumpt"
+	ValidatorFilePython        ValidatorType = "file.python"
+	ValidatorFileJavaScript    ValidatorType = "file.javascript"
+	ValidatorFileRust          ValidatorType = "file.rust"
+	ValidatorFileSensitiveRead ValidatorType = "file.sensitive_read"
+	ValidatorFileAll           ValidatorType = "file.*"
+	ValidatorSecrets           ValidatorType = "secrets.secrets"
+	ValidatorShellBacktick     ValidatorType = "shell.backtick"
+	ValidatorShellDestructive  ValidatorType = "shell.destructive"
+	ValidatorShellTestFailure  ValidatorType = "shell.test_failure"
+	ValidatorNotification      ValidatorType = "notification.bell"
+	ValidatorRules             ValidatorType = "rules"
+	ValidatorAll               ValidatorType = "*"
 )
 
 // Rule represents a single validation rule with match conditions and action.
@@ -75,6 +96,9 @@ type Rule struct {
 
 	// Action specifies what happens when the rule matches.
 	Action *RuleAction
+
+	// Tests are test cases for the rule, run by RuleEngine.RunTests.
+	Tests []*RuleTest
 }
 
 // RuleMatch contains all conditions for a rule to match.
@@ -127,18 +151,122 @@ type RuleMatch struct {
 
 	// PatternMode specifies how multiple patterns are combined ("any" or "all").
 	PatternMode string
+
+	// Program matches the name of a parsed bash command (e.g., "curl").
+	Program string
+
+	// Subcommand matches the subcommand of a parsed bash command (e.g., "push").
+	Subcommand string
+
+	// Flags lists flags that must all be present on the parsed command.
+	Flags []string
+
+	// WithoutFlags lists flags that must all be absent from the parsed command.
+	WithoutFlags []string
+
+	// Args lists patterns that must each match a positional argument.
+	Args []string
+
+	// InPipeline requires (true) or forbids (false) the command to be part of a pipeline.
+	InPipeline *bool
+
+	// InSubshell requires (true) or forbids (false) the command to run in a subshell.
+	InSubshell *bool
+
+	// InCommandSubstitution requires (true) or forbids (false) the command to
+	// run inside command substitution.
+	InCommandSubstitution *bool
+
+	// PipelinePosition requires the command to be the "first", "middle" or
+	// "last" stage of a pipeline.
+	PipelinePosition string
+
+	// Env maps environment variable names to patterns their values must
+	// match (e.g., {"AWS_PROFILE": "prod-*"}). Unset variables never match.
+	Env map[string]string
+
+	// CWDPattern matches against the working directory of the hook.
+	CWDPattern string
+
+	// TimeWindow restricts the rule to a time-of-day and day-of-week window.
+	TimeWindow *TimeWindow
+
+	// MinSessionCommands requires at least this many commands to have been
+	// recorded in the current session.
+	MinSessionCommands *int
+
+	// MaxSessionCommands requires at most this many commands to have been
+	// recorded in the current session.
+	MaxSessionCommands *int
+
+	// StagedFiles lists file patterns of which at least one must match a
+	// staged file.
+	StagedFiles []string
+
+	// Dirty requires (true) or forbids (false) staged, modified or untracked
+	// files in the working tree.
+	Dirty *bool
+
+	// MinCommitsAhead requires the current branch to be at least this many
+	// commits ahead of its upstream branch.
+	MinCommitsAhead *int
+
+	// MaxCommitsAhead requires the current branch to be at most this many
+	// commits ahead of its upstream branch.
+	MaxCommitsAhead *int
+
+	// RemoteHost matches the host of the remote URL (e.g., "github.com").
+	RemoteHost string
+
+	// RemoteOwner matches the owner of the remote URL (e.g., "smykla-labs").
+	RemoteOwner string
+
+	// RemoteRepo matches the repository name of the remote URL.
+	RemoteRepo string
+
+	// All contains nested conditions that must all match.
+	All []*RuleMatch
+
+	// Any contains nested conditions of which at least one must match.
+	Any []*RuleMatch
+
+	// Not contains nested conditions that must not match.
+	Not *RuleMatch
+}
+
+// TimeWindow is a time-of-day and day-of-week window.
+type TimeWindow struct {
+	// Start is the start of the window in "HH:MM" format (inclusive).
+	Start string
+
+	// End is the end of the window in "HH:MM" format (exclusive). A window
+	// with End before Start spans midnight (e.g., "22:00" to "06:00").
+	End string
+
+	// Days restricts the window to days of the week ("mon" through "sun").
+	// For windows spanning midnight, the day is the day the window starts.
+	Days []string
+
+	// Timezone is the IANA time zone name (e.g., "Europe/Warsaw").
+	// Default: local time.
+	Timezone string
 }
 
 // RuleAction specifies what happens when a rule matches.
 type RuleAction struct {
-	// Type is the action to take (block, warn, allow).
+	// Type is the action to take (block, warn, allow, ask, suggest, poison, log).
 	Type ActionType
 
-	// Message is the human-readable message to display.
+	// Message is the human-readable message to display. It may be a
+	// text/template executed with templates.ContextData.
 	Message string
 
 	// Reference is an optional error reference code (e.g., "GIT019").
 	Reference string
+
+	// Suggestion is the text/template for the replacement command of the
+	// suggest action. It is executed with templates.ContextData.
+	Suggestion string
 }
 
 // RuleResult represents the outcome of rule evaluation.
@@ -157,6 +285,13 @@ type RuleResult struct {
 
 	// Reference is the error reference code (if any).
 	Reference string
+
+	// Suggestion is the rendered replacement command (suggest action only).
+	Suggestion string
+
+	// Logged contains the rules with the log action that matched. They do
+	// not affect the decision.
+	Logged []*Rule
 }
 
 // GitContext contains git-specific data for rule matching.
@@ -172,6 +307,45 @@ type GitContext struct {
 
 	// IsInRepo indicates whether we're inside a git repository.
 	IsInRepo bool
+
+	// RemoteURL is the URL of Remote (if known).
+	RemoteURL string
+}
+
+// GitState contains working tree and upstream data for rule matching. It is
+// more expensive to collect than GitContext, so it is only loaded for rules
+// with git state conditions.
+type GitState struct {
+	// StagedFiles lists the files staged for commit.
+	StagedFiles []string
+
+	// ModifiedFiles lists the modified but unstaged files.
+	ModifiedFiles []string
+
+	// UntrackedFiles lists the untracked files.
+	UntrackedFiles []string
+
+	// HasUpstream indicates whether the current branch has an upstream branch.
+	HasUpstream bool
+
+	// CommitsAhead is the number of commits the current branch is ahead of
+	// its upstream branch.
+	CommitsAhead int
+}
+
+// IsDirty returns true if there are staged, modified or untracked files.
+func (s *GitState) IsDirty() bool {
+	return len(s.StagedFiles) > 0 || len(s.ModifiedFiles) > 0 || len(s.UntrackedFiles) > 0
+}
+
+// GitProvider loads git data for rule matching. It is only called for rules
+// with git conditions.
+type GitProvider interface {
+	// GitContext returns the git context, or nil outside a git repository.
+	GitContext(ctx *MatchContext) *GitContext
+
+	// GitState returns the git state, or nil if it cannot be determined.
+	GitState(ctx *MatchContext) *GitState
 }
 
 // FileContext contains file-specific data for rule matching.
@@ -188,9 +362,14 @@ type MatchContext struct {
 	// HookContext is the original hook context.
 	HookContext *hook.Context
 
-	// GitContext contains git-related data (may be nil).
+	// GitContext contains git-related data (may be nil). If nil, it is
+	// loaded from the engine's git provider on first use.
 	GitContext *GitContext
 
+	// GitState contains working tree and upstream data (may be nil). If nil,
+	// it is loaded from the engine's git provider on first use.
+	GitState *GitState
+
 	// FileContext contains file-related data (may be nil).
 	FileContext *FileContext
 
@@ -199,6 +378,128 @@ type MatchContext struct {
 
 	// Command is the bash command being executed (if applicable).
 	Command string
+
+	// Session contains data about the current session (may be nil).
+	Session *SessionContext
+
+	// LookupEnv looks up environment variables. Defaults to os.LookupEnv.
+	LookupEnv func(key string) (string, bool)
+
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+
+	// parsedCommands caches the commands extracted from the bash command.
+	parsedCommands []parser.Command
+
+	// parsed indicates whether parsedCommands has been populated.
+	parsed bool
+
+	// gitProvider loads GitContext and GitState on first use.
+	gitProvider GitProvider
+
+	// gitLoaded and gitStateLoaded indicate whether gitProvider was called.
+	gitLoaded      bool
+	gitStateLoaded bool
+
+	// scopedOnly skips rules without a validator_type or with "*", which
+	// are evaluated by other validators.
+	scopedOnly bool
+}
+
+// SessionContext contains session data for rule matching.
+type SessionContext struct {
+	// ID is the session identifier.
+	ID string
+
+	// CommandCount is the number of commands recorded in the session before
+	// the current one.
+	CommandCount int
+}
+
+// SessionProvider returns the session data for a session ID, or nil if
+// session data is not available.
+type SessionProvider func(sessionID string) *SessionContext
+
+// WorkingDir returns the working directory of the hook, falling back to the
+// working directory of the process.
+func (ctx *MatchContext) WorkingDir() string {
+	if ctx.HookContext != nil && ctx.HookContext.CWD != "" {
+		return ctx.HookContext.CWD
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+
+	return dir
+}
+
+// lookupEnv looks up an environment variable using LookupEnv if set.
+func (ctx *MatchContext) lookupEnv(key string) (string, bool) {
+	if ctx.LookupEnv != nil {
+		return ctx.LookupEnv(key)
+	}
+
+	return os.LookupEnv(key)
+}
+
+// now returns the current time using Now if set.
+func (ctx *MatchContext) now() time.Time {
+	if ctx.Now != nil {
+		return ctx.Now()
+	}
+
+	return time.Now()
+}
+
+// git returns the git context, loading it from the git provider on first use.
+func (ctx *MatchContext) git() *GitContext {
+	if ctx.GitContext == nil && ctx.gitProvider != nil && !ctx.gitLoaded {
+		ctx.gitLoaded = true
+		ctx.GitContext = ctx.gitProvider.GitContext(ctx)
+	}
+
+	return ctx.GitContext
+}
+
+// gitState returns the git state, loading it from the git provider on first use.
+func (ctx *MatchContext) gitState() *GitState {
+	if ctx.GitState == nil && ctx.gitProvider != nil && !ctx.gitStateLoaded {
+		ctx.gitStateLoaded = true
+		ctx.GitState = ctx.gitProvider.GitState(ctx)
+	}
+
+	return ctx.GitState
+}
+
+// ParsedCommands returns the commands extracted from the bash command,
+// parsing it on first use. Returns nil if there is no command or it cannot
+// be parsed.
+func (ctx *MatchContext) ParsedCommands() []parser.Command {
+	if ctx.parsed {
+		return ctx.parsedCommands
+	}
+
+	ctx.parsed = true
+
+	command := ctx.Command
+	if command == "" && ctx.HookContext != nil {
+		command = ctx.HookContext.GetCommand()
+	}
+
+	if command == "" {
+		return nil
+	}
+
+	result, err := parser.NewBashParser().Parse(command)
+	if err != nil {
+		return nil
+	}
+
+	ctx.parsedCommands = result.Commands
+
+	return ctx.parsedCommands
 }
 
 // Engine is the main interface for the rule engine.
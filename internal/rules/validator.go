@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"context"
+
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+// Validator is a standalone validator that evaluates rules against every
+// hook context, so rules take effect without a backing built-in validator.
+//
+// Rules are matched with the "rules" validator type. Rules scoped to a
+// built-in validator (e.g., "git.push") are left to that validator. Rules
+// without a validator_type or with "*" are evaluated by the built-in
+// validators, so they are only evaluated here for hook contexts that no
+// built-in validator covers.
+type Validator struct {
+	validator.BaseValidator
+	adapter *RuleValidatorAdapter
+
+	// coveredBy matches the hook contexts for which built-in validators
+	// evaluate rules.
+	coveredBy validator.Predicate
+}
+
+// NewValidator creates a new standalone rules validator for the engine.
+// coveredBy matches the hook contexts for which built-in validators
+// evaluate rules.
+func NewValidator(
+	engine *RuleEngine,
+	log logger.Logger,
+	coveredBy validator.Predicate,
+) *Validator {
+	return &Validator{
+		BaseValidator: *validator.NewBaseValidator("validate-rules", log),
+		adapter: NewRuleValidatorAdapter(
+			engine,
+			ValidatorRules,
+			WithAdapterLogger(log),
+		),
+		coveredBy: coveredBy,
+	}
+}
+
+// Validate evaluates the enabled rules that no built-in validator evaluates
+// for the hook context.
+func (v *Validator) Validate(ctx context.Context, hookCtx *hook.Context) *validator.Result {
+	v.Logger().Debug("Running standalone rules validation")
+
+	scopedOnly := v.coveredBy.Matches(hookCtx)
+
+	if result := v.adapter.checkRules(ctx, hookCtx, scopedOnly); result != nil {
+		return result
+	}
+
+	return validator.Pass()
+}
+
+// Category returns the validator category for parallel execution.
+func (*Validator) Category() validator.ValidatorCategory {
+	return validator.CategoryCPU
+}
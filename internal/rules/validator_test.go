@@ -0,0 +1,106 @@
+package rules_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+var _ = Describe("Validator", func() {
+	var v *rules.Validator
+
+	BeforeEach(func() {
+		engine, err := rules.NewRuleEngine([]*rules.Rule{
+			{
+				Name:    "no-kubectl-delete",
+				Enabled: true,
+				Match:   &rules.RuleMatch{Program: "kubectl", Subcommand: "delete"},
+				Action: &rules.RuleAction{
+					Type:    rules.ActionBlock,
+					Message: "kubectl delete is not allowed",
+				},
+			},
+			{
+				Name:    "no-env-writes",
+				Enabled: true,
+				Match:   &rules.RuleMatch{FilePattern: "**/.env"},
+				Action:  &rules.RuleAction{Type: rules.ActionBlock, Message: ".env writes are not allowed"},
+			},
+			{
+				Name:    "standalone-only",
+				Enabled: true,
+				Match: &rules.RuleMatch{
+					ValidatorType: rules.ValidatorRules,
+					FilePattern:   "**/secrets.txt",
+				},
+				Action: &rules.RuleAction{Type: rules.ActionBlock, Message: "secrets.txt is off limits"},
+			},
+			{
+				Name:    "push-only",
+				Enabled: true,
+				Match: &rules.RuleMatch{
+					ValidatorType:  rules.ValidatorGitPush,
+					CommandPattern: "git push*",
+				},
+				Action: &rules.RuleAction{Type: rules.ActionBlock},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		v = rules.NewValidator(
+			engine,
+			logger.NewNoOpLogger(),
+			validator.ToolTypeIs(hook.ToolTypeWrite),
+		)
+	})
+
+	bash := func(command string) *hook.Context {
+		return &hook.Context{
+			EventType: hook.EventTypePreToolUse,
+			ToolName:  hook.ToolTypeBash,
+			ToolInput: hook.ToolInput{Command: command},
+		}
+	}
+
+	It("evaluates unscoped rules for commands without a built-in validator", func() {
+		result := v.Validate(context.Background(), bash("kubectl delete ns prod"))
+
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.ShouldBlock).To(BeTrue())
+		Expect(result.Message).To(Equal("kubectl delete is not allowed"))
+	})
+
+	It("leaves unscoped rules to the built-in validators covering the context", func() {
+		write := func(path string) *hook.Context {
+			return &hook.Context{
+				EventType: hook.EventTypePreToolUse,
+				ToolName:  hook.ToolTypeWrite,
+				ToolInput: hook.ToolInput{FilePath: path, Content: "x"},
+			}
+		}
+
+		Expect(v.Validate(context.Background(), write("app/.env")).Passed).To(BeTrue())
+
+		result := v.Validate(context.Background(), write("app/secrets.txt"))
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Message).To(Equal("secrets.txt is off limits"))
+	})
+
+	It("leaves rules scoped to a built-in validator alone", func() {
+		Expect(v.Validate(context.Background(), bash("git push origin main")).Passed).To(BeTrue())
+	})
+
+	It("passes when no rule matches", func() {
+		Expect(v.Validate(context.Background(), bash("kubectl get pods")).Passed).To(BeTrue())
+	})
+
+	It("is named validate-rules", func() {
+		Expect(v.Name()).To(Equal("validate-rules"))
+	})
+})
@@ -14,6 +14,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 
+	"github.com/smykla-labs/klaudiush/internal/statestore"
 	"github.com/smykla-labs/klaudiush/pkg/config"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
 )
@@ -51,6 +52,14 @@ const (
 
 	// AuditActionUnpoison indicates a session was unpoisoned.
 	AuditActionUnpoison
+
+	// AuditActionWarn indicates a session was warned about blocked attempts.
+	AuditActionWarn
+
+	// AuditActionExpire indicates the poisoning of a session expired after
+	// its cooldown, or its warning expired as blocked attempts left the
+	// escalation window.
+	AuditActionExpire
 )
 
 // AuditEntry represents an audit log entry for session operations.
@@ -58,7 +67,7 @@ type AuditEntry struct {
 	// Timestamp is when the action occurred.
 	Timestamp time.Time `json:"timestamp"`
 
-	// Action is the type of operation (poison/unpoison).
+	// Action is the type of operation (poison/unpoison/warn/expire).
 	Action AuditAction `json:"action"`
 
 	// SessionID is the Claude Code session identifier.
@@ -82,6 +91,16 @@ type AuditEntry struct {
 
 	// WorkingDir is the working directory when the action occurred.
 	WorkingDir string `json:"working_dir,omitempty"`
+
+	// PreviousStatus is the session status before the action.
+	PreviousStatus Status `json:"previous_status"`
+
+	// Status is the session status after the action.
+	Status Status `json:"status"`
+
+	// Attempts is the number of blocked attempts of the codes within the
+	// escalation window (for poison and warn actions).
+	Attempts int `json:"attempts,omitempty"`
 }
 
 // AuditLogger manages audit logging for session operations.
@@ -167,28 +186,32 @@ func (a *AuditLogger) Log(entry *AuditEntry) error {
 		return nil
 	}
 
-	a.mu.Lock()
-	defer a.mu.Unlock()
-
-	// Check and perform rotation if needed
-	if rotateErr := a.rotateIfNeededLocked(); rotateErr != nil {
-		a.logger.Error("failed to rotate session audit log",
-			"error", rotateErr.Error(),
-		)
-		// Continue to log even if rotation fails
-	}
-
 	// Marshal entry to JSON
 	data, err := json.Marshal(entry)
 	if err != nil {
 		return errors.Wrap(err, "marshaling session audit entry")
 	}
 
-	return a.writeEntryLocked(data)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Lock the log file, so entries and rotations of concurrent invocations
+	// do not interleave
+	return a.store().WithLock(func() error {
+		// Check and perform rotation if needed
+		if rotateErr := a.rotateIfNeededLocked(); rotateErr != nil {
+			a.logger.Error("failed to rotate session audit log",
+				"error", rotateErr.Error(),
+			)
+			// Continue to log even if rotation fails
+		}
+
+		return a.writeEntryLocked(data)
+	})
 }
 
 // writeEntryLocked writes the JSON data to the log file.
-// Must be called with mu held.
+// Must be called with mu and the log file lock held.
 func (a *AuditLogger) writeEntryLocked(data []byte) error {
 	path := a.resolveLogPath()
 
@@ -298,7 +321,7 @@ func (a *AuditLogger) Rotate() error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	return a.rotateLocked()
+	return a.store().WithLock(a.rotateLocked)
 }
 
 // Cleanup removes old backup files and entries exceeding retention.
@@ -306,13 +329,25 @@ func (a *AuditLogger) Cleanup() error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	// Clean up old backup files
-	if err := a.cleanupBackupsLocked(); err != nil {
-		return err
-	}
+	return a.store().WithLock(func() error {
+		// Clean up old backup files
+		if err := a.cleanupBackupsLocked(); err != nil {
+			return err
+		}
 
-	// Clean up old entries from current file
-	return a.cleanupOldEntriesLocked()
+		// Clean up old entries from current file
+		return a.cleanupOldEntriesLocked()
+	})
+}
+
+// store returns the state store guarding the log file against concurrent
+// writes of other processes.
+func (a *AuditLogger) store() *statestore.Store {
+	return statestore.New(
+		a.resolveLogPath(),
+		statestore.WithFilePermissions(auditFilePermissions),
+		statestore.WithDirPermissions(auditDirPermissions),
+	)
 }
 
 // GetLogPath returns the resolved log file path.
@@ -321,7 +356,7 @@ func (a *AuditLogger) GetLogPath() string {
 }
 
 // rotateIfNeededLocked checks if rotation is needed and performs it.
-// Must be called with mu held.
+// Must be called with mu and the log file lock held.
 func (a *AuditLogger) rotateIfNeededLocked() error {
 	path := a.resolveLogPath()
 
@@ -348,7 +383,7 @@ func (a *AuditLogger) rotateIfNeededLocked() error {
 }
 
 // rotateLocked rotates the audit log file.
-// Must be called with mu held.
+// Must be called with mu and the log file lock held.
 func (a *AuditLogger) rotateLocked() error {
 	path := a.resolveLogPath()
 
@@ -378,7 +413,7 @@ func (a *AuditLogger) rotateLocked() error {
 }
 
 // cleanupBackupsLocked removes excess backup files.
-// Must be called with mu held.
+// Must be called with mu and the log file lock held.
 func (a *AuditLogger) cleanupBackupsLocked() error {
 	path := a.resolveLogPath()
 	dir := filepath.Dir(path)
@@ -453,7 +488,7 @@ func (a *AuditLogger) removeExcessBackups(backups []string) {
 }
 
 // cleanupOldEntriesLocked removes entries older than max age.
-// Must be called with mu held.
+// Must be called with mu and the log file lock held.
 func (a *AuditLogger) cleanupOldEntriesLocked() error {
 	path := a.resolveLogPath()
 
@@ -10,11 +10,11 @@ import (
 	"github.com/cockroachdb/errors"
 )
 
-const _AuditActionName = "PoisonUnpoison"
+const _AuditActionName = "PoisonUnpoisonWarnExpire"
 
-var _AuditActionIndex = [...]uint8{0, 6, 14}
+var _AuditActionIndex = [...]uint8{0, 6, 14, 18, 24}
 
-const _AuditActionLowerName = "poisonunpoison"
+const _AuditActionLowerName = "poisonunpoisonwarnexpire"
 
 func (i AuditAction) String() string {
 	if i < 0 || i >= AuditAction(len(_AuditActionIndex)-1) {
@@ -29,20 +29,28 @@ func _AuditActionNoOp() {
 	var x [1]struct{}
 	_ = x[AuditActionPoison-(0)]
 	_ = x[AuditActionUnpoison-(1)]
+	_ = x[AuditActionWarn-(2)]
+	_ = x[AuditActionExpire-(3)]
 }
 
-var _AuditActionValues = []AuditAction{AuditActionPoison, AuditActionUnpoison}
+var _AuditActionValues = []AuditAction{AuditActionPoison, AuditActionUnpoison, AuditActionWarn, AuditActionExpire}
 
 var _AuditActionNameToValueMap = map[string]AuditAction{
-	_AuditActionName[0:6]:       AuditActionPoison,
-	_AuditActionLowerName[0:6]:  AuditActionPoison,
-	_AuditActionName[6:14]:      AuditActionUnpoison,
-	_AuditActionLowerName[6:14]: AuditActionUnpoison,
+	_AuditActionName[0:6]:        AuditActionPoison,
+	_AuditActionLowerName[0:6]:   AuditActionPoison,
+	_AuditActionName[6:14]:       AuditActionUnpoison,
+	_AuditActionLowerName[6:14]:  AuditActionUnpoison,
+	_AuditActionName[14:18]:      AuditActionWarn,
+	_AuditActionLowerName[14:18]: AuditActionWarn,
+	_AuditActionName[18:24]:      AuditActionExpire,
+	_AuditActionLowerName[18:24]: AuditActionExpire,
 }
 
 var _AuditActionNames = []string{
 	_AuditActionName[0:6],
 	_AuditActionName[6:14],
+	_AuditActionName[14:18],
+	_AuditActionName[18:24],
 }
 
 // AuditActionString retrieves an enum value from the enum constants string name.
@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/smykla-labs/klaudiush/internal/session"
+	"github.com/smykla-labs/klaudiush/internal/statestore"
 	"github.com/smykla-labs/klaudiush/pkg/config"
 )
 
@@ -586,7 +587,8 @@ var _ = Describe("AuditLogger additional coverage", func() {
 
 			backupCount := 0
 			for _, f := range files {
-				if f.Name() != "session_audit.jsonl" {
+				if f.Name() != "session_audit.jsonl" &&
+					f.Name() != "session_audit.jsonl"+statestore.LockSuffix {
 					backupCount++
 				}
 			}
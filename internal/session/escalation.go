@@ -0,0 +1,217 @@
+package session
+
+import (
+	"time"
+
+	"github.com/smykla-labs/klaudiush/pkg/config"
+)
+
+// EscalationPolicy defines how blocked attempts escalate a session. Blocked
+// attempts are counted per error code within the window, and the session
+// status is raised to the highest stage whose threshold the count reached.
+// A zero threshold disables its stage.
+type EscalationPolicy struct {
+	// Window is the sliding window in which blocked attempts are counted.
+	// Zero counts only the current attempt.
+	Window time.Duration
+
+	// WarnThreshold is the number of attempts before the session is warned.
+	WarnThreshold int
+
+	// AckThreshold is the number of attempts before the session is poisoned
+	// and requires an acknowledgment token.
+	AckThreshold int
+
+	// PoisonThreshold is the number of attempts before the session is
+	// hard-poisoned.
+	PoisonThreshold int
+
+	// Cooldown is how long a session stays poisoned by escalation before it
+	// is cleaned automatically. Zero disables automatic expiry.
+	Cooldown time.Duration
+}
+
+// DefaultEscalationPolicy returns the policy used without escalation config:
+// the first blocking error poisons the session until acknowledged.
+func DefaultEscalationPolicy() EscalationPolicy {
+	return EscalationPolicy{AckThreshold: 1}
+}
+
+// NewEscalationPolicy creates the escalation policy of the session config.
+func NewEscalationPolicy(cfg *config.SessionConfig) EscalationPolicy {
+	if !cfg.IsEscalationEnabled() {
+		return DefaultEscalationPolicy()
+	}
+
+	escalation := cfg.Escalation
+
+	return EscalationPolicy{
+		Window:          escalation.GetWindow(),
+		WarnThreshold:   escalation.GetWarnThreshold(),
+		AckThreshold:    escalation.GetAckThreshold(),
+		PoisonThreshold: escalation.GetPoisonThreshold(),
+		Cooldown:        escalation.GetPoisonCooldown(),
+	}
+}
+
+// Transition describes a change of the session status.
+type Transition struct {
+	// From is the status before the change.
+	From Status
+
+	// To is the status after the change.
+	To Status
+
+	// Codes are the error codes that caused the change.
+	Codes []string
+
+	// Attempts is the highest number of blocked attempts of the codes within
+	// the escalation window.
+	Attempts int
+}
+
+// Changed returns true if the status changed.
+func (t Transition) Changed() bool {
+	return t.From != t.To
+}
+
+// statusFor returns the status for the given number of blocked attempts.
+func (p EscalationPolicy) statusFor(attempts int) Status {
+	switch {
+	case p.PoisonThreshold > 0 && attempts >= p.PoisonThreshold:
+		return StatusHardPoisoned
+	case p.AckThreshold > 0 && attempts >= p.AckThreshold:
+		return StatusPoisoned
+	case p.WarnThreshold > 0 && attempts >= p.WarnThreshold:
+		return StatusWarned
+	default:
+		return StatusClean
+	}
+}
+
+// recordBlocked records a blocked attempt of codes at now and raises the
+// session status if a threshold was reached. An expired warning is cleared
+// first, otherwise the status is never lowered.
+func (p EscalationPolicy) recordBlocked(
+	info *SessionInfo,
+	codes []string,
+	message string,
+	now time.Time,
+) Transition {
+	p.expireWarning(info, now)
+
+	attempts := 1
+
+	if p.Window > 0 {
+		p.pruneAttempts(info, now)
+
+		if info.BlockedAttempts == nil {
+			info.BlockedAttempts = make(map[string][]time.Time, len(codes))
+		}
+
+		for _, code := range codes {
+			info.BlockedAttempts[code] = append(info.BlockedAttempts[code], now)
+			attempts = max(attempts, len(info.BlockedAttempts[code]))
+		}
+	}
+
+	transition := Transition{
+		From:     info.Status,
+		To:       info.Status,
+		Codes:    codes,
+		Attempts: attempts,
+	}
+
+	status := p.statusFor(attempts)
+	if status.severity() <= info.Status.severity() {
+		return transition
+	}
+
+	transition.To = status
+	info.Status = status
+
+	if info.IsPoisoned() {
+		info.PoisonedAt = &now
+		info.PoisonCodes = codes
+		info.PoisonMessage = message
+		info.PoisonExpiresAt = nil
+
+		if p.Cooldown > 0 {
+			expiresAt := now.Add(p.Cooldown)
+			info.PoisonExpiresAt = &expiresAt
+		}
+	}
+
+	return transition
+}
+
+// isWarningExpired returns true if the session is warned, but its blocked
+// attempts within the window at now fell below the warn threshold.
+func (p EscalationPolicy) isWarningExpired(info *SessionInfo, now time.Time) bool {
+	if info.Status != StatusWarned {
+		return false
+	}
+
+	attempts := 0
+
+	if p.Window > 0 {
+		cutoff := now.Add(-p.Window)
+
+		for _, times := range info.BlockedAttempts {
+			count := 0
+
+			for _, attempt := range times {
+				if attempt.After(cutoff) {
+					count++
+				}
+			}
+
+			attempts = max(attempts, count)
+		}
+	}
+
+	return p.statusFor(attempts).severity() < StatusWarned.severity()
+}
+
+// expireWarning lowers a warned session back to clean if its warning
+// expired. Returns true if the warning expired.
+func (p EscalationPolicy) expireWarning(info *SessionInfo, now time.Time) bool {
+	if !p.isWarningExpired(info, now) {
+		return false
+	}
+
+	info.Status = StatusClean
+
+	if p.Window > 0 {
+		p.pruneAttempts(info, now)
+	}
+
+	return true
+}
+
+// pruneAttempts removes blocked attempts that fell out of the window.
+func (p EscalationPolicy) pruneAttempts(info *SessionInfo, now time.Time) {
+	cutoff := now.Add(-p.Window)
+
+	for code, attempts := range info.BlockedAttempts {
+		kept := attempts[:0]
+
+		for _, attempt := range attempts {
+			if attempt.After(cutoff) {
+				kept = append(kept, attempt)
+			}
+		}
+
+		if len(kept) == 0 {
+			delete(info.BlockedAttempts, code)
+
+			continue
+		}
+
+		info.BlockedAttempts[code] = kept
+	}
+
+	if len(info.BlockedAttempts) == 0 {
+		info.BlockedAttempts = nil
+	}
+}
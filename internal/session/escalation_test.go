@@ -0,0 +1,252 @@
+package session_test
+
+import (
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/session"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+)
+
+var _ = Describe("Escalation", func() {
+	const sessionID = "session-1"
+
+	var (
+		tracker     *session.Tracker
+		currentTime time.Time
+		policy      session.EscalationPolicy
+	)
+
+	newTracker := func(opts ...session.TrackerOption) *session.Tracker {
+		return session.NewTracker(nil, append([]session.TrackerOption{
+			session.WithStateFile(filepath.Join(GinkgoT().TempDir(), "session_state.json")),
+			session.WithTimeFunc(func() time.Time { return currentTime }),
+		}, opts...)...)
+	}
+
+	BeforeEach(func() {
+		currentTime = time.Date(2025, 12, 4, 10, 30, 0, 0, time.UTC)
+		policy = session.EscalationPolicy{
+			Window:          time.Hour,
+			WarnThreshold:   1,
+			AckThreshold:    2,
+			PoisonThreshold: 3,
+		}
+	})
+
+	Describe("NewEscalationPolicy", func() {
+		It("poisons on the first attempt without escalation config", func() {
+			Expect(session.NewEscalationPolicy(nil)).To(Equal(session.DefaultEscalationPolicy()))
+			Expect(session.NewEscalationPolicy(&config.SessionConfig{})).
+				To(Equal(session.DefaultEscalationPolicy()))
+		})
+
+		It("uses the configured thresholds", func() {
+			cfg := &config.SessionConfig{
+				Escalation: &config.SessionEscalationConfig{
+					Window:         config.Duration(10 * time.Minute),
+					AckThreshold:   3,
+					PoisonCooldown: config.Duration(time.Hour),
+				},
+			}
+
+			Expect(session.NewEscalationPolicy(cfg)).To(Equal(session.EscalationPolicy{
+				Window:          10 * time.Minute,
+				WarnThreshold:   config.DefaultEscalationWarnThreshold,
+				AckThreshold:    3,
+				PoisonThreshold: config.DefaultEscalationPoisonThreshold,
+				Cooldown:        time.Hour,
+			}))
+		})
+	})
+
+	Describe("RecordBlocked", func() {
+		It("poisons on the first attempt with the default policy", func() {
+			tracker = newTracker()
+
+			transition := tracker.RecordBlocked(sessionID, []string{"GIT001"}, "blocked")
+			Expect(transition.From).To(Equal(session.StatusClean))
+			Expect(transition.To).To(Equal(session.StatusPoisoned))
+
+			info := tracker.GetInfo(sessionID)
+			Expect(info.PoisonCodes).To(Equal([]string{"GIT001"}))
+			Expect(info.PoisonMessage).To(Equal("blocked"))
+			Expect(info.BlockedAttempts).To(BeEmpty())
+		})
+
+		It("moves through warned, poisoned and hard-poisoned", func() {
+			tracker = newTracker(session.WithEscalationPolicy(policy))
+
+			transition := tracker.RecordBlocked(sessionID, []string{"GIT001"}, "blocked")
+			Expect(transition.To).To(Equal(session.StatusWarned))
+			Expect(transition.Attempts).To(Equal(1))
+
+			transition = tracker.RecordBlocked(sessionID, []string{"GIT001"}, "blocked")
+			Expect(transition.From).To(Equal(session.StatusWarned))
+			Expect(transition.To).To(Equal(session.StatusPoisoned))
+
+			// Acknowledging keeps the attempts, so retrying escalates further
+			tracker.Unpoison(sessionID)
+
+			transition = tracker.RecordBlocked(sessionID, []string{"GIT001"}, "blocked")
+			Expect(transition.From).To(Equal(session.StatusClean))
+			Expect(transition.To).To(Equal(session.StatusHardPoisoned))
+			Expect(transition.Attempts).To(Equal(3))
+
+			poisoned, info := tracker.IsPoisoned(sessionID)
+			Expect(poisoned).To(BeTrue())
+			Expect(info.IsHardPoisoned()).To(BeTrue())
+		})
+
+		It("counts attempts per code", func() {
+			tracker = newTracker(session.WithEscalationPolicy(policy))
+
+			tracker.RecordBlocked(sessionID, []string{"GIT001"}, "blocked")
+			transition := tracker.RecordBlocked(sessionID, []string{"GIT002"}, "blocked")
+
+			Expect(transition.Changed()).To(BeFalse())
+			Expect(transition.Attempts).To(Equal(1))
+			Expect(tracker.GetInfo(sessionID).BlockedAttempts).To(HaveLen(2))
+		})
+
+		It("forgets attempts outside the window", func() {
+			tracker = newTracker(session.WithEscalationPolicy(policy))
+
+			tracker.RecordBlocked(sessionID, []string{"GIT001"}, "blocked")
+
+			currentTime = currentTime.Add(2 * time.Hour)
+
+			transition := tracker.RecordBlocked(sessionID, []string{"GIT001"}, "blocked")
+			Expect(transition.Attempts).To(Equal(1))
+			Expect(transition.To).To(Equal(session.StatusWarned))
+		})
+
+		It("keeps attempts of other trackers when saving", func() {
+			stateFile := filepath.Join(GinkgoT().TempDir(), "session_state.json")
+			open := func() *session.Tracker {
+				t := session.NewTracker(nil,
+					session.WithStateFile(stateFile),
+					session.WithTimeFunc(func() time.Time { return currentTime }),
+					session.WithEscalationPolicy(policy),
+				)
+				Expect(t.Load()).To(Succeed())
+
+				return t
+			}
+
+			first, second := open(), open()
+
+			first.RecordBlocked(sessionID, []string{"GIT001"}, "blocked")
+			second.RecordBlocked(sessionID, []string{"GIT001"}, "blocked")
+			Expect(first.Save()).To(Succeed())
+			Expect(second.Save()).To(Succeed())
+
+			info := open().GetInfo(sessionID)
+			Expect(info.BlockedAttempts["GIT001"]).To(HaveLen(2))
+			Expect(info.Status).To(Equal(session.StatusPoisoned))
+		})
+	})
+
+	Describe("PreviewBlocked", func() {
+		It("does not change the session", func() {
+			tracker = newTracker(session.WithEscalationPolicy(policy))
+			tracker.RecordBlocked(sessionID, []string{"GIT001"}, "blocked")
+
+			transition := tracker.PreviewBlocked(sessionID, []string{"GIT001"})
+			Expect(transition.To).To(Equal(session.StatusPoisoned))
+
+			info := tracker.GetInfo(sessionID)
+			Expect(info.Status).To(Equal(session.StatusWarned))
+			Expect(info.BlockedAttempts["GIT001"]).To(HaveLen(1))
+		})
+	})
+
+	Describe("ExpireCooldown", func() {
+		BeforeEach(func() {
+			policy.Cooldown = 30 * time.Minute
+			tracker = newTracker(session.WithEscalationPolicy(policy))
+
+			tracker.RecordBlocked(sessionID, []string{"GIT001"}, "blocked")
+			tracker.RecordBlocked(sessionID, []string{"GIT001"}, "blocked")
+		})
+
+		It("sets the expiry when poisoning", func() {
+			info := tracker.GetInfo(sessionID)
+			Expect(*info.PoisonExpiresAt).To(Equal(currentTime.Add(30 * time.Minute)))
+		})
+
+		It("keeps the poisoning before the cooldown elapsed", func() {
+			currentTime = currentTime.Add(29 * time.Minute)
+
+			Expect(tracker.ExpireCooldown(sessionID).Changed()).To(BeFalse())
+
+			poisoned, _ := tracker.IsPoisoned(sessionID)
+			Expect(poisoned).To(BeTrue())
+		})
+
+		It("cleans the session after the cooldown elapsed", func() {
+			currentTime = currentTime.Add(30 * time.Minute)
+
+			// The expired poisoning no longer fast-fails before it is recorded
+			poisoned, _ := tracker.IsPoisoned(sessionID)
+			Expect(poisoned).To(BeFalse())
+
+			transition := tracker.ExpireCooldown(sessionID)
+			Expect(transition.From).To(Equal(session.StatusPoisoned))
+			Expect(transition.To).To(Equal(session.StatusClean))
+			Expect(transition.Codes).To(Equal([]string{"GIT001"}))
+
+			info := tracker.GetInfo(sessionID)
+			Expect(info.Status).To(Equal(session.StatusClean))
+			Expect(info.PoisonExpiresAt).To(BeNil())
+			Expect(info.BlockedAttempts).To(BeEmpty())
+		})
+	})
+
+	Describe("warning expiry", func() {
+		BeforeEach(func() {
+			policy.WarnThreshold = 2
+			policy.AckThreshold = 4
+			policy.PoisonThreshold = 0
+			tracker = newTracker(session.WithEscalationPolicy(policy))
+
+			tracker.RecordBlocked(sessionID, []string{"GIT001"}, "blocked")
+
+			currentTime = currentTime.Add(30 * time.Minute)
+
+			transition := tracker.RecordBlocked(sessionID, []string{"GIT001"}, "blocked")
+			Expect(transition.To).To(Equal(session.StatusWarned))
+		})
+
+		It("keeps the warning while the attempts reach the threshold", func() {
+			currentTime = currentTime.Add(29 * time.Minute)
+
+			Expect(tracker.ExpireCooldown(sessionID).Changed()).To(BeFalse())
+			Expect(tracker.GetInfo(sessionID).Status).To(Equal(session.StatusWarned))
+		})
+
+		It("cleans the session once attempts fall below the threshold", func() {
+			currentTime = currentTime.Add(30 * time.Minute)
+
+			transition := tracker.ExpireCooldown(sessionID)
+			Expect(transition.From).To(Equal(session.StatusWarned))
+			Expect(transition.To).To(Equal(session.StatusClean))
+
+			info := tracker.GetInfo(sessionID)
+			Expect(info.Status).To(Equal(session.StatusClean))
+			Expect(info.BlockedAttempts["GIT001"]).To(HaveLen(1))
+		})
+
+		It("cleans the session before recording the next attempt", func() {
+			currentTime = currentTime.Add(time.Hour)
+
+			transition := tracker.RecordBlocked(sessionID, []string{"GIT002"}, "blocked")
+			Expect(transition.From).To(Equal(session.StatusClean))
+			Expect(transition.To).To(Equal(session.StatusClean))
+			Expect(tracker.GetInfo(sessionID).Status).To(Equal(session.StatusClean))
+		})
+	})
+})
@@ -6,48 +6,36 @@ import (
 	"path/filepath"
 
 	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/internal/statestore"
 )
 
-// Load loads the session state from the configured state file.
+// Load loads the session state from the configured state file. Changes not
+// saved yet are applied on top of the loaded state.
 func (t *Tracker) Load() error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
 	path := t.resolveStatePath()
 
-	// Path comes from trusted configuration, not user input.
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from config
+	data, err := t.store().Read()
 	if err != nil {
-		if os.IsNotExist(err) {
-			t.logger.Debug("state file does not exist, using fresh state",
-				"path", path,
-			)
-
-			return nil
-		}
-
 		return errors.Wrap(err, "reading state file")
 	}
 
-	var state SessionState
-	if err := json.Unmarshal(data, &state); err != nil {
-		t.logger.Debug("failed to parse state file, using fresh state",
+	if data == nil {
+		t.logger.Debug("state file does not exist, using fresh state",
 			"path", path,
-			"error", err.Error(),
 		)
 
 		return nil
 	}
 
-	// Initialize map if nil (could happen with corrupted/old state files)
-	if state.Sessions == nil {
-		state.Sessions = make(map[string]*SessionInfo)
-	}
-
-	t.state = &state
+	t.state = t.parseState(path, data)
 
-	// Cleanup expired sessions after loading
-	t.cleanupExpiredLocked()
+	for _, change := range t.pending {
+		change(t.state)
+	}
 
 	t.logger.Debug("loaded state from file",
 		"path", path,
@@ -57,46 +45,82 @@ func (t *Tracker) Load() error {
 	return nil
 }
 
-// Save persists the current session state to the configured state file.
+// Save persists the session state to the configured state file. The state
+// file is locked while the changes made since the last load or save are
+// merged into its current content, so concurrent invocations sharing the
+// file do not overwrite each other's changes.
 func (t *Tracker) Save() error {
-	t.mu.RLock()
-	state := t.state
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	path := t.resolveStatePath()
-	t.mu.RUnlock()
 
-	// Ensure directory exists
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, stateDirPermissions); err != nil {
-		return errors.Wrap(err, "creating state directory")
-	}
+	var merged *SessionState
 
-	data, err := json.MarshalIndent(state, "", "  ")
-	if err != nil {
-		return errors.Wrap(err, "marshaling state")
-	}
+	err := t.store().Update(func(current []byte) ([]byte, error) {
+		merged = NewSessionState()
+		if current != nil {
+			merged = t.parseState(path, current)
+		}
 
-	// Write to temp file first for atomic operation
-	tmpPath := path + ".tmp"
-	if err := os.WriteFile(tmpPath, data, stateFilePermissions); err != nil {
-		return errors.Wrap(err, "writing temp state file")
-	}
+		for _, change := range t.pending {
+			change(merged)
+		}
 
-	// Rename for atomic replace
-	if err := os.Rename(tmpPath, path); err != nil {
-		// Clean up temp file on error
-		_ = os.Remove(tmpPath)
+		data, err := json.MarshalIndent(merged, "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "marshaling state")
+		}
 
-		return errors.Wrap(err, "renaming state file")
+		return data, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "saving state file")
 	}
 
+	t.state = merged
+	t.pending = nil
+
 	t.logger.Debug("saved state to file",
 		"path", path,
-		"sessions", len(state.Sessions),
+		"sessions", len(merged.Sessions),
 	)
 
 	return nil
 }
 
+// store returns the state store of the configured state file.
+func (t *Tracker) store() *statestore.Store {
+	return statestore.New(
+		t.resolveStatePath(),
+		statestore.WithFilePermissions(stateFilePermissions),
+		statestore.WithDirPermissions(stateDirPermissions),
+	)
+}
+
+// parseState parses the state file content without expired sessions.
+// Returns a fresh state if the content is corrupted.
+func (t *Tracker) parseState(path string, data []byte) *SessionState {
+	var state SessionState
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.logger.Debug("failed to parse state file, using fresh state",
+			"path", path,
+			"error", err.Error(),
+		)
+
+		return NewSessionState()
+	}
+
+	// Initialize map if nil (could happen with corrupted/old state files)
+	if state.Sessions == nil {
+		state.Sessions = make(map[string]*SessionInfo)
+	}
+
+	t.cleanupExpired(&state)
+
+	return &state
+}
+
 // resolveStatePath expands ~ in the state file path.
 func (t *Tracker) resolveStatePath() string {
 	path := t.stateFile
@@ -110,12 +134,11 @@ func (t *Tracker) resolveStatePath() string {
 	return path
 }
 
-// cleanupExpiredLocked removes expired sessions.
-// Must be called with mu held (write lock).
-func (t *Tracker) cleanupExpiredLocked() {
-	for sessionID, info := range t.state.Sessions {
+// cleanupExpired removes expired sessions from state.
+func (t *Tracker) cleanupExpired(state *SessionState) {
+	for sessionID, info := range state.Sessions {
 		if t.isExpiredLocked(info) {
-			delete(t.state.Sessions, sessionID)
+			delete(state.Sessions, sessionID)
 
 			t.logger.Debug("expired session removed during load",
 				"session_id", sessionID,
@@ -4,6 +4,7 @@
 package session
 
 import (
+	"slices"
 	"time"
 )
 
@@ -18,10 +19,28 @@ const (
 	StatusClean Status = iota
 
 	// StatusPoisoned indicates a session that was blocked by a validation error.
-	// Subsequent commands in this session will be fast-failed.
+	// Subsequent commands in this session will be fast-failed until the poison
+	// codes are acknowledged.
 	StatusPoisoned
+
+	// StatusWarned indicates a session with blocked attempts below the
+	// acknowledgment threshold. Commands are not fast-failed.
+	StatusWarned
+
+	// StatusHardPoisoned indicates a session that kept retrying blocked
+	// commands. Subsequent commands are fast-failed and acknowledgment tokens
+	// are not accepted.
+	StatusHardPoisoned
 )
 
+// statusSeverity lists the statuses from clean to hard-poisoned.
+var statusSeverity = []Status{StatusClean, StatusWarned, StatusPoisoned, StatusHardPoisoned}
+
+// severity returns the position of the status in statusSeverity.
+func (s Status) severity() int {
+	return slices.Index(statusSeverity, s)
+}
+
 // SessionInfo contains the state information for a single session.
 type SessionInfo struct {
 	// SessionID is the unique identifier for the session (UUID format).
@@ -39,6 +58,14 @@ type SessionInfo struct {
 	// PoisonMessage is the original error message that caused the poisoning.
 	PoisonMessage string `json:"poison_message,omitempty"`
 
+	// PoisonExpiresAt is when the poisoning expires automatically (nil if it
+	// does not expire).
+	PoisonExpiresAt *time.Time `json:"poison_expires_at,omitempty"`
+
+	// BlockedAttempts maps error codes to the times of blocked attempts within
+	// the escalation window.
+	BlockedAttempts map[string][]time.Time `json:"blocked_attempts,omitempty"`
+
 	// CommandCount is the number of commands processed in this session.
 	CommandCount int `json:"command_count"`
 
@@ -46,9 +73,56 @@ type SessionInfo struct {
 	LastActivity time.Time `json:"last_activity"`
 }
 
-// IsPoisoned returns true if the session is in poisoned state.
+// IsPoisoned returns true if the session is in poisoned or hard-poisoned state.
 func (s *SessionInfo) IsPoisoned() bool {
-	return s.Status == StatusPoisoned
+	return s.Status == StatusPoisoned || s.Status == StatusHardPoisoned
+}
+
+// IsHardPoisoned returns true if the session is in hard-poisoned state.
+func (s *SessionInfo) IsHardPoisoned() bool {
+	return s.Status == StatusHardPoisoned
+}
+
+// IsPoisonExpired returns true if the session is poisoned and its poisoning
+// expired at the given time.
+func (s *SessionInfo) IsPoisonExpired(now time.Time) bool {
+	return s.IsPoisoned() && s.PoisonExpiresAt != nil && !now.Before(*s.PoisonExpiresAt)
+}
+
+// clearPoison resets the poison state of the session to clean.
+func (s *SessionInfo) clearPoison() {
+	s.Status = StatusClean
+	s.PoisonedAt = nil
+	s.PoisonCodes = nil
+	s.PoisonMessage = ""
+	s.PoisonExpiresAt = nil
+}
+
+// clone returns a deep copy of the session info.
+func (s *SessionInfo) clone() *SessionInfo {
+	infoCopy := *s
+
+	if s.PoisonedAt != nil {
+		poisonedAt := *s.PoisonedAt
+		infoCopy.PoisonedAt = &poisonedAt
+	}
+
+	if s.PoisonExpiresAt != nil {
+		expiresAt := *s.PoisonExpiresAt
+		infoCopy.PoisonExpiresAt = &expiresAt
+	}
+
+	infoCopy.PoisonCodes = slices.Clone(s.PoisonCodes)
+
+	if s.BlockedAttempts != nil {
+		infoCopy.BlockedAttempts = make(map[string][]time.Time, len(s.BlockedAttempts))
+
+		for code, attempts := range s.BlockedAttempts {
+			infoCopy.BlockedAttempts[code] = slices.Clone(attempts)
+		}
+	}
+
+	return &infoCopy
 }
 
 // SessionState contains state for all tracked sessions.
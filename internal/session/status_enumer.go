@@ -10,11 +10,11 @@ import (
 	"github.com/cockroachdb/errors"
 )
 
-const _StatusName = "CleanPoisoned"
+const _StatusName = "CleanPoisonedWarnedHardPoisoned"
 
-var _StatusIndex = [...]uint8{0, 5, 13}
+var _StatusIndex = [...]uint8{0, 5, 13, 19, 31}
 
-const _StatusLowerName = "cleanpoisoned"
+const _StatusLowerName = "cleanpoisonedwarnedhardpoisoned"
 
 func (i Status) String() string {
 	if i < 0 || i >= Status(len(_StatusIndex)-1) {
@@ -29,20 +29,28 @@ func _StatusNoOp() {
 	var x [1]struct{}
 	_ = x[StatusClean-(0)]
 	_ = x[StatusPoisoned-(1)]
+	_ = x[StatusWarned-(2)]
+	_ = x[StatusHardPoisoned-(3)]
 }
 
-var _StatusValues = []Status{StatusClean, StatusPoisoned}
+var _StatusValues = []Status{StatusClean, StatusPoisoned, StatusWarned, StatusHardPoisoned}
 
 var _StatusNameToValueMap = map[string]Status{
-	_StatusName[0:5]:       StatusClean,
-	_StatusLowerName[0:5]:  StatusClean,
-	_StatusName[5:13]:      StatusPoisoned,
-	_StatusLowerName[5:13]: StatusPoisoned,
+	_StatusName[0:5]:        StatusClean,
+	_StatusLowerName[0:5]:   StatusClean,
+	_StatusName[5:13]:       StatusPoisoned,
+	_StatusLowerName[5:13]:  StatusPoisoned,
+	_StatusName[13:19]:      StatusWarned,
+	_StatusLowerName[13:19]: StatusWarned,
+	_StatusName[19:31]:      StatusHardPoisoned,
+	_StatusLowerName[19:31]: StatusHardPoisoned,
 }
 
 var _StatusNames = []string{
 	_StatusName[0:5],
 	_StatusName[5:13],
+	_StatusName[13:19],
+	_StatusName[19:31],
 }
 
 // StatusString retrieves an enum value from the enum constants string name.
@@ -31,14 +31,24 @@ type Tracker struct {
 	// stateFile is the resolved path for state persistence.
 	stateFile string
 
+	// pending are the state changes made since the last save. Save replays
+	// them on the state file, so changes of other processes are kept.
+	pending []stateChange
+
 	// maxSessionAge is the maximum age before a session is expired.
 	maxSessionAge time.Duration
 
+	// escalation defines how blocked attempts escalate a session.
+	escalation EscalationPolicy
+
 	// now is a function that returns the current time.
 	// Used for testing to control time.
 	now func() time.Time
 }
 
+// stateChange is a change of the session state.
+type stateChange func(state *SessionState)
+
 // TrackerOption configures the Tracker.
 type TrackerOption func(*Tracker)
 
@@ -78,6 +88,13 @@ func WithMaxSessionAge(d time.Duration) TrackerOption {
 	}
 }
 
+// WithEscalationPolicy sets a custom escalation policy.
+func WithEscalationPolicy(policy EscalationPolicy) TrackerOption {
+	return func(t *Tracker) {
+		t.escalation = policy
+	}
+}
+
 // NewTracker creates a new session tracker.
 func NewTracker(cfg *config.SessionConfig, opts ...TrackerOption) *Tracker {
 	t := &Tracker{
@@ -85,6 +102,7 @@ func NewTracker(cfg *config.SessionConfig, opts ...TrackerOption) *Tracker {
 		config:        cfg,
 		logger:        logger.NewNoOpLogger(),
 		maxSessionAge: defaultMaxSessionAge,
+		escalation:    NewEscalationPolicy(cfg),
 		now:           time.Now,
 	}
 
@@ -110,7 +128,7 @@ func NewTracker(cfg *config.SessionConfig, opts ...TrackerOption) *Tracker {
 }
 
 // IsPoisoned checks if a session is poisoned.
-// Returns (isPoisoned, sessionInfo).
+// Returns (isPoisoned, sessionInfo), where sessionInfo is a copy.
 func (t *Tracker) IsPoisoned(sessionID string) (bool, *SessionInfo) {
 	if sessionID == "" {
 		return false, nil
@@ -129,7 +147,14 @@ func (t *Tracker) IsPoisoned(sessionID string) (bool, *SessionInfo) {
 		return false, nil
 	}
 
-	return info.IsPoisoned(), info
+	// Poisoning past its cooldown no longer fast-fails, even before
+	// ExpireCooldown records the expiry
+	if info.IsPoisonExpired(t.now()) {
+		return false, info.clone()
+	}
+
+	// Return a deep copy, so later changes do not alter the returned info
+	return info.IsPoisoned(), info.clone()
 }
 
 // Poison marks a session as poisoned with the given error codes and message.
@@ -144,24 +169,25 @@ func (t *Tracker) Poison(sessionID string, codes []string, message string) {
 	defer t.mu.Unlock()
 
 	now := t.now()
-	info, exists := t.state.Sessions[sessionID]
 
-	if !exists {
-		info = &SessionInfo{
-			SessionID:    sessionID,
-			Status:       StatusPoisoned,
-			CommandCount: 0,
-			LastActivity: now,
+	t.applyLocked(func(state *SessionState) {
+		info, exists := state.Sessions[sessionID]
+		if !exists {
+			info = &SessionInfo{
+				SessionID:    sessionID,
+				CommandCount: 0,
+			}
+			state.Sessions[sessionID] = info
 		}
-		t.state.Sessions[sessionID] = info
-	}
 
-	info.Status = StatusPoisoned
-	info.PoisonedAt = &now
-	info.PoisonCodes = codes
-	info.PoisonMessage = message
-	info.LastActivity = now
-	t.state.LastUpdated = now
+		info.Status = StatusPoisoned
+		info.PoisonedAt = &now
+		info.PoisonCodes = codes
+		info.PoisonMessage = message
+		info.PoisonExpiresAt = nil
+		info.LastActivity = now
+		state.LastUpdated = now
+	})
 
 	t.logger.Debug("session poisoned",
 		"session_id", sessionID,
@@ -182,8 +208,7 @@ func (t *Tracker) Unpoison(sessionID string) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	info, exists := t.state.Sessions[sessionID]
-	if !exists {
+	if _, exists := t.state.Sessions[sessionID]; !exists {
 		t.logger.Debug("cannot unpoison non-existent session",
 			"session_id", sessionID,
 		)
@@ -191,56 +216,195 @@ func (t *Tracker) Unpoison(sessionID string) {
 		return
 	}
 
-	info.Status = StatusClean
-	info.PoisonedAt = nil
-	info.PoisonCodes = nil
-	info.PoisonMessage = ""
-	info.LastActivity = t.now()
-	t.state.LastUpdated = t.now()
+	now := t.now()
+
+	t.applyLocked(func(state *SessionState) {
+		info, exists := state.Sessions[sessionID]
+		if !exists {
+			return
+		}
+
+		// Blocked attempts are kept, so an acknowledged session that keeps
+		// retrying still escalates
+		info.clearPoison()
+		info.LastActivity = now
+		state.LastUpdated = now
+	})
 
 	t.logger.Debug("session unpoisoned",
 		"session_id", sessionID,
 	)
 }
 
-// RecordCommand increments the command count for a session.
-func (t *Tracker) RecordCommand(sessionID string) {
+// RecordBlocked records a blocked attempt of the given error codes and
+// escalates the session according to the escalation policy. Returns the
+// resulting status transition.
+func (t *Tracker) RecordBlocked(sessionID string, codes []string, message string) Transition {
 	if sessionID == "" {
-		return
+		t.logger.Debug("cannot record blocked attempt for session with empty ID")
+
+		return Transition{}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+
+	var transition Transition
+
+	t.applyLocked(func(state *SessionState) {
+		info, exists := state.Sessions[sessionID]
+		if !exists {
+			info = &SessionInfo{SessionID: sessionID}
+			state.Sessions[sessionID] = info
+		}
+
+		transition = t.escalation.recordBlocked(info, codes, message, now)
+		info.LastActivity = now
+		state.LastUpdated = now
+	})
+
+	t.logger.Debug("recorded blocked attempt",
+		"session_id", sessionID,
+		"codes", codes,
+		"attempts", transition.Attempts,
+		"status", transition.To,
+	)
+
+	return transition
+}
+
+// PreviewBlocked returns the transition RecordBlocked would make for the
+// given error codes, without changing the session state.
+func (t *Tracker) PreviewBlocked(sessionID string, codes []string) Transition {
+	if sessionID == "" {
+		return Transition{}
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	info := &SessionInfo{SessionID: sessionID}
+	if existing, exists := t.state.Sessions[sessionID]; exists {
+		info = existing.clone()
+	}
+
+	return t.escalation.recordBlocked(info, codes, "", t.now())
+}
+
+// ExpireCooldown cleans a session whose poisoning expired after its cooldown,
+// together with its blocked attempts, or whose warning expired as its
+// blocked attempts fell out of the escalation window. Returns the resulting
+// status transition, which is unchanged if nothing expired.
+func (t *Tracker) ExpireCooldown(sessionID string) Transition {
+	if sessionID == "" {
+		return Transition{}
 	}
 
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
 	now := t.now()
-	info, exists := t.state.Sessions[sessionID]
 
+	current, exists := t.state.Sessions[sessionID]
 	if !exists {
-		info = &SessionInfo{
-			SessionID:    sessionID,
-			Status:       StatusClean,
-			CommandCount: 0,
-			LastActivity: now,
+		return Transition{}
+	}
+
+	if current.Status == StatusWarned {
+		return t.expireWarning(sessionID, now)
+	}
+
+	if !current.IsPoisonExpired(now) {
+		return Transition{From: current.Status, To: current.Status}
+	}
+
+	transition := Transition{
+		From:  current.Status,
+		To:    StatusClean,
+		Codes: current.PoisonCodes,
+	}
+
+	t.applyLocked(func(state *SessionState) {
+		info, exists := state.Sessions[sessionID]
+		if !exists || !info.IsPoisonExpired(now) {
+			return
 		}
-		t.state.Sessions[sessionID] = info
+
+		info.clearPoison()
+		info.BlockedAttempts = nil
+		state.LastUpdated = now
+	})
+
+	t.logger.Debug("session poison expired",
+		"session_id", sessionID,
+		"codes", transition.Codes,
+	)
+
+	return transition
+}
+
+// expireWarning cleans a warned session whose warning expired. Must be
+// called with the lock held.
+func (t *Tracker) expireWarning(sessionID string, now time.Time) Transition {
+	transition := Transition{From: StatusWarned, To: StatusWarned}
+
+	if !t.escalation.isWarningExpired(t.state.Sessions[sessionID], now) {
+		return transition
 	}
 
-	// Check if session has expired - if so, reset it
-	if t.isExpiredLocked(info) {
-		info.Status = StatusClean
-		info.PoisonedAt = nil
-		info.PoisonCodes = nil
-		info.PoisonMessage = ""
-		info.CommandCount = 0
+	t.applyLocked(func(state *SessionState) {
+		info, exists := state.Sessions[sessionID]
+		if exists && t.escalation.expireWarning(info, now) {
+			transition.To = StatusClean
+			state.LastUpdated = now
+		}
+	})
+
+	t.logger.Debug("session warning expired", "session_id", sessionID)
+
+	return transition
+}
+
+// RecordCommand increments the command count for a session.
+func (t *Tracker) RecordCommand(sessionID string) {
+	if sessionID == "" {
+		return
 	}
 
-	info.CommandCount++
-	info.LastActivity = now
-	t.state.LastUpdated = now
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+
+	t.applyLocked(func(state *SessionState) {
+		info, exists := state.Sessions[sessionID]
+		if !exists {
+			info = &SessionInfo{
+				SessionID:    sessionID,
+				Status:       StatusClean,
+				CommandCount: 0,
+				LastActivity: now,
+			}
+			state.Sessions[sessionID] = info
+		}
+
+		// Check if session has expired - if so, reset it
+		if t.isExpiredLocked(info) {
+			info.clearPoison()
+			info.BlockedAttempts = nil
+			info.CommandCount = 0
+		}
+
+		info.CommandCount++
+		info.LastActivity = now
+		state.LastUpdated = now
+	})
 
 	t.logger.Debug("recorded command",
 		"session_id", sessionID,
-		"command_count", info.CommandCount,
+		"command_count", t.state.Sessions[sessionID].CommandCount,
 	)
 }
 
@@ -260,13 +424,7 @@ func (t *Tracker) GetInfo(sessionID string) *SessionInfo {
 	}
 
 	// Return a deep copy
-	infoCopy := *info
-	if info.PoisonedAt != nil {
-		poisonedAtCopy := *info.PoisonedAt
-		infoCopy.PoisonedAt = &poisonedAtCopy
-	}
-
-	return &infoCopy
+	return info.clone()
 }
 
 // GetState returns a copy of the current session state.
@@ -279,13 +437,7 @@ func (t *Tracker) GetState() SessionState {
 	state.Sessions = make(map[string]*SessionInfo, len(t.state.Sessions))
 
 	for k, v := range t.state.Sessions {
-		infoCopy := *v
-		if v.PoisonedAt != nil {
-			poisonedAtCopy := *v.PoisonedAt
-			infoCopy.PoisonedAt = &poisonedAtCopy
-		}
-
-		state.Sessions[k] = &infoCopy
+		state.Sessions[k] = v.clone()
 	}
 
 	return state
@@ -300,8 +452,12 @@ func (t *Tracker) ClearSession(sessionID string) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	delete(t.state.Sessions, sessionID)
-	t.state.LastUpdated = t.now()
+	now := t.now()
+
+	t.applyLocked(func(state *SessionState) {
+		delete(state.Sessions, sessionID)
+		state.LastUpdated = now
+	})
 
 	t.logger.Debug("cleared session",
 		"session_id", sessionID,
@@ -318,8 +474,6 @@ func (t *Tracker) CleanupExpired() int {
 
 	for sessionID, info := range t.state.Sessions {
 		if t.isExpiredLocked(info) {
-			delete(t.state.Sessions, sessionID)
-
 			removed++
 
 			t.logger.Debug("expired session removed",
@@ -329,7 +483,17 @@ func (t *Tracker) CleanupExpired() int {
 	}
 
 	if removed > 0 {
-		t.state.LastUpdated = t.now()
+		now := t.now()
+
+		t.applyLocked(func(state *SessionState) {
+			for sessionID, info := range state.Sessions {
+				if t.isExpiredLocked(info) {
+					delete(state.Sessions, sessionID)
+				}
+			}
+
+			state.LastUpdated = now
+		})
 	}
 
 	return removed
@@ -340,12 +504,23 @@ func (t *Tracker) Reset() {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	t.state = NewSessionState()
-	t.state.LastUpdated = t.now()
+	now := t.now()
+
+	t.applyLocked(func(state *SessionState) {
+		clear(state.Sessions)
+		state.LastUpdated = now
+	})
 
 	t.logger.Debug("session state reset")
 }
 
+// applyLocked applies change to the in-memory state and records it for Save.
+// Must be called with mu held (write lock).
+func (t *Tracker) applyLocked(change stateChange) {
+	change(t.state)
+	t.pending = append(t.pending, change)
+}
+
 // isExpiredLocked checks if a session has expired based on maxSessionAge.
 // Must be called with mu held (read or write lock).
 func (t *Tracker) isExpiredLocked(info *SessionInfo) bool {
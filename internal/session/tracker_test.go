@@ -476,6 +476,28 @@ var _ = Describe("Tracker", func() {
 			_, err = os.Stat(nestedPath)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("merges changes saved by other trackers", func() {
+			other := session.NewTracker(
+				nil,
+				session.WithStateFile(stateFile),
+				session.WithTimeFunc(timeFunc),
+			)
+
+			Expect(tracker.Load()).To(Succeed())
+			Expect(other.Load()).To(Succeed())
+
+			tracker.RecordCommand("session-1")
+			other.RecordCommand("session-1")
+			other.Poison("session-2", []string{"GIT001"}, "test")
+
+			Expect(tracker.Save()).To(Succeed())
+			Expect(other.Save()).To(Succeed())
+
+			state := other.GetState()
+			Expect(state.Sessions["session-1"].CommandCount).To(Equal(2))
+			Expect(state.Sessions["session-2"].Status).To(Equal(session.StatusPoisoned))
+		})
 	})
 
 	Describe("Session expiry", func() {
@@ -0,0 +1,29 @@
+package statestore_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestMain(m *testing.M) {
+	// Run as a concurrent writer forked by the stress test
+	if dir := os.Getenv(writerDirEnv); dir != "" {
+		if err := runWriter(dir, os.Getenv(writerIDEnv)); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestStateStore(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "StateStore Suite")
+}
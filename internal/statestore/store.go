@@ -0,0 +1,263 @@
+// Package statestore provides state files shared between concurrently
+// running klaudiush processes.
+//
+// Claude Code runs hooks for parallel tool calls as separate processes, so
+// state files under ~/.klaudiush are read and written concurrently. A Store
+// serializes read-modify-write cycles with an exclusive advisory lock on a
+// sibling lock file and replaces the state file atomically, so readers never
+// see partial writes and writers never lose each other's updates.
+package statestore
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// LockSuffix is appended to the state file path to name its lock file.
+	LockSuffix = ".lock"
+
+	// DefaultFilePermissions is the default permission mode for state files.
+	DefaultFilePermissions = 0o600
+
+	// DefaultDirPermissions is the default permission mode for state directories.
+	DefaultDirPermissions = 0o700
+
+	// DefaultLockTimeout is how long to wait for the lock by default.
+	DefaultLockTimeout = 5 * time.Second
+
+	// lockRetryInterval is how long to wait between lock attempts.
+	lockRetryInterval = 5 * time.Millisecond
+)
+
+// ErrLockTimeout is returned when the lock cannot be acquired in time.
+var ErrLockTimeout = errors.New("timed out waiting for state file lock")
+
+// Store is a state file shared between processes.
+type Store struct {
+	path        string
+	filePerm    os.FileMode
+	dirPerm     os.FileMode
+	lockTimeout time.Duration
+}
+
+// Option configures the Store.
+type Option func(*Store)
+
+// WithFilePermissions sets the permission mode of the state and lock files.
+func WithFilePermissions(perm os.FileMode) Option {
+	return func(s *Store) {
+		if perm != 0 {
+			s.filePerm = perm
+		}
+	}
+}
+
+// WithDirPermissions sets the permission mode of created directories.
+func WithDirPermissions(perm os.FileMode) Option {
+	return func(s *Store) {
+		if perm != 0 {
+			s.dirPerm = perm
+		}
+	}
+}
+
+// WithLockTimeout sets how long to wait for the lock.
+func WithLockTimeout(timeout time.Duration) Option {
+	return func(s *Store) {
+		if timeout > 0 {
+			s.lockTimeout = timeout
+		}
+	}
+}
+
+// New creates a store for the state file at path.
+func New(path string, opts ...Option) *Store {
+	s := &Store{
+		path:        path,
+		filePerm:    DefaultFilePermissions,
+		dirPerm:     DefaultDirPermissions,
+		lockTimeout: DefaultLockTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Path returns the state file path.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Read returns the contents of the state file, or nil if it does not exist.
+// Reads do not take the lock, as writes replace the file atomically.
+func (s *Store) Read() ([]byte, error) {
+	// Path comes from trusted configuration, not user input.
+	data, err := os.ReadFile(s.path) //nolint:gosec // G304: path is from config
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "reading state file")
+	}
+
+	return data, nil
+}
+
+// Update runs fn with the current contents of the state file (nil if it does
+// not exist) while holding the lock, and atomically replaces the file with
+// the returned contents. If fn returns nil contents, the file is not written.
+func (s *Store) Update(fn func(current []byte) ([]byte, error)) error {
+	return s.WithLock(func() error {
+		current, err := s.Read()
+		if err != nil {
+			return err
+		}
+
+		updated, err := fn(current)
+		if err != nil {
+			return err
+		}
+
+		if updated == nil {
+			return nil
+		}
+
+		return s.WriteLocked(updated)
+	})
+}
+
+// Write atomically replaces the state file with data while holding the lock.
+func (s *Store) Write(data []byte) error {
+	return s.WithLock(func() error {
+		return s.WriteLocked(data)
+	})
+}
+
+// Append appends data to the state file while holding the lock, creating
+// the file if needed. Used for append-only logs such as JSONL audit files.
+func (s *Store) Append(data []byte) error {
+	return s.WithLock(func() error {
+		// Path comes from trusted configuration, not user input.
+		//nolint:gosec // G304: path is from config
+		file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, s.filePerm)
+		if err != nil {
+			return errors.Wrap(err, "opening state file for append")
+		}
+
+		if _, err := file.Write(data); err != nil {
+			_ = file.Close()
+
+			return errors.Wrap(err, "appending to state file")
+		}
+
+		return errors.Wrap(file.Close(), "closing state file")
+	})
+}
+
+// WithLock runs fn while holding the exclusive lock of the state file. The
+// lock is not reentrant: fn must not call other locking methods of a store
+// for the same path.
+func (s *Store) WithLock(fn func() error) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), s.dirPerm); err != nil {
+		return errors.Wrap(err, "creating state directory")
+	}
+
+	lockPath := s.path + LockSuffix
+
+	// Path comes from trusted configuration, not user input.
+	//nolint:gosec // G304: path is from config
+	lockFile, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, s.filePerm)
+	if err != nil {
+		return errors.Wrap(err, "opening lock file")
+	}
+
+	defer func() {
+		_ = lockFile.Close()
+	}()
+
+	if err := s.acquire(lockFile); err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = unlockFile(lockFile)
+	}()
+
+	return fn()
+}
+
+// acquire takes the lock of lockFile, retrying until the lock timeout.
+func (s *Store) acquire(lockFile *os.File) error {
+	deadline := time.Now().Add(s.lockTimeout)
+
+	for {
+		locked, err := tryLockFile(lockFile)
+		if err != nil {
+			return errors.Wrap(err, "locking state file")
+		}
+
+		if locked {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return errors.Wrapf(ErrLockTimeout, "%s", lockFile.Name())
+		}
+
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// WriteLocked atomically replaces the state file with data without taking
+// the lock. Must be called from within WithLock.
+func (s *Store) WriteLocked(data []byte) error {
+	dir := filepath.Dir(s.path)
+
+	tmpFile, err := os.CreateTemp(dir, filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "creating temp state file")
+	}
+
+	tmpPath := tmpFile.Name()
+
+	// Clean up the temp file on any error
+	cleanup := func() {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpPath)
+	}
+
+	if _, err := tmpFile.Write(data); err != nil {
+		cleanup()
+
+		return errors.Wrap(err, "writing temp state file")
+	}
+
+	if err := tmpFile.Chmod(s.filePerm); err != nil {
+		cleanup()
+
+		return errors.Wrap(err, "setting state file permissions")
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+
+		return errors.Wrap(err, "closing temp state file")
+	}
+
+	// Rename for atomic replace
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		_ = os.Remove(tmpPath)
+
+		return errors.Wrap(err, "renaming state file")
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package statestore_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/statestore"
+)
+
+var _ = Describe("Store", func() {
+	var (
+		dir   string
+		path  string
+		store *statestore.Store
+	)
+
+	increment := func(current []byte) ([]byte, error) {
+		count, _ := strconv.Atoi(string(current))
+
+		return []byte(strconv.Itoa(count + 1)), nil
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		path = filepath.Join(dir, "nested", "state.json")
+		store = statestore.New(path)
+	})
+
+	It("reads nil for a missing file", func() {
+		data, err := store.Read()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(BeNil())
+	})
+
+	It("creates the file and directory with restricted permissions", func() {
+		Expect(store.Write([]byte("{}"))).To(Succeed())
+
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+
+		dirInfo, err := os.Stat(filepath.Dir(path))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dirInfo.Mode().Perm()).To(Equal(os.FileMode(0o700)))
+	})
+
+	It("updates the current content", func() {
+		Expect(store.Update(increment)).To(Succeed())
+		Expect(store.Update(increment)).To(Succeed())
+
+		data, err := store.Read()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("2"))
+	})
+
+	It("does not write when the update returns nil", func() {
+		Expect(store.Update(func([]byte) ([]byte, error) { return nil, nil })).To(Succeed())
+
+		_, err := os.Stat(path)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("keeps the file when the update fails", func() {
+		Expect(store.Write([]byte("1"))).To(Succeed())
+
+		err := store.Update(func([]byte) ([]byte, error) { return nil, errors.New("boom") })
+		Expect(err).To(MatchError("boom"))
+
+		data, err := store.Read()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("1"))
+
+		// No temp files are left behind
+		entries, err := os.ReadDir(filepath.Dir(path))
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, entry := range entries {
+			Expect(entry.Name()).To(Or(Equal("state.json"), Equal("state.json.lock")))
+		}
+	})
+
+	It("appends to the file", func() {
+		Expect(store.Append([]byte("a\n"))).To(Succeed())
+		Expect(store.Append([]byte("b\n"))).To(Succeed())
+
+		data, err := store.Read()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("a\nb\n"))
+	})
+
+	It("serializes concurrent updates", func() {
+		var wg sync.WaitGroup
+
+		for range 20 {
+			wg.Go(func() {
+				defer GinkgoRecover()
+
+				// Each goroutine uses its own store, like separate processes
+				Expect(statestore.New(path).Update(increment)).To(Succeed())
+			})
+		}
+
+		wg.Wait()
+
+		data, err := store.Read()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("20"))
+	})
+
+	It("times out while another holder keeps the lock", func() {
+		locked := make(chan struct{})
+		release := make(chan struct{})
+		done := make(chan error)
+
+		go func() {
+			done <- store.WithLock(func() error {
+				close(locked)
+				<-release
+
+				return nil
+			})
+		}()
+
+		<-locked
+
+		err := statestore.New(path, statestore.WithLockTimeout(20*time.Millisecond)).
+			Update(increment)
+		Expect(errors.Is(err, statestore.ErrLockTimeout)).To(BeTrue())
+
+		close(release)
+		Expect(<-done).To(Succeed())
+	})
+})
@@ -0,0 +1,155 @@
+package statestore_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/exceptions"
+	"github.com/smykla-labs/klaudiush/internal/session"
+	"github.com/smykla-labs/klaudiush/internal/statestore"
+)
+
+const (
+	// writerDirEnv makes the test binary run as a writer on the given directory.
+	writerDirEnv = "KLAUDIUSH_STATESTORE_WRITER_DIR"
+
+	// writerIDEnv is the identifier of a writer process.
+	writerIDEnv = "KLAUDIUSH_STATESTORE_WRITER_ID"
+
+	// writerProcesses is the number of concurrent writer processes.
+	writerProcesses = 12
+
+	// writerIterations is the number of updates per writer process.
+	writerIterations = 15
+)
+
+// runWriter performs the updates of one writer process, the way concurrent
+// hook invocations update the shared state files.
+func runWriter(dir, id string) error {
+	counter := statestore.New(filepath.Join(dir, "counter"))
+	log := statestore.New(filepath.Join(dir, "log.jsonl"))
+
+	for i := range writerIterations {
+		err := counter.Update(func(current []byte) ([]byte, error) {
+			count, _ := strconv.Atoi(string(current))
+
+			return []byte(strconv.Itoa(count + 1)), nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := log.Append(fmt.Appendf(nil, "%s-%d\n", id, i)); err != nil {
+			return err
+		}
+
+		tracker := session.NewTracker(nil, session.WithStateFile(filepath.Join(dir, "session.json")))
+		if err := tracker.Load(); err != nil {
+			return err
+		}
+
+		tracker.RecordCommand("session-" + id)
+
+		if err := tracker.Save(); err != nil {
+			return err
+		}
+
+		limiter := exceptions.NewRateLimiter(
+			nil,
+			nil,
+			exceptions.WithStateFile(filepath.Join(dir, "rate_limit.json")),
+		)
+		if err := limiter.Load(); err != nil {
+			return err
+		}
+
+		if err := limiter.Record("GIT022"); err != nil {
+			return err
+		}
+
+		if err := limiter.Save(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var _ = Describe("Concurrent processes", func() {
+	It("do not lose updates", func() {
+		dir := GinkgoT().TempDir()
+
+		var wg sync.WaitGroup
+
+		for i := range writerProcesses {
+			wg.Go(func() {
+				defer GinkgoRecover()
+
+				//nolint:gosec // G204: runs the test binary itself
+				cmd := exec.Command(os.Args[0])
+				cmd.Env = append(os.Environ(),
+					writerDirEnv+"="+dir,
+					writerIDEnv+"="+strconv.Itoa(i),
+				)
+
+				output, err := cmd.CombinedOutput()
+				Expect(err).NotTo(HaveOccurred(), string(output))
+			})
+		}
+
+		wg.Wait()
+
+		total := writerProcesses * writerIterations
+
+		By("counting all updates")
+
+		counter, err := os.ReadFile(filepath.Join(dir, "counter"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(counter)).To(Equal(strconv.Itoa(total)))
+
+		By("keeping all appended lines intact")
+
+		log, err := os.ReadFile(filepath.Join(dir, "log.jsonl"))
+		Expect(err).NotTo(HaveOccurred())
+
+		lines := strings.Split(strings.TrimSpace(string(log)), "\n")
+		Expect(lines).To(HaveLen(total))
+
+		for i := range writerProcesses {
+			for j := range writerIterations {
+				Expect(lines).To(ContainElement(fmt.Sprintf("%d-%d", i, j)))
+			}
+		}
+
+		By("merging session state of all processes")
+
+		sessionData, err := os.ReadFile(filepath.Join(dir, "session.json"))
+		Expect(err).NotTo(HaveOccurred())
+
+		var sessionState session.SessionState
+		Expect(json.Unmarshal(sessionData, &sessionState)).To(Succeed())
+		Expect(sessionState.Sessions).To(HaveLen(writerProcesses))
+
+		for _, info := range sessionState.Sessions {
+			Expect(info.CommandCount).To(Equal(writerIterations))
+		}
+
+		By("merging rate limit usage of all processes")
+
+		rateData, err := os.ReadFile(filepath.Join(dir, "rate_limit.json"))
+		Expect(err).NotTo(HaveOccurred())
+
+		var rateState exceptions.RateLimitState
+		Expect(json.Unmarshal(rateData, &rateState)).To(Succeed())
+		Expect(rateState.DailyUsage["GIT022"]).To(Equal(total))
+	})
+})
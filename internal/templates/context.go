@@ -0,0 +1,43 @@
+package templates
+
+// ContextData holds the hook context data available to message templates
+// from user configuration: rule messages and suggestions, and message
+// overrides of validation errors.
+type ContextData struct {
+	// Command is the bash command being executed.
+	Command string
+
+	// File is the file path being operated on.
+	File string
+
+	// FilePath is an alias of File.
+	FilePath string
+
+	// ToolName is the name of the tool being invoked.
+	ToolName string
+
+	// Branch is the current or target branch name (if known).
+	Branch string
+
+	// Remote is the target remote name (if known).
+	Remote string
+
+	// RepoRoot is the repository root path (if known).
+	RepoRoot string
+
+	// Groups contains the named groups captured by the regex patterns of the
+	// matched rule, e.g. "ticket" for `(?P<ticket>[A-Z]+-\d+)`.
+	Groups map[string]string
+
+	// Code is the reference code of the validation error (e.g., "GIT019").
+	// Set for message overrides only.
+	Code string
+
+	// Message is the original message of the validation error.
+	// Set for message overrides only.
+	Message string
+
+	// FixHint is the original fix hint of the validation error.
+	// Set for message overrides only.
+	FixHint string
+}
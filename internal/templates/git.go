@@ -125,6 +125,34 @@ Suggested alternatives: [{{.SuggestedRemotesStr}}]
 Available remotes: [{{.AvailableRemotesStr}}]
 {{- end}}`,
 	)
+
+	// PushForceProtectedTemplate formats error for force push to a protected branch
+	PushForceProtectedTemplate = Parse(
+		"push_force_protected",
+		`❌ {{.Operation}} targeting protected branch '{{.Branch}}' is not allowed
+
+Rewriting the history of protected branches [{{.ProtectedBranchesStr}}] can destroy
+commits other people rely on. Push to a feature branch and open a PR instead.`,
+	)
+
+	// PushForceWithoutLeaseTemplate formats error for force push without --force-with-lease
+	PushForceWithoutLeaseTemplate = Parse(
+		"push_force_without_lease",
+		`❌ Force push with '{{.Flag}}' is not allowed
+
+{{.Flag}} overwrites the remote branch even if someone else pushed to it.
+Use --force-with-lease so the push fails when the remote has unexpected commits.
+
+Expected: git push --force-with-lease {{.Remote}}{{if .Branch}} {{.Branch}}{{end}}`,
+	)
+
+	// PushRemoteDeleteTemplate formats error for deleting a remote branch
+	PushRemoteDeleteTemplate = Parse(
+		"push_remote_delete",
+		`❌ Deleting remote branch '{{.Branch}}' on '{{.Remote}}' is not allowed
+
+Remote branch deletion cannot be undone from the local repository.`,
+	)
 )
 
 // GitAddTmpFilesData holds data for GitAddTmpFilesTemplate
@@ -184,3 +212,23 @@ type PushBlockedRemoteData struct {
 	SuggestedRemotesStr string
 	AvailableRemotesStr string
 }
+
+// PushForceProtectedData holds data for PushForceProtectedTemplate
+type PushForceProtectedData struct {
+	Operation            string
+	Branch               string
+	ProtectedBranchesStr string
+}
+
+// PushForceWithoutLeaseData holds data for PushForceWithoutLeaseTemplate
+type PushForceWithoutLeaseData struct {
+	Flag   string
+	Remote string
+	Branch string
+}
+
+// PushRemoteDeleteData holds data for PushRemoteDeleteTemplate
+type PushRemoteDeleteData struct {
+	Remote string
+	Branch string
+}
@@ -9,6 +9,9 @@ import (
 
 var funcMap = template.FuncMap{
 	"join": strings.Join,
+	"replace": func(old, replacement, s string) string {
+		return strings.ReplaceAll(s, old, replacement)
+	},
 }
 
 // Execute executes a template with the given data
@@ -33,5 +36,12 @@ func MustExecute(tmpl *template.Template, data any) string {
 
 // Parse parses a template string with the funcMap
 func Parse(name, text string) *template.Template {
-	return template.Must(template.New(name).Funcs(funcMap).Parse(text))
+	return template.Must(Compile(name, text))
+}
+
+// Compile parses a template string with the funcMap, returning an error
+// instead of panicking. Used for templates from user configuration, so
+// missing map keys render as empty strings.
+func Compile(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(funcMap).Option("missingkey=zero").Parse(text)
 }
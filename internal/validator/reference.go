@@ -9,7 +9,7 @@ type Reference string
 // ReferenceBaseURL is the base URL for error references.
 const ReferenceBaseURL = "https://klaudiu.sh"
 
-// Git-related references (GIT001-GIT025).
+// Git-related references (GIT001-GIT028).
 const (
 	// RefGitNoSignoff indicates missing -s/--signoff flag.
 	RefGitNoSignoff Reference = ReferenceBaseURL + "/GIT001"
@@ -85,9 +85,18 @@ const (
 
 	// RefGitBlockedRemote indicates push to a blocked remote.
 	RefGitBlockedRemote Reference = ReferenceBaseURL + "/GIT025"
+
+	// RefGitForcePushProtected indicates a force push or mirror push to a protected branch.
+	RefGitForcePushProtected Reference = ReferenceBaseURL + "/GIT026"
+
+	// RefGitForceWithoutLease indicates a force push without --force-with-lease.
+	RefGitForceWithoutLease Reference = ReferenceBaseURL + "/GIT027"
+
+	// RefGitRemoteBranchDelete indicates deletion of a remote branch via git push.
+	RefGitRemoteBranchDelete Reference = ReferenceBaseURL + "/GIT028"
 )
 
-// File-related references (FILE001-FILE009).
+// File-related references (FILE001-FILE010).
 const (
 	// RefShellcheck indicates shellcheck validation failure.
 	RefShellcheck Reference = ReferenceBaseURL + "/FILE001"
@@ -115,6 +124,9 @@ const (
 
 	// RefRustfmtCheck indicates rustfmt Rust code formatting failure.
 	RefRustfmtCheck Reference = ReferenceBaseURL + "/FILE009"
+
+	// RefSensitiveFileRead indicates a read of a credential or secret file.
+	RefSensitiveFileRead Reference = ReferenceBaseURL + "/FILE010"
 )
 
 // Security-related references (SEC001-SEC005).
@@ -135,10 +147,31 @@ const (
 	RefSecretsConnString Reference = ReferenceBaseURL + "/SEC005"
 )
 
-// Shell-related references (SHELL001-SHELL005).
+// Shell-related references (SHELL001-SHELL008).
 const (
 	// RefShellBackticks indicates unescaped backticks in double-quoted strings.
 	RefShellBackticks Reference = ReferenceBaseURL + "/SHELL001"
+
+	// RefShellRecursiveDelete indicates a recursive delete outside the repository root.
+	RefShellRecursiveDelete Reference = ReferenceBaseURL + "/SHELL002"
+
+	// RefShellProtectedPathDelete indicates a delete of /, $HOME or an unguarded variable expansion.
+	RefShellProtectedPathDelete Reference = ReferenceBaseURL + "/SHELL003"
+
+	// RefShellGitDiscard indicates a git command that discards uncommitted changes.
+	RefShellGitDiscard Reference = ReferenceBaseURL + "/SHELL004"
+
+	// RefShellFindDelete indicates find with -delete.
+	RefShellFindDelete Reference = ReferenceBaseURL + "/SHELL005"
+
+	// RefShellDeviceWrite indicates dd writing directly to a device.
+	RefShellDeviceWrite Reference = ReferenceBaseURL + "/SHELL006"
+
+	// RefShellChmodRecursive indicates a recursive world-writable chmod.
+	RefShellChmodRecursive Reference = ReferenceBaseURL + "/SHELL007"
+
+	// RefShellTestFailure indicates a failing test run reported after the command ran.
+	RefShellTestFailure Reference = ReferenceBaseURL + "/SHELL008"
 )
 
 // GitHub CLI-related references (GH001-GH005).
@@ -171,6 +204,13 @@ const (
 	RefSessionPoisoned Reference = ReferenceBaseURL + "/SESS001"
 )
 
+// Exception-related references (EXC001-EXC005).
+const (
+	// RefExceptionUserOnly indicates the agent attempting an exception step
+	// reserved for the user, such as granting exception tokens.
+	RefExceptionUserOnly Reference = ReferenceBaseURL + "/EXC001"
+)
+
 // minCodeLength is the minimum length for a valid reference code.
 const minCodeLength = 3
 
@@ -2,6 +2,7 @@
 package validator
 
 import (
+	"fmt"
 	"path/filepath"
 	"regexp"
 	"slices"
@@ -12,7 +13,39 @@ import (
 )
 
 // Predicate determines if a validator should be applied to a context.
-type Predicate func(*hook.Context) bool
+// Every predicate carries a human-readable description of what it matches,
+// used when explaining validator selection.
+type Predicate struct {
+	match       func(*hook.Context) bool
+	description string
+	compound    bool
+}
+
+// NewPredicate creates a predicate from a match function and its description.
+func NewPredicate(description string, match func(*hook.Context) bool) Predicate {
+	return Predicate{match: match, description: description}
+}
+
+// Matches reports whether the predicate matches the context. The zero
+// Predicate never matches.
+func (p Predicate) Matches(ctx *hook.Context) bool {
+	return p.match != nil && p.match(ctx)
+}
+
+// String returns the description of the predicate.
+func (p Predicate) String() string {
+	return p.description
+}
+
+// nested returns the description for use inside a combinator, parenthesized
+// when the predicate is itself a combination.
+func (p Predicate) nested() string {
+	if p.compound {
+		return "(" + p.description + ")"
+	}
+
+	return p.description
+}
 
 // Registration represents a validator registration with its predicate.
 type Registration struct {
@@ -20,6 +53,14 @@ type Registration struct {
 	Predicate Predicate
 }
 
+// Selection records whether a registration's predicate matched a context.
+type Selection struct {
+	Registration
+
+	// Selected is true if the predicate matched.
+	Selected bool
+}
+
 // Registry manages validator registrations and selection.
 type Registry struct {
 	registrations []Registration
@@ -45,7 +86,7 @@ func (r *Registry) FindValidators(ctx *hook.Context) []Validator {
 	validators := make([]Validator, 0)
 
 	for _, reg := range r.registrations {
-		if reg.Predicate(ctx) {
+		if reg.Predicate.Matches(ctx) {
 			validators = append(validators, reg.Validator)
 		}
 	}
@@ -53,6 +94,22 @@ func (r *Registry) FindValidators(ctx *hook.Context) []Validator {
 	return validators
 }
 
+// Explain evaluates every registration against the context and reports which
+// were selected, in registration order. Selected registrations are exactly the
+// validators returned by FindValidators.
+func (r *Registry) Explain(ctx *hook.Context) []Selection {
+	selections := make([]Selection, 0, len(r.registrations))
+
+	for _, reg := range r.registrations {
+		selections = append(selections, Selection{
+			Registration: reg,
+			Selected:     reg.Predicate.Matches(ctx),
+		})
+	}
+
+	return selections
+}
+
 // Count returns the number of registered validators.
 func (r *Registry) Count() int {
 	return len(r.registrations)
@@ -62,64 +119,72 @@ func (r *Registry) Count() int {
 
 // EventTypeIs returns a predicate that matches the given event type.
 func EventTypeIs(eventType hook.EventType) Predicate {
-	return func(ctx *hook.Context) bool {
+	return NewPredicate(eventType.String(), func(ctx *hook.Context) bool {
 		return ctx.EventType == eventType
-	}
+	})
 }
 
 // ToolTypeIs returns a predicate that matches the given tool type.
 func ToolTypeIs(toolType hook.ToolType) Predicate {
-	return func(ctx *hook.Context) bool {
+	return NewPredicate(toolType.String(), func(ctx *hook.Context) bool {
 		return ctx.ToolName == toolType
-	}
+	})
 }
 
 // ToolTypeIn returns a predicate that matches any of the given tool types.
 func ToolTypeIn(toolTypes ...hook.ToolType) Predicate {
-	return func(ctx *hook.Context) bool {
+	return NewPredicate(joinStrings(toolTypes), func(ctx *hook.Context) bool {
 		return slices.Contains(toolTypes, ctx.ToolName)
-	}
+	})
 }
 
 // CommandMatches returns a predicate that matches if the command matches the pattern.
 func CommandMatches(pattern string) Predicate {
 	re := regexp.MustCompile(pattern)
 
-	return func(ctx *hook.Context) bool {
+	return NewPredicate("command matches /"+pattern+"/", func(ctx *hook.Context) bool {
 		return re.MatchString(ctx.GetCommand())
-	}
+	})
 }
 
 // CommandContains returns a predicate that matches if the command contains the substring.
 func CommandContains(substring string) Predicate {
-	return func(ctx *hook.Context) bool {
+	description := fmt.Sprintf("command contains %q", substring)
+
+	return NewPredicate(description, func(ctx *hook.Context) bool {
 		return strings.Contains(ctx.GetCommand(), substring)
-	}
+	})
 }
 
 // CommandStartsWith returns a predicate that matches if the command starts with the prefix.
 func CommandStartsWith(prefix string) Predicate {
-	return func(ctx *hook.Context) bool {
+	description := fmt.Sprintf("command starts with %q", prefix)
+
+	return NewPredicate(description, func(ctx *hook.Context) bool {
 		cmd := strings.TrimSpace(ctx.GetCommand())
 
 		return strings.HasPrefix(cmd, prefix)
-	}
+	})
 }
 
 // FilePathMatches returns a predicate that matches if the file path matches the pattern.
 func FilePathMatches(pattern string) Predicate {
-	return func(ctx *hook.Context) bool {
+	description := fmt.Sprintf("path matches %q", pattern)
+
+	return NewPredicate(description, func(ctx *hook.Context) bool {
 		matched, err := filepath.Match(pattern, ctx.GetFilePath())
 
 		return err == nil && matched
-	}
+	})
 }
 
 // FilePathContains returns a predicate that matches if the file path contains the substring.
 func FilePathContains(substring string) Predicate {
-	return func(ctx *hook.Context) bool {
+	description := fmt.Sprintf("path contains %q", substring)
+
+	return NewPredicate(description, func(ctx *hook.Context) bool {
 		return strings.Contains(ctx.GetFilePath(), substring)
-	}
+	})
 }
 
 // FileExtensionIs returns a predicate that matches if the file has the given extension.
@@ -128,45 +193,28 @@ func FileExtensionIs(ext string) Predicate {
 		ext = "." + ext
 	}
 
-	return func(ctx *hook.Context) bool {
+	return NewPredicate("*"+ext, func(ctx *hook.Context) bool {
 		return filepath.Ext(ctx.GetFilePath()) == ext
-	}
+	})
 }
 
 // FileExtensionIn returns a predicate that matches if the file has any of the given extensions.
 func FileExtensionIn(exts ...string) Predicate {
-	// Normalize extensions
-	normalized := make([]string, len(exts))
+	normalized := normalizeExtensions(exts)
 
-	for i, ext := range exts {
-		if !strings.HasPrefix(ext, ".") {
-			ext = "." + ext
-		}
-
-		normalized[i] = ext
-	}
-
-	return func(ctx *hook.Context) bool {
+	return NewPredicate(extensionsDescription(normalized), func(ctx *hook.Context) bool {
 		fileExt := filepath.Ext(ctx.GetFilePath())
 		return slices.Contains(normalized, fileExt)
-	}
+	})
 }
 
 // BashWritesFileWithExtension returns a predicate that matches if a Bash command writes
 // to a file with any of the given extensions.
 func BashWritesFileWithExtension(exts ...string) Predicate {
-	// Normalize extensions
-	normalized := make([]string, len(exts))
-
-	for i, ext := range exts {
-		if !strings.HasPrefix(ext, ".") {
-			ext = "." + ext
-		}
-
-		normalized[i] = ext
-	}
+	normalized := normalizeExtensions(exts)
+	description := "Bash writes " + extensionsDescription(normalized)
 
-	return func(ctx *hook.Context) bool {
+	return NewPredicate(description, func(ctx *hook.Context) bool {
 		// Only apply to Bash commands
 		if ctx.ToolName != hook.ToolTypeBash {
 			return false
@@ -189,56 +237,118 @@ func BashWritesFileWithExtension(exts ...string) Predicate {
 		}
 
 		return false
+	})
+}
+
+// normalizeExtensions prefixes every extension with a dot.
+func normalizeExtensions(exts []string) []string {
+	normalized := make([]string, len(exts))
+
+	for i, ext := range exts {
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+
+		normalized[i] = ext
 	}
+
+	return normalized
+}
+
+// extensionsDescription describes normalized extensions as globs, e.g. "*.yml/*.yaml".
+func extensionsDescription(exts []string) string {
+	globs := make([]string, len(exts))
+
+	for i, ext := range exts {
+		globs[i] = "*" + ext
+	}
+
+	return strings.Join(globs, "/")
+}
+
+// joinStrings joins the string forms of values with "/".
+func joinStrings[T fmt.Stringer](values []T) string {
+	parts := make([]string, len(values))
+
+	for i, v := range values {
+		parts[i] = v.String()
+	}
+
+	return strings.Join(parts, "/")
 }
 
 // Predicate Combinators
 
 // And returns a predicate that matches if all predicates match.
 func And(predicates ...Predicate) Predicate {
-	return func(ctx *hook.Context) bool {
+	description := combineDescriptions(predicates, " and ")
+
+	combined := NewPredicate(description, func(ctx *hook.Context) bool {
 		for _, p := range predicates {
-			if !p(ctx) {
+			if !p.Matches(ctx) {
 				return false
 			}
 		}
 
 		return true
-	}
+	})
+	combined.compound = len(predicates) > 1
+
+	return combined
 }
 
 // Or returns a predicate that matches if any predicate matches.
 func Or(predicates ...Predicate) Predicate {
-	return func(ctx *hook.Context) bool {
+	description := combineDescriptions(predicates, " or ")
+
+	combined := NewPredicate(description, func(ctx *hook.Context) bool {
 		for _, p := range predicates {
-			if p(ctx) {
+			if p.Matches(ctx) {
 				return true
 			}
 		}
 
 		return false
-	}
+	})
+	combined.compound = len(predicates) > 1
+
+	return combined
 }
 
 // Not returns a predicate that inverts the given predicate.
 func Not(predicate Predicate) Predicate {
-	return func(ctx *hook.Context) bool {
-		return !predicate(ctx)
+	return NewPredicate("not "+predicate.nested(), func(ctx *hook.Context) bool {
+		return !predicate.Matches(ctx)
+	})
+}
+
+// combineDescriptions joins the descriptions of combined predicates.
+func combineDescriptions(predicates []Predicate, sep string) string {
+	if len(predicates) == 1 {
+		return predicates[0].String()
+	}
+
+	parts := make([]string, len(predicates))
+
+	for i, p := range predicates {
+		parts[i] = p.nested()
 	}
+
+	return strings.Join(parts, sep)
 }
 
 // Always returns a predicate that always matches.
 func Always() Predicate {
-	return func(*hook.Context) bool {
+	return NewPredicate("always", func(*hook.Context) bool {
 		return true
-	}
+	})
 }
 
 // Never returns a predicate that never matches.
 func Never() Predicate {
-	return func(*hook.Context) bool {
+	return NewPredicate("never", func(*hook.Context) bool {
 		return false
-	}
+	})
 }
 
 // Git Command Predicates
@@ -246,7 +356,7 @@ func Never() Predicate {
 // GitSubcommandIs returns a predicate that matches if any git command in the chain
 // has the given subcommand. This properly handles command chains like "git add && git commit".
 func GitSubcommandIs(subcommand string) Predicate {
-	return func(ctx *hook.Context) bool {
+	return NewPredicate("git "+subcommand, func(ctx *hook.Context) bool {
 		gitCmds := parseAllGitFromContext(ctx)
 
 		for _, gitCmd := range gitCmds {
@@ -256,13 +366,13 @@ func GitSubcommandIs(subcommand string) Predicate {
 		}
 
 		return false
-	}
+	})
 }
 
 // GitSubcommandIn returns a predicate that matches if any git command in the chain
 // has any of the given subcommands.
 func GitSubcommandIn(subcommands ...string) Predicate {
-	return func(ctx *hook.Context) bool {
+	return NewPredicate("git "+strings.Join(subcommands, "/"), func(ctx *hook.Context) bool {
 		gitCmds := parseAllGitFromContext(ctx)
 
 		for _, gitCmd := range gitCmds {
@@ -272,12 +382,12 @@ func GitSubcommandIn(subcommands ...string) Predicate {
 		}
 
 		return false
-	}
+	})
 }
 
 // GitHasFlag returns a predicate that matches if any git command in the chain has the given flag.
 func GitHasFlag(flag string) Predicate {
-	return func(ctx *hook.Context) bool {
+	return NewPredicate("git with "+flag, func(ctx *hook.Context) bool {
 		gitCmds := parseAllGitFromContext(ctx)
 
 		for _, gitCmd := range gitCmds {
@@ -287,13 +397,13 @@ func GitHasFlag(flag string) Predicate {
 		}
 
 		return false
-	}
+	})
 }
 
 // GitHasAnyFlag returns a predicate that matches if any git command in the chain
 // has any of the given flags.
 func GitHasAnyFlag(flags ...string) Predicate {
-	return func(ctx *hook.Context) bool {
+	return NewPredicate("git with "+strings.Join(flags, "/"), func(ctx *hook.Context) bool {
 		gitCmds := parseAllGitFromContext(ctx)
 
 		for _, gitCmd := range gitCmds {
@@ -303,13 +413,15 @@ func GitHasAnyFlag(flags ...string) Predicate {
 		}
 
 		return false
-	}
+	})
 }
 
 // GitSubcommandWithFlag returns a predicate that matches if any git command in the chain
 // has the given subcommand AND has the given flag.
 func GitSubcommandWithFlag(subcommand, flag string) Predicate {
-	return func(ctx *hook.Context) bool {
+	description := "git " + subcommand + " with " + flag
+
+	return NewPredicate(description, func(ctx *hook.Context) bool {
 		gitCmds := parseAllGitFromContext(ctx)
 
 		for _, gitCmd := range gitCmds {
@@ -319,13 +431,15 @@ func GitSubcommandWithFlag(subcommand, flag string) Predicate {
 		}
 
 		return false
-	}
+	})
 }
 
 // GitSubcommandWithAnyFlag returns a predicate that matches if any git command in the chain
 // has the given subcommand AND has any of the given flags.
 func GitSubcommandWithAnyFlag(subcommand string, flags ...string) Predicate {
-	return func(ctx *hook.Context) bool {
+	description := "git " + subcommand + " with " + strings.Join(flags, "/")
+
+	return NewPredicate(description, func(ctx *hook.Context) bool {
 		gitCmds := parseAllGitFromContext(ctx)
 
 		for _, gitCmd := range gitCmds {
@@ -335,13 +449,15 @@ func GitSubcommandWithAnyFlag(subcommand string, flags ...string) Predicate {
 		}
 
 		return false
-	}
+	})
 }
 
 // GitSubcommandWithoutFlag returns a predicate that matches if any git command in the chain
 // has the given subcommand AND does NOT have the given flag.
 func GitSubcommandWithoutFlag(subcommand, flag string) Predicate {
-	return func(ctx *hook.Context) bool {
+	description := "git " + subcommand + " without " + flag
+
+	return NewPredicate(description, func(ctx *hook.Context) bool {
 		gitCmds := parseAllGitFromContext(ctx)
 
 		for _, gitCmd := range gitCmds {
@@ -351,13 +467,15 @@ func GitSubcommandWithoutFlag(subcommand, flag string) Predicate {
 		}
 
 		return false
-	}
+	})
 }
 
 // GitSubcommandWithoutAnyFlag returns a predicate that matches if any git command in the chain
 // has the given subcommand AND does NOT have any of the given flags.
 func GitSubcommandWithoutAnyFlag(subcommand string, flags ...string) Predicate {
-	return func(ctx *hook.Context) bool {
+	description := "git " + subcommand + " without " + strings.Join(flags, "/")
+
+	return NewPredicate(description, func(ctx *hook.Context) bool {
 		gitCmds := parseAllGitFromContext(ctx)
 
 		for _, gitCmd := range gitCmds {
@@ -367,7 +485,7 @@ func GitSubcommandWithoutAnyFlag(subcommand string, flags ...string) Predicate {
 		}
 
 		return false
-	}
+	})
 }
 
 // parseAllGitFromContext parses all git commands from a hook context.
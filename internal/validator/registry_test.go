@@ -3,6 +3,7 @@ package validator_test
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
 
 	"github.com/smykla-labs/klaudiush/internal/validator"
 	"github.com/smykla-labs/klaudiush/pkg/hook"
@@ -17,7 +18,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitSubcommandIs("checkout")
-			Expect(predicate(ctx)).To(BeTrue())
+			Expect(predicate.Matches(ctx)).To(BeTrue())
 		})
 
 		It("matches git checkout with -C global option", func() {
@@ -27,7 +28,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitSubcommandIs("checkout")
-			Expect(predicate(ctx)).To(BeTrue())
+			Expect(predicate.Matches(ctx)).To(BeTrue())
 		})
 
 		It("matches git checkout with long path in -C option", func() {
@@ -39,7 +40,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitSubcommandIs("checkout")
-			Expect(predicate(ctx)).To(BeTrue())
+			Expect(predicate.Matches(ctx)).To(BeTrue())
 		})
 
 		It("does not match different subcommand", func() {
@@ -49,7 +50,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitSubcommandIs("checkout")
-			Expect(predicate(ctx)).To(BeFalse())
+			Expect(predicate.Matches(ctx)).To(BeFalse())
 		})
 
 		It("does not match non-git command", func() {
@@ -59,7 +60,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitSubcommandIs("checkout")
-			Expect(predicate(ctx)).To(BeFalse())
+			Expect(predicate.Matches(ctx)).To(BeFalse())
 		})
 
 		It("does not match non-Bash tool", func() {
@@ -69,7 +70,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitSubcommandIs("checkout")
-			Expect(predicate(ctx)).To(BeFalse())
+			Expect(predicate.Matches(ctx)).To(BeFalse())
 		})
 	})
 
@@ -81,7 +82,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitSubcommandIn("checkout", "switch", "branch")
-			Expect(predicate(ctx)).To(BeTrue())
+			Expect(predicate.Matches(ctx)).To(BeTrue())
 		})
 
 		It("does not match unlisted subcommand", func() {
@@ -91,7 +92,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitSubcommandIn("checkout", "switch", "branch")
-			Expect(predicate(ctx)).To(BeFalse())
+			Expect(predicate.Matches(ctx)).To(BeFalse())
 		})
 	})
 
@@ -103,7 +104,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitHasFlag("-b")
-			Expect(predicate(ctx)).To(BeTrue())
+			Expect(predicate.Matches(ctx)).To(BeTrue())
 		})
 
 		It("matches flag with -C global option", func() {
@@ -113,7 +114,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitHasFlag("-b")
-			Expect(predicate(ctx)).To(BeTrue())
+			Expect(predicate.Matches(ctx)).To(BeTrue())
 		})
 
 		It("does not match missing flag", func() {
@@ -123,7 +124,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitHasFlag("-b")
-			Expect(predicate(ctx)).To(BeFalse())
+			Expect(predicate.Matches(ctx)).To(BeFalse())
 		})
 	})
 
@@ -137,7 +138,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitHasAnyFlag("-b", "--branch")
-			Expect(predicate(ctx)).To(BeTrue())
+			Expect(predicate.Matches(ctx)).To(BeTrue())
 		})
 
 		It("matches long flag variant", func() {
@@ -147,7 +148,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitHasAnyFlag("-b", "--branch")
-			Expect(predicate(ctx)).To(BeTrue())
+			Expect(predicate.Matches(ctx)).To(BeTrue())
 		})
 
 		It("does not match when none of the flags are present", func() {
@@ -157,7 +158,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitHasAnyFlag("-b", "--branch")
-			Expect(predicate(ctx)).To(BeFalse())
+			Expect(predicate.Matches(ctx)).To(BeFalse())
 		})
 	})
 
@@ -171,7 +172,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitSubcommandWithFlag("checkout", "-b")
-			Expect(predicate(ctx)).To(BeTrue())
+			Expect(predicate.Matches(ctx)).To(BeTrue())
 		})
 
 		It("does not match subcommand without flag", func() {
@@ -181,7 +182,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitSubcommandWithFlag("checkout", "-b")
-			Expect(predicate(ctx)).To(BeFalse())
+			Expect(predicate.Matches(ctx)).To(BeFalse())
 		})
 
 		It("does not match different subcommand with flag", func() {
@@ -191,7 +192,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitSubcommandWithFlag("checkout", "-b")
-			Expect(predicate(ctx)).To(BeFalse())
+			Expect(predicate.Matches(ctx)).To(BeFalse())
 		})
 	})
 
@@ -205,7 +206,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitSubcommandWithAnyFlag("checkout", "-b", "--branch")
-			Expect(predicate(ctx)).To(BeTrue())
+			Expect(predicate.Matches(ctx)).To(BeTrue())
 		})
 
 		It("matches switch with -c flag", func() {
@@ -221,7 +222,7 @@ var _ = Describe("Git Predicates", func() {
 				"-C",
 				"--force-create",
 			)
-			Expect(predicate(ctx)).To(BeTrue())
+			Expect(predicate.Matches(ctx)).To(BeTrue())
 		})
 
 		It("does not match checkout without branch creation flag", func() {
@@ -231,7 +232,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitSubcommandWithAnyFlag("checkout", "-b", "--branch")
-			Expect(predicate(ctx)).To(BeFalse())
+			Expect(predicate.Matches(ctx)).To(BeFalse())
 		})
 	})
 
@@ -243,7 +244,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitSubcommandWithoutAnyFlag("branch", "-d", "-D", "--delete")
-			Expect(predicate(ctx)).To(BeTrue())
+			Expect(predicate.Matches(ctx)).To(BeTrue())
 		})
 
 		It("does not match branch with delete flag", func() {
@@ -253,7 +254,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitSubcommandWithoutAnyFlag("branch", "-d", "-D", "--delete")
-			Expect(predicate(ctx)).To(BeFalse())
+			Expect(predicate.Matches(ctx)).To(BeFalse())
 		})
 
 		It("does not match branch with -D flag", func() {
@@ -263,7 +264,7 @@ var _ = Describe("Git Predicates", func() {
 			}
 
 			predicate := validator.GitSubcommandWithoutAnyFlag("branch", "-d", "-D", "--delete")
-			Expect(predicate(ctx)).To(BeFalse())
+			Expect(predicate.Matches(ctx)).To(BeFalse())
 		})
 	})
 
@@ -278,7 +279,7 @@ var _ = Describe("Git Predicates", func() {
 				}
 
 				predicate := validator.GitSubcommandIs("commit")
-				Expect(predicate(ctx)).To(BeTrue())
+				Expect(predicate.Matches(ctx)).To(BeTrue())
 			})
 
 			It("matches git add in 'git add && git commit' chain", func() {
@@ -290,7 +291,7 @@ var _ = Describe("Git Predicates", func() {
 				}
 
 				predicate := validator.GitSubcommandIs("add")
-				Expect(predicate(ctx)).To(BeTrue())
+				Expect(predicate.Matches(ctx)).To(BeTrue())
 			})
 
 			It("matches git push in 'git add && git commit && git push' chain", func() {
@@ -302,7 +303,7 @@ var _ = Describe("Git Predicates", func() {
 				}
 
 				predicate := validator.GitSubcommandIs("push")
-				Expect(predicate(ctx)).To(BeTrue())
+				Expect(predicate.Matches(ctx)).To(BeTrue())
 			})
 
 			It("matches all subcommands in a triple chain", func() {
@@ -313,10 +314,10 @@ var _ = Describe("Git Predicates", func() {
 					},
 				}
 
-				Expect(validator.GitSubcommandIs("add")(ctx)).To(BeTrue())
-				Expect(validator.GitSubcommandIs("commit")(ctx)).To(BeTrue())
-				Expect(validator.GitSubcommandIs("push")(ctx)).To(BeTrue())
-				Expect(validator.GitSubcommandIs("checkout")(ctx)).To(BeFalse())
+				Expect(validator.GitSubcommandIs("add").Matches(ctx)).To(BeTrue())
+				Expect(validator.GitSubcommandIs("commit").Matches(ctx)).To(BeTrue())
+				Expect(validator.GitSubcommandIs("push").Matches(ctx)).To(BeTrue())
+				Expect(validator.GitSubcommandIs("checkout").Matches(ctx)).To(BeFalse())
 			})
 
 			It("does not match subcommand not in chain", func() {
@@ -328,7 +329,7 @@ var _ = Describe("Git Predicates", func() {
 				}
 
 				predicate := validator.GitSubcommandIs("push")
-				Expect(predicate(ctx)).To(BeFalse())
+				Expect(predicate.Matches(ctx)).To(BeFalse())
 			})
 
 			It("handles semicolon-separated commands", func() {
@@ -339,8 +340,8 @@ var _ = Describe("Git Predicates", func() {
 					},
 				}
 
-				Expect(validator.GitSubcommandIs("add")(ctx)).To(BeTrue())
-				Expect(validator.GitSubcommandIs("commit")(ctx)).To(BeTrue())
+				Expect(validator.GitSubcommandIs("add").Matches(ctx)).To(BeTrue())
+				Expect(validator.GitSubcommandIs("commit").Matches(ctx)).To(BeTrue())
 			})
 
 			It("handles pipe chains (only first command is git)", func() {
@@ -352,7 +353,7 @@ var _ = Describe("Git Predicates", func() {
 				}
 
 				predicate := validator.GitSubcommandIs("log")
-				Expect(predicate(ctx)).To(BeTrue())
+				Expect(predicate.Matches(ctx)).To(BeTrue())
 			})
 		})
 
@@ -366,7 +367,7 @@ var _ = Describe("Git Predicates", func() {
 				}
 
 				predicate := validator.GitSubcommandIn("commit", "push")
-				Expect(predicate(ctx)).To(BeTrue())
+				Expect(predicate.Matches(ctx)).To(BeTrue())
 			})
 
 			It("matches when chain contains one of many options", func() {
@@ -378,7 +379,7 @@ var _ = Describe("Git Predicates", func() {
 				}
 
 				predicate := validator.GitSubcommandIn("checkout", "switch")
-				Expect(predicate(ctx)).To(BeTrue())
+				Expect(predicate.Matches(ctx)).To(BeTrue())
 			})
 		})
 
@@ -391,10 +392,10 @@ var _ = Describe("Git Predicates", func() {
 					},
 				}
 
-				Expect(validator.GitHasFlag("-A")(ctx)).To(BeTrue())
-				Expect(validator.GitHasFlag("-s")(ctx)).To(BeTrue())
-				Expect(validator.GitHasFlag("-S")(ctx)).To(BeTrue())
-				Expect(validator.GitHasFlag("-m")(ctx)).To(BeTrue())
+				Expect(validator.GitHasFlag("-A").Matches(ctx)).To(BeTrue())
+				Expect(validator.GitHasFlag("-s").Matches(ctx)).To(BeTrue())
+				Expect(validator.GitHasFlag("-S").Matches(ctx)).To(BeTrue())
+				Expect(validator.GitHasFlag("-m").Matches(ctx)).To(BeTrue())
 			})
 
 			It("does not match flag not present in any command", func() {
@@ -406,7 +407,7 @@ var _ = Describe("Git Predicates", func() {
 				}
 
 				predicate := validator.GitHasFlag("--force")
-				Expect(predicate(ctx)).To(BeFalse())
+				Expect(predicate.Matches(ctx)).To(BeFalse())
 			})
 		})
 
@@ -420,9 +421,9 @@ var _ = Describe("Git Predicates", func() {
 				}
 
 				// commit has -s flag
-				Expect(validator.GitSubcommandWithFlag("commit", "-s")(ctx)).To(BeTrue())
+				Expect(validator.GitSubcommandWithFlag("commit", "-s").Matches(ctx)).To(BeTrue())
 				// add has -A flag
-				Expect(validator.GitSubcommandWithFlag("add", "-A")(ctx)).To(BeTrue())
+				Expect(validator.GitSubcommandWithFlag("add", "-A").Matches(ctx)).To(BeTrue())
 			})
 
 			It("does not match when subcommand doesn't have the flag", func() {
@@ -434,9 +435,9 @@ var _ = Describe("Git Predicates", func() {
 				}
 
 				// add has -A but commit doesn't have -A
-				Expect(validator.GitSubcommandWithFlag("commit", "-A")(ctx)).To(BeFalse())
+				Expect(validator.GitSubcommandWithFlag("commit", "-A").Matches(ctx)).To(BeFalse())
 				// commit has -m but add doesn't have -m
-				Expect(validator.GitSubcommandWithFlag("add", "-m")(ctx)).To(BeFalse())
+				Expect(validator.GitSubcommandWithFlag("add", "-m").Matches(ctx)).To(BeFalse())
 			})
 
 			It("does not match subcommand not in chain even if flag exists elsewhere", func() {
@@ -448,7 +449,7 @@ var _ = Describe("Git Predicates", func() {
 				}
 
 				// push is not in chain
-				Expect(validator.GitSubcommandWithFlag("push", "-A")(ctx)).To(BeFalse())
+				Expect(validator.GitSubcommandWithFlag("push", "-A").Matches(ctx)).To(BeFalse())
 			})
 		})
 
@@ -463,7 +464,7 @@ var _ = Describe("Git Predicates", func() {
 
 				// commit doesn't have --no-verify flag
 				Expect(
-					validator.GitSubcommandWithoutFlag("commit", "--no-verify")(ctx),
+					validator.GitSubcommandWithoutFlag("commit", "--no-verify").Matches(ctx),
 				).To(BeTrue())
 			})
 
@@ -476,7 +477,7 @@ var _ = Describe("Git Predicates", func() {
 				}
 
 				Expect(
-					validator.GitSubcommandWithoutFlag("commit", "--no-verify")(ctx),
+					validator.GitSubcommandWithoutFlag("commit", "--no-verify").Matches(ctx),
 				).To(BeFalse())
 			})
 		})
@@ -491,7 +492,7 @@ var _ = Describe("Git Predicates", func() {
 				}
 
 				predicate := validator.GitSubcommandWithAnyFlag("commit", "-s", "--signoff")
-				Expect(predicate(ctx)).To(BeTrue())
+				Expect(predicate.Matches(ctx)).To(BeTrue())
 			})
 		})
 
@@ -505,7 +506,7 @@ var _ = Describe("Git Predicates", func() {
 				}
 
 				predicate := validator.GitSubcommandWithoutAnyFlag("push", "--force", "-f")
-				Expect(predicate(ctx)).To(BeTrue())
+				Expect(predicate.Matches(ctx)).To(BeTrue())
 			})
 
 			It("does not match when subcommand has one of the flags", func() {
@@ -517,7 +518,7 @@ var _ = Describe("Git Predicates", func() {
 				}
 
 				predicate := validator.GitSubcommandWithoutAnyFlag("push", "--force", "-f")
-				Expect(predicate(ctx)).To(BeFalse())
+				Expect(predicate.Matches(ctx)).To(BeFalse())
 			})
 		})
 
@@ -540,7 +541,7 @@ generation for ` + "`EventType`" + `, ` + "`ToolType`" + `, and ` + "`Severity`"
 					validator.GitSubcommandIs("commit"),
 				)
 
-				Expect(predicate(ctx)).To(BeTrue())
+				Expect(predicate.Matches(ctx)).To(BeTrue())
 			})
 
 			It("handles git status && git add && git commit pattern", func() {
@@ -552,11 +553,11 @@ generation for ` + "`EventType`" + `, ` + "`ToolType`" + `, and ` + "`Severity`"
 					},
 				}
 
-				Expect(validator.GitSubcommandIs("status")(ctx)).To(BeTrue())
-				Expect(validator.GitSubcommandIs("add")(ctx)).To(BeTrue())
-				Expect(validator.GitSubcommandIs("commit")(ctx)).To(BeTrue())
-				Expect(validator.GitSubcommandWithFlag("commit", "-s")(ctx)).To(BeTrue())
-				Expect(validator.GitSubcommandWithFlag("commit", "-S")(ctx)).To(BeTrue())
+				Expect(validator.GitSubcommandIs("status").Matches(ctx)).To(BeTrue())
+				Expect(validator.GitSubcommandIs("add").Matches(ctx)).To(BeTrue())
+				Expect(validator.GitSubcommandIs("commit").Matches(ctx)).To(BeTrue())
+				Expect(validator.GitSubcommandWithFlag("commit", "-s").Matches(ctx)).To(BeTrue())
+				Expect(validator.GitSubcommandWithFlag("commit", "-S").Matches(ctx)).To(BeTrue())
 			})
 
 			It("handles mixed git and non-git commands", func() {
@@ -567,8 +568,8 @@ generation for ` + "`EventType`" + `, ` + "`ToolType`" + `, and ` + "`Severity`"
 					},
 				}
 
-				Expect(validator.GitSubcommandIs("add")(ctx)).To(BeTrue())
-				Expect(validator.GitSubcommandIs("commit")(ctx)).To(BeTrue())
+				Expect(validator.GitSubcommandIs("add").Matches(ctx)).To(BeTrue())
+				Expect(validator.GitSubcommandIs("commit").Matches(ctx)).To(BeTrue())
 			})
 
 			It("handles git command with HEREDOC body", func() {
@@ -586,7 +587,7 @@ EOF
 				}
 
 				predicate := validator.GitSubcommandIs("commit")
-				Expect(predicate(ctx)).To(BeTrue())
+				Expect(predicate.Matches(ctx)).To(BeTrue())
 			})
 		})
 	})
@@ -617,7 +618,7 @@ EOF
 				),
 			)
 
-			Expect(predicate(ctx)).To(BeTrue())
+			Expect(predicate.Matches(ctx)).To(BeTrue())
 		})
 
 		It("matches git commit with -C path", func() {
@@ -634,7 +635,7 @@ EOF
 				validator.GitSubcommandIs("commit"),
 			)
 
-			Expect(predicate(ctx)).To(BeTrue())
+			Expect(predicate.Matches(ctx)).To(BeTrue())
 		})
 
 		It("matches git push with --git-dir option", func() {
@@ -651,7 +652,69 @@ EOF
 				validator.GitSubcommandIs("push"),
 			)
 
-			Expect(predicate(ctx)).To(BeTrue())
+			Expect(predicate.Matches(ctx)).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("Predicate descriptions", func() {
+	It("describes combined predicates", func() {
+		predicate := validator.And(
+			validator.EventTypeIs(hook.EventTypePreToolUse),
+			validator.ToolTypeIn(hook.ToolTypeWrite, hook.ToolTypeEdit),
+			validator.Or(
+				validator.FileExtensionIn(".yml", "yaml"),
+				validator.Not(validator.CommandContains("gh pr create")),
+			),
+		)
+
+		Expect(predicate.String()).To(Equal(
+			`PreToolUse and Write/Edit and (*.yml/*.yaml or not command contains "gh pr create")`,
+		))
+	})
+
+	It("does not parenthesize a single combined predicate", func() {
+		predicate := validator.Or(validator.And(validator.GitSubcommandWithoutAnyFlag("branch", "-d", "-D")))
+
+		Expect(predicate.String()).To(Equal("git branch without -d/-D"))
+	})
+
+	It("never matches the zero predicate", func() {
+		Expect(validator.Predicate{}.Matches(&hook.Context{})).To(BeFalse())
+	})
+})
+
+var _ = Describe("Registry", func() {
+	Describe("Explain", func() {
+		var (
+			registry *validator.Registry
+			push     validator.Validator
+			write    validator.Validator
+		)
+
+		BeforeEach(func() {
+			ctrl := gomock.NewController(GinkgoT())
+			push = validator.NewMockValidator(ctrl)
+			write = validator.NewMockValidator(ctrl)
+
+			registry = validator.NewRegistry()
+			registry.Register(push, validator.GitSubcommandIs("push"))
+			registry.Register(write, validator.ToolTypeIs(hook.ToolTypeWrite))
+		})
+
+		It("reports every registration in order with its selection", func() {
+			selections := registry.Explain(&hook.Context{
+				ToolName:  hook.ToolTypeBash,
+				ToolInput: hook.ToolInput{Command: "git push origin main"},
+			})
+
+			Expect(selections).To(HaveLen(2))
+			Expect(selections[0].Validator).To(BeIdenticalTo(push))
+			Expect(selections[0].Predicate.String()).To(Equal("git push"))
+			Expect(selections[0].Selected).To(BeTrue())
+			Expect(selections[1].Validator).To(BeIdenticalTo(write))
+			Expect(selections[1].Predicate.String()).To(Equal("Write"))
+			Expect(selections[1].Selected).To(BeFalse())
 		})
 	})
 })
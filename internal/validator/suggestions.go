@@ -29,17 +29,21 @@ var DefaultSuggestions = map[Reference]string{
 	RefGitPRValidation:       "Fix PR title, body, markdown formatting, or labels per validation errors",
 	RefGitFetchNoRemote:      "Specify valid remote: git fetch <remote> (use 'git remote -v' to list remotes)",
 	RefGitBlockedRemote:      "Use an allowed remote instead (see error message for suggested alternatives)",
+	RefGitForcePushProtected: "Never rewrite protected branch history; push to a feature branch and open a PR",
+	RefGitForceWithoutLease:  "Use --force-with-lease instead of --force to avoid overwriting others' work",
+	RefGitRemoteBranchDelete: "Delete remote branches manually or via the hosting UI after review",
 
 	// File suggestions
-	RefShellcheck:   "Run 'shellcheck <file>' to see detailed errors",
-	RefTerraformFmt: "Run 'terraform fmt' or 'tofu fmt' to fix formatting",
-	RefTflint:       "Run 'tflint' to see detailed linting issues",
-	RefActionlint:   "Run 'actionlint' to see workflow issues",
-	RefMarkdownLint: "Check markdown formatting and structure",
-	RefGofumpt:      "Run 'gofumpt -w <file>' to auto-fix formatting",
-	RefRuffCheck:    "Run 'ruff check <file>' to see Python code quality issues",
-	RefOxlintCheck:  "Run 'oxlint <file>' to see JavaScript/TypeScript code quality issues",
-	RefRustfmtCheck: "Run 'rustfmt <file>' to auto-fix formatting",
+	RefShellcheck:        "Run 'shellcheck <file>' to see detailed errors",
+	RefTerraformFmt:      "Run 'terraform fmt' or 'tofu fmt' to fix formatting",
+	RefTflint:            "Run 'tflint' to see detailed linting issues",
+	RefActionlint:        "Run 'actionlint' to see workflow issues",
+	RefMarkdownLint:      "Check markdown formatting and structure",
+	RefGofumpt:           "Run 'gofumpt -w <file>' to auto-fix formatting",
+	RefRuffCheck:         "Run 'ruff check <file>' to see Python code quality issues",
+	RefOxlintCheck:       "Run 'oxlint <file>' to see JavaScript/TypeScript code quality issues",
+	RefRustfmtCheck:      "Run 'rustfmt <file>' to auto-fix formatting",
+	RefSensitiveFileRead: "Ask the user for the specific value you need instead of reading the file",
 
 	// Security suggestions
 	RefSecretsAPIKey:     "Remove API key and use environment variables or secret management",
@@ -49,7 +53,14 @@ var DefaultSuggestions = map[Reference]string{
 	RefSecretsConnString: "Use environment variables for database connection strings",
 
 	// Shell suggestions
-	RefShellBackticks: "Use HEREDOC (git commit -m \"$(cat <<'EOF'\\n...\\nEOF\\n)\") or file-based input (--body-file)",
+	RefShellBackticks:           "Use HEREDOC (git commit -m \"$(cat <<'EOF'\\n...\\nEOF\\n)\") or file-based input (--body-file)",
+	RefShellRecursiveDelete:     "Limit recursive deletes to paths inside the repository",
+	RefShellProtectedPathDelete: "Use an explicit path or guard variables with ${VAR:?} before deleting",
+	RefShellGitDiscard:          "Commit or stash your changes first (git stash -u)",
+	RefShellFindDelete:          "Run the find command without -delete first to review the matches",
+	RefShellDeviceWrite:         "Double-check the target device; write to a file instead of /dev/*",
+	RefShellChmodRecursive:      "Grant only the permissions needed (e.g., chmod -R u+rwX,go+rX)",
+	RefShellTestFailure:         "Fix the failures shown in the output and re-run the tests",
 
 	// GitHub CLI suggestions
 	RefGHIssueValidation: "Fix markdown formatting in issue body (empty lines around headings, proper list spacing)",
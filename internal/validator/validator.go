@@ -69,6 +69,15 @@ type Result struct {
 	// Some validators may only warn without blocking.
 	ShouldBlock bool
 
+	// ShouldAsk indicates that the user should be prompted to confirm the
+	// operation instead of having it blocked or allowed outright.
+	ShouldAsk bool
+
+	// ShouldPoison indicates that the session should be poisoned with the
+	// result's reference, even for events that do not poison on blocking
+	// results (e.g., PostToolUse).
+	ShouldPoison bool
+
 	// Reference is the URL that uniquely identifies this error type.
 	// Format: https://klaudiu.sh/{CODE} (e.g., https://klaudiu.sh/GIT001).
 	Reference Reference
@@ -156,6 +165,29 @@ func WarnWithRef(ref Reference, message string) *Result {
 	}
 }
 
+// Ask creates a failing validation result that asks the user for confirmation.
+func Ask(message string) *Result {
+	return &Result{
+		Passed:      false,
+		Message:     message,
+		ShouldBlock: false,
+		ShouldAsk:   true,
+	}
+}
+
+// AskWithRef creates an ask validation result with a reference URL.
+// Automatically populates FixHint from the suggestions registry.
+func AskWithRef(ref Reference, message string) *Result {
+	return &Result{
+		Passed:      false,
+		Message:     message,
+		ShouldBlock: false,
+		ShouldAsk:   true,
+		Reference:   ref,
+		FixHint:     GetSuggestion(ref),
+	}
+}
+
 // AddDetail adds a detail to the result.
 func (r *Result) AddDetail(key, value string) *Result {
 	if r.Details == nil {
@@ -177,6 +209,10 @@ func (r *Result) String() string {
 		return "BLOCK"
 	}
 
+	if r.ShouldAsk {
+		return "ASK"
+	}
+
 	return "WARN"
 }
 
@@ -2,11 +2,44 @@ package file
 
 import (
 	"cmp"
+	"fmt"
+	"os"
 	"strings"
 
+	"github.com/cockroachdb/errors"
+
+	"github.com/smykla-labs/klaudiush/pkg/hook"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
 )
 
+// errEditNotApplicable is returned when a MultiEdit entry cannot be applied to the file.
+var errEditNotApplicable = errors.New("edit old_string not found in file content")
+
+// MultiEditFragment is the region of a file touched by a single MultiEdit entry.
+type MultiEditFragment struct {
+	// Index is the 1-based position of the edit in the edits array.
+	Index int
+
+	// Fragment is the edited region with surrounding context lines.
+	Fragment string
+
+	// StartLine is the 0-indexed line in Original where the fragment starts.
+	StartLine int
+
+	// Original is the content the edit was applied to, i.e. the on-disk file
+	// with all preceding edits already applied.
+	Original string
+
+	// OldString is the text replaced by this edit.
+	OldString string
+}
+
+// editFinding is the lint output for a single failing MultiEdit entry.
+type editFinding struct {
+	fragment MultiEditFragment
+	output   string
+}
+
 // ExtractEditFragment extracts the edit region with surrounding context lines.
 // It finds the oldStr in content, replaces it with newStr, and returns a fragment
 // containing the edit plus contextLines before and after for proper linting context.
@@ -150,3 +183,189 @@ func EditReachesEOF(content, oldStr string) bool {
 
 	return trimmed == ""
 }
+
+// ApplyEdits applies Edit or MultiEdit entries in order, each against the result of the
+// previous one, mirroring how Claude Code applies them.
+func ApplyEdits(content string, edits []hook.EditOp) (string, error) {
+	for i, edit := range edits {
+		if edit.OldString == "" || !strings.Contains(content, edit.OldString) {
+			return "", errors.Wrapf(errEditNotApplicable, "edit #%d", i+1)
+		}
+
+		content = applyEdit(content, edit)
+	}
+
+	return content, nil
+}
+
+// applyEdit applies a single edit to content.
+func applyEdit(content string, edit hook.EditOp) string {
+	if edit.ReplaceAll {
+		return strings.ReplaceAll(content, edit.OldString, edit.NewString)
+	}
+
+	return strings.Replace(content, edit.OldString, edit.NewString, 1)
+}
+
+// ExtractMultiEditFragments applies MultiEdit entries in order against content
+// and returns a fragment with context lines for every edit that can be linted.
+// Edits that delete text (empty new_string) are applied but not returned, like
+// single Edit operations. Extraction stops at the first edit whose old_string
+// is not found, since Claude Code rejects the whole MultiEdit in that case.
+func ExtractMultiEditFragments(
+	content string,
+	edits []hook.EditOp,
+	contextLines int,
+	log logger.Logger,
+) []MultiEditFragment {
+	fragments := make([]MultiEditFragment, 0, len(edits))
+
+	for i, edit := range edits {
+		if edit.OldString == "" || !strings.Contains(content, edit.OldString) {
+			log.Debug("multi-edit old_string not found, stopping", "edit", i+1)
+			break
+		}
+
+		if edit.NewString != "" {
+			fragment := ExtractEditFragment(content, edit.OldString, edit.NewString, contextLines, log)
+			if fragment != "" {
+				fragments = append(fragments, MultiEditFragment{
+					Index:     i + 1,
+					Fragment:  fragment,
+					StartLine: getFragmentStartLine(content, edit.OldString, contextLines),
+					Original:  content,
+					OldString: edit.OldString,
+				})
+			}
+		}
+
+		content = applyEdit(content, edit)
+	}
+
+	return fragments
+}
+
+// readMultiEditFragments reads the target file of a MultiEdit context and
+// extracts a fragment for each edit.
+func readMultiEditFragments(
+	ctx *hook.Context,
+	contextLines int,
+	log logger.Logger,
+) ([]MultiEditFragment, error) {
+	filePath := ctx.GetFilePath()
+	if filePath == "" {
+		return nil, errNoContent
+	}
+
+	//nolint:gosec // filePath is from Claude Code tool context, not user input
+	originalContent, err := os.ReadFile(filePath)
+	if err != nil {
+		log.Debug("failed to read file for multi-edit validation", "file", filePath, "error", err)
+		return nil, err
+	}
+
+	fragments := ExtractMultiEditFragments(
+		string(originalContent),
+		ctx.ToolInput.Edits,
+		contextLines,
+		log,
+	)
+	if len(fragments) == 0 {
+		return nil, errNoContent
+	}
+
+	log.Debug("validating multi-edit fragments", "count", len(fragments))
+
+	return fragments, nil
+}
+
+// readMultiEditContent reads the target file of a MultiEdit context and
+// returns its content with all edits applied.
+func readMultiEditContent(ctx *hook.Context, log logger.Logger) (string, error) {
+	return readEditedContent(ctx, ctx.ToolInput.Edits, log)
+}
+
+// readEditContent reads the target file of an Edit context and returns its
+// content with the edit applied.
+func readEditContent(ctx *hook.Context, log logger.Logger) (string, error) {
+	return readEditedContent(ctx, []hook.EditOp{{
+		OldString:  ctx.ToolInput.OldString,
+		NewString:  ctx.ToolInput.NewString,
+		ReplaceAll: ctx.ToolInput.ReplaceAll,
+	}}, log)
+}
+
+// readEditedContent reads the target file of ctx and applies edits to it.
+func readEditedContent(ctx *hook.Context, edits []hook.EditOp, log logger.Logger) (string, error) {
+	filePath := ctx.GetFilePath()
+	if filePath == "" {
+		return "", errNoContent
+	}
+
+	//nolint:gosec // filePath is from Claude Code tool context, not user input
+	originalContent, err := os.ReadFile(filePath)
+	if err != nil {
+		log.Debug("failed to read file for edit validation", "file", filePath, "error", err)
+		return "", err
+	}
+
+	return ApplyEdits(string(originalContent), edits)
+}
+
+// isPreToolMultiEdit returns true for MultiEdit operations in PreToolUse.
+func isPreToolMultiEdit(ctx *hook.Context) bool {
+	return ctx.EventType == hook.EventTypePreToolUse && ctx.IsMultiEdit()
+}
+
+// lintFragments runs lint on every fragment and collects one finding per failing edit.
+// lint returns the formatted output and whether the fragment passed.
+func lintFragments(
+	fragments []MultiEditFragment,
+	lint func(fragment MultiEditFragment) (string, bool),
+) []editFinding {
+	var findings []editFinding
+
+	for _, fragment := range fragments {
+		if output, ok := lint(fragment); !ok {
+			findings = append(findings, editFinding{fragment: fragment, output: output})
+		}
+	}
+
+	return findings
+}
+
+// formatEditFindings formats findings with one section per failing edit.
+func formatEditFindings(findings []editFinding) string {
+	var builder strings.Builder
+
+	for i, finding := range findings {
+		if i > 0 {
+			builder.WriteString("\n\n")
+		}
+
+		fmt.Fprintf(
+			&builder,
+			"Edit #%d (near line %d):\n%s",
+			finding.fragment.Index,
+			finding.fragment.StartLine+1,
+			strings.TrimSpace(finding.output),
+		)
+	}
+
+	return builder.String()
+}
+
+// cleanOutputLines removes empty lines from linter output.
+func cleanOutputLines(output string) string {
+	lines := strings.Split(output, "\n")
+
+	cleanLines := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			cleanLines = append(cleanLines, line)
+		}
+	}
+
+	return strings.Join(cleanLines, "\n")
+}
@@ -5,6 +5,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/smykla-labs/klaudiush/internal/validators/file"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
 )
 
@@ -577,3 +578,96 @@ var _ = Describe("EditReachesEOF", func() {
 		})
 	})
 })
+
+var _ = Describe("ExtractMultiEditFragments", func() {
+	var log logger.Logger
+
+	BeforeEach(func() {
+		log = logger.NewNoOpLogger()
+	})
+
+	content := `line 1
+line 2
+line 3
+line 4
+line 5
+line 6
+line 7`
+
+	It("returns one fragment per edit in order", func() {
+		fragments := file.ExtractMultiEditFragments(content, []hook.EditOp{
+			{OldString: "line 2", NewString: "line two"},
+			{OldString: "line 6", NewString: "line six"},
+		}, 1, log)
+
+		Expect(fragments).To(HaveLen(2))
+		Expect(fragments[0].Index).To(Equal(1))
+		Expect(fragments[0].Fragment).To(Equal("line 1\nline two\nline 3"))
+		Expect(fragments[0].StartLine).To(Equal(0))
+		Expect(fragments[1].Index).To(Equal(2))
+		Expect(fragments[1].Fragment).To(Equal("line 5\nline six\nline 7"))
+		Expect(fragments[1].StartLine).To(Equal(4))
+	})
+
+	It("applies later edits against the result of earlier ones", func() {
+		fragments := file.ExtractMultiEditFragments(content, []hook.EditOp{
+			{OldString: "line 3", NewString: "line 3 changed"},
+			{OldString: "line 3 changed", NewString: "line 3 changed twice"},
+		}, 0, log)
+
+		Expect(fragments).To(HaveLen(2))
+		Expect(fragments[1].Fragment).To(Equal("line 3 changed twice"))
+		Expect(fragments[1].Original).To(ContainSubstring("line 3 changed\n"))
+	})
+
+	It("skips deletions but still applies them", func() {
+		fragments := file.ExtractMultiEditFragments(content, []hook.EditOp{
+			{OldString: "line 2\n", NewString: ""},
+			{OldString: "line 3", NewString: "line three"},
+		}, 1, log)
+
+		Expect(fragments).To(HaveLen(1))
+		Expect(fragments[0].Index).To(Equal(2))
+		Expect(fragments[0].Fragment).To(Equal("line 1\nline three\nline 4"))
+	})
+
+	It("stops at the first edit whose old_string is missing", func() {
+		fragments := file.ExtractMultiEditFragments(content, []hook.EditOp{
+			{OldString: "line 1", NewString: "line one"},
+			{OldString: "missing", NewString: "x"},
+			{OldString: "line 7", NewString: "line seven"},
+		}, 0, log)
+
+		Expect(fragments).To(HaveLen(1))
+		Expect(fragments[0].Index).To(Equal(1))
+	})
+})
+
+var _ = Describe("ApplyEdits", func() {
+	It("applies edits in order", func() {
+		result, err := file.ApplyEdits("a b a", []hook.EditOp{
+			{OldString: "a", NewString: "c"},
+			{OldString: "c b", NewString: "d"},
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal("d a"))
+	})
+
+	It("honors replace_all", func() {
+		result, err := file.ApplyEdits("a b a", []hook.EditOp{
+			{OldString: "a", NewString: "c", ReplaceAll: true},
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal("c b c"))
+	})
+
+	It("returns an error when an old_string is not found", func() {
+		_, err := file.ApplyEdits("a b", []hook.EditOp{
+			{OldString: "x", NewString: "y"},
+		})
+
+		Expect(err).To(MatchError(ContainSubstring("edit #1")))
+	})
+})
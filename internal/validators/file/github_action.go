@@ -215,6 +215,11 @@ func (v *WorkflowValidator) getContent(ctx *hook.Context) (string, error) {
 		return ctx.ToolInput.Content, nil
 	}
 
+	// For MultiEdit operations in PreToolUse, read file and apply all edits in order
+	if isPreToolMultiEdit(ctx) {
+		return readMultiEditContent(ctx, log)
+	}
+
 	// For Edit operations in PreToolUse, read file and apply edit
 	if ctx.EventType == hook.EventTypePreToolUse && ctx.ToolName == hook.ToolTypeEdit {
 		filePath := ctx.GetFilePath()
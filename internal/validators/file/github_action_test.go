@@ -2,6 +2,8 @@ package file_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -802,4 +804,55 @@ jobs:
 			})
 		})
 	})
+
+	Describe("MultiEdit operations", func() {
+		var ctx *hook.Context
+
+		BeforeEach(func() {
+			workflowDir := filepath.Join(GinkgoT().TempDir(), ".github", "workflows")
+			Expect(os.MkdirAll(workflowDir, 0o755)).To(Succeed())
+
+			tempFile := filepath.Join(workflowDir, "test.yml")
+			content := `name: Test
+on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@b4ffde65f46336ab88eb53be808477a3936bae11 # v4.1.1
+      - run: make test
+`
+			Expect(os.WriteFile(tempFile, []byte(content), 0o600)).To(Succeed())
+
+			ctx = &hook.Context{
+				EventType: hook.EventTypePreToolUse,
+				ToolName:  hook.ToolTypeMultiEdit,
+				ToolInput: hook.ToolInput{FilePath: tempFile},
+			}
+		})
+
+		It("validates the workflow with all edits applied", func() {
+			ctx.ToolInput.Edits = []hook.EditOp{
+				{OldString: "name: Test", NewString: "name: CI"},
+				{OldString: "run: make test", NewString: "run: make check"},
+			}
+
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+
+		It("fails when an edit introduces an unpinned action", func() {
+			ctx.ToolInput.Edits = []hook.EditOp{
+				{OldString: "name: Test", NewString: "name: CI"},
+				{
+					OldString: "actions/checkout@b4ffde65f46336ab88eb53be808477a3936bae11 # v4.1.1",
+					NewString: "actions/checkout@v4",
+				},
+			}
+
+			result := validator.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Details["errors"]).To(ContainSubstring("uses tag without digest"))
+		})
+	})
 })
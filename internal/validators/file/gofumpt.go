@@ -110,10 +110,20 @@ func (v *GofumptValidator) getContent(
 ) (string, error) {
 	log := v.Logger()
 
-	// For Edit operations, skip validation initially (no fragment support)
+	// For PostToolUse, validate the file as written
+	if written := ctx.GetWrittenContent(); written != "" {
+		return written, nil
+	}
+
+	// For MultiEdit operations, validate the whole file with all edits applied
+	// since gofumpt cannot format fragments
+	if isPreToolMultiEdit(ctx) {
+		return readMultiEditContent(ctx, log)
+	}
+
+	// For Edit operations, validate the whole file with the edit applied
 	if ctx.EventType == hook.EventTypePreToolUse && ctx.ToolName == hook.ToolTypeEdit {
-		log.Debug("skipping Edit operations (no fragment support)")
-		return "", os.ErrNotExist
+		return readEditContent(ctx, log)
 	}
 
 	// Get content from context (Write operation)
@@ -112,16 +112,100 @@ var _ = Describe("GofumptValidator", func() {
 		})
 
 		Context("when Edit operation", func() {
-			It("should return Pass (no fragment support)", func() {
+			It("should check the file with the edit applied", func() {
+				original := "package main\n\nfunc a() {}\n\nfunc b() { a() }\n"
+				Expect(os.WriteFile(testFilePath, []byte(original), 0o600)).To(Succeed())
+
+				hookCtx.ToolName = hook.ToolTypeEdit
+				hookCtx.ToolInput.OldString = "a()"
+				hookCtx.ToolInput.NewString = "alpha()"
+				hookCtx.ToolInput.ReplaceAll = true
+
+				edited := "package main\n\nfunc alpha() {}\n\nfunc b() { alpha() }\n"
+
+				mockChecker.EXPECT().
+					CheckWithOptions(gomock.Any(), edited, gomock.Any()).
+					Return(&linters.LintResult{Success: false, RawOut: "main.go"})
+
+				validator = file.NewGofumptValidator(log, mockChecker, nil, nil)
+
+				result := validator.Validate(ctx, hookCtx)
+
+				Expect(result.Passed).To(BeFalse())
+			})
+
+			It("should pass when the edit cannot be applied", func() {
+				Expect(os.WriteFile(testFilePath, []byte("package main\n"), 0o600)).To(Succeed())
+
+				hookCtx.ToolName = hook.ToolTypeEdit
+				hookCtx.ToolInput.OldString = "missing"
+				hookCtx.ToolInput.NewString = "x"
+
+				validator = file.NewGofumptValidator(log, mockChecker, nil, nil)
+
+				result := validator.Validate(ctx, hookCtx)
+
+				Expect(result.Passed).To(BeTrue())
+			})
+		})
+
+		Context("when MultiEdit operation", func() {
+			It("should check the file with all edits applied", func() {
+				original := "package main\n\nfunc a() {}\n\nfunc b() {}\n"
+				Expect(os.WriteFile(testFilePath, []byte(original), 0o600)).To(Succeed())
+
+				hookCtx.ToolName = hook.ToolTypeMultiEdit
+				hookCtx.ToolInput.Edits = []hook.EditOp{
+					{OldString: "func a() {}", NewString: "func alpha() {}"},
+					{OldString: "func b() {}", NewString: "func beta() {}"},
+				}
+
+				mockChecker.EXPECT().
+					CheckWithOptions(gomock.Any(), "package main\n\nfunc alpha() {}\n\nfunc beta() {}\n", gomock.Any()).
+					Return(&linters.LintResult{Success: true})
+
+				validator = file.NewGofumptValidator(log, mockChecker, nil, nil)
+
+				result := validator.Validate(ctx, hookCtx)
+
+				Expect(result.Passed).To(BeTrue())
+			})
+
+			It("should pass when an edit cannot be applied", func() {
+				Expect(os.WriteFile(testFilePath, []byte("package main\n"), 0o600)).To(Succeed())
+
+				hookCtx.ToolName = hook.ToolTypeMultiEdit
+				hookCtx.ToolInput.Edits = []hook.EditOp{
+					{OldString: "missing", NewString: "x"},
+				}
+
+				validator = file.NewGofumptValidator(log, mockChecker, nil, nil)
+
+				result := validator.Validate(ctx, hookCtx)
+
+				Expect(result.Passed).To(BeTrue())
+			})
+		})
+
+		Context("when PostToolUse Edit operation", func() {
+			It("should check the file as written", func() {
+				written := "package main\n\nfunc main()  {}\n"
+
+				hookCtx.EventType = hook.EventTypePostToolUse
 				hookCtx.ToolName = hook.ToolTypeEdit
 				hookCtx.ToolInput.OldString = "old"
 				hookCtx.ToolInput.NewString = "new"
+				hookCtx.ToolResponse = &hook.ToolResponse{Content: written}
+
+				mockChecker.EXPECT().
+					CheckWithOptions(gomock.Any(), written, gomock.Any()).
+					Return(&linters.LintResult{Success: false, RawOut: "main.go"})
 
 				validator = file.NewGofumptValidator(log, mockChecker, nil, nil)
 
 				result := validator.Validate(ctx, hookCtx)
 
-				Expect(result.Passed).To(BeTrue())
+				Expect(result.Passed).To(BeFalse())
 			})
 		})
 
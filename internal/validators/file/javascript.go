@@ -80,6 +80,11 @@ func (v *JavaScriptValidator) Validate(
 		return validator.Pass()
 	}
 
+	// MultiEdit operations are validated one fragment per edit
+	if isPreToolMultiEdit(hookCtx) {
+		return v.validateMultiEdit(ctx, hookCtx)
+	}
+
 	// Get content based on operation type
 	jsc, err := v.getContent(hookCtx, filePath)
 	if err != nil {
@@ -105,6 +110,42 @@ func (v *JavaScriptValidator) Validate(
 	return validator.FailWithRef(validator.RefOxlintCheck, v.formatOxlintOutput(result))
 }
 
+// validateMultiEdit runs oxlint on each MultiEdit fragment and reports
+// one finding per failing edit.
+func (v *JavaScriptValidator) validateMultiEdit(
+	ctx context.Context,
+	hookCtx *hook.Context,
+) *validator.Result {
+	log := v.Logger()
+
+	fragments, err := readMultiEditFragments(hookCtx, v.getContextLines(), log)
+	if err != nil {
+		log.Debug("failed to get multi-edit fragments", "error", err)
+		return validator.Pass()
+	}
+
+	opts := v.buildOxlintOptions(true)
+
+	findings := lintFragments(fragments, func(fragment MultiEditFragment) (string, bool) {
+		lintCtx, cancel := context.WithTimeout(ctx, v.getTimeout())
+		defer cancel()
+
+		result := v.checker.CheckWithOptions(lintCtx, fragment.Fragment, opts)
+
+		return formatOxlintFindings(result), result.Success
+	})
+	if len(findings) == 0 {
+		log.Debug("oxlint passed for all edits")
+		return validator.Pass()
+	}
+
+	return validator.FailWithRef(
+		validator.RefOxlintCheck,
+		"Oxlint validation failed\n\n"+formatEditFindings(findings)+
+			"\n\nFix these issues before committing.",
+	)
+}
+
 // javascriptContent holds JavaScript/TypeScript script content and metadata for validation
 type javascriptContent struct {
 	content    string
@@ -200,20 +241,15 @@ func (v *JavaScriptValidator) getEditContent(
 
 // formatOxlintOutput formats oxlint findings into human-readable text.
 func (*JavaScriptValidator) formatOxlintOutput(result *linters.LintResult) string {
-	if len(result.Findings) == 0 {
-		// Fallback to raw output if no findings parsed
-		lines := strings.Split(result.RawOut, "\n")
-
-		var cleanLines []string
-
-		for _, line := range lines {
-			if strings.TrimSpace(line) != "" {
-				cleanLines = append(cleanLines, line)
-			}
-		}
+	return "Oxlint validation failed\n\n" + formatOxlintFindings(result) +
+		"\n\nFix these issues before committing."
+}
 
-		return "Oxlint validation failed\n\n" + strings.Join(cleanLines, "\n") +
-			"\n\nFix these issues before committing."
+// formatOxlintFindings formats oxlint findings as one line per finding,
+// falling back to the raw output if no findings were parsed.
+func formatOxlintFindings(result *linters.LintResult) string {
+	if len(result.Findings) == 0 {
+		return cleanOutputLines(result.RawOut)
 	}
 
 	lines := make([]string, 0, len(result.Findings))
@@ -228,8 +264,7 @@ func (*JavaScriptValidator) formatOxlintOutput(result *linters.LintResult) strin
 		lines = append(lines, line)
 	}
 
-	return "Oxlint validation failed\n\n" + strings.Join(lines, "\n") +
-		"\n\nFix these issues before committing."
+	return strings.Join(lines, "\n")
 }
 
 // buildOxlintOptions creates OxlintCheckOptions with excludes from config and fragment-specific rules.
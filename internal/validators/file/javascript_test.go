@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -451,4 +452,58 @@ if (require.main === module) {
 			})
 		})
 	})
+
+	Describe("MultiEdit operations", func() {
+		BeforeEach(func() {
+			tempFile := filepath.Join(GinkgoT().TempDir(), "app.js")
+			content := "function alpha() {\n  return 1;\n}\n\nfunction beta() {\n  return 2;\n}\n"
+			Expect(os.WriteFile(tempFile, []byte(content), 0o600)).To(Succeed())
+
+			ctx.ToolName = hook.ToolTypeMultiEdit
+			ctx.ToolInput.FilePath = tempFile
+			ctx.ToolInput.Edits = []hook.EditOp{
+				{OldString: "return 1;", NewString: "return 10;"},
+				{OldString: "return 2;", NewString: "return undefinedVar;"},
+			}
+		})
+
+		It("lints every edit fragment", func() {
+			mockChecker.EXPECT().
+				CheckWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&linters.LintResult{Success: true}).
+				Times(2)
+
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+
+		It("reports one finding per failing edit", func() {
+			mockChecker.EXPECT().
+				CheckWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(
+					_ context.Context,
+					content string,
+					_ *linters.OxlintCheckOptions,
+				) *linters.LintResult {
+					if !strings.Contains(content, "undefinedVar") {
+						return &linters.LintResult{Success: true}
+					}
+
+					return &linters.LintResult{
+						Success: false,
+						Findings: []linters.LintFinding{
+							{File: "-", Line: 2, Column: 10, Message: "'undefinedVar' is not defined", Rule: "no-undef"},
+						},
+					}
+				}).
+				Times(2)
+
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefOxlintCheck))
+			Expect(result.Message).To(ContainSubstring("Edit #2"))
+			Expect(result.Message).To(ContainSubstring("no-undef"))
+			Expect(result.Message).NotTo(ContainSubstring("Edit #1"))
+		})
+	})
 })
@@ -100,6 +100,11 @@ func (v *MarkdownValidator) Validate(ctx context.Context, hookCtx *hook.Context)
 		return validator.Pass()
 	}
 
+	// MultiEdit operations are validated one fragment per edit
+	if isPreToolMultiEdit(hookCtx) {
+		return v.validateMultiEdit(ctx, hookCtx)
+	}
+
 	content, initialState, err := v.getContentWithState(hookCtx)
 	if err != nil {
 		log.Debug("skipping markdown validation", "error", err)
@@ -142,6 +147,42 @@ func (v *MarkdownValidator) Validate(ctx context.Context, hookCtx *hook.Context)
 	return validator.Pass()
 }
 
+// validateMultiEdit lints each MultiEdit fragment with its detected markdown
+// state and reports one finding per failing edit.
+func (v *MarkdownValidator) validateMultiEdit(
+	ctx context.Context,
+	hookCtx *hook.Context,
+) *validator.Result {
+	log := v.Logger()
+
+	fragments, err := readMultiEditFragments(hookCtx, v.getContextLines(), log)
+	if err != nil {
+		log.Debug("skipping markdown validation", "error", err)
+		return validator.Pass()
+	}
+
+	displayPath := getDisplayPath(hookCtx.GetFilePath())
+
+	findings := lintFragments(fragments, func(fragment MultiEditFragment) (string, bool) {
+		state := validators.DetectMarkdownState(fragment.Original, fragment.StartLine)
+		state.StartLine = fragment.StartLine
+		state.EndsAtEOF = EditReachesEOF(fragment.Original, fragment.OldString)
+
+		lintCtx, cancel := context.WithTimeout(ctx, v.getTimeout())
+		defer cancel()
+
+		result := v.linter.LintWithPath(lintCtx, fragment.Fragment, &state, displayPath)
+
+		return result.RawOut, result.Success
+	})
+	if len(findings) == 0 {
+		return validator.Pass()
+	}
+
+	return validator.FailWithRef(validator.RefMarkdownLint, "Markdown formatting errors").
+		AddDetail("errors", formatEditFindings(findings))
+}
+
 // getContentWithState extracts markdown content and detects initial state from context
 func (v *MarkdownValidator) getContentWithState(
 	ctx *hook.Context,
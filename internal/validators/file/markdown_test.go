@@ -2,14 +2,18 @@ package file_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
 
 	execpkg "github.com/smykla-labs/klaudiush/internal/exec"
 	"github.com/smykla-labs/klaudiush/internal/linters"
+	"github.com/smykla-labs/klaudiush/internal/validators"
 	"github.com/smykla-labs/klaudiush/internal/validators/file"
 	"github.com/smykla-labs/klaudiush/pkg/hook"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
@@ -573,4 +577,66 @@ code
 			})
 		})
 	})
+
+	Describe("MultiEdit operations", func() {
+		var (
+			mockCtrl   *gomock.Controller
+			mockLinter *linters.MockMarkdownLinter
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockLinter = linters.NewMockMarkdownLinter(mockCtrl)
+			v = file.NewMarkdownValidator(nil, mockLinter, logger.NewNoOpLogger(), nil)
+
+			tempFile := filepath.Join(GinkgoT().TempDir(), "README.md")
+			content := "# Title\n\nFirst paragraph.\n\n## Section\n\nSecond paragraph.\n"
+			Expect(os.WriteFile(tempFile, []byte(content), 0o600)).To(Succeed())
+
+			ctx.ToolName = hook.ToolTypeMultiEdit
+			ctx.ToolInput.FilePath = tempFile
+			ctx.ToolInput.Edits = []hook.EditOp{
+				{OldString: "First paragraph.", NewString: "Changed paragraph."},
+				{OldString: "Second paragraph.", NewString: "Broken paragraph."},
+			}
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		It("lints every edit fragment", func() {
+			mockLinter.EXPECT().
+				LintWithPath(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&linters.LintResult{Success: true}).
+				Times(2)
+
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+
+		It("reports one finding per failing edit", func() {
+			mockLinter.EXPECT().
+				LintWithPath(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(
+					_ context.Context,
+					content string,
+					_ *validators.MarkdownState,
+					_ string,
+				) *linters.LintResult {
+					if !strings.Contains(content, "Broken") {
+						return &linters.LintResult{Success: true}
+					}
+
+					return &linters.LintResult{Success: false, RawOut: "MD009 trailing spaces"}
+				}).
+				Times(2)
+
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Details["errors"]).To(ContainSubstring("Edit #2"))
+			Expect(result.Details["errors"]).To(ContainSubstring("MD009"))
+			Expect(result.Details["errors"]).NotTo(ContainSubstring("Edit #1"))
+		})
+	})
 })
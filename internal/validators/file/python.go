@@ -79,6 +79,11 @@ func (v *PythonValidator) Validate(
 		return validator.Pass()
 	}
 
+	// MultiEdit operations are validated one fragment per edit
+	if isPreToolMultiEdit(hookCtx) {
+		return v.validateMultiEdit(ctx, hookCtx)
+	}
+
 	// Get content based on operation type
 	pc, err := v.getContent(hookCtx, filePath)
 	if err != nil {
@@ -104,6 +109,42 @@ func (v *PythonValidator) Validate(
 	return validator.FailWithRef(validator.RefRuffCheck, v.formatRuffOutput(result))
 }
 
+// validateMultiEdit runs ruff on each MultiEdit fragment and reports
+// one finding per failing edit.
+func (v *PythonValidator) validateMultiEdit(
+	ctx context.Context,
+	hookCtx *hook.Context,
+) *validator.Result {
+	log := v.Logger()
+
+	fragments, err := readMultiEditFragments(hookCtx, v.getContextLines(), log)
+	if err != nil {
+		log.Debug("failed to get multi-edit fragments", "error", err)
+		return validator.Pass()
+	}
+
+	opts := v.buildRuffOptions(true)
+
+	findings := lintFragments(fragments, func(fragment MultiEditFragment) (string, bool) {
+		lintCtx, cancel := context.WithTimeout(ctx, v.getTimeout())
+		defer cancel()
+
+		result := v.checker.CheckWithOptions(lintCtx, fragment.Fragment, opts)
+
+		return formatRuffFindings(result), result.Success
+	})
+	if len(findings) == 0 {
+		log.Debug("ruff passed for all edits")
+		return validator.Pass()
+	}
+
+	return validator.FailWithRef(
+		validator.RefRuffCheck,
+		"Ruff validation failed\n\n"+formatEditFindings(findings)+
+			"\n\nFix these issues before committing.",
+	)
+}
+
 // pythonContent holds Python script content and metadata for validation
 type pythonContent struct {
 	content    string
@@ -199,20 +240,15 @@ func (v *PythonValidator) getEditContent(
 
 // formatRuffOutput formats ruff findings into human-readable text.
 func (*PythonValidator) formatRuffOutput(result *linters.LintResult) string {
-	if len(result.Findings) == 0 {
-		// Fallback to raw output if no findings parsed
-		lines := strings.Split(result.RawOut, "\n")
-
-		var cleanLines []string
-
-		for _, line := range lines {
-			if strings.TrimSpace(line) != "" {
-				cleanLines = append(cleanLines, line)
-			}
-		}
+	return "Ruff validation failed\n\n" + formatRuffFindings(result) +
+		"\n\nFix these issues before committing."
+}
 
-		return "Ruff validation failed\n\n" + strings.Join(cleanLines, "\n") +
-			"\n\nFix these issues before committing."
+// formatRuffFindings formats ruff findings as one line per finding,
+// falling back to the raw output if no findings were parsed.
+func formatRuffFindings(result *linters.LintResult) string {
+	if len(result.Findings) == 0 {
+		return cleanOutputLines(result.RawOut)
 	}
 
 	lines := make([]string, 0, len(result.Findings))
@@ -227,8 +263,7 @@ func (*PythonValidator) formatRuffOutput(result *linters.LintResult) string {
 		lines = append(lines, line)
 	}
 
-	return "Ruff validation failed\n\n" + strings.Join(lines, "\n") +
-		"\n\nFix these issues before committing."
+	return strings.Join(lines, "\n")
 }
 
 // buildRuffOptions creates RuffCheckOptions with excludes from config and fragment-specific rules.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -205,6 +206,70 @@ if __name__ == "__main__":
 		})
 	})
 
+	Describe("MultiEdit operations", func() {
+		var tempFile string
+
+		BeforeEach(func() {
+			tmpDir := GinkgoT().TempDir()
+			tempFile = filepath.Join(tmpDir, "test.py")
+
+			content := `def greet(name):
+    """Greet someone by name."""
+    print(f"Hello, {name}!")
+
+
+def farewell(name):
+    print(f"Bye, {name}!")
+`
+			err := os.WriteFile(tempFile, []byte(content), 0o600)
+			Expect(err).NotTo(HaveOccurred())
+
+			ctx.EventType = hook.EventTypePreToolUse
+			ctx.ToolName = hook.ToolTypeMultiEdit
+			ctx.ToolInput.FilePath = tempFile
+			ctx.ToolInput.Edits = []hook.EditOp{
+				{OldString: `"""Greet someone by name."""`, NewString: `"""Say hello."""`},
+				{OldString: `print(f"Bye, {name}!")`, NewString: `print(undefined_var)`},
+			}
+		})
+
+		It("should lint every edit fragment", func() {
+			mockChecker.EXPECT().
+				CheckWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&linters.LintResult{Success: true}).
+				Times(2)
+
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+
+		It("should report one finding per failing edit", func() {
+			mockChecker.EXPECT().
+				CheckWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, content string, _ *linters.RuffCheckOptions) *linters.LintResult {
+					if !strings.Contains(content, "undefined_var") {
+						return &linters.LintResult{Success: true}
+					}
+
+					return &linters.LintResult{
+						Success: false,
+						Findings: []linters.LintFinding{
+							{File: "-", Line: 2, Column: 11, Message: "undefined name 'undefined_var'", Rule: "F821"},
+						},
+					}
+				}).
+				Times(2)
+
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefRuffCheck))
+			Expect(result.Message).To(ContainSubstring("Ruff validation failed"))
+			Expect(result.Message).To(ContainSubstring("Edit #2 (near line 5)"))
+			Expect(result.Message).To(ContainSubstring("F821"))
+			Expect(result.Message).NotTo(ContainSubstring("Edit #1"))
+		})
+	})
+
 	Describe("no file path", func() {
 		It("should pass when no file path is provided", func() {
 			ctx.ToolInput.FilePath = ""
@@ -80,6 +80,11 @@ func (v *RustValidator) Validate(
 		return validator.Pass()
 	}
 
+	// MultiEdit operations are validated one fragment per edit
+	if isPreToolMultiEdit(hookCtx) {
+		return v.validateMultiEdit(ctx, hookCtx, filePath)
+	}
+
 	// Get content based on operation type
 	rustc, err := v.getContent(hookCtx, filePath)
 	if err != nil {
@@ -105,6 +110,43 @@ func (v *RustValidator) Validate(
 	return validator.FailWithRef(validator.RefRustfmtCheck, v.formatRustfmtOutput(result))
 }
 
+// validateMultiEdit runs rustfmt on each MultiEdit fragment and reports
+// one finding per failing edit.
+func (v *RustValidator) validateMultiEdit(
+	ctx context.Context,
+	hookCtx *hook.Context,
+	filePath string,
+) *validator.Result {
+	log := v.Logger()
+
+	fragments, err := readMultiEditFragments(hookCtx, v.getContextLines(), log)
+	if err != nil {
+		log.Debug("failed to get multi-edit fragments", "error", err)
+		return validator.Pass()
+	}
+
+	opts := v.buildRustfmtOptions(filePath)
+
+	findings := lintFragments(fragments, func(fragment MultiEditFragment) (string, bool) {
+		lintCtx, cancel := context.WithTimeout(ctx, v.getTimeout())
+		defer cancel()
+
+		result := v.checker.CheckWithOptions(lintCtx, fragment.Fragment, opts)
+
+		return cleanOutputLines(result.RawOut), result.Success
+	})
+	if len(findings) == 0 {
+		log.Debug("rustfmt passed for all edits")
+		return validator.Pass()
+	}
+
+	return validator.FailWithRef(
+		validator.RefRustfmtCheck,
+		"Rust code formatting issues detected\n\n"+formatEditFindings(findings)+
+			"\n\nRun 'rustfmt <file>' to auto-fix.",
+	)
+}
+
 // rustContent holds Rust code content and metadata for validation
 type rustContent struct {
 	content    string
@@ -120,6 +162,11 @@ func (v *RustValidator) getContent(
 ) (*rustContent, error) {
 	log := v.Logger()
 
+	// For PostToolUse, validate the file as written
+	if written := ctx.GetWrittenContent(); written != "" {
+		return &rustContent{content: written, isFragment: false}, nil
+	}
+
 	// For Edit operations, validate only the changed fragment with context
 	if ctx.EventType == hook.EventTypePreToolUse && ctx.ToolName == hook.ToolTypeEdit {
 		content, err := v.getEditContent(ctx, filePath)
@@ -303,24 +350,14 @@ func (*RustValidator) parseCargoToml(cargoTomlPath string) (string, error) {
 
 // formatRustfmtOutput formats rustfmt output into human-readable text.
 func (*RustValidator) formatRustfmtOutput(result *linters.LintResult) string {
-	// Clean up the output - remove empty lines
-	lines := strings.Split(result.RawOut, "\n")
-
-	var cleanLines []string
-
-	for _, line := range lines {
-		if strings.TrimSpace(line) != "" {
-			cleanLines = append(cleanLines, line)
-		}
-	}
-
-	if len(cleanLines) == 0 {
+	output := cleanOutputLines(result.RawOut)
+	if output == "" {
 		return "Rust code formatting issues detected"
 	}
 
 	return fmt.Sprintf(
 		"Rust code formatting issues detected\n\n%s\n\nRun 'rustfmt <file>' to auto-fix.",
-		strings.Join(cleanLines, "\n"),
+		output,
 	)
 }
 
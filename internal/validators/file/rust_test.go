@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -531,4 +532,52 @@ edition = "2018"
 			Expect(v.Category()).To(Equal(validator.CategoryIO))
 		})
 	})
+
+	Describe("MultiEdit operations", func() {
+		BeforeEach(func() {
+			tempFile := filepath.Join(GinkgoT().TempDir(), "lib.rs")
+			content := "fn alpha() {\n    println!(\"a\");\n}\n\nfn beta() {\n    println!(\"b\");\n}\n"
+			Expect(os.WriteFile(tempFile, []byte(content), 0o600)).To(Succeed())
+
+			ctx.ToolName = hook.ToolTypeMultiEdit
+			ctx.ToolInput.FilePath = tempFile
+			ctx.ToolInput.Edits = []hook.EditOp{
+				{OldString: `println!("a");`, NewString: `println!("alpha");`},
+				{OldString: `println!("b");`, NewString: `println!( "beta" );`},
+			}
+		})
+
+		It("checks every edit fragment", func() {
+			mockChecker.EXPECT().
+				CheckWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&linters.LintResult{Success: true}).
+				Times(2)
+
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+
+		It("reports one finding per failing edit", func() {
+			mockChecker.EXPECT().
+				CheckWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(
+					_ context.Context,
+					content string,
+					_ *linters.RustfmtOptions,
+				) *linters.LintResult {
+					if !strings.Contains(content, `( "beta" )`) {
+						return &linters.LintResult{Success: true}
+					}
+
+					return &linters.LintResult{Success: false, RawOut: "Diff in stdin at line 6"}
+				}).
+				Times(2)
+
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefRustfmtCheck))
+			Expect(result.Message).To(ContainSubstring("Edit #2"))
+			Expect(result.Message).NotTo(ContainSubstring("Edit #1"))
+		})
+	})
 })
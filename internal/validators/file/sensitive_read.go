@@ -0,0 +1,393 @@
+package file
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
+)
+
+// DefaultSensitiveReadPatterns are the built-in patterns for files whose
+// contents should not be read by the agent.
+var DefaultSensitiveReadPatterns = []string{
+	"**/.env*",
+	"~/.ssh/**",
+	"~/.aws/credentials",
+	"**/*.pem",
+	"~/.kube/config",
+	"**/kubeconfig",
+	"**/*.kubeconfig",
+	"~/.klaudiush/*.json",
+	"~/.klaudiush/*.jsonl",
+}
+
+// DefaultSensitiveReadAllowPatterns are the built-in patterns for files that
+// match a sensitive pattern but are safe to read.
+var DefaultSensitiveReadAllowPatterns = []string{
+	"**/.env.example",
+	"**/.env.sample",
+	"**/.env.template",
+	"~/.ssh/*.pub",
+	"~/.ssh/known_hosts",
+}
+
+// fileReaderCommands are commands that print the contents of their file arguments.
+var fileReaderCommands = []string{
+	"cat", "tac", "less", "more", "head", "tail", "bat", "nl",
+	"xxd", "hexdump", "od", "strings", "base64",
+}
+
+// patternReaderCommands are commands that read their file arguments after a
+// leading pattern or script operand, mapped to the flags that supply the
+// pattern or script instead.
+var patternReaderCommands = map[string][]string{
+	"grep":  {"-e", "--regexp", "-f", "--file"},
+	"egrep": {"-e", "--regexp", "-f", "--file"},
+	"fgrep": {"-e", "--regexp", "-f", "--file"},
+	"rg":    {"-e", "--regexp", "-f", "--file"},
+	"awk":   {"-f", "--file"},
+	"sed":   {"-e", "--expression", "-f", "--file"},
+}
+
+// fileCopyCommands are commands that copy all but their last file argument.
+var fileCopyCommands = []string{"cp", "scp", "rsync"}
+
+// SensitiveReadValidator guards against reading credential and secret files
+// such as .env files, SSH keys, cloud credentials and kubeconfigs, either with
+// the Read, Grep and Glob tools or with Bash commands like cat and cp.
+type SensitiveReadValidator struct {
+	validator.BaseValidator
+	config      *config.SensitiveReadValidatorConfig
+	ruleAdapter *rules.RuleValidatorAdapter
+	homeDir     string
+	sensitive   []rules.Pattern
+	allowed     []rules.Pattern
+}
+
+// SensitiveReadValidatorOption configures a SensitiveReadValidator.
+type SensitiveReadValidatorOption func(*SensitiveReadValidator)
+
+// WithSensitiveReadHomeDir sets the directory ~ expands to.
+// Defaults to the current user's home directory.
+func WithSensitiveReadHomeDir(homeDir string) SensitiveReadValidatorOption {
+	return func(v *SensitiveReadValidator) {
+		v.homeDir = homeDir
+	}
+}
+
+// NewSensitiveReadValidator creates a new SensitiveReadValidator.
+func NewSensitiveReadValidator(
+	log logger.Logger,
+	cfg *config.SensitiveReadValidatorConfig,
+	ruleAdapter *rules.RuleValidatorAdapter,
+	opts ...SensitiveReadValidatorOption,
+) *SensitiveReadValidator {
+	homeDir, _ := os.UserHomeDir()
+
+	v := &SensitiveReadValidator{
+		BaseValidator: *validator.NewBaseValidator("validate-sensitive-read", log),
+		config:        cfg,
+		ruleAdapter:   ruleAdapter,
+		homeDir:       homeDir,
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	patterns := DefaultSensitiveReadPatterns
+	allowPatterns := DefaultSensitiveReadAllowPatterns
+
+	if cfg != nil {
+		if len(cfg.Patterns) > 0 {
+			patterns = cfg.Patterns
+		}
+
+		patterns = append(slices.Clone(patterns), cfg.AdditionalPatterns...)
+
+		if len(cfg.AllowPatterns) > 0 {
+			allowPatterns = cfg.AllowPatterns
+		}
+	}
+
+	v.sensitive = v.compilePatterns(patterns)
+	v.allowed = v.compilePatterns(allowPatterns)
+
+	return v
+}
+
+// compilePatterns compiles path patterns, expanding a leading ~/ to the home
+// directory. Invalid patterns are logged and skipped.
+func (v *SensitiveReadValidator) compilePatterns(patterns []string) []rules.Pattern {
+	compiled := make([]rules.Pattern, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		p, err := rules.CompilePattern(v.expandHome(pattern))
+		if err != nil {
+			v.Logger().Error("invalid sensitive read pattern", "pattern", pattern, "error", err)
+
+			continue
+		}
+
+		compiled = append(compiled, p)
+	}
+
+	return compiled
+}
+
+// Validate checks whether the tool call reads a sensitive file.
+func (v *SensitiveReadValidator) Validate(
+	ctx context.Context,
+	hookCtx *hook.Context,
+) *validator.Result {
+	log := v.Logger()
+	log.Debug("validating sensitive file reads")
+
+	// Check rules first if rule adapter is configured
+	if v.ruleAdapter != nil {
+		if result := v.ruleAdapter.CheckRules(ctx, hookCtx); result != nil {
+			return result
+		}
+	}
+
+	var paths []string
+
+	switch hookCtx.ToolName {
+	case hook.ToolTypeRead, hook.ToolTypeGlob:
+		if path := hookCtx.GetFilePath(); path != "" {
+			paths = append(paths, v.resolve(cwdOf(hookCtx), path))
+		}
+	case hook.ToolTypeGrep:
+		paths = v.grepReadPaths(hookCtx)
+	case hook.ToolTypeBash:
+		if v.config.IsCheckBash() {
+			paths = v.bashReadPaths(hookCtx)
+		}
+	default:
+		return validator.Pass()
+	}
+
+	var matched []string
+
+	for _, path := range paths {
+		if v.isSensitive(path) && !slices.Contains(matched, path) {
+			matched = append(matched, path)
+		}
+	}
+
+	if len(matched) == 0 {
+		log.Debug("no sensitive files read")
+		return validator.Pass()
+	}
+
+	return v.createResult(hookCtx.ToolName, matched)
+}
+
+// bashReadPaths returns the absolute paths read by file reader and copy commands.
+func (v *SensitiveReadValidator) bashReadPaths(hookCtx *hook.Context) []string {
+	command := hookCtx.GetCommand()
+	if command == "" {
+		return nil
+	}
+
+	parseResult, err := parser.NewBashParser().Parse(command)
+	if err != nil {
+		v.Logger().Debug("failed to parse command", "error", err)
+		return nil
+	}
+
+	var paths []string
+
+	for _, cmd := range parseResult.Commands {
+		name, args := cmd.UnwrapSudo()
+
+		operands := fileOperands(args)
+
+		switch {
+		case slices.Contains(fileReaderCommands, name):
+		case patternReaderCommands[name] != nil:
+			if !hasAnyFlag(args, patternReaderCommands[name]) && len(operands) > 0 {
+				operands = operands[1:]
+			}
+		case slices.Contains(fileCopyCommands, name) && len(operands) > 1:
+			operands = operands[:len(operands)-1]
+		default:
+			continue
+		}
+
+		base := cwdOf(hookCtx)
+		if cmd.WorkingDirectory != "" {
+			base = v.resolve(base, cmd.WorkingDirectory)
+		}
+
+		for _, operand := range operands {
+			paths = append(paths, v.resolve(base, operand))
+		}
+	}
+
+	return paths
+}
+
+// grepReadPaths returns the path searched by the Grep tool. A glob filter is
+// joined to the searched path, as Grep matches it at any depth below it.
+func (v *SensitiveReadValidator) grepReadPaths(hookCtx *hook.Context) []string {
+	path := v.resolve(cwdOf(hookCtx), cmp.Or(hookCtx.GetFilePath(), "."))
+
+	glob := hookCtx.ToolInput.Glob
+	if glob == "" {
+		return []string{path}
+	}
+
+	if !strings.Contains(glob, "/") {
+		glob = "**/" + glob
+	}
+
+	return []string{filepath.Join(path, glob)}
+}
+
+// isSensitive reports whether path matches a sensitive pattern and no allow
+// pattern. A path containing glob wildcards is also sensitive if it selects a
+// sensitive pattern, so filters like ~/.klaudiush/*.key are caught.
+func (v *SensitiveReadValidator) isSensitive(path string) bool {
+	if v.isSensitiveFile(path) {
+		return true
+	}
+
+	if !strings.ContainsAny(path, "*?[{") {
+		return false
+	}
+
+	selector, err := rules.CompilePattern(path)
+	if err != nil {
+		return false
+	}
+
+	return slices.ContainsFunc(v.sensitive, func(p rules.Pattern) bool {
+		return selector.Match(p.String()) && v.isSensitiveFile(p.String())
+	})
+}
+
+// isSensitiveFile reports whether the file at path matches a sensitive pattern
+// and no allow pattern.
+func (v *SensitiveReadValidator) isSensitiveFile(path string) bool {
+	matches := func(p rules.Pattern) bool { return p.Match(path) }
+
+	return slices.ContainsFunc(v.sensitive, matches) && !slices.ContainsFunc(v.allowed, matches)
+}
+
+// resolve returns path as an absolute path, expanding ~ and $HOME.
+func (v *SensitiveReadValidator) resolve(base, path string) string {
+	path = v.expandHome(path)
+
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+
+	return filepath.Join(base, path)
+}
+
+// expandHome replaces a leading ~, $HOME or ${HOME} with the home directory.
+func (v *SensitiveReadValidator) expandHome(path string) string {
+	if v.homeDir == "" {
+		return path
+	}
+
+	for _, prefix := range []string{"~", "$HOME", "${HOME}"} {
+		if path == prefix {
+			return v.homeDir
+		}
+
+		if rest, ok := strings.CutPrefix(path, prefix+"/"); ok {
+			return v.homeDir + "/" + rest
+		}
+	}
+
+	return path
+}
+
+// createResult builds the failure or warning result for sensitive reads.
+func (v *SensitiveReadValidator) createResult(
+	toolName hook.ToolType,
+	paths []string,
+) *validator.Result {
+	var message strings.Builder
+
+	fmt.Fprintf(&message, "%s would read sensitive files:\n", toolName)
+
+	for _, path := range paths {
+		fmt.Fprintf(&message, "  - %s\n", path)
+	}
+
+	message.WriteString("\nThese files may contain credentials or secrets.")
+
+	if v.config.IsBlockOnMatch() {
+		return validator.FailWithRef(validator.RefSensitiveFileRead, message.String())
+	}
+
+	return validator.WarnWithRef(validator.RefSensitiveFileRead, message.String())
+}
+
+// Category returns the validator category for parallel execution.
+func (*SensitiveReadValidator) Category() validator.ValidatorCategory {
+	return validator.CategoryCPU
+}
+
+// cwdOf returns the directory relative paths are resolved against.
+func cwdOf(hookCtx *hook.Context) string {
+	if hookCtx.CWD != "" {
+		return hookCtx.CWD
+	}
+
+	cwd, _ := os.Getwd()
+
+	return cwd
+}
+
+// hasAnyFlag reports whether any of the raw arguments is one of flags, either
+// on its own or with an attached =value.
+func hasAnyFlag(rawArgs, flags []string) bool {
+	for _, raw := range rawArgs {
+		arg := strings.NewReplacer(`"`, "", "'", "").Replace(raw)
+
+		for _, flag := range flags {
+			if arg == flag || strings.HasPrefix(arg, flag+"=") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// fileOperands returns the unquoted non-flag arguments of a command.
+func fileOperands(rawArgs []string) []string {
+	operands := make([]string, 0, len(rawArgs))
+	endOfFlags := false
+
+	for _, raw := range rawArgs {
+		arg := strings.NewReplacer(`"`, "", "'", "").Replace(raw)
+
+		switch {
+		case endOfFlags:
+			operands = append(operands, arg)
+		case arg == "--":
+			endOfFlags = true
+		case strings.HasPrefix(arg, "-") && arg != "-":
+			continue
+		default:
+			operands = append(operands, arg)
+		}
+	}
+
+	return operands
+}
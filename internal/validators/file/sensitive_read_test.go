@@ -0,0 +1,232 @@
+package file_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/internal/validators/file"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+var _ = Describe("SensitiveReadValidator", func() {
+	var cfg *config.SensitiveReadValidatorConfig
+
+	validate := func(hookCtx *hook.Context) *validator.Result {
+		v := file.NewSensitiveReadValidator(
+			logger.NewNoOpLogger(),
+			cfg,
+			nil,
+			file.WithSensitiveReadHomeDir("/home/user"),
+		)
+
+		hookCtx.EventType = hook.EventTypePreToolUse
+		hookCtx.CWD = "/home/user/project"
+
+		return v.Validate(context.Background(), hookCtx)
+	}
+
+	read := func(path string) *validator.Result {
+		return validate(&hook.Context{
+			ToolName:  hook.ToolTypeRead,
+			ToolInput: hook.ToolInput{FilePath: path},
+		})
+	}
+
+	bash := func(command string) *validator.Result {
+		return validate(&hook.Context{
+			ToolName:  hook.ToolTypeBash,
+			ToolInput: hook.ToolInput{Command: command},
+		})
+	}
+
+	BeforeEach(func() {
+		cfg = &config.SensitiveReadValidatorConfig{}
+	})
+
+	Describe("Read tool", func() {
+		DescribeTable("blocks sensitive files",
+			func(path string) {
+				result := read(path)
+
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.ShouldBlock).To(BeTrue())
+				Expect(result.Reference).To(Equal(validator.RefSensitiveFileRead))
+			},
+			Entry(".env", ".env"),
+			Entry(".env.local", "/home/user/project/config/.env.local"),
+			Entry("ssh private key", "/home/user/.ssh/id_ed25519"),
+			Entry("aws credentials", "~/.aws/credentials"),
+			Entry("pem file", "certs/server.pem"),
+			Entry("kube config", "/home/user/.kube/config"),
+			Entry("kubeconfig file", "deploy/prod.kubeconfig"),
+			Entry("klaudiush state", "/home/user/.klaudiush/exception_state.json"),
+			Entry("klaudiush audit log", "/home/user/.klaudiush/session_audit.jsonl"),
+		)
+
+		DescribeTable("passes regular and allowed files",
+			func(path string) {
+				Expect(read(path).Passed).To(BeTrue())
+			},
+			Entry("source file", "main.go"),
+			Entry("env example", ".env.example"),
+			Entry("ssh public key", "/home/user/.ssh/id_ed25519.pub"),
+			Entry("klaudiush config", "/home/user/.klaudiush/config.toml"),
+			Entry("aws config", "/home/user/.aws/config"),
+		)
+	})
+
+	Describe("Grep and Glob tools", func() {
+		It("blocks searching a sensitive file", func() {
+			result := validate(&hook.Context{
+				ToolName:  hook.ToolTypeGrep,
+				ToolInput: hook.ToolInput{Pattern: "KEY", Path: ".env"},
+			})
+
+			Expect(result.Passed).To(BeFalse())
+		})
+
+		It("blocks listing the ssh directory", func() {
+			result := validate(&hook.Context{
+				ToolName:  hook.ToolTypeGlob,
+				ToolInput: hook.ToolInput{Pattern: "*", Path: "~/.ssh"},
+			})
+
+			Expect(result.Passed).To(BeFalse())
+		})
+
+		DescribeTable("blocks searching sensitive files selected by a glob",
+			func(path, glob string) {
+				result := validate(&hook.Context{
+					ToolName:  hook.ToolTypeGrep,
+					ToolInput: hook.ToolInput{Pattern: "KEY", Path: path, Glob: glob},
+				})
+
+				Expect(result.Passed).To(BeFalse())
+			},
+			Entry("env file in the project", ".", ".env"),
+			Entry("env file without a path", "", ".env.local"),
+			Entry("pem files", "certs", "*.pem"),
+			Entry("klaudiush state", "~/.klaudiush", "*.json"),
+		)
+
+		It("passes searching the project with a glob", func() {
+			result := validate(&hook.Context{
+				ToolName:  hook.ToolTypeGrep,
+				ToolInput: hook.ToolInput{Pattern: "TODO", Path: ".", Glob: "*.go"},
+			})
+
+			Expect(result.Passed).To(BeTrue())
+		})
+
+		It("passes searching the project", func() {
+			result := validate(&hook.Context{
+				ToolName:  hook.ToolTypeGrep,
+				ToolInput: hook.ToolInput{Pattern: "TODO"},
+			})
+
+			Expect(result.Passed).To(BeTrue())
+		})
+	})
+
+	Describe("Bash commands", func() {
+		DescribeTable("blocks reading sensitive files",
+			func(command string) {
+				result := bash(command)
+
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.Reference).To(Equal(validator.RefSensitiveFileRead))
+			},
+			Entry("cat", "cat .env"),
+			Entry("head with flags", "head -n 5 ~/.ssh/id_rsa"),
+			Entry("less with $HOME", `less "$HOME/.aws/credentials"`),
+			Entry("sudo tail", "sudo tail -f /home/user/.kube/config"),
+			Entry("cp source", "cp .env /tmp/env-backup"),
+			Entry("cd in chain", "cd certs && cat tls.pem"),
+			Entry("pipeline", "cat .env.production | grep SECRET"),
+			Entry("grep", "grep -r KEY ~/.ssh"),
+			Entry("grep with -e", "grep -e KEY -e SECRET .env"),
+			Entry("rg", "rg --no-ignore TOKEN .env.local"),
+			Entry("awk", `awk -F= '{print $2}' .env`),
+			Entry("sed", "sed -n 1p ~/.aws/credentials"),
+		)
+
+		DescribeTable("passes other commands",
+			func(command string) {
+				Expect(bash(command).Passed).To(BeTrue())
+			},
+			Entry("cat regular file", "cat README.md"),
+			Entry("cp to sensitive path", "cp .env.example .env"),
+			Entry("non-reader command", "rm .env"),
+			Entry("echo", "echo ~/.ssh/id_rsa"),
+			Entry("grep for a sensitive name", "grep -r .env src"),
+			Entry("sed script", "sed -i s/.env/.env.example/ README.md"),
+			Entry("empty", ""),
+		)
+
+		It("lists every sensitive file once", func() {
+			result := bash("cat .env .env && cat certs/ca.pem")
+
+			Expect(result.Message).To(ContainSubstring("/home/user/project/.env\n"))
+			Expect(result.Message).To(ContainSubstring("/home/user/project/certs/ca.pem"))
+			Expect(result.Message).NotTo(MatchRegexp(`(?s)project/\.env\n.*project/\.env\n`))
+		})
+
+		It("skips Bash commands when disabled", func() {
+			checkBash := false
+			cfg.CheckBash = &checkBash
+
+			Expect(bash("cat .env").Passed).To(BeTrue())
+		})
+	})
+
+	Describe("configuration", func() {
+		It("warns instead of blocking when configured", func() {
+			block := false
+			cfg.BlockOnMatch = &block
+
+			result := read(".env")
+
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeFalse())
+		})
+
+		It("replaces the built-in patterns", func() {
+			cfg.Patterns = []string{"**/secrets/*.yaml"}
+
+			Expect(read(".env").Passed).To(BeTrue())
+			Expect(read("deploy/secrets/prod.yaml").Passed).To(BeFalse())
+		})
+
+		It("adds to the built-in patterns", func() {
+			cfg.AdditionalPatterns = []string{`^/home/user/\.netrc$`}
+
+			Expect(read(".env").Passed).To(BeFalse())
+			Expect(read("~/.netrc").Passed).To(BeFalse())
+		})
+
+		It("replaces the built-in allow list", func() {
+			cfg.AllowPatterns = []string{"**/.env.test"}
+
+			Expect(read(".env.example").Passed).To(BeFalse())
+			Expect(read(".env.test").Passed).To(BeTrue())
+		})
+
+		It("skips invalid patterns", func() {
+			cfg.AdditionalPatterns = []string{"[invalid"}
+
+			Expect(read(".env").Passed).To(BeFalse())
+			Expect(read("main.go").Passed).To(BeTrue())
+		})
+	})
+
+	It("uses the CPU category", func() {
+		v := file.NewSensitiveReadValidator(logger.NewNoOpLogger(), cfg, nil)
+
+		Expect(v.Category()).To(Equal(validator.CategoryCPU))
+	})
+})
@@ -78,6 +78,11 @@ func (v *ShellScriptValidator) Validate(
 		return validator.Pass()
 	}
 
+	// MultiEdit operations are validated one fragment per edit
+	if isPreToolMultiEdit(hookCtx) {
+		return v.validateMultiEdit(ctx, hookCtx, filePath)
+	}
+
 	// Get content based on operation type
 	sc, err := v.getContent(hookCtx, filePath)
 	if err != nil {
@@ -109,6 +114,53 @@ func (v *ShellScriptValidator) Validate(
 	return validator.FailWithRef(validator.RefShellcheck, v.formatShellCheckOutput(result.RawOut))
 }
 
+// validateMultiEdit runs shellcheck on each MultiEdit fragment and reports
+// one finding per failing edit.
+func (v *ShellScriptValidator) validateMultiEdit(
+	ctx context.Context,
+	hookCtx *hook.Context,
+	filePath string,
+) *validator.Result {
+	log := v.Logger()
+
+	fragments, err := readMultiEditFragments(hookCtx, v.getContextLines(), log)
+	if err != nil {
+		log.Debug("failed to get multi-edit fragments", "error", err)
+		return validator.Pass()
+	}
+
+	if v.isFishScript(filePath, fragments[0].Original) {
+		log.Debug("skipping Fish script", "file", filePath)
+		return validator.Pass()
+	}
+
+	opts := v.buildShellCheckOptions(true)
+
+	findings := lintFragments(fragments, func(fragment MultiEditFragment) (string, bool) {
+		content := fragment.Fragment
+		if fragment.StartLine > 0 {
+			content = v.prependShellDirective(fragment.Original, content, log)
+		}
+
+		lintCtx, cancel := context.WithTimeout(ctx, v.getTimeout())
+		defer cancel()
+
+		result := v.checker.CheckWithOptions(lintCtx, content, opts)
+
+		return cleanOutputLines(result.RawOut), result.Success
+	})
+	if len(findings) == 0 {
+		log.Debug("shellcheck passed for all edits")
+		return validator.Pass()
+	}
+
+	return validator.FailWithRef(
+		validator.RefShellcheck,
+		"Shellcheck validation failed\n\n"+formatEditFindings(findings)+
+			"\n\nFix these issues before committing.",
+	)
+}
+
 // shellContent holds shell script content and metadata for validation
 type shellContent struct {
 	content    string
@@ -287,21 +339,8 @@ func (*ShellScriptValidator) isFishScript(filePath, content string) bool {
 
 // formatShellCheckOutput formats shellcheck output for display.
 func (*ShellScriptValidator) formatShellCheckOutput(output string) string {
-	// Clean up the output - remove empty lines
-	lines := strings.Split(output, "\n")
-
-	var cleanLines []string
-
-	for _, line := range lines {
-		if strings.TrimSpace(line) != "" {
-			cleanLines = append(cleanLines, line)
-		}
-	}
-
-	return "Shellcheck validation failed\n\n" + strings.Join(
-		cleanLines,
-		"\n",
-	) + "\n\nFix these issues before committing."
+	return "Shellcheck validation failed\n\n" + cleanOutputLines(output) +
+		"\n\nFix these issues before committing."
 }
 
 // buildShellCheckOptions creates ShellCheckOptions with excludes from config and fragment-specific rules.
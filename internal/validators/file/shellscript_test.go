@@ -4,10 +4,12 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
 
 	execpkg "github.com/smykla-labs/klaudiush/internal/exec"
 	"github.com/smykla-labs/klaudiush/internal/linters"
@@ -432,4 +434,65 @@ echo $VAR
 			Expect(result.Passed).To(BeTrue())
 		})
 	})
+
+	Describe("MultiEdit operations", func() {
+		var (
+			mockCtrl    *gomock.Controller
+			mockChecker *linters.MockShellChecker
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockChecker = linters.NewMockShellChecker(mockCtrl)
+			v = file.NewShellScriptValidator(logger.NewNoOpLogger(), mockChecker, nil, nil)
+
+			tempFile := filepath.Join(GinkgoT().TempDir(), "deploy.sh")
+			content := "#!/bin/bash\n\necho \"start\"\n\necho \"done\"\n"
+			Expect(os.WriteFile(tempFile, []byte(content), 0o600)).To(Succeed())
+
+			ctx.ToolName = hook.ToolTypeMultiEdit
+			ctx.ToolInput.FilePath = tempFile
+			ctx.ToolInput.Edits = []hook.EditOp{
+				{OldString: `echo "start"`, NewString: `echo "begin"`},
+				{OldString: `echo "done"`, NewString: `echo $undefined`},
+			}
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		It("checks every edit fragment", func() {
+			mockChecker.EXPECT().
+				CheckWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&linters.LintResult{Success: true}).
+				Times(2)
+
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+
+		It("reports one finding per failing edit", func() {
+			mockChecker.EXPECT().
+				CheckWithOptions(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(
+					_ context.Context,
+					content string,
+					_ *linters.ShellCheckOptions,
+				) *linters.LintResult {
+					if !strings.Contains(content, "$undefined") {
+						return &linters.LintResult{Success: true}
+					}
+
+					return &linters.LintResult{Success: false, RawOut: "SC2154: undefined is referenced but not assigned."}
+				}).
+				Times(2)
+
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("Edit #2"))
+			Expect(result.Message).To(ContainSubstring("SC2154"))
+			Expect(result.Message).NotTo(ContainSubstring("Edit #1"))
+		})
+	})
 })
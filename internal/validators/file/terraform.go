@@ -66,6 +66,11 @@ func (v *TerraformValidator) Validate(
 		}
 	}
 
+	// MultiEdit operations are validated one fragment per edit
+	if isPreToolMultiEdit(hookCtx) {
+		return v.validateMultiEdit(ctx, hookCtx)
+	}
+
 	content, err := v.getContent(hookCtx)
 	if err != nil {
 		log.Debug("skipping terraform validation", "error", err)
@@ -80,11 +85,59 @@ func (v *TerraformValidator) Validate(
 	tool := v.formatter.DetectTool()
 	log.Debug("detected terraform tool", "tool", tool)
 
+	warnings := v.collectWarnings(ctx, content, tool)
+
+	if len(warnings) > 0 {
+		message := "Terraform validation warnings"
+		details := map[string]string{
+			"warnings": strings.Join(warnings, "\n"),
+		}
+
+		return validator.WarnWithDetails(message, details)
+	}
+
+	return validator.Pass()
+}
+
+// validateMultiEdit checks each MultiEdit fragment and reports one finding per failing edit.
+func (v *TerraformValidator) validateMultiEdit(
+	ctx context.Context,
+	hookCtx *hook.Context,
+) *validator.Result {
+	log := v.Logger()
+
+	fragments, err := readMultiEditFragments(hookCtx, v.getContextLines(), log)
+	if err != nil {
+		log.Debug("skipping terraform validation", "error", err)
+		return validator.Pass()
+	}
+
+	tool := v.formatter.DetectTool()
+	log.Debug("detected terraform tool", "tool", tool)
+
+	findings := lintFragments(fragments, func(fragment MultiEditFragment) (string, bool) {
+		warnings := v.collectWarnings(ctx, fragment.Fragment, tool)
+
+		return strings.Join(warnings, "\n"), len(warnings) == 0
+	})
+	if len(findings) == 0 {
+		return validator.Pass()
+	}
+
+	return validator.WarnWithDetails("Terraform validation warnings", map[string]string{
+		"warnings": formatEditFindings(findings),
+	})
+}
+
+// collectWarnings runs the format check and tflint on content.
+func (v *TerraformValidator) collectWarnings(ctx context.Context, content, tool string) []string {
+	log := v.Logger()
+
 	// Create temp file for tflint
 	tmpFile, cleanup, err := v.tempManager.Create("terraform-*.tf", content)
 	if err != nil {
 		log.Debug("failed to create temp file", "error", err)
-		return validator.Pass()
+		return nil
 	}
 	defer cleanup()
 
@@ -104,22 +157,18 @@ func (v *TerraformValidator) Validate(
 		}
 	}
 
-	if len(warnings) > 0 {
-		message := "Terraform validation warnings"
-		details := map[string]string{
-			"warnings": strings.Join(warnings, "\n"),
-		}
-
-		return validator.WarnWithDetails(message, details)
-	}
-
-	return validator.Pass()
+	return warnings
 }
 
 // getContent extracts terraform content from context
 func (v *TerraformValidator) getContent(ctx *hook.Context) (string, error) {
 	log := v.Logger()
 
+	// For PostToolUse, validate the file as written
+	if written := ctx.GetWrittenContent(); written != "" {
+		return written, nil
+	}
+
 	// Try to get content from tool input (Write operation)
 	if ctx.ToolInput.Content != "" {
 		return ctx.ToolInput.Content, nil
@@ -2,14 +2,19 @@ package file_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
 
 	execpkg "github.com/smykla-labs/klaudiush/internal/exec"
 	"github.com/smykla-labs/klaudiush/internal/linters"
 	"github.com/smykla-labs/klaudiush/internal/validators/file"
+	"github.com/smykla-labs/klaudiush/pkg/config"
 	"github.com/smykla-labs/klaudiush/pkg/hook"
 	"github.com/smykla-labs/klaudiush/pkg/logger"
 )
@@ -334,4 +339,74 @@ resource "aws_instance" "multiple" {
 			})
 		})
 	})
+
+	Describe("MultiEdit operations", func() {
+		var (
+			mockCtrl      *gomock.Controller
+			mockFormatter *linters.MockTerraformFormatter
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockFormatter = linters.NewMockTerraformFormatter(mockCtrl)
+			mockFormatter.EXPECT().DetectTool().Return("terraform").AnyTimes()
+
+			useTflint := false
+			v = file.NewTerraformValidator(
+				mockFormatter,
+				linters.NewMockTfLinter(mockCtrl),
+				logger.NewNoOpLogger(),
+				&config.TerraformValidatorConfig{UseTflint: &useTflint},
+				nil,
+			)
+
+			tempFile := filepath.Join(GinkgoT().TempDir(), "main.tf")
+			content := "variable \"a\" {\n  default = 1\n}\n\nvariable \"b\" {\n  default = 2\n}\n"
+			Expect(os.WriteFile(tempFile, []byte(content), 0o600)).To(Succeed())
+
+			ctx.ToolName = hook.ToolTypeMultiEdit
+			ctx.ToolInput.FilePath = tempFile
+			ctx.ToolInput.Edits = []hook.EditOp{
+				{OldString: "default = 1", NewString: "default = 10"},
+				{OldString: "default = 2", NewString: "default    = 20"},
+			}
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		It("checks every edit fragment", func() {
+			mockFormatter.EXPECT().
+				CheckFormat(gomock.Any(), gomock.Any()).
+				Return(&linters.LintResult{Success: true}).
+				Times(2)
+
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeTrue())
+		})
+
+		It("warns once per failing edit", func() {
+			mockFormatter.EXPECT().
+				CheckFormat(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, content string) *linters.LintResult {
+					if !strings.Contains(content, "default    = 20") {
+						return &linters.LintResult{Success: true}
+					}
+
+					return &linters.LintResult{
+						Success:  false,
+						RawOut:   "-  default    = 20\n+  default = 20",
+						Findings: []linters.LintFinding{{Message: "not formatted"}},
+					}
+				}).
+				Times(2)
+
+			result := v.Validate(context.Background(), ctx)
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeFalse())
+			Expect(result.Details["warnings"]).To(ContainSubstring("Edit #2"))
+			Expect(result.Details["warnings"]).NotTo(ContainSubstring("Edit #1"))
+		})
+	})
 })
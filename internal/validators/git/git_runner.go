@@ -3,6 +3,7 @@ package git
 import (
 	"context"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -144,6 +145,21 @@ func (r *CLIGitRunnerWithPath) GetBranchRemote(branch string) (string, error) {
 	return strings.TrimSpace(result.Stdout), nil
 }
 
+// GetCommitsAhead returns the number of commits the branch is ahead of its upstream
+func (r *CLIGitRunnerWithPath) GetCommitsAhead(branch string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result := r.runner.Run(
+		ctx, "git", "-C", r.path, "rev-list", "--count", branch+"@{upstream}.."+branch,
+	)
+	if result.Err != nil {
+		return 0, result.Err
+	}
+
+	return strconv.Atoi(strings.TrimSpace(result.Stdout))
+}
+
 // GetRemotes returns the list of all remotes with their URLs
 func (r *CLIGitRunnerWithPath) GetRemotes() (map[string]string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
@@ -369,6 +385,19 @@ func (r *CLIGitRunner) GetRemotes() (map[string]string, error) {
 	return remotes, nil
 }
 
+// GetCommitsAhead returns the number of commits the branch is ahead of its upstream
+func (r *CLIGitRunner) GetCommitsAhead(branch string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	result := r.runner.Run(ctx, "git", "rev-list", "--count", branch+"@{upstream}.."+branch)
+	if result.Err != nil {
+		return 0, result.Err
+	}
+
+	return strconv.Atoi(strings.TrimSpace(result.Stdout))
+}
+
 // parseLines splits output by newlines and filters empty lines
 func parseLines(output string) []string {
 	output = strings.TrimSpace(output)
@@ -16,14 +16,29 @@ import (
 
 const (
 	defaultRemote = "origin"
+	headsPrefix   = "refs/heads/"
 )
 
 // PushValidator validates git push commands
 type PushValidator struct {
 	validator.BaseValidator
-	gitRunner   GitRunner
-	config      *config.PushValidatorConfig
-	ruleAdapter *rules.RuleValidatorAdapter
+	gitRunner         GitRunner
+	config            *config.PushValidatorConfig
+	ruleAdapter       *rules.RuleValidatorAdapter
+	protectedBranches []string
+}
+
+// PushValidatorOption configures a PushValidator.
+type PushValidatorOption func(*PushValidator)
+
+// WithPushProtectedBranches sets the branches protected from force pushes.
+// Defaults to the branch validator defaults ("main", "master") when empty.
+func WithPushProtectedBranches(branches []string) PushValidatorOption {
+	return func(v *PushValidator) {
+		if len(branches) > 0 {
+			v.protectedBranches = branches
+		}
+	}
 }
 
 // NewPushValidator creates a new PushValidator instance
@@ -32,17 +47,32 @@ func NewPushValidator(
 	gitRunner GitRunner,
 	cfg *config.PushValidatorConfig,
 	ruleAdapter *rules.RuleValidatorAdapter,
+	opts ...PushValidatorOption,
 ) *PushValidator {
 	if gitRunner == nil {
 		gitRunner = NewGitRunner()
 	}
 
-	return &PushValidator{
-		BaseValidator: *validator.NewBaseValidator("validate-git-push", log),
-		gitRunner:     gitRunner,
-		config:        cfg,
-		ruleAdapter:   ruleAdapter,
+	v := &PushValidator{
+		BaseValidator:     *validator.NewBaseValidator("validate-git-push", log),
+		gitRunner:         gitRunner,
+		config:            cfg,
+		ruleAdapter:       ruleAdapter,
+		protectedBranches: defaultProtectedBranches,
+	}
+
+	for _, opt := range opts {
+		opt(v)
 	}
+
+	return v
+}
+
+// pushTarget is a remote branch updated by a git push command.
+type pushTarget struct {
+	branch string
+	force  bool // +refspec
+	delete bool // :branch or --delete
 }
 
 // Name returns the validator name
@@ -85,7 +115,232 @@ func (v *PushValidator) validatePushCommand(gitCmd *parser.GitCommand) *validato
 		return result
 	}
 
-	return v.validateRemoteExists(remote, runner)
+	if result := v.validateRemoteExists(remote, runner); !result.Passed {
+		return result
+	}
+
+	return v.validateHistoryRewrite(gitCmd, remote, runner)
+}
+
+// validateHistoryRewrite enforces the force push and remote branch deletion policies
+func (v *PushValidator) validateHistoryRewrite(
+	gitCmd *parser.GitCommand,
+	remote string,
+	runner GitRunner,
+) *validator.Result {
+	forceFlag := ""
+
+	switch {
+	case gitCmd.HasFlag("--force"):
+		forceFlag = "--force"
+	case gitCmd.HasFlag("-f"):
+		forceFlag = "-f"
+	}
+
+	lease := hasFlagPrefix(gitCmd, "--force-with-lease")
+	mirror := gitCmd.HasFlag("--mirror")
+	forced := forceFlag != "" || lease
+
+	targets := v.resolvePushTargets(gitCmd, runner, forced || mirror)
+
+	if v.config.IsBlockForcePushProtectedEnabled() {
+		if result := v.validateProtectedTargets(targets, forced, mirror); !result.Passed {
+			return result
+		}
+	}
+
+	if v.config.IsBlockRemoteDeleteEnabled() {
+		for _, target := range targets {
+			if target.delete {
+				return validator.FailWithRef(
+					validator.RefGitRemoteBranchDelete,
+					templates.MustExecute(
+						templates.PushRemoteDeleteTemplate,
+						templates.PushRemoteDeleteData{Remote: remote, Branch: target.branch},
+					),
+				)
+			}
+		}
+	}
+
+	if !v.config.IsRequireForceWithLeaseEnabled() {
+		return validator.Pass()
+	}
+
+	// --force and -f force every target, a +refspec only its own
+	var branches []string
+
+	if forceFlag != "" {
+		for _, target := range targets {
+			branches = append(branches, target.branch)
+		}
+	} else {
+		for _, target := range targets {
+			if target.force && !lease {
+				forceFlag = "+" + target.branch
+				branches = []string{target.branch}
+
+				break
+			}
+		}
+	}
+
+	if forceFlag == "" {
+		return validator.Pass()
+	}
+
+	branch := strings.Join(branches, " ")
+
+	return validator.FailWithRef(
+		validator.RefGitForceWithoutLease,
+		templates.MustExecute(
+			templates.PushForceWithoutLeaseTemplate,
+			templates.PushForceWithoutLeaseData{Flag: forceFlag, Remote: remote, Branch: branch},
+		),
+	)
+}
+
+// validateProtectedTargets blocks force updates, mirror pushes and deletions of protected branches
+func (v *PushValidator) validateProtectedTargets(
+	targets []pushTarget,
+	forced bool,
+	mirror bool,
+) *validator.Result {
+	for _, target := range targets {
+		if !slices.Contains(v.protectedBranches, target.branch) {
+			continue
+		}
+
+		var operation string
+
+		switch {
+		case mirror:
+			operation = "Mirror push"
+		case target.delete:
+			operation = "Deletion"
+		case forced || target.force:
+			operation = "Force push"
+		default:
+			continue
+		}
+
+		return validator.FailWithRef(
+			validator.RefGitForcePushProtected,
+			templates.MustExecute(
+				templates.PushForceProtectedTemplate,
+				templates.PushForceProtectedData{
+					Operation:            operation,
+					Branch:               target.branch,
+					ProtectedBranchesStr: strings.Join(v.protectedBranches, ", "),
+				},
+			),
+		)
+	}
+
+	return validator.Pass()
+}
+
+// resolvePushTargets returns the remote branches updated by the push.
+// Implicit refspecs resolve to the current branch. --all and --mirror update
+// every branch, so they are reported as updating all protected branches when
+// the push rewrites history.
+func (v *PushValidator) resolvePushTargets(
+	gitCmd *parser.GitCommand,
+	runner GitRunner,
+	rewritesHistory bool,
+) []pushTarget {
+	if gitCmd.HasFlag("--mirror") || (rewritesHistory && gitCmd.HasFlag("--all")) {
+		targets := make([]pushTarget, 0, len(v.protectedBranches))
+		for _, branch := range v.protectedBranches {
+			targets = append(targets, pushTarget{branch: branch, force: true})
+		}
+
+		return targets
+	}
+
+	deleteAll := gitCmd.HasFlag("--delete") || gitCmd.HasFlag("-d")
+
+	var refspecs []string
+	if len(gitCmd.Args) > 1 {
+		refspecs = gitCmd.Args[1:]
+	}
+
+	if len(refspecs) == 0 {
+		branch, err := runner.GetCurrentBranch()
+		if err != nil || branch == "" {
+			v.Logger().Debug("could not resolve current branch", "error", err)
+			return nil
+		}
+
+		return []pushTarget{{branch: branch, delete: deleteAll}}
+	}
+
+	targets := make([]pushTarget, 0, len(refspecs))
+
+	for _, refspec := range refspecs {
+		target := pushTarget{delete: deleteAll}
+
+		if after, ok := strings.CutPrefix(refspec, "+"); ok {
+			target.force = true
+			refspec = after
+		}
+
+		// A bare ":" pushes every branch that exists on both sides
+		if refspec == ":" {
+			targets = append(targets, v.matchingPushTargets(target.force, rewritesHistory)...)
+			continue
+		}
+
+		src, dst, hasDst := strings.Cut(refspec, ":")
+
+		switch {
+		case hasDst && src == "":
+			target.delete = true
+		case !hasDst:
+			dst = src
+		}
+
+		if dst == "HEAD" || dst == "" {
+			branch, err := runner.GetCurrentBranch()
+			if err != nil {
+				continue
+			}
+
+			dst = branch
+		}
+
+		target.branch = strings.TrimPrefix(dst, headsPrefix)
+		targets = append(targets, target)
+	}
+
+	return targets
+}
+
+// matchingPushTargets returns the targets of a matching push. They are
+// reported as updating all protected branches when the push rewrites history,
+// and ignored otherwise, as the matching branches are unknown.
+func (v *PushValidator) matchingPushTargets(force, rewritesHistory bool) []pushTarget {
+	if !force && !rewritesHistory {
+		return nil
+	}
+
+	targets := make([]pushTarget, 0, len(v.protectedBranches))
+	for _, branch := range v.protectedBranches {
+		targets = append(targets, pushTarget{branch: branch, force: force})
+	}
+
+	return targets
+}
+
+// hasFlagPrefix checks if any flag starts with the given prefix (e.g., --force-with-lease=main)
+func hasFlagPrefix(gitCmd *parser.GitCommand, prefix string) bool {
+	for _, flag := range gitCmd.Flags {
+		if strings.HasPrefix(flag, prefix) {
+			return true
+		}
+	}
+
+	return false
 }
 
 // getRunnerForCommand returns the appropriate git runner for the command.
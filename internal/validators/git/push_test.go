@@ -110,12 +110,12 @@ var _ = Describe("PushValidator", func() {
 						description: "nonexistent remote",
 					},
 					{
-						command:     "git push --force-with-lease upstream main",
+						command:     "git push --force-with-lease upstream feature-branch",
 						shouldPass:  true,
 						description: "with flags before remote",
 					},
 					{
-						command:     "git push origin main --force",
+						command:     "git push origin feature-branch --force-with-lease",
 						shouldPass:  true,
 						description: "with flags after branch",
 					},
@@ -367,5 +367,190 @@ var _ = Describe("PushValidator", func() {
 				Expect(result.Passed).To(BeTrue())
 			})
 		})
+
+		Context("force push and history rewrite", func() {
+			BeforeEach(func() {
+				fakeGit.CurrentBranch = "feat/my-feature"
+				fakeGit.BranchRemotes = map[string]string{"feat/my-feature": "origin"}
+			})
+
+			DescribeTable("blocks force pushes to protected branches",
+				func(command string) {
+					result := validator.Validate(context.Background(), createContext(command))
+
+					Expect(result.Passed).To(BeFalse())
+					Expect(result.Reference).To(Equal(validatorpkg.RefGitForcePushProtected))
+					Expect(result.Message).To(ContainSubstring("protected branch 'main'"))
+				},
+				Entry("--force", "git push --force origin main"),
+				Entry("-f", "git push -f origin main"),
+				Entry("combined short flags", "git push -uf origin main"),
+				Entry("--force-with-lease", "git push --force-with-lease origin main"),
+				Entry("--force-with-lease with value", "git push --force-with-lease=main origin main"),
+				Entry("+refspec", "git push origin +main"),
+				Entry("+src:dst refspec", "git push origin +HEAD:refs/heads/main"),
+				Entry("--mirror", "git push --mirror origin"),
+				Entry("--all --force", "git push --all --force origin"),
+			)
+
+			It("resolves the implicit refspec to the current branch", func() {
+				fakeGit.CurrentBranch = "main"
+				fakeGit.BranchRemotes = map[string]string{"main": "origin"}
+
+				result := validator.Validate(
+					context.Background(),
+					createContext("git push --force-with-lease"),
+				)
+
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.Reference).To(Equal(validatorpkg.RefGitForcePushProtected))
+			})
+
+			It("resolves HEAD refspec to the current branch", func() {
+				fakeGit.CurrentBranch = "master"
+
+				result := validator.Validate(
+					context.Background(),
+					createContext("git push --force-with-lease origin HEAD"),
+				)
+
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.Reference).To(Equal(validatorpkg.RefGitForcePushProtected))
+			})
+
+			It("uses configured protected branches", func() {
+				validator = git.NewPushValidator(
+					log,
+					fakeGit,
+					nil,
+					nil,
+					git.WithPushProtectedBranches([]string{"develop"}),
+				)
+
+				result := validator.Validate(
+					context.Background(),
+					createContext("git push --force-with-lease origin develop"),
+				)
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.Reference).To(Equal(validatorpkg.RefGitForcePushProtected))
+
+				result = validator.Validate(
+					context.Background(),
+					createContext("git push --force-with-lease origin main"),
+				)
+				Expect(result.Passed).To(BeTrue())
+			})
+
+			It("allows --force-with-lease to feature branches", func() {
+				result := validator.Validate(
+					context.Background(),
+					createContext("git push --force-with-lease origin feat/my-feature"),
+				)
+
+				Expect(result.Passed).To(BeTrue())
+			})
+
+			DescribeTable("requires --force-with-lease over --force",
+				func(command string, flag string, expected string) {
+					result := validator.Validate(context.Background(), createContext(command))
+
+					Expect(result.Passed).To(BeFalse())
+					Expect(result.Reference).To(Equal(validatorpkg.RefGitForceWithoutLease))
+					Expect(result.Message).To(ContainSubstring("'" + flag + "'"))
+					Expect(result.Message).To(HaveSuffix("Expected: " + expected))
+				},
+				Entry("--force", "git push --force origin feat/my-feature", "--force",
+					"git push --force-with-lease origin feat/my-feature"),
+				Entry("-f with implicit refspec", "git push -f", "-f",
+					"git push --force-with-lease origin feat/my-feature"),
+				Entry("+refspec", "git push origin +feat/my-feature", "+feat/my-feature",
+					"git push --force-with-lease origin feat/my-feature"),
+				Entry("+refspec after a regular refspec", "git push origin feat/a +feat/b",
+					"+feat/b", "git push --force-with-lease origin feat/b"),
+			)
+
+			It("treats a bare colon refspec as a matching push", func() {
+				result := validator.Validate(
+					context.Background(),
+					createContext("git push origin :"),
+				)
+
+				Expect(result.Passed).To(BeTrue())
+			})
+
+			DescribeTable("blocks forced matching pushes as protected branch violations",
+				func(command string) {
+					result := validator.Validate(context.Background(), createContext(command))
+
+					Expect(result.Passed).To(BeFalse())
+					Expect(result.Reference).To(Equal(validatorpkg.RefGitForcePushProtected))
+					Expect(result.Message).To(ContainSubstring("Force push"))
+				},
+				Entry("+:", "git push origin +:"),
+				Entry("--force :", "git push --force origin :"),
+			)
+
+			DescribeTable("blocks remote branch deletion",
+				func(command string) {
+					result := validator.Validate(context.Background(), createContext(command))
+
+					Expect(result.Passed).To(BeFalse())
+					Expect(result.Reference).To(Equal(validatorpkg.RefGitRemoteBranchDelete))
+					Expect(result.Message).To(ContainSubstring("'feat/old'"))
+				},
+				Entry("--delete", "git push origin --delete feat/old"),
+				Entry("-d", "git push -d origin feat/old"),
+				Entry(":branch refspec", "git push origin :feat/old"),
+			)
+
+			It("blocks deletion of protected branches as a protected branch violation", func() {
+				result := validator.Validate(
+					context.Background(),
+					createContext("git push origin --delete main"),
+				)
+
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.Reference).To(Equal(validatorpkg.RefGitForcePushProtected))
+				Expect(result.Message).To(ContainSubstring("Deletion targeting protected branch"))
+			})
+
+			It("allows regular pushes to protected branches", func() {
+				result := validator.Validate(
+					context.Background(),
+					createContext("git push origin main"),
+				)
+
+				Expect(result.Passed).To(BeTrue())
+			})
+
+			Context("with policies disabled", func() {
+				BeforeEach(func() {
+					disabled := false
+					validator = git.NewPushValidator(log, fakeGit, &config.PushValidatorConfig{
+						BlockForcePushProtected: &disabled,
+						RequireForceWithLease:   &disabled,
+						BlockRemoteDelete:       &disabled,
+					}, nil)
+				})
+
+				It("allows force pushes to protected branches", func() {
+					result := validator.Validate(
+						context.Background(),
+						createContext("git push --force origin main"),
+					)
+
+					Expect(result.Passed).To(BeTrue())
+				})
+
+				It("allows remote branch deletion", func() {
+					result := validator.Validate(
+						context.Background(),
+						createContext("git push origin --delete feat/old"),
+					)
+
+					Expect(result.Passed).To(BeTrue())
+				})
+			})
+		})
 	})
 })
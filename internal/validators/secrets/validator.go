@@ -72,7 +72,7 @@ func compileAllowList(patterns []string, log logger.Logger) []*regexp.Regexp {
 	return compiled
 }
 
-// Validate checks file content for secrets.
+// Validate checks file content or submitted prompts for secrets.
 func (v *SecretsValidator) Validate(ctx context.Context, hookCtx *hook.Context) *validator.Result {
 	log := v.Logger()
 	log.Debug("validating content for secrets")
@@ -123,6 +123,16 @@ func (v *SecretsValidator) Validate(ctx context.Context, hookCtx *hook.Context)
 
 // getContent extracts content to validate from the hook context.
 func (*SecretsValidator) getContent(hookCtx *hook.Context) string {
+	// For submitted prompts, validate the prompt text
+	if hookCtx.EventType == hook.EventTypeUserPromptSubmit {
+		return hookCtx.Prompt
+	}
+
+	// For tool results, validate the command output
+	if hookCtx.EventType == hook.EventTypePostToolUse {
+		return hookCtx.GetToolOutput()
+	}
+
 	// For Write operations, use the content directly
 	if hookCtx.ToolName == hook.ToolTypeWrite {
 		return hookCtx.GetContent()
@@ -0,0 +1,562 @@
+package shell
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	gitpkg "github.com/smykla-labs/klaudiush/internal/git"
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	gitvalidators "github.com/smykla-labs/klaudiush/internal/validators/git"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
+)
+
+// unguardedVarPattern matches a path that starts with a plain variable expansion
+// ($VAR or ${VAR}), capturing the remainder. Expansions with modifiers such as
+// ${VAR:?} are not matched because they fail instead of expanding to "".
+var unguardedVarPattern = regexp.MustCompile(`^\$(?:[A-Za-z_][A-Za-z0-9_]*|\{[A-Za-z_][A-Za-z0-9_]*\})(.*)$`)
+
+// safeDevices are device files that dd may write to without risk.
+var safeDevices = []string{"/dev/null", "/dev/zero", "/dev/stdout", "/dev/stderr", "/dev/tty"}
+
+// worldWritableModes are chmod modes that make files writable by everyone.
+var worldWritableModes = []string{"777", "0777", "a+rwx", "ugo+rwx", "a=rwx", "ugo=rwx"}
+
+// destructiveFinding is a single destructive operation detected in a command.
+type destructiveFinding struct {
+	ref     validator.Reference
+	action  string
+	message string
+}
+
+// DestructiveValidator guards against destructive shell commands such as
+// rm -rf outside the repository, git reset --hard on a dirty tree, find -delete,
+// dd to devices and chmod -R 777.
+type DestructiveValidator struct {
+	validator.BaseValidator
+	gitRunner   gitpkg.Runner
+	config      *config.DestructiveValidatorConfig
+	ruleAdapter *rules.RuleValidatorAdapter
+
+	// runnerForDir creates a runner for the repository of the hook's working
+	// directory. Nil when gitRunner is used for every directory.
+	runnerForDir func(dir string) gitpkg.Runner
+}
+
+// DestructiveValidatorOption configures a DestructiveValidator.
+type DestructiveValidatorOption func(*DestructiveValidator)
+
+// WithDestructiveRunnerForDir sets the function that creates a runner for the
+// repository of the hook's working directory. Without it, the git runner
+// passed to NewDestructiveValidator is used for every directory.
+func WithDestructiveRunnerForDir(fn func(dir string) gitpkg.Runner) DestructiveValidatorOption {
+	return func(v *DestructiveValidator) {
+		v.runnerForDir = fn
+	}
+}
+
+// NewDestructiveValidator creates a new DestructiveValidator instance.
+func NewDestructiveValidator(
+	log logger.Logger,
+	gitRunner gitpkg.Runner,
+	cfg *config.DestructiveValidatorConfig,
+	ruleAdapter *rules.RuleValidatorAdapter,
+	opts ...DestructiveValidatorOption,
+) *DestructiveValidator {
+	if gitRunner == nil {
+		gitRunner = gitvalidators.NewGitRunner()
+	}
+
+	v := &DestructiveValidator{
+		BaseValidator: *validator.NewBaseValidator("validate-destructive", log),
+		gitRunner:     gitRunner,
+		config:        cfg,
+		ruleAdapter:   ruleAdapter,
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Validate checks the command for destructive operations.
+func (v *DestructiveValidator) Validate(ctx context.Context, hookCtx *hook.Context) *validator.Result {
+	log := v.Logger()
+	log.Debug("Running destructive command validation")
+
+	// Check rules first if rule adapter is configured
+	if v.ruleAdapter != nil {
+		if result := v.ruleAdapter.CheckRules(ctx, hookCtx); result != nil {
+			return result
+		}
+	}
+
+	command := hookCtx.GetCommand()
+	if command == "" {
+		log.Debug("Empty command, skipping validation")
+		return validator.Pass()
+	}
+
+	bashParser := parser.NewBashParser()
+
+	parseResult, err := bashParser.Parse(command)
+	if err != nil {
+		log.Debug("Failed to parse command", "error", err)
+		return validator.Pass()
+	}
+
+	var findings []destructiveFinding
+
+	runner := v.runnerFor(hookCtx)
+
+	for _, cmd := range parseResult.Commands {
+		findings = append(findings, v.checkCommand(hookCtx, runner, cmd)...)
+	}
+
+	return buildDestructiveResult(findings)
+}
+
+// checkCommand dispatches a single parsed command to the matching category checks.
+func (v *DestructiveValidator) checkCommand(
+	hookCtx *hook.Context,
+	runner gitpkg.Runner,
+	cmd parser.Command,
+) []destructiveFinding {
+	name, args := cmd.UnwrapSudo()
+
+	switch name {
+	case "rm":
+		return v.checkRm(hookCtx, runner, cmd.WorkingDirectory, args)
+	case "find":
+		return v.checkFind(args)
+	case "dd":
+		return v.checkDD(args)
+	case "chmod":
+		return v.checkChmod(args)
+	case "git":
+		return v.checkGit(runner, cmd)
+	default:
+		return nil
+	}
+}
+
+// checkRm checks rm for deletes of protected paths and recursive deletes
+// outside the repository root.
+func (v *DestructiveValidator) checkRm(
+	hookCtx *hook.Context,
+	runner gitpkg.Runner,
+	workingDir string,
+	rawArgs []string,
+) []destructiveFinding {
+	recursive, targets := parseRmArgs(rawArgs)
+
+	var findings []destructiveFinding
+
+	protectedAction := v.config.GetProtectedPathDelete()
+	recursiveAction := v.config.GetRecursiveDelete()
+
+	for _, raw := range targets {
+		target := unquote(raw)
+
+		if protectedAction != config.DestructiveActionOff && isProtectedTarget(target) {
+			findings = append(findings, destructiveFinding{
+				ref:     validator.RefShellProtectedPathDelete,
+				action:  protectedAction,
+				message: "rm targets a protected path: " + raw,
+			})
+
+			continue
+		}
+
+		if !recursive || recursiveAction == config.DestructiveActionOff {
+			continue
+		}
+
+		if v.isOutsideRepo(hookCtx, runner, workingDir, target) {
+			findings = append(findings, destructiveFinding{
+				ref:     validator.RefShellRecursiveDelete,
+				action:  recursiveAction,
+				message: "Recursive delete outside the repository root: " + raw,
+			})
+		}
+	}
+
+	return findings
+}
+
+// checkFind checks find for the -delete action.
+func (v *DestructiveValidator) checkFind(rawArgs []string) []destructiveFinding {
+	action := v.config.GetFindDelete()
+	if action == config.DestructiveActionOff || !slices.Contains(rawArgs, "-delete") {
+		return nil
+	}
+
+	return []destructiveFinding{{
+		ref:     validator.RefShellFindDelete,
+		action:  action,
+		message: "find with -delete removes every match without confirmation",
+	}}
+}
+
+// checkDD checks dd for writes to device files.
+func (v *DestructiveValidator) checkDD(rawArgs []string) []destructiveFinding {
+	action := v.config.GetDeviceWrite()
+	if action == config.DestructiveActionOff {
+		return nil
+	}
+
+	for _, raw := range rawArgs {
+		output, ok := strings.CutPrefix(unquote(raw), "of=")
+		if !ok || !strings.HasPrefix(output, "/dev/") || isSafeDevice(output) {
+			continue
+		}
+
+		return []destructiveFinding{{
+			ref:     validator.RefShellDeviceWrite,
+			action:  action,
+			message: "dd writes directly to device " + output,
+		}}
+	}
+
+	return nil
+}
+
+// checkChmod checks chmod for recursive world-writable permissions.
+func (v *DestructiveValidator) checkChmod(rawArgs []string) []destructiveFinding {
+	action := v.config.GetChmodRecursive()
+	if action == config.DestructiveActionOff {
+		return nil
+	}
+
+	recursive := false
+	worldWritable := false
+
+	for _, raw := range rawArgs {
+		arg := unquote(raw)
+
+		switch {
+		case arg == "--recursive" || isShortFlagWith(arg, 'R'):
+			recursive = true
+		case slices.Contains(worldWritableModes, arg):
+			worldWritable = true
+		}
+	}
+
+	if !recursive || !worldWritable {
+		return nil
+	}
+
+	return []destructiveFinding{{
+		ref:     validator.RefShellChmodRecursive,
+		action:  action,
+		message: "chmod -R 777 makes every file world-writable",
+	}}
+}
+
+// checkGit checks git commands that discard uncommitted work on a dirty tree.
+func (v *DestructiveValidator) checkGit(
+	runner gitpkg.Runner,
+	cmd parser.Command,
+) []destructiveFinding {
+	action := v.config.GetGitDiscard()
+	if action == config.DestructiveActionOff {
+		return nil
+	}
+
+	gitCmd, err := parser.ParseGitCommand(cmd)
+	if err != nil {
+		return nil
+	}
+
+	var (
+		description string
+		dirty       bool
+	)
+
+	switch {
+	case gitCmd.Subcommand == "reset" && gitCmd.HasFlag("--hard"):
+		description = "git reset --hard"
+		dirty = v.hasUncommittedChanges(runner)
+	case gitCmd.Subcommand == "checkout" && slices.Contains(gitCmd.Args, "."):
+		description = "git checkout -- ."
+		dirty = v.hasUncommittedChanges(runner)
+	case gitCmd.Subcommand == "clean" && (gitCmd.HasFlag("-f") || gitCmd.HasFlag("--force")):
+		description = "git clean -f"
+		dirty = v.hasUntrackedFiles(runner)
+	default:
+		return nil
+	}
+
+	if !dirty {
+		return nil
+	}
+
+	return []destructiveFinding{{
+		ref:     validator.RefShellGitDiscard,
+		action:  action,
+		message: description + " would discard uncommitted changes in the working tree",
+	}}
+}
+
+// hasUncommittedChanges returns true if the working tree has staged or modified files.
+func (v *DestructiveValidator) hasUncommittedChanges(runner gitpkg.Runner) bool {
+	if !runner.IsInRepo() {
+		return false
+	}
+
+	staged, err := runner.GetStagedFiles()
+	if err != nil {
+		v.Logger().Debug("Failed to get staged files", "error", err)
+		return false
+	}
+
+	modified, err := runner.GetModifiedFiles()
+	if err != nil {
+		v.Logger().Debug("Failed to get modified files", "error", err)
+		return false
+	}
+
+	return len(staged) > 0 || len(modified) > 0
+}
+
+// hasUntrackedFiles returns true if the working tree has untracked files.
+func (v *DestructiveValidator) hasUntrackedFiles(runner gitpkg.Runner) bool {
+	if !runner.IsInRepo() {
+		return false
+	}
+
+	untracked, err := runner.GetUntrackedFiles()
+	if err != nil {
+		v.Logger().Debug("Failed to get untracked files", "error", err)
+		return false
+	}
+
+	return len(untracked) > 0
+}
+
+// runnerFor returns the git runner for the repository of the hook's working
+// directory.
+//
+//nolint:ireturn // Returns interface for flexibility between injected and path-specific runners
+func (v *DestructiveValidator) runnerFor(hookCtx *hook.Context) gitpkg.Runner {
+	if v.runnerForDir == nil || hookCtx.CWD == "" {
+		return v.gitRunner
+	}
+
+	return v.runnerForDir(hookCtx.CWD)
+}
+
+// isOutsideRepo returns true if the resolved target is the repository root
+// itself or lies outside it. When the hook's working directory is not in a
+// repository, only absolute targets and targets that are or escape the
+// working directory are outside. Targets that cannot be resolved statically
+// (variable expansions, command substitutions) are not reported.
+func (*DestructiveValidator) isOutsideRepo(
+	hookCtx *hook.Context,
+	runner gitpkg.Runner,
+	workingDir string,
+	target string,
+) bool {
+	if strings.ContainsAny(target, "$`") {
+		return false
+	}
+
+	path := resolvePath(baseDir(hookCtx, workingDir), target)
+
+	if !runner.IsInRepo() {
+		return isAbsTarget(target) || isOutsideDir(baseDir(hookCtx, ""), path)
+	}
+
+	root, err := runner.GetRepoRoot()
+	if err != nil || root == "" {
+		return true
+	}
+
+	return isOutsideDir(root, path)
+}
+
+// Category returns the validator category for parallel execution.
+// Uses CategoryGit because repository state is queried.
+func (*DestructiveValidator) Category() validator.ValidatorCategory {
+	return validator.CategoryGit
+}
+
+// buildDestructiveResult converts findings into a validation result.
+// Any blocking finding blocks; otherwise warnings are reported.
+func buildDestructiveResult(findings []destructiveFinding) *validator.Result {
+	var blocking, warnings []destructiveFinding
+
+	for _, finding := range findings {
+		switch finding.action {
+		case config.DestructiveActionWarn:
+			warnings = append(warnings, finding)
+		case config.DestructiveActionOff:
+		default:
+			blocking = append(blocking, finding)
+		}
+	}
+
+	if len(blocking) > 0 {
+		return validator.FailWithRef(blocking[0].ref, formatFindings(blocking))
+	}
+
+	if len(warnings) > 0 {
+		return validator.WarnWithRef(warnings[0].ref, formatFindings(warnings))
+	}
+
+	return validator.Pass()
+}
+
+// formatFindings formats findings into a single message.
+func formatFindings(findings []destructiveFinding) string {
+	if len(findings) == 1 {
+		return "Destructive command: " + findings[0].message
+	}
+
+	lines := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		lines = append(lines, "- "+finding.message)
+	}
+
+	return "Destructive commands:\n" + strings.Join(lines, "\n")
+}
+
+// parseRmArgs splits rm arguments into the recursive flag and the targets.
+func parseRmArgs(rawArgs []string) (bool, []string) {
+	recursive := false
+	targets := make([]string, 0, len(rawArgs))
+	endOfFlags := false
+
+	for _, raw := range rawArgs {
+		arg := unquote(raw)
+
+		switch {
+		case endOfFlags:
+			targets = append(targets, raw)
+		case arg == "--":
+			endOfFlags = true
+		case arg == "--recursive":
+			recursive = true
+		case strings.HasPrefix(arg, "--"):
+		case isShortFlagWith(arg, 'r') || isShortFlagWith(arg, 'R'):
+			recursive = true
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+		default:
+			targets = append(targets, raw)
+		}
+	}
+
+	return recursive, targets
+}
+
+// isShortFlagWith returns true if arg is a (possibly combined) short flag containing letter.
+func isShortFlagWith(arg string, letter byte) bool {
+	if len(arg) < 2 || arg[0] != '-' || arg[1] == '-' {
+		return false
+	}
+
+	return strings.IndexByte(arg[1:], letter) >= 0
+}
+
+// isProtectedTarget returns true for /, the home directory and unguarded
+// variable expansions that resolve to / when the variable is unset.
+func isProtectedTarget(target string) bool {
+	trimmed := trimTrailingWildcard(target)
+
+	switch trimmed {
+	case "", "~", "$HOME", "${HOME}":
+		return true
+	}
+
+	if home, err := os.UserHomeDir(); err == nil && filepath.Clean(trimmed) == home {
+		return true
+	}
+
+	match := unguardedVarPattern.FindStringSubmatch(target)
+	if match == nil {
+		return false
+	}
+
+	// "$DIR/" or "$DIR/*" become "/" or "/*" when DIR is unset
+	rest := match[1]
+
+	return rest != "" && trimTrailingWildcard(rest) == ""
+}
+
+// trimTrailingWildcard strips trailing "/", "/*" and "/." from a path.
+func trimTrailingWildcard(path string) string {
+	for {
+		trimmed := strings.TrimSuffix(path, "/*")
+		trimmed = strings.TrimSuffix(trimmed, "/.")
+		trimmed = strings.TrimSuffix(trimmed, "/")
+
+		if trimmed == path {
+			return path
+		}
+
+		path = trimmed
+	}
+}
+
+// isOutsideDir returns true if path is dir itself or lies outside it.
+func isOutsideDir(dir, path string) bool {
+	rel, err := filepath.Rel(filepath.Clean(dir), path)
+	if err != nil {
+		return true
+	}
+
+	return rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// isAbsTarget returns true for absolute targets, including ones under ~.
+func isAbsTarget(target string) bool {
+	return filepath.IsAbs(target) || target == "~" || strings.HasPrefix(target, "~/")
+}
+
+// isSafeDevice returns true for device files that are safe to write to.
+func isSafeDevice(device string) bool {
+	return slices.Contains(safeDevices, device) || strings.HasPrefix(device, "/dev/fd/")
+}
+
+// baseDir returns the directory relative paths are resolved against.
+func baseDir(hookCtx *hook.Context, workingDir string) string {
+	cwd := hookCtx.CWD
+	if cwd == "" {
+		cwd, _ = os.Getwd()
+	}
+
+	if workingDir == "" {
+		return cwd
+	}
+
+	return resolvePath(cwd, workingDir)
+}
+
+// resolvePath resolves path against base, expanding a leading ~.
+func resolvePath(base, path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+	}
+
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+
+	return filepath.Join(base, path)
+}
+
+// unquote removes shell quotes from a raw argument.
+func unquote(raw string) string {
+	return strings.NewReplacer(`"`, "", "'", "").Replace(raw)
+}
@@ -0,0 +1,306 @@
+package shell_test
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/cockroachdb/errors"
+	gogit "github.com/go-git/go-git/v6"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/git"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	gitvalidators "github.com/smykla-labs/klaudiush/internal/validators/git"
+	"github.com/smykla-labs/klaudiush/internal/validators/shell"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+var _ = Describe("DestructiveValidator", func() {
+	var (
+		ctx    context.Context
+		runner *git.FakeRunner
+		cfg    *config.DestructiveValidatorConfig
+	)
+
+	validate := func(command string) *validator.Result {
+		v := shell.NewDestructiveValidator(logger.NewNoOpLogger(), runner, cfg, nil)
+
+		return v.Validate(ctx, &hook.Context{
+			EventType: hook.EventTypePreToolUse,
+			ToolName:  hook.ToolTypeBash,
+			CWD:       "/mock/repo",
+			ToolInput: hook.ToolInput{Command: command},
+		})
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		runner = git.NewFakeRunner()
+		cfg = &config.DestructiveValidatorConfig{}
+	})
+
+	Describe("rm", func() {
+		It("passes recursive deletes inside the repository", func() {
+			Expect(validate("rm -rf build/ ./dist /mock/repo/tmp").Passed).To(BeTrue())
+		})
+
+		It("blocks recursive deletes outside the repository", func() {
+			result := validate("rm -rf ../other")
+
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefShellRecursiveDelete))
+		})
+
+		It("blocks recursive deletes of the repository root", func() {
+			result := validate("rm -r -f .")
+
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellRecursiveDelete))
+		})
+
+		It("honours cd in the command chain", func() {
+			result := validate("cd /tmp && rm -rf cache")
+
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellRecursiveDelete))
+		})
+
+		It("blocks recursive deletes when not in a repository", func() {
+			runner.InRepo = false
+
+			result := validate("rm -rf /etc/nginx")
+
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellRecursiveDelete))
+		})
+
+		It("passes relative recursive deletes when not in a repository", func() {
+			runner.InRepo = false
+
+			Expect(validate("rm -rf build ./dist").Passed).To(BeTrue())
+		})
+
+		It("blocks escaping recursive deletes when not in a repository", func() {
+			runner.InRepo = false
+
+			result := validate("rm -rf ../other")
+
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellRecursiveDelete))
+		})
+
+		It("resolves the repository root from the hook's working directory", func() {
+			repoDir, err := filepath.EvalSymlinks(GinkgoT().TempDir())
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = gogit.PlainInit(repoDir, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			v := shell.NewDestructiveValidator(
+				logger.NewNoOpLogger(),
+				nil,
+				cfg,
+				nil,
+				shell.WithDestructiveRunnerForDir(func(dir string) git.Runner {
+					return gitvalidators.NewGitRunnerForPath(dir)
+				}),
+			)
+			validateIn := func(command string) *validator.Result {
+				return v.Validate(ctx, &hook.Context{
+					EventType: hook.EventTypePreToolUse,
+					ToolName:  hook.ToolTypeBash,
+					CWD:       repoDir,
+					ToolInput: hook.ToolInput{Command: command},
+				})
+			}
+
+			Expect(validateIn("rm -rf build").Passed).To(BeTrue())
+			Expect(validateIn("rm -rf ../other").Reference).To(Equal(validator.RefShellRecursiveDelete))
+		})
+
+		It("passes non-recursive deletes outside the repository", func() {
+			Expect(validate("rm /tmp/file.txt").Passed).To(BeTrue())
+		})
+
+		DescribeTable("blocks deletes of protected paths",
+			func(command string) {
+				result := validate(command)
+
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.ShouldBlock).To(BeTrue())
+				Expect(result.Reference).To(Equal(validator.RefShellProtectedPathDelete))
+			},
+			Entry("root", "rm -rf /"),
+			Entry("root wildcard", "rm -rf /*"),
+			Entry("home tilde", "rm -rf ~"),
+			Entry("home variable", `rm -rf "$HOME"`),
+			Entry("home braced variable", "rm -rf ${HOME}/"),
+			Entry("unset variable with slash", `rm -rf "$DIR/"`),
+			Entry("unset variable with wildcard", "rm -rf $BUILD_DIR/*"),
+			Entry("sudo", "sudo rm -rf /"),
+			Entry("after end of flags", "rm -rf -- /"),
+		)
+
+		It("passes guarded variable expansions", func() {
+			Expect(validate(`rm -rf "${DIR:?}/"`).Passed).To(BeTrue())
+		})
+
+		It("passes variable expansions with a path suffix", func() {
+			Expect(validate(`rm -rf "$DIR/build"`).Passed).To(BeTrue())
+		})
+	})
+
+	Describe("git", func() {
+		DescribeTable("blocks discarding changes on a dirty tree",
+			func(command string) {
+				runner.ModifiedFiles = []string{"main.go"}
+				runner.UntrackedFiles = []string{"notes.txt"}
+
+				result := validate(command)
+
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.Reference).To(Equal(validator.RefShellGitDiscard))
+			},
+			Entry("reset --hard", "git reset --hard HEAD~1"),
+			Entry("checkout -- .", "git checkout -- ."),
+			Entry("clean -f", "git clean -f"),
+			Entry("clean -fdx", "git clean -fdx"),
+		)
+
+		It("blocks reset --hard with only staged changes", func() {
+			runner.StagedFiles = []string{"main.go"}
+
+			Expect(validate("git reset --hard").Passed).To(BeFalse())
+		})
+
+		DescribeTable("passes on a clean tree",
+			func(command string) {
+				Expect(validate(command).Passed).To(BeTrue())
+			},
+			Entry("reset --hard", "git reset --hard"),
+			Entry("checkout -- .", "git checkout -- ."),
+			Entry("clean -fdx", "git clean -fdx"),
+		)
+
+		It("passes git clean -f with only modified files", func() {
+			runner.ModifiedFiles = []string{"main.go"}
+
+			Expect(validate("git clean -f").Passed).To(BeTrue())
+		})
+
+		It("passes git clean dry runs", func() {
+			runner.UntrackedFiles = []string{"notes.txt"}
+
+			Expect(validate("git clean -n").Passed).To(BeTrue())
+		})
+
+		It("passes soft resets", func() {
+			runner.ModifiedFiles = []string{"main.go"}
+
+			Expect(validate("git reset --soft HEAD~1").Passed).To(BeTrue())
+		})
+
+		It("passes when git state cannot be read", func() {
+			runner.Err = errors.New("git failed")
+
+			Expect(validate("git reset --hard").Passed).To(BeTrue())
+		})
+	})
+
+	Describe("find", func() {
+		It("warns on -delete by default", func() {
+			result := validate("find . -name '*.tmp' -delete")
+
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellFindDelete))
+		})
+
+		It("passes without -delete", func() {
+			Expect(validate("find . -name '*.tmp'").Passed).To(BeTrue())
+		})
+	})
+
+	Describe("dd", func() {
+		It("blocks writes to block devices", func() {
+			result := validate("dd if=image.iso of=/dev/sda bs=4M")
+
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellDeviceWrite))
+		})
+
+		It("passes writes to /dev/null", func() {
+			Expect(validate("dd if=/dev/zero of=/dev/null count=1").Passed).To(BeTrue())
+		})
+
+		It("passes writes to regular files", func() {
+			Expect(validate("dd if=/dev/zero of=disk.img count=1").Passed).To(BeTrue())
+		})
+	})
+
+	Describe("chmod", func() {
+		DescribeTable("blocks recursive world-writable permissions",
+			func(command string) {
+				result := validate(command)
+
+				Expect(result.Passed).To(BeFalse())
+				Expect(result.Reference).To(Equal(validator.RefShellChmodRecursive))
+			},
+			Entry("-R 777", "chmod -R 777 ."),
+			Entry("--recursive 0777", "chmod --recursive 0777 src"),
+			Entry("-R a+rwx", "chmod -R a+rwx src"),
+		)
+
+		It("passes non-recursive chmod 777", func() {
+			Expect(validate("chmod 777 script.sh").Passed).To(BeTrue())
+		})
+
+		It("passes recursive chmod with safe modes", func() {
+			Expect(validate("chmod -R 755 bin").Passed).To(BeTrue())
+		})
+	})
+
+	Describe("configuration", func() {
+		It("warns instead of blocking when configured", func() {
+			cfg.ProtectedPathDelete = config.DestructiveActionWarn
+
+			result := validate("rm -rf /")
+
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeFalse())
+			Expect(result.Reference).To(Equal(validator.RefShellProtectedPathDelete))
+		})
+
+		It("skips categories set to off", func() {
+			cfg.FindDelete = config.DestructiveActionOff
+
+			Expect(validate("find . -delete").Passed).To(BeTrue())
+		})
+
+		It("blocks when any finding blocks and lists every finding", func() {
+			result := validate("find . -delete && chmod -R 777 .")
+
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Reference).To(Equal(validator.RefShellChmodRecursive))
+			Expect(result.Message).To(ContainSubstring("chmod -R 777"))
+		})
+	})
+
+	It("passes empty commands", func() {
+		Expect(validate("").Passed).To(BeTrue())
+	})
+
+	It("passes unparseable commands", func() {
+		Expect(validate("rm -rf 'unterminated").Passed).To(BeTrue())
+	})
+
+	It("uses the git category", func() {
+		v := shell.NewDestructiveValidator(logger.NewNoOpLogger(), runner, cfg, nil)
+
+		Expect(v.Category()).To(Equal(validator.CategoryGit))
+	})
+})
@@ -0,0 +1,141 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/smykla-labs/klaudiush/internal/rules"
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+	"github.com/smykla-labs/klaudiush/pkg/parser"
+)
+
+// testFailurePattern matches failure markers of go test, task and make in
+// command output. It is used when the tool response has no exit code.
+var testFailurePattern = regexp.MustCompile(
+	`(?m)^(?:--- FAIL|FAIL\b|panic: |task: Failed to run task|make(?:\[\d+\])?: \*\*\* )`,
+)
+
+// TestFailureValidator reports failing test runs after a Bash command has run
+// (PostToolUse), so that the test output is fed back to the model.
+type TestFailureValidator struct {
+	validator.BaseValidator
+	config      *config.TestFailureValidatorConfig
+	ruleAdapter *rules.RuleValidatorAdapter
+}
+
+// NewTestFailureValidator creates a new TestFailureValidator instance.
+func NewTestFailureValidator(
+	log logger.Logger,
+	cfg *config.TestFailureValidatorConfig,
+	ruleAdapter *rules.RuleValidatorAdapter,
+) *TestFailureValidator {
+	return &TestFailureValidator{
+		BaseValidator: *validator.NewBaseValidator("validate-test-failure", log),
+		config:        cfg,
+		ruleAdapter:   ruleAdapter,
+	}
+}
+
+// Validate checks whether a test command failed.
+func (v *TestFailureValidator) Validate(ctx context.Context, hookCtx *hook.Context) *validator.Result {
+	log := v.Logger()
+	log.Debug("Running test failure validation")
+
+	// Check rules first if rule adapter is configured
+	if v.ruleAdapter != nil {
+		if result := v.ruleAdapter.CheckRules(ctx, hookCtx); result != nil {
+			return result
+		}
+	}
+
+	resp := hookCtx.ToolResponse
+	if resp == nil || resp.Interrupted {
+		log.Debug("No tool response, skipping validation")
+		return validator.Pass()
+	}
+
+	testCommand := v.findTestCommand(hookCtx.GetCommand())
+	if testCommand == "" {
+		log.Debug("Not a test command, skipping validation")
+		return validator.Pass()
+	}
+
+	output := resp.Output()
+
+	if !resp.Failed() && (resp.ExitCode != nil || !testFailurePattern.MatchString(output)) {
+		log.Debug("Test command passed", "command", testCommand)
+		return validator.Pass()
+	}
+
+	return validator.FailWithRef(
+		validator.RefShellTestFailure,
+		v.buildMessage(testCommand, output),
+	)
+}
+
+// findTestCommand returns the first command in the chain that matches a
+// configured test command prefix, or an empty string.
+func (v *TestFailureValidator) findTestCommand(command string) string {
+	if command == "" {
+		return ""
+	}
+
+	parseResult, err := parser.NewBashParser().Parse(command)
+	if err != nil {
+		v.Logger().Debug("Failed to parse command", "error", err)
+		return ""
+	}
+
+	for _, cmd := range parseResult.Commands {
+		words := append([]string{filepath.Base(cmd.Name)}, cmd.Args...)
+
+		for _, prefix := range v.config.GetCommands() {
+			fields := strings.Fields(prefix)
+			if len(fields) > 0 && len(words) >= len(fields) &&
+				slices.Equal(words[:len(fields)], fields) {
+				return strings.Join(words, " ")
+			}
+		}
+	}
+
+	return ""
+}
+
+// buildMessage formats the failure report with the trailing output lines.
+func (v *TestFailureValidator) buildMessage(testCommand, output string) string {
+	var message strings.Builder
+
+	fmt.Fprintf(&message, "Test run failed: %s", testCommand)
+
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if output == "" || len(lines) == 0 {
+		return message.String()
+	}
+
+	maxLines := v.config.GetMaxOutputLines()
+	if maxLines > 0 && len(lines) > maxLines {
+		fmt.Fprintf(&message, "\n\nLast %d of %d output lines:", maxLines, len(lines))
+
+		lines = lines[len(lines)-maxLines:]
+	} else {
+		message.WriteString("\n\nOutput:")
+	}
+
+	for _, line := range lines {
+		message.WriteString("\n  " + line)
+	}
+
+	return message.String()
+}
+
+// Category returns the validator category for parallel execution.
+func (*TestFailureValidator) Category() validator.ValidatorCategory {
+	return validator.CategoryCPU
+}
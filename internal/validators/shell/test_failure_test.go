@@ -0,0 +1,119 @@
+package shell_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/internal/validator"
+	"github.com/smykla-labs/klaudiush/internal/validators/shell"
+	"github.com/smykla-labs/klaudiush/pkg/config"
+	"github.com/smykla-labs/klaudiush/pkg/hook"
+	"github.com/smykla-labs/klaudiush/pkg/logger"
+)
+
+var _ = Describe("TestFailureValidator", func() {
+	var cfg *config.TestFailureValidatorConfig
+
+	exitCode := func(code int) *int { return &code }
+
+	validate := func(command string, resp *hook.ToolResponse) *validator.Result {
+		v := shell.NewTestFailureValidator(logger.NewNoOpLogger(), cfg, nil)
+
+		return v.Validate(context.Background(), &hook.Context{
+			EventType:    hook.EventTypePostToolUse,
+			ToolName:     hook.ToolTypeBash,
+			ToolInput:    hook.ToolInput{Command: command},
+			ToolResponse: resp,
+		})
+	}
+
+	BeforeEach(func() {
+		cfg = &config.TestFailureValidatorConfig{}
+	})
+
+	It("reports failing go test runs with their output", func() {
+		result := validate("go test ./...", &hook.ToolResponse{
+			Stdout:   "--- FAIL: TestParse (0.00s)\n    parse_test.go:12: want 1, got 2\nFAIL",
+			ExitCode: exitCode(1),
+		})
+
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.ShouldBlock).To(BeTrue())
+		Expect(result.Reference).To(Equal(validator.RefShellTestFailure))
+		Expect(result.Message).To(ContainSubstring("Test run failed: go test ./..."))
+		Expect(result.Message).To(ContainSubstring("want 1, got 2"))
+	})
+
+	It("reports failing task runs detected from the output", func() {
+		result := validate("cd api && task test", &hook.ToolResponse{
+			Stderr: "task: Failed to run task \"test\": exit status 1",
+		})
+
+		Expect(result.Passed).To(BeFalse())
+		Expect(result.Message).To(ContainSubstring("Test run failed: task test"))
+	})
+
+	It("passes successful test runs", func() {
+		Expect(validate("go test ./...", &hook.ToolResponse{
+			Stdout:   "ok  \texample.com/pkg\t0.01s",
+			ExitCode: exitCode(0),
+		}).Passed).To(BeTrue())
+	})
+
+	It("trusts a zero exit code over failure markers in the output", func() {
+		Expect(validate("go test -run TestFAIL ./...", &hook.ToolResponse{
+			Stdout:   "FAIL is a word in this log",
+			ExitCode: exitCode(0),
+		}).Passed).To(BeTrue())
+	})
+
+	It("passes failing commands that are not test runs", func() {
+		Expect(validate("go build ./...", &hook.ToolResponse{
+			Stderr:   "undefined: foo",
+			ExitCode: exitCode(1),
+		}).Passed).To(BeTrue())
+	})
+
+	It("passes interrupted runs", func() {
+		Expect(validate("go test ./...", &hook.ToolResponse{
+			ExitCode:    exitCode(130),
+			Interrupted: true,
+		}).Passed).To(BeTrue())
+	})
+
+	It("passes without a tool response", func() {
+		Expect(validate("go test ./...", nil).Passed).To(BeTrue())
+	})
+
+	It("uses the configured test commands", func() {
+		cfg.Commands = []string{"npm test"}
+
+		failed := &hook.ToolResponse{ExitCode: exitCode(1)}
+
+		Expect(validate("npm test -- --watch=false", failed).Passed).To(BeFalse())
+		Expect(validate("go test ./...", failed).Passed).To(BeTrue())
+	})
+
+	It("limits the reported output to the last lines", func() {
+		maxLines := 2
+		cfg.MaxOutputLines = &maxLines
+
+		lines := make([]string, 0, 10)
+		for i := range 10 {
+			lines = append(lines, fmt.Sprintf("line %d", i))
+		}
+
+		result := validate("go test ./...", &hook.ToolResponse{
+			Stdout:   strings.Join(lines, "\n"),
+			ExitCode: exitCode(1),
+		})
+
+		Expect(result.Message).To(ContainSubstring("Last 2 of 10 output lines"))
+		Expect(result.Message).To(ContainSubstring("line 9"))
+		Expect(result.Message).NotTo(ContainSubstring("line 7"))
+	})
+})
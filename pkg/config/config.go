@@ -26,6 +26,13 @@ type Config struct {
 
 	// CrashDump contains configuration for the crash dump system.
 	CrashDump *CrashDumpConfig `json:"crash_dump,omitempty" koanf:"crash_dump" toml:"crash_dump"`
+
+	// Output contains configuration for how hook results are reported.
+	Output *OutputConfig `json:"output,omitempty" koanf:"output" toml:"output"`
+
+	// Messages overrides the message and fix hint of validation errors by
+	// reference code (e.g., "GIT019").
+	Messages map[string]MessageOverrideConfig `json:"messages,omitempty" koanf:"messages" toml:"messages"`
 }
 
 // ValidatorsConfig groups all validator configurations by category.
@@ -202,3 +209,12 @@ func (c *Config) GetCrashDump() *CrashDumpConfig {
 
 	return c.CrashDump
 }
+
+// GetOutput returns the output config, creating it if it doesn't exist.
+func (c *Config) GetOutput() *OutputConfig {
+	if c.Output == nil {
+		c.Output = &OutputConfig{}
+	}
+
+	return c.Output
+}
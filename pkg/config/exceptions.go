@@ -1,6 +1,8 @@
 // Package config provides configuration schema types for klaudiush validators.
 package config
 
+import "time"
+
 // Default values for exception configuration.
 const (
 	// DefaultMinReasonLength is the minimum reason length when required.
@@ -20,6 +22,15 @@ const (
 
 	// DefaultAuditMaxBackups is the number of backup files to keep.
 	DefaultAuditMaxBackups = 3
+
+	// DefaultSigningKeyFile is the key file used to sign exception tokens.
+	DefaultSigningKeyFile = "~/.klaudiush/exception_signing.key"
+
+	// DefaultApprovalDir is the directory of the exception approval queue.
+	DefaultApprovalDir = "~/.klaudiush/exception_approvals"
+
+	// DefaultApprovalMaxAge is how long approval requests stay valid.
+	DefaultApprovalMaxAge = 24 * time.Hour
 )
 
 // ExceptionsConfig contains configuration for the exception workflow.
@@ -40,9 +51,17 @@ type ExceptionsConfig struct {
 	// Audit configures exception audit logging.
 	Audit *ExceptionAuditConfig `json:"audit,omitempty" koanf:"audit" toml:"audit"`
 
+	// Approval configures the approval queue of policies with require_approval.
+	Approval *ExceptionApprovalConfig `json:"approval,omitempty" koanf:"approval" toml:"approval"`
+
 	// TokenPrefix is the prefix used for exception tokens.
 	// Default: "EXC"
 	TokenPrefix string `json:"token_prefix,omitempty" koanf:"token_prefix" toml:"token_prefix"`
+
+	// SigningKeyFile is the path to the key used to sign exception tokens
+	// with "klaudiush exception grant". Created on the first grant.
+	// Default: "~/.klaudiush/exception_signing.key"
+	SigningKeyFile string `json:"signing_key_file,omitempty" koanf:"signing_key_file" toml:"signing_key_file"`
 }
 
 // ExceptionPolicyConfig defines policy for a specific error code.
@@ -77,6 +96,34 @@ type ExceptionPolicyConfig struct {
 
 	// Description is a human-readable description of the policy.
 	Description string `json:"description,omitempty" koanf:"description" toml:"description"`
+
+	// BindSession requires the token to be bound to the session it is used in.
+	// Default: false
+	BindSession *bool `json:"bind_session,omitempty" koanf:"bind_session" toml:"bind_session"`
+
+	// BindCommand requires the token to be bound to the hash of the
+	// normalized command it is used with.
+	// Default: false
+	BindCommand *bool `json:"bind_command,omitempty" koanf:"bind_command" toml:"bind_command"`
+
+	// RequireExpiry requires the token to carry an expiry timestamp.
+	// Default: false
+	RequireExpiry *bool `json:"require_expiry,omitempty" koanf:"require_expiry" toml:"require_expiry"`
+
+	// MaxTokenTTL limits how far in the future the token expiry may be.
+	// Default: 0 (unlimited)
+	MaxTokenTTL Duration `json:"max_token_ttl,omitempty" koanf:"max_token_ttl" toml:"max_token_ttl"`
+
+	// RequireSignature requires the token to be signed with the signing key,
+	// so only tokens minted by "klaudiush exception grant" are accepted.
+	// Default: false
+	RequireSignature *bool `json:"require_signature,omitempty" koanf:"require_signature" toml:"require_signature"`
+
+	// RequireApproval queues exception requests for approval by a human with
+	// "klaudiush exception approve". The command is allowed on the first retry
+	// in the same session after it was approved.
+	// Default: false
+	RequireApproval *bool `json:"require_approval,omitempty" koanf:"require_approval" toml:"require_approval"`
 }
 
 // ExceptionRateLimitConfig configures global rate limiting for exceptions.
@@ -121,6 +168,18 @@ type ExceptionAuditConfig struct {
 	MaxBackups *int `json:"max_backups,omitempty" koanf:"max_backups" toml:"max_backups"`
 }
 
+// ExceptionApprovalConfig configures the exception approval queue.
+type ExceptionApprovalConfig struct {
+	// Dir is the directory holding one file per approval request.
+	// Default: "~/.klaudiush/exception_approvals"
+	Dir string `json:"dir,omitempty" koanf:"dir" toml:"dir"`
+
+	// MaxAge is how long a request stays valid after it was queued or
+	// resolved. Expired requests are queued again on the next retry.
+	// Default: "24h"
+	MaxAge Duration `json:"max_age,omitempty" koanf:"max_age" toml:"max_age"`
+}
+
 // IsEnabled returns true if the exceptions system is enabled.
 // Returns true if Enabled is nil (default behavior).
 func (e *ExceptionsConfig) IsEnabled() bool {
@@ -140,6 +199,16 @@ func (e *ExceptionsConfig) GetTokenPrefix() string {
 	return e.TokenPrefix
 }
 
+// GetSigningKeyFile returns the signing key file path.
+// Returns DefaultSigningKeyFile if SigningKeyFile is empty.
+func (e *ExceptionsConfig) GetSigningKeyFile() string {
+	if e == nil || e.SigningKeyFile == "" {
+		return DefaultSigningKeyFile
+	}
+
+	return e.SigningKeyFile
+}
+
 // GetPolicy returns the policy for the given error code.
 // Returns nil if no policy is defined.
 func (e *ExceptionsConfig) GetPolicy(errorCode string) *ExceptionPolicyConfig {
@@ -210,6 +279,66 @@ func (p *ExceptionPolicyConfig) GetMaxPerDay() int {
 	return *p.MaxPerDay
 }
 
+// IsSessionBound returns true if tokens must be bound to a session.
+// Returns false if BindSession is nil (default behavior).
+func (p *ExceptionPolicyConfig) IsSessionBound() bool {
+	if p == nil || p.BindSession == nil {
+		return false
+	}
+
+	return *p.BindSession
+}
+
+// IsCommandBound returns true if tokens must be bound to a command hash.
+// Returns false if BindCommand is nil (default behavior).
+func (p *ExceptionPolicyConfig) IsCommandBound() bool {
+	if p == nil || p.BindCommand == nil {
+		return false
+	}
+
+	return *p.BindCommand
+}
+
+// IsExpiryRequired returns true if tokens must carry an expiry timestamp.
+// Returns false if RequireExpiry is nil (default behavior).
+func (p *ExceptionPolicyConfig) IsExpiryRequired() bool {
+	if p == nil || p.RequireExpiry == nil {
+		return false
+	}
+
+	return *p.RequireExpiry
+}
+
+// GetMaxTokenTTL returns the maximum token lifetime.
+// Returns 0 if MaxTokenTTL is not set (unlimited).
+func (p *ExceptionPolicyConfig) GetMaxTokenTTL() time.Duration {
+	if p == nil {
+		return 0
+	}
+
+	return time.Duration(p.MaxTokenTTL)
+}
+
+// IsSignatureRequired returns true if tokens must be signed.
+// Returns false if RequireSignature is nil (default behavior).
+func (p *ExceptionPolicyConfig) IsSignatureRequired() bool {
+	if p == nil || p.RequireSignature == nil {
+		return false
+	}
+
+	return *p.RequireSignature
+}
+
+// IsApprovalRequired returns true if exceptions must be approved by a human.
+// Returns false if RequireApproval is nil (default behavior).
+func (p *ExceptionPolicyConfig) IsApprovalRequired() bool {
+	if p == nil || p.RequireApproval == nil {
+		return false
+	}
+
+	return *p.RequireApproval
+}
+
 // IsRateLimitEnabled returns true if rate limiting is enabled.
 // Returns true if Enabled is nil (default behavior).
 func (r *ExceptionRateLimitConfig) IsRateLimitEnabled() bool {
@@ -299,3 +428,23 @@ func (a *ExceptionAuditConfig) GetMaxBackups() int {
 
 	return *a.MaxBackups
 }
+
+// GetDir returns the approval queue directory.
+// Returns DefaultApprovalDir if Dir is empty.
+func (a *ExceptionApprovalConfig) GetDir() string {
+	if a == nil || a.Dir == "" {
+		return DefaultApprovalDir
+	}
+
+	return a.Dir
+}
+
+// GetMaxAge returns how long approval requests stay valid.
+// Returns DefaultApprovalMaxAge if MaxAge is zero.
+func (a *ExceptionApprovalConfig) GetMaxAge() time.Duration {
+	if a == nil || a.MaxAge == 0 {
+		return DefaultApprovalMaxAge
+	}
+
+	return time.Duration(a.MaxAge)
+}
@@ -1,6 +1,8 @@
 package config_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -51,6 +53,18 @@ var _ = Describe("ExceptionsConfig", func() {
 		})
 	})
 
+	Describe("GetSigningKeyFile", func() {
+		It("should return the default path when SigningKeyFile is empty", func() {
+			var cfg *config.ExceptionsConfig
+			Expect(cfg.GetSigningKeyFile()).To(Equal(config.DefaultSigningKeyFile))
+		})
+
+		It("should return the configured path", func() {
+			cfg := &config.ExceptionsConfig{SigningKeyFile: "/tmp/key"}
+			Expect(cfg.GetSigningKeyFile()).To(Equal("/tmp/key"))
+		})
+	})
+
 	Describe("GetPolicy", func() {
 		It("should return nil for nil config", func() {
 			var cfg *config.ExceptionsConfig
@@ -207,6 +221,49 @@ var _ = Describe("ExceptionPolicyConfig", func() {
 			Expect(cfg.GetMaxPerDay()).To(Equal(0))
 		})
 	})
+
+	Describe("token scoping", func() {
+		It("should not require scopes by default", func() {
+			cfg := &config.ExceptionPolicyConfig{}
+			Expect(cfg.IsSessionBound()).To(BeFalse())
+			Expect(cfg.IsCommandBound()).To(BeFalse())
+			Expect(cfg.IsExpiryRequired()).To(BeFalse())
+			Expect(cfg.IsSignatureRequired()).To(BeFalse())
+			Expect(cfg.GetMaxTokenTTL()).To(BeZero())
+		})
+
+		It("should return the configured scopes", func() {
+			enabled := true
+			cfg := &config.ExceptionPolicyConfig{
+				BindSession:      &enabled,
+				BindCommand:      &enabled,
+				RequireExpiry:    &enabled,
+				RequireSignature: &enabled,
+				MaxTokenTTL:      config.Duration(time.Hour),
+			}
+			Expect(cfg.IsSessionBound()).To(BeTrue())
+			Expect(cfg.IsCommandBound()).To(BeTrue())
+			Expect(cfg.IsExpiryRequired()).To(BeTrue())
+			Expect(cfg.IsSignatureRequired()).To(BeTrue())
+			Expect(cfg.GetMaxTokenTTL()).To(Equal(time.Hour))
+		})
+
+		It("should not require approval by default", func() {
+			var cfg *config.ExceptionPolicyConfig
+			Expect(cfg.IsApprovalRequired()).To(BeFalse())
+
+			enabled := true
+			cfg = &config.ExceptionPolicyConfig{RequireApproval: &enabled}
+			Expect(cfg.IsApprovalRequired()).To(BeTrue())
+		})
+
+		It("should not require scopes for nil config", func() {
+			var cfg *config.ExceptionPolicyConfig
+			Expect(cfg.IsSessionBound()).To(BeFalse())
+			Expect(cfg.IsSignatureRequired()).To(BeFalse())
+			Expect(cfg.GetMaxTokenTTL()).To(BeZero())
+		})
+	})
 })
 
 var _ = Describe("ExceptionRateLimitConfig", func() {
@@ -404,3 +461,20 @@ var _ = Describe("Config GetExceptions", func() {
 		})
 	})
 })
+
+var _ = Describe("ExceptionApprovalConfig", func() {
+	It("should return defaults for nil config", func() {
+		var cfg *config.ExceptionApprovalConfig
+		Expect(cfg.GetDir()).To(Equal(config.DefaultApprovalDir))
+		Expect(cfg.GetMaxAge()).To(Equal(config.DefaultApprovalMaxAge))
+	})
+
+	It("should return the configured values", func() {
+		cfg := &config.ExceptionApprovalConfig{
+			Dir:    "/tmp/approvals",
+			MaxAge: config.Duration(time.Hour),
+		}
+		Expect(cfg.GetDir()).To(Equal("/tmp/approvals"))
+		Expect(cfg.GetMaxAge()).To(Equal(time.Hour))
+	})
+})
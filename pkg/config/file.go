@@ -26,6 +26,12 @@ type FileConfig struct {
 
 	// Rust validator configuration
 	Rust *RustValidatorConfig `json:"rust,omitempty" koanf:"rust" toml:"rust"`
+
+	// SensitiveRead validator configuration (reads of credential files)
+	SensitiveRead *SensitiveReadValidatorConfig `json:"sensitive_read,omitempty" koanf:"sensitive_read" toml:"sensitive_read"`
+
+	// PostWrite validator configuration (formatter checks on the written file)
+	PostWrite *PostWriteValidatorConfig `json:"post_write,omitempty" koanf:"post_write" toml:"post_write"`
 }
 
 // MarkdownValidatorConfig configures the Markdown file validator.
@@ -304,3 +310,63 @@ type RustValidatorConfig struct {
 	// Default: "" (use rustfmt defaults)
 	RustfmtConfig string `json:"rustfmt_config,omitempty" koanf:"rustfmt_config" toml:"rustfmt_config"`
 }
+
+// SensitiveReadValidatorConfig configures the sensitive file read validator.
+// It guards Read, Grep and Glob tool calls and Bash commands that read
+// credential files (cat, less, head, cp, ...).
+//
+// Patterns use the same syntax as rule patterns: glob by default, regex when
+// the pattern contains regex syntax. A leading ~/ is expanded to the home directory.
+type SensitiveReadValidatorConfig struct {
+	ValidatorConfig `koanf:",squash"`
+
+	// BlockOnMatch determines if reading a sensitive file blocks the operation.
+	// When false, reads are reported as warnings instead.
+	// Default: true
+	BlockOnMatch *bool `json:"block_on_match,omitempty" koanf:"block_on_match" toml:"block_on_match"`
+
+	// CheckBash enables checking Bash commands that read files (cat, less, head, cp, ...).
+	// Default: true
+	CheckBash *bool `json:"check_bash,omitempty" koanf:"check_bash" toml:"check_bash"`
+
+	// Patterns replaces the built-in list of sensitive path patterns.
+	// Default: [] (use built-in patterns: .env*, ~/.ssh/**, ~/.aws/credentials,
+	// *.pem, kubeconfigs and ~/.klaudiush state files)
+	Patterns []string `json:"patterns,omitempty" koanf:"patterns" toml:"patterns"`
+
+	// AdditionalPatterns are sensitive path patterns checked in addition to Patterns.
+	// Default: []
+	AdditionalPatterns []string `json:"additional_patterns,omitempty" koanf:"additional_patterns" toml:"additional_patterns"`
+
+	// AllowPatterns are path patterns that are never reported, even if they
+	// match a sensitive pattern. Replaces the built-in allow list
+	// (.env.example, .env.sample, .env.template, ~/.ssh/*.pub, ~/.ssh/known_hosts).
+	// Default: [] (use built-in allow list)
+	AllowPatterns []string `json:"allow_patterns,omitempty" koanf:"allow_patterns" toml:"allow_patterns"`
+}
+
+// IsBlockOnMatch returns whether sensitive reads should block. Defaults to true.
+func (c *SensitiveReadValidatorConfig) IsBlockOnMatch() bool {
+	if c == nil || c.BlockOnMatch == nil {
+		return true
+	}
+
+	return *c.BlockOnMatch
+}
+
+// IsCheckBash returns whether Bash commands should be checked. Defaults to true.
+func (c *SensitiveReadValidatorConfig) IsCheckBash() bool {
+	if c == nil || c.CheckBash == nil {
+		return true
+	}
+
+	return *c.CheckBash
+}
+
+// PostWriteValidatorConfig configures re-running the formatter-based file
+// validators (gofumpt, rustfmt, terraform) after Write, Edit and MultiEdit
+// (PostToolUse), on the file contents as written to disk. Each formatter uses
+// its own configuration and only runs if it is enabled.
+type PostWriteValidatorConfig struct {
+	ValidatorConfig `koanf:",squash"`
+}
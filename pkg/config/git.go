@@ -125,6 +125,48 @@ type PushValidatorConfig struct {
 	// RequireTracking requires branches to have remote tracking configured before push.
 	// Default: true
 	RequireTracking *bool `json:"require_tracking,omitempty" koanf:"require_tracking" toml:"require_tracking"`
+
+	// BlockForcePushProtected blocks force pushes (--force, --force-with-lease, +refspec)
+	// and mirror pushes targeting branches listed in the branch validator's
+	// protected_branches.
+	// Default: true
+	BlockForcePushProtected *bool `json:"block_force_push_protected,omitempty" koanf:"block_force_push_protected" toml:"block_force_push_protected"`
+
+	// RequireForceWithLease requires --force-with-lease instead of --force, -f or +refspec
+	// when force pushing to unprotected branches.
+	// Default: true
+	RequireForceWithLease *bool `json:"require_force_with_lease,omitempty" koanf:"require_force_with_lease" toml:"require_force_with_lease"`
+
+	// BlockRemoteDelete blocks deleting remote branches via --delete, -d or :branch refspecs.
+	// Default: true
+	BlockRemoteDelete *bool `json:"block_remote_delete,omitempty" koanf:"block_remote_delete" toml:"block_remote_delete"`
+}
+
+// IsBlockForcePushProtectedEnabled returns whether force pushes to protected branches are blocked.
+func (c *PushValidatorConfig) IsBlockForcePushProtectedEnabled() bool {
+	if c == nil || c.BlockForcePushProtected == nil {
+		return true
+	}
+
+	return *c.BlockForcePushProtected
+}
+
+// IsRequireForceWithLeaseEnabled returns whether --force-with-lease is required for force pushes.
+func (c *PushValidatorConfig) IsRequireForceWithLeaseEnabled() bool {
+	if c == nil || c.RequireForceWithLease == nil {
+		return true
+	}
+
+	return *c.RequireForceWithLease
+}
+
+// IsBlockRemoteDeleteEnabled returns whether remote branch deletion is blocked.
+func (c *PushValidatorConfig) IsBlockRemoteDeleteEnabled() bool {
+	if c == nil || c.BlockRemoteDelete == nil {
+		return true
+	}
+
+	return *c.BlockRemoteDelete
 }
 
 // AddValidatorConfig configures the git add validator.
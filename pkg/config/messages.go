@@ -0,0 +1,20 @@
+package config
+
+// MessageOverrideConfig overrides the message and fix hint of validation
+// errors with a reference code. Both are Go text/templates with access to
+// the hook context (e.g., {{.Branch}}, {{.File}}, {{.Command}}) and the
+// original texts ({{.Message}}, {{.FixHint}}).
+//
+// Example configuration:
+//
+//	[messages.GIT019]
+//	fix_hint = "{{.FixHint}}. See https://runbooks.example.com/git/signing"
+type MessageOverrideConfig struct {
+	// Message replaces the message of the validation error.
+	// Default: "" (keep the original message)
+	Message string `json:"message,omitempty" koanf:"message" toml:"message"`
+
+	// FixHint replaces the fix hint of the validation error.
+	// Default: "" (keep the original fix hint)
+	FixHint string `json:"fix_hint,omitempty" koanf:"fix_hint" toml:"fix_hint"`
+}
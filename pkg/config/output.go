@@ -0,0 +1,73 @@
+package config
+
+const (
+	// OutputFormatText writes validation messages to stderr and signals
+	// blocking via exit code 2.
+	OutputFormatText = "text"
+
+	// OutputFormatJSON writes a structured Claude Code hook response to stdout
+	// and always exits with code 0.
+	OutputFormatJSON = "json"
+)
+
+// ValidOutputFormats are the valid hook output formats.
+var ValidOutputFormats = []string{OutputFormatText, OutputFormatJSON}
+
+// OutputConfig contains configuration for how hook results are reported
+// back to Claude Code.
+//
+// Example configuration:
+//
+//	[output]
+//	format = "json"
+//	suppress_output = false
+//	stop_on_block = false
+type OutputConfig struct {
+	// Format is the hook output format ("text" or "json").
+	// Default: "text"
+	Format string `json:"format,omitempty" koanf:"format" toml:"format"`
+
+	// SuppressOutput hides the hook output from the Claude Code transcript.
+	// Only applies to the JSON format.
+	// Default: false
+	SuppressOutput *bool `json:"suppress_output,omitempty" koanf:"suppress_output" toml:"suppress_output"`
+
+	// StopOnBlock stops Claude entirely (continue=false) when an operation
+	// is blocked, instead of only denying the single tool call.
+	// Only applies to the JSON format.
+	// Default: false
+	StopOnBlock *bool `json:"stop_on_block,omitempty" koanf:"stop_on_block" toml:"stop_on_block"`
+}
+
+// GetFormat returns the output format.
+// Returns OutputFormatText if Format is empty.
+func (o *OutputConfig) GetFormat() string {
+	if o == nil || o.Format == "" {
+		return OutputFormatText
+	}
+
+	return o.Format
+}
+
+// IsJSON returns true if the JSON output format is selected.
+func (o *OutputConfig) IsJSON() bool {
+	return o.GetFormat() == OutputFormatJSON
+}
+
+// IsSuppressOutput returns whether hook output should be hidden from the transcript.
+func (o *OutputConfig) IsSuppressOutput() bool {
+	if o == nil || o.SuppressOutput == nil {
+		return false
+	}
+
+	return *o.SuppressOutput
+}
+
+// IsStopOnBlock returns whether Claude should stop when an operation is blocked.
+func (o *OutputConfig) IsStopOnBlock() bool {
+	if o == nil || o.StopOnBlock == nil {
+		return false
+	}
+
+	return *o.StopOnBlock
+}
@@ -0,0 +1,55 @@
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/smykla-labs/klaudiush/pkg/config"
+)
+
+var _ = Describe("OutputConfig", func() {
+	Describe("GetFormat", func() {
+		It("returns text by default", func() {
+			cfg := &config.OutputConfig{}
+			Expect(cfg.GetFormat()).To(Equal(config.OutputFormatText))
+			Expect(cfg.IsJSON()).To(BeFalse())
+		})
+
+		It("returns text for nil config", func() {
+			var cfg *config.OutputConfig
+			Expect(cfg.GetFormat()).To(Equal(config.OutputFormatText))
+		})
+
+		It("returns configured format", func() {
+			cfg := &config.OutputConfig{Format: config.OutputFormatJSON}
+			Expect(cfg.GetFormat()).To(Equal(config.OutputFormatJSON))
+			Expect(cfg.IsJSON()).To(BeTrue())
+		})
+	})
+
+	Describe("IsSuppressOutput", func() {
+		It("returns false by default", func() {
+			cfg := &config.OutputConfig{}
+			Expect(cfg.IsSuppressOutput()).To(BeFalse())
+		})
+
+		It("returns true when enabled", func() {
+			suppress := true
+			cfg := &config.OutputConfig{SuppressOutput: &suppress}
+			Expect(cfg.IsSuppressOutput()).To(BeTrue())
+		})
+	})
+
+	Describe("IsStopOnBlock", func() {
+		It("returns false by default", func() {
+			var cfg *config.OutputConfig
+			Expect(cfg.IsStopOnBlock()).To(BeFalse())
+		})
+
+		It("returns true when enabled", func() {
+			stop := true
+			cfg := &config.OutputConfig{StopOnBlock: &stop}
+			Expect(cfg.IsStopOnBlock()).To(BeTrue())
+		})
+	})
+})
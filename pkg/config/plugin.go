@@ -144,7 +144,8 @@ const (
 // PluginPredicate configures when a plugin should be invoked.
 type PluginPredicate struct {
 	// EventTypes filters by event type.
-	// Example: ["PreToolUse", "PostToolUse"]
+	// Default: ["PreToolUse"]
+	// Example: ["PreToolUse", "PostToolUse", "UserPromptSubmit", "Stop"]
 	EventTypes []string `json:"event_types,omitempty" koanf:"event_types" toml:"event_types"`
 
 	// ToolTypes filters by tool type.
@@ -229,9 +230,10 @@ func (c *PluginInstanceConfig) GetTimeout(defaultTimeout time.Duration) time.Dur
 }
 
 // MatchesEventType returns whether this predicate matches the given event type.
+// Without an event type filter only PreToolUse matches.
 func (p *PluginPredicate) MatchesEventType(eventType hook.EventType) bool {
 	if p == nil || len(p.EventTypes) == 0 {
-		return true
+		return eventType == hook.EventTypePreToolUse
 	}
 
 	eventTypeStr := eventType.String()
@@ -1,17 +1,43 @@
 // Package config provides configuration schema types for klaudiush validators.
 package config
 
+import "path/filepath"
+
+// DefaultRulesAuditLogFile is the default audit log file for rules with the
+// "log" action.
+const DefaultRulesAuditLogFile = "~/.klaudiush/rules_audit.jsonl"
+
+// RuleStatsFileName is the name of the rule hit statistics file, stored in
+// the directory of the session state file.
+const RuleStatsFileName = "rule_stats.json"
+
 // Valid values for rules configuration.
 // These are exported for use by validation and doctor packages.
 var (
 	// ValidActionTypes are the valid action types for rules.
-	ValidActionTypes = []string{"allow", "block", "warn"}
+	ValidActionTypes = []string{"allow", "block", "warn", "ask", "suggest", "poison", "log"}
 
 	// ValidEventTypes are the valid event types for rules (case-insensitive matching supported).
-	ValidEventTypes = []string{"PreToolUse", "PostToolUse", "Notification"}
+	ValidEventTypes = []string{
+		"PreToolUse",
+		"PostToolUse",
+		"Notification",
+		"UserPromptSubmit",
+		"Stop",
+		"SubagentStop",
+		"SessionStart",
+		"SessionEnd",
+		"PreCompact",
+	}
 
 	// ValidToolTypes are the valid tool types for rules (case-insensitive matching supported).
 	ValidToolTypes = []string{"Bash", "Write", "Edit", "MultiEdit", "Grep", "Read", "Glob"}
+
+	// ValidPipelinePositions are the valid pipeline positions for rules.
+	ValidPipelinePositions = []string{"first", "middle", "last"}
+
+	// ValidTimeWindowDays are the valid time window days for rules.
+	ValidTimeWindowDays = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
 )
 
 // RulesConfig contains the dynamic rule configuration.
@@ -24,8 +50,64 @@ type RulesConfig struct {
 	// Default: true
 	StopOnFirstMatch *bool `json:"stop_on_first_match,omitempty" koanf:"stop_on_first_match" toml:"stop_on_first_match"`
 
+	// Standalone controls whether rules are also evaluated by a standalone
+	// "rules" validator for every hook event, so they take effect even when
+	// no built-in validator runs (e.g., for Read, Glob or WebFetch).
+	// Default: true
+	Standalone *bool `json:"standalone,omitempty" koanf:"standalone" toml:"standalone"`
+
+	// AuditLogFile is the JSONL file that rules with the "log" action write
+	// their matches to.
+	// Default: "~/.klaudiush/rules_audit.jsonl"
+	AuditLogFile string `json:"audit_log_file,omitempty" koanf:"audit_log_file" toml:"audit_log_file"`
+
+	// Stats controls whether rule hit statistics are recorded for
+	// "klaudiush rules stats".
+	// Default: true
+	Stats *bool `json:"stats,omitempty" koanf:"stats" toml:"stats"`
+
+	// Packs lists rule packs to load. Entries are either pack names with an
+	// optional version constraint ("kong-oss", "kong-oss@1", "kong-oss@~1.2"),
+	// resolved from .klaudiush/packs in the project and ~/.klaudiush/packs,
+	// or paths to a pack directory or file ("./ci/klaudiush-rules").
+	// Rules defined in the configuration override pack rules with the same name.
+	Packs []string `json:"packs,omitempty" koanf:"packs" toml:"packs"`
+
 	// Rules is the list of validation rules.
 	Rules []RuleConfig `json:"rules,omitempty" koanf:"rules" toml:"rules"`
+
+	// ResolvedPacks contains the rule packs loaded from Packs, in load order.
+	// Set by the configuration loader.
+	ResolvedPacks []RulePack `json:"-" koanf:"-" toml:"-"`
+}
+
+// RulePack is a shareable, versioned bundle of rules. A pack is either a
+// single TOML file or a directory with a pack.toml file; both hold the
+// metadata in a [pack] table and rules in [[rules]] tables.
+type RulePack struct {
+	// Name identifies the pack in rules.packs references.
+	Name string `json:"name,omitempty" koanf:"name" toml:"name"`
+
+	// Version is the semantic version of the pack (e.g., "1.2.0").
+	Version string `json:"version,omitempty" koanf:"version" toml:"version"`
+
+	// Description provides human-readable explanation of the pack.
+	Description string `json:"description,omitempty" koanf:"description" toml:"description"`
+
+	// KlaudiushVersion is a version constraint the running klaudiush must
+	// satisfy (e.g., ">= 1.5.0"). Development builds skip the check.
+	KlaudiushVersion string `json:"klaudiush_version,omitempty" koanf:"klaudiush_version" toml:"klaudiush_version"`
+
+	// Path is the pack file or directory. Set by the configuration loader.
+	Path string `json:"-" koanf:"-" toml:"-"`
+
+	// Rules are the rules defined by the pack. Set by the configuration loader.
+	Rules []RuleConfig `json:"-" koanf:"-" toml:"-"`
+}
+
+// ID returns the pack name and version in "name@version" form.
+func (p *RulePack) ID() string {
+	return p.Name + "@" + p.Version
 }
 
 // RuleConfig represents a single validation rule configuration.
@@ -49,10 +131,62 @@ type RuleConfig struct {
 
 	// Action specifies what happens when the rule matches.
 	Action *RuleActionConfig `json:"action,omitempty" koanf:"action" toml:"action"`
+
+	// Tests are inputs with the expected outcome, run by "klaudiush rules
+	// test" and "klaudiush doctor".
+	Tests []RuleTestConfig `json:"tests,omitempty" koanf:"tests" toml:"tests"`
+
+	// Source is the file the rule was loaded from. Set by the configuration loader.
+	Source string `json:"-" koanf:"-" toml:"-"`
+
+	// Pack is the "name@version" of the rule pack that defined the rule, or
+	// empty for rules from configuration files. Set by the configuration loader.
+	Pack string `json:"-" koanf:"-" toml:"-"`
+}
+
+// RuleTestConfig is a test case for a rule. The input is evaluated against
+// all rules, like a hook invocation would be.
+type RuleTestConfig struct {
+	// Name describes the test case.
+	Name string `json:"name,omitempty" koanf:"name" toml:"name"`
+
+	// ValidatorType is the validator the input is evaluated for (e.g., "git.push").
+	ValidatorType string `json:"validator_type,omitempty" koanf:"validator_type" toml:"validator_type"`
+
+	// ToolType is the tool name (e.g., "Bash", "Write").
+	// Default: "Bash" with a command, "Write" with a file path
+	ToolType string `json:"tool_type,omitempty" koanf:"tool_type" toml:"tool_type"`
+
+	// EventType is the hook event type.
+	// Default: "PreToolUse"
+	EventType string `json:"event_type,omitempty" koanf:"event_type" toml:"event_type"`
+
+	// Command is the bash command.
+	Command string `json:"command,omitempty" koanf:"command" toml:"command"`
+
+	// FilePath is the file path being operated on.
+	FilePath string `json:"file_path,omitempty" koanf:"file_path" toml:"file_path"`
+
+	// Content is the file content.
+	Content string `json:"content,omitempty" koanf:"content" toml:"content"`
+
+	// Branch is the current git branch.
+	Branch string `json:"branch,omitempty" koanf:"branch" toml:"branch"`
+
+	// Remote is the git remote name.
+	Remote string `json:"remote,omitempty" koanf:"remote" toml:"remote"`
+
+	// Matched is whether the rule is expected to match the input.
+	Matched bool `json:"matched" koanf:"matched" toml:"matched"`
+
+	// Action is the expected action of the evaluation (e.g., "block"). It is
+	// "allow" if no rule matches. Optional.
+	Action string `json:"action,omitempty" koanf:"action" toml:"action"`
 }
 
 // RuleMatchConfig contains all conditions for a rule to match.
-// All non-empty conditions must be satisfied (AND logic).
+// All non-empty conditions must be satisfied (AND logic). Nested all, any
+// and not blocks allow arbitrary boolean composition of conditions.
 type RuleMatchConfig struct {
 	// ValidatorType filters by validator type (supports wildcards).
 	// Examples: "git.push", "git.*", "*"
@@ -111,6 +245,97 @@ type RuleMatchConfig struct {
 	// PatternMode specifies how multiple patterns are combined when using pattern lists.
 	// Values: "any" (OR logic, default), "all" (AND logic)
 	PatternMode string `json:"pattern_mode,omitempty" koanf:"pattern_mode" toml:"pattern_mode"`
+
+	// Program matches the name of any command in the parsed bash command,
+	// including commands in && chains, pipelines, subshells and $(...).
+	// Supports glob patterns and regex. Examples: "curl", "sh", "git"
+	Program string `json:"program,omitempty" koanf:"program" toml:"program"`
+
+	// Subcommand matches the subcommand of the parsed command. For git this is
+	// the subcommand after global options (e.g., "push" in "git -C dir push"),
+	// for other programs the first positional argument.
+	Subcommand string `json:"subcommand,omitempty" koanf:"subcommand" toml:"subcommand"`
+
+	// Flags lists flags that must all be present on the parsed command.
+	// Long flags also match "--flag=value", short flags match combined
+	// short flags (e.g., "-f" matches "-rf").
+	Flags []string `json:"flags,omitempty" koanf:"flags" toml:"flags"`
+
+	// WithoutFlags lists flags that must all be absent from the parsed command.
+	WithoutFlags []string `json:"without_flags,omitempty" koanf:"without_flags" toml:"without_flags"`
+
+	// Args lists patterns that must each match a positional argument of the
+	// parsed command. For git, only arguments after the subcommand are checked.
+	Args []string `json:"args,omitempty" koanf:"args" toml:"args"`
+
+	// InPipeline requires (true) or forbids (false) the command to be part of a pipeline.
+	InPipeline *bool `json:"in_pipeline,omitempty" koanf:"in_pipeline" toml:"in_pipeline"`
+
+	// InSubshell requires (true) or forbids (false) the command to run in a subshell.
+	InSubshell *bool `json:"in_subshell,omitempty" koanf:"in_subshell" toml:"in_subshell"`
+
+	// InCommandSubstitution requires (true) or forbids (false) the command to run
+	// inside $(...) or backticks.
+	InCommandSubstitution *bool `json:"in_command_substitution,omitempty" koanf:"in_command_substitution" toml:"in_command_substitution"`
+
+	// PipelinePosition requires the command to be a specific pipeline stage.
+	// Values: "first", "middle", "last"
+	PipelinePosition string `json:"pipeline_position,omitempty" koanf:"pipeline_position" toml:"pipeline_position"`
+
+	// Env maps environment variable names to patterns their values must match.
+	// Unset variables never match. Example: { AWS_PROFILE = "prod-*" }
+	Env map[string]string `json:"env,omitempty" koanf:"env" toml:"env"`
+
+	// CWDPattern matches against the working directory of the hook.
+	// Supports glob patterns, regex, and negation (! prefix).
+	CWDPattern string `json:"cwd_pattern,omitempty" koanf:"cwd_pattern" toml:"cwd_pattern"`
+
+	// TimeWindow matches when the current time is within the window.
+	TimeWindow *TimeWindowConfig `json:"time_window,omitempty" koanf:"time_window" toml:"time_window"`
+
+	// MinSessionCommands matches when the session has recorded at least this
+	// many commands. Never matches without session tracking.
+	MinSessionCommands *int `json:"min_session_commands,omitempty" koanf:"min_session_commands" toml:"min_session_commands"`
+
+	// MaxSessionCommands matches when the session has recorded at most this
+	// many commands. Never matches without session tracking.
+	MaxSessionCommands *int `json:"max_session_commands,omitempty" koanf:"max_session_commands" toml:"max_session_commands"`
+
+	// RemoteHost matches the host of the remote URL (e.g., "github.com").
+	// The remote is the one named by git push/fetch/pull, else the tracking
+	// remote of the current branch, else "origin".
+	RemoteHost string `json:"remote_host,omitempty" koanf:"remote_host" toml:"remote_host"`
+
+	// RemoteOwner matches the owner (organization or user) of the remote URL.
+	RemoteOwner string `json:"remote_owner,omitempty" koanf:"remote_owner" toml:"remote_owner"`
+
+	// RemoteRepo matches the repository name of the remote URL (without ".git").
+	RemoteRepo string `json:"remote_repo,omitempty" koanf:"remote_repo" toml:"remote_repo"`
+
+	// StagedFiles lists file patterns of which at least one must match a staged file.
+	StagedFiles []string `json:"staged_files,omitempty" koanf:"staged_files" toml:"staged_files"`
+
+	// Dirty requires (true) or forbids (false) staged, modified or untracked files.
+	Dirty *bool `json:"dirty,omitempty" koanf:"dirty" toml:"dirty"`
+
+	// MinCommitsAhead matches when the current branch is at least this many
+	// commits ahead of its upstream. Never matches branches without upstream.
+	MinCommitsAhead *int `json:"min_commits_ahead,omitempty" koanf:"min_commits_ahead" toml:"min_commits_ahead"`
+
+	// MaxCommitsAhead matches when the current branch is at most this many
+	// commits ahead of its upstream. Never matches branches without upstream.
+	MaxCommitsAhead *int `json:"max_commits_ahead,omitempty" koanf:"max_commits_ahead" toml:"max_commits_ahead"`
+
+	// All contains nested match blocks that must all match (AND logic).
+	// Each nested block is evaluated on its own, including its own
+	// case_insensitive and pattern_mode settings.
+	All []RuleMatchConfig `json:"all,omitempty" koanf:"all" toml:"all"`
+
+	// Any contains nested match blocks of which at least one must match (OR logic).
+	Any []RuleMatchConfig `json:"any,omitempty" koanf:"any" toml:"any"`
+
+	// Not contains a nested match block that must not match.
+	Not *RuleMatchConfig `json:"not,omitempty" koanf:"not" toml:"not"`
 }
 
 // IsCaseInsensitive returns true if case-insensitive matching is enabled.
@@ -152,20 +377,102 @@ func (m *RuleMatchConfig) HasMatchConditions() bool {
 		m.CommandPattern != "" ||
 		len(m.CommandPatterns) > 0 ||
 		m.ToolType != "" ||
-		m.EventType != ""
+		m.EventType != "" ||
+		m.HasParsedCommandConditions() ||
+		m.HasContextConditions() ||
+		m.HasGitStateConditions() ||
+		len(m.All) > 0 ||
+		len(m.Any) > 0 ||
+		m.Not != nil
+}
+
+// HasParsedCommandConditions returns true if the match config has at least one
+// condition that is evaluated against the parsed bash command.
+func (m *RuleMatchConfig) HasParsedCommandConditions() bool {
+	if m == nil {
+		return false
+	}
+
+	return m.Program != "" ||
+		m.Subcommand != "" ||
+		len(m.Flags) > 0 ||
+		len(m.WithoutFlags) > 0 ||
+		len(m.Args) > 0 ||
+		m.InPipeline != nil ||
+		m.InSubshell != nil ||
+		m.InCommandSubstitution != nil ||
+		m.PipelinePosition != ""
+}
+
+// HasContextConditions returns true if the match config has at least one
+// condition that is evaluated against the environment, working directory,
+// time or session.
+func (m *RuleMatchConfig) HasContextConditions() bool {
+	if m == nil {
+		return false
+	}
+
+	return len(m.Env) > 0 ||
+		m.CWDPattern != "" ||
+		m.TimeWindow != nil ||
+		m.MinSessionCommands != nil ||
+		m.MaxSessionCommands != nil
+}
+
+// HasGitStateConditions returns true if the match config has at least one
+// condition that is evaluated against the remote URL or the working tree.
+func (m *RuleMatchConfig) HasGitStateConditions() bool {
+	if m == nil {
+		return false
+	}
+
+	return m.RemoteHost != "" ||
+		m.RemoteOwner != "" ||
+		m.RemoteRepo != "" ||
+		len(m.StagedFiles) > 0 ||
+		m.Dirty != nil ||
+		m.MinCommitsAhead != nil ||
+		m.MaxCommitsAhead != nil
+}
+
+// TimeWindowConfig specifies a time-of-day and weekday window.
+type TimeWindowConfig struct {
+	// Start is the start of the window in "HH:MM" format (inclusive).
+	// Empty means midnight.
+	Start string `json:"start,omitempty" koanf:"start" toml:"start"`
+
+	// End is the end of the window in "HH:MM" format (exclusive).
+	// Empty means end of day. An end before start spans midnight, and an end
+	// equal to start is invalid.
+	End string `json:"end,omitempty" koanf:"end" toml:"end"`
+
+	// Days restricts the window to weekdays ("mon", "tue", ..., "sun").
+	// Empty means every day.
+	Days []string `json:"days,omitempty" koanf:"days" toml:"days"`
+
+	// Timezone is the IANA time zone of the window (e.g., "Europe/Warsaw").
+	// Default: local time
+	Timezone string `json:"timezone,omitempty" koanf:"timezone" toml:"timezone"`
 }
 
 // RuleActionConfig specifies what happens when a rule matches.
 type RuleActionConfig struct {
-	// Type is the action to take (block, warn, allow).
+	// Type is the action to take (block, warn, allow, ask, suggest, poison, log).
 	// Default: "block"
 	Type string `json:"type,omitempty" koanf:"type" toml:"type"`
 
-	// Message is the human-readable message to display.
+	// Message is the human-readable message to display. It may be a Go
+	// text/template with access to the match context
+	// (e.g., `Pushes to {{.Branch}} are not allowed`).
 	Message string `json:"message,omitempty" koanf:"message" toml:"message"`
 
 	// Reference is an optional error reference code (e.g., "GIT019").
+	// Required for the "poison" action, as the session is poisoned with it.
 	Reference string `json:"reference,omitempty" koanf:"reference" toml:"reference"`
+
+	// Suggestion is a Go text/template for the replacement command of the
+	// "suggest" action (e.g., `{{replace "--force" "--force-with-lease" .Command}}`).
+	Suggestion string `json:"suggestion,omitempty" koanf:"suggestion" toml:"suggestion"`
 }
 
 // IsEnabled returns true if the rules engine is enabled.
@@ -188,6 +495,42 @@ func (r *RulesConfig) ShouldStopOnFirstMatch() bool {
 	return *r.StopOnFirstMatch
 }
 
+// IsStandaloneEnabled returns true if the standalone rules validator is enabled.
+// Returns true if Standalone is nil (default behavior).
+func (r *RulesConfig) IsStandaloneEnabled() bool {
+	if r == nil || r.Standalone == nil {
+		return true
+	}
+
+	return *r.Standalone
+}
+
+// GetAuditLogFile returns the audit log file for the "log" action.
+// Returns DefaultRulesAuditLogFile if AuditLogFile is empty.
+func (r *RulesConfig) GetAuditLogFile() string {
+	if r == nil || r.AuditLogFile == "" {
+		return DefaultRulesAuditLogFile
+	}
+
+	return r.AuditLogFile
+}
+
+// IsStatsEnabled returns true if rule hit statistics are recorded.
+// Returns true if Stats is nil (default behavior).
+func (r *RulesConfig) IsStatsEnabled() bool {
+	if r == nil || r.Stats == nil {
+		return true
+	}
+
+	return *r.Stats
+}
+
+// GetRuleStatsFile returns the rule hit statistics file, which is stored
+// next to the session state file.
+func (c *Config) GetRuleStatsFile() string {
+	return filepath.Join(filepath.Dir(c.GetSession().GetStateFile()), RuleStatsFileName)
+}
+
 // IsRuleEnabled returns true if the rule is enabled.
 // Returns true if Enabled is nil (default behavior).
 func (r *RuleConfig) IsRuleEnabled() bool {
@@ -55,6 +55,35 @@ var _ = Describe("RulesConfig", func() {
 			Expect(cfg.ShouldStopOnFirstMatch()).To(BeTrue())
 		})
 	})
+
+	Describe("IsStatsEnabled", func() {
+		It("should return true for nil RulesConfig", func() {
+			var cfg *config.RulesConfig
+			Expect(cfg.IsStatsEnabled()).To(BeTrue())
+		})
+
+		It("should return false when Stats is false", func() {
+			disabled := false
+			cfg := &config.RulesConfig{Stats: &disabled}
+			Expect(cfg.IsStatsEnabled()).To(BeFalse())
+		})
+	})
+})
+
+var _ = Describe("Config", func() {
+	Describe("GetRuleStatsFile", func() {
+		It("should default to the default session state directory", func() {
+			cfg := &config.Config{}
+			Expect(cfg.GetRuleStatsFile()).To(Equal("~/.klaudiush/rule_stats.json"))
+		})
+
+		It("should follow the session state file", func() {
+			cfg := &config.Config{
+				Session: &config.SessionConfig{StateFile: "/var/lib/klaudiush/session.json"},
+			}
+			Expect(cfg.GetRuleStatsFile()).To(Equal("/var/lib/klaudiush/rule_stats.json"))
+		})
+	})
 })
 
 var _ = Describe("RuleConfig", func() {
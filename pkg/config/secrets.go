@@ -30,6 +30,11 @@ type SecretsValidatorConfig struct {
 	// Default: true
 	BlockOnDetection *bool `json:"block_on_detection,omitempty" koanf:"block_on_detection" toml:"block_on_detection"`
 
+	// CheckToolOutput enables scanning Bash command output (PostToolUse events)
+	// for secrets echoed by commands such as env or cat.
+	// Default: false
+	CheckToolOutput *bool `json:"check_tool_output,omitempty" koanf:"check_tool_output" toml:"check_tool_output"`
+
 	// AllowList is a list of patterns that should be ignored even if they match.
 	// Useful for test fixtures, documentation examples, or known false positives.
 	// Each entry is a regex pattern that, if it matches the detected secret,
@@ -88,6 +93,15 @@ func (c *SecretsValidatorConfig) IsBlockOnDetectionEnabled() bool {
 	return *c.BlockOnDetection
 }
 
+// IsCheckToolOutputEnabled returns whether Bash command output should be scanned.
+func (c *SecretsValidatorConfig) IsCheckToolOutputEnabled() bool {
+	if c == nil || c.CheckToolOutput == nil {
+		return false
+	}
+
+	return *c.CheckToolOutput
+}
+
 // GetMaxFileSize returns the configured max file size or the default.
 func (c *SecretsValidatorConfig) GetMaxFileSize() ByteSize {
 	if c == nil || c.MaxFileSize == 0 {
@@ -36,6 +36,22 @@ var _ = Describe("SecretsValidatorConfig", func() {
 		})
 	})
 
+	Describe("IsCheckToolOutputEnabled", func() {
+		It("returns false when CheckToolOutput is nil (opt-in)", func() {
+			var cfg *config.SecretsValidatorConfig
+			Expect(cfg.IsCheckToolOutputEnabled()).To(BeFalse())
+			Expect((&config.SecretsValidatorConfig{}).IsCheckToolOutputEnabled()).To(BeFalse())
+		})
+
+		It("returns true when explicitly enabled", func() {
+			checkToolOutput := true
+			cfg := &config.SecretsValidatorConfig{
+				CheckToolOutput: &checkToolOutput,
+			}
+			Expect(cfg.IsCheckToolOutputEnabled()).To(BeTrue())
+		})
+	})
+
 	Describe("IsBlockOnDetectionEnabled", func() {
 		It("returns true for nil config (default to blocking)", func() {
 			var cfg *config.SecretsValidatorConfig
@@ -21,6 +21,21 @@ const (
 
 	// DefaultSessionAuditMaxBackups is the default max number of backup files.
 	DefaultSessionAuditMaxBackups = 5
+
+	// DefaultEscalationWindow is the default window for counting blocked attempts.
+	DefaultEscalationWindow = time.Hour
+
+	// DefaultEscalationWarnThreshold is the default number of blocked attempts
+	// of a code before the session is warned.
+	DefaultEscalationWarnThreshold = 1
+
+	// DefaultEscalationAckThreshold is the default number of blocked attempts
+	// of a code before the session requires an acknowledgment token.
+	DefaultEscalationAckThreshold = 2
+
+	// DefaultEscalationPoisonThreshold is the default number of blocked
+	// attempts of a code before the session is hard-poisoned.
+	DefaultEscalationPoisonThreshold = 3
 )
 
 // SessionConfig contains configuration for session tracking.
@@ -41,6 +56,47 @@ type SessionConfig struct {
 
 	// Audit contains audit logging configuration for session operations.
 	Audit *SessionAuditConfig `json:"audit,omitempty" koanf:"audit" toml:"audit"`
+
+	// Escalation contains graduated escalation configuration. When not set,
+	// the first blocking error poisons the session.
+	Escalation *SessionEscalationConfig `json:"escalation,omitempty" koanf:"escalation" toml:"escalation"`
+}
+
+// SessionEscalationConfig contains configuration for graduated session
+// escalation. Blocked attempts are counted per error code within a sliding
+// window, and the session moves through warned, poisoned (acknowledgment
+// token required) and hard-poisoned (acknowledgment tokens rejected) as the
+// count reaches each threshold.
+type SessionEscalationConfig struct {
+	// Enabled controls whether graduated escalation is active.
+	// Default: true
+	Enabled *bool `json:"enabled,omitempty" koanf:"enabled" toml:"enabled"`
+
+	// Window is the sliding window in which blocked attempts are counted.
+	// Default: "1h"
+	Window Duration `json:"window,omitempty" koanf:"window" toml:"window"`
+
+	// WarnThreshold is the number of blocked attempts of a code before the
+	// session is warned.
+	// Default: 1
+	WarnThreshold int `json:"warn_threshold,omitempty" koanf:"warn_threshold" toml:"warn_threshold"`
+
+	// AckThreshold is the number of blocked attempts of a code before the
+	// session requires an acknowledgment token.
+	// Default: 2
+	AckThreshold int `json:"ack_threshold,omitempty" koanf:"ack_threshold" toml:"ack_threshold"`
+
+	// PoisonThreshold is the number of blocked attempts of a code before the
+	// session is hard-poisoned. Hard-poisoned sessions do not accept
+	// acknowledgment tokens.
+	// Default: 3
+	PoisonThreshold int `json:"poison_threshold,omitempty" koanf:"poison_threshold" toml:"poison_threshold"`
+
+	// PoisonCooldown is how long a session stays poisoned before it is
+	// cleaned automatically. Zero keeps it poisoned until acknowledged or
+	// unpoisoned.
+	// Default: "0s"
+	PoisonCooldown Duration `json:"poison_cooldown,omitempty" koanf:"poison_cooldown" toml:"poison_cooldown"`
 }
 
 // SessionAuditConfig contains configuration for session audit logging.
@@ -105,6 +161,72 @@ func (s *SessionConfig) GetAudit() *SessionAuditConfig {
 	return s.Audit
 }
 
+// IsEscalationEnabled returns true if graduated escalation is configured and
+// not disabled.
+func (s *SessionConfig) IsEscalationEnabled() bool {
+	return s != nil && s.Escalation != nil && s.Escalation.IsEnabled()
+}
+
+// IsEnabled returns true if graduated escalation is active.
+// Returns true if Enabled is nil (default behavior).
+func (e *SessionEscalationConfig) IsEnabled() bool {
+	if e == nil || e.Enabled == nil {
+		return true
+	}
+
+	return *e.Enabled
+}
+
+// GetWindow returns the window for counting blocked attempts.
+// Returns DefaultEscalationWindow if Window is zero.
+func (e *SessionEscalationConfig) GetWindow() time.Duration {
+	if e == nil || e.Window == 0 {
+		return DefaultEscalationWindow
+	}
+
+	return time.Duration(e.Window)
+}
+
+// GetWarnThreshold returns the warn threshold.
+// Returns DefaultEscalationWarnThreshold if WarnThreshold is zero.
+func (e *SessionEscalationConfig) GetWarnThreshold() int {
+	if e == nil || e.WarnThreshold == 0 {
+		return DefaultEscalationWarnThreshold
+	}
+
+	return e.WarnThreshold
+}
+
+// GetAckThreshold returns the acknowledgment threshold.
+// Returns DefaultEscalationAckThreshold if AckThreshold is zero.
+func (e *SessionEscalationConfig) GetAckThreshold() int {
+	if e == nil || e.AckThreshold == 0 {
+		return DefaultEscalationAckThreshold
+	}
+
+	return e.AckThreshold
+}
+
+// GetPoisonThreshold returns the hard poison threshold.
+// Returns DefaultEscalationPoisonThreshold if PoisonThreshold is zero.
+func (e *SessionEscalationConfig) GetPoisonThreshold() int {
+	if e == nil || e.PoisonThreshold == 0 {
+		return DefaultEscalationPoisonThreshold
+	}
+
+	return e.PoisonThreshold
+}
+
+// GetPoisonCooldown returns how long a session stays poisoned.
+// Returns zero (no automatic expiry) if PoisonCooldown is not set.
+func (e *SessionEscalationConfig) GetPoisonCooldown() time.Duration {
+	if e == nil {
+		return 0
+	}
+
+	return time.Duration(e.PoisonCooldown)
+}
+
 // IsAuditEnabled returns true if session audit logging is enabled.
 // Returns true if Enabled is nil (default behavior).
 func (a *SessionAuditConfig) IsAuditEnabled() bool {
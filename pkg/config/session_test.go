@@ -192,6 +192,64 @@ var _ = Describe("SessionAuditConfig", func() {
 	})
 })
 
+var _ = Describe("SessionEscalationConfig", func() {
+	Describe("IsEscalationEnabled", func() {
+		It("returns false without escalation config", func() {
+			cfg := &config.SessionConfig{}
+			Expect(cfg.IsEscalationEnabled()).To(BeFalse())
+		})
+
+		It("returns true when escalation is configured", func() {
+			cfg := &config.SessionConfig{Escalation: &config.SessionEscalationConfig{}}
+			Expect(cfg.IsEscalationEnabled()).To(BeTrue())
+		})
+
+		It("returns false when escalation is disabled", func() {
+			enabled := false
+			cfg := &config.SessionConfig{
+				Escalation: &config.SessionEscalationConfig{Enabled: &enabled},
+			}
+			Expect(cfg.IsEscalationEnabled()).To(BeFalse())
+		})
+
+		It("returns false for nil config", func() {
+			var cfg *config.SessionConfig
+			Expect(cfg.IsEscalationEnabled()).To(BeFalse())
+		})
+	})
+
+	It("returns defaults for empty config", func() {
+		cfg := &config.SessionEscalationConfig{}
+		Expect(cfg.GetWindow()).To(Equal(config.DefaultEscalationWindow))
+		Expect(cfg.GetWarnThreshold()).To(Equal(config.DefaultEscalationWarnThreshold))
+		Expect(cfg.GetAckThreshold()).To(Equal(config.DefaultEscalationAckThreshold))
+		Expect(cfg.GetPoisonThreshold()).To(Equal(config.DefaultEscalationPoisonThreshold))
+		Expect(cfg.GetPoisonCooldown()).To(BeZero())
+	})
+
+	It("returns defaults for nil config", func() {
+		var cfg *config.SessionEscalationConfig
+		Expect(cfg.GetWindow()).To(Equal(config.DefaultEscalationWindow))
+		Expect(cfg.GetAckThreshold()).To(Equal(config.DefaultEscalationAckThreshold))
+		Expect(cfg.GetPoisonCooldown()).To(BeZero())
+	})
+
+	It("returns custom values when set", func() {
+		cfg := &config.SessionEscalationConfig{
+			Window:          config.Duration(10 * time.Minute),
+			WarnThreshold:   2,
+			AckThreshold:    4,
+			PoisonThreshold: 6,
+			PoisonCooldown:  config.Duration(30 * time.Minute),
+		}
+		Expect(cfg.GetWindow()).To(Equal(10 * time.Minute))
+		Expect(cfg.GetWarnThreshold()).To(Equal(2))
+		Expect(cfg.GetAckThreshold()).To(Equal(4))
+		Expect(cfg.GetPoisonThreshold()).To(Equal(6))
+		Expect(cfg.GetPoisonCooldown()).To(Equal(30 * time.Minute))
+	})
+})
+
 var _ = Describe("Config.GetSession", func() {
 	It("creates session config if nil", func() {
 		cfg := &config.Config{}
@@ -5,6 +5,12 @@ package config
 type ShellConfig struct {
 	// Backtick validator configuration
 	Backtick *BacktickValidatorConfig `json:"backtick,omitempty" koanf:"backtick" toml:"backtick"`
+
+	// Destructive validator configuration
+	Destructive *DestructiveValidatorConfig `json:"destructive,omitempty" koanf:"destructive" toml:"destructive"`
+
+	// TestFailure validator configuration (PostToolUse)
+	TestFailure *TestFailureValidatorConfig `json:"test_failure,omitempty" koanf:"test_failure" toml:"test_failure"`
 }
 
 // BacktickValidatorConfig configures the backtick validator.
@@ -42,3 +48,158 @@ func (c *BacktickValidatorConfig) SuggestSingleQuotesOrDefault() bool {
 
 	return *c.SuggestSingleQuotes
 }
+
+const (
+	// DestructiveActionBlock blocks the destructive command.
+	DestructiveActionBlock = "block"
+
+	// DestructiveActionWarn allows the destructive command with a warning.
+	DestructiveActionWarn = "warn"
+
+	// DestructiveActionOff disables the check.
+	DestructiveActionOff = "off"
+)
+
+// ValidDestructiveActions are the valid actions for destructive command categories.
+var ValidDestructiveActions = []string{
+	DestructiveActionBlock,
+	DestructiveActionWarn,
+	DestructiveActionOff,
+}
+
+// DestructiveValidatorConfig configures the destructive shell command validator.
+// Each category can be set to "block", "warn" or "off".
+//
+// Example configuration:
+//
+//	[validators.shell.destructive]
+//	recursive_delete = "block"
+//	protected_path_delete = "block"
+//	git_discard = "block"
+//	find_delete = "warn"
+//	device_write = "block"
+//	chmod_recursive = "block"
+type DestructiveValidatorConfig struct {
+	ValidatorConfig `koanf:",squash"`
+
+	// RecursiveDelete controls recursive deletes (rm -r) of paths outside the repository root.
+	// Default: "block"
+	RecursiveDelete string `json:"recursive_delete,omitempty" koanf:"recursive_delete" toml:"recursive_delete"`
+
+	// ProtectedPathDelete controls deletes of /, $HOME and paths built from
+	// variable expansions that resolve to / when unset (e.g., "$DIR/").
+	// Default: "block"
+	ProtectedPathDelete string `json:"protected_path_delete,omitempty" koanf:"protected_path_delete" toml:"protected_path_delete"`
+
+	// GitDiscard controls git reset --hard, git checkout -- . and git clean -f
+	// when the working tree has uncommitted changes or untracked files.
+	// Default: "block"
+	GitDiscard string `json:"git_discard,omitempty" koanf:"git_discard" toml:"git_discard"`
+
+	// FindDelete controls find ... -delete.
+	// Default: "warn"
+	FindDelete string `json:"find_delete,omitempty" koanf:"find_delete" toml:"find_delete"`
+
+	// DeviceWrite controls dd writing to a block device (dd of=/dev/*).
+	// Default: "block"
+	DeviceWrite string `json:"device_write,omitempty" koanf:"device_write" toml:"device_write"`
+
+	// ChmodRecursive controls world-writable recursive permission changes (chmod -R 777).
+	// Default: "block"
+	ChmodRecursive string `json:"chmod_recursive,omitempty" koanf:"chmod_recursive" toml:"chmod_recursive"`
+}
+
+// GetRecursiveDelete returns the action for recursive deletes outside the repository.
+func (c *DestructiveValidatorConfig) GetRecursiveDelete() string {
+	if c == nil || c.RecursiveDelete == "" {
+		return DestructiveActionBlock
+	}
+
+	return c.RecursiveDelete
+}
+
+// GetProtectedPathDelete returns the action for deletes of protected paths.
+func (c *DestructiveValidatorConfig) GetProtectedPathDelete() string {
+	if c == nil || c.ProtectedPathDelete == "" {
+		return DestructiveActionBlock
+	}
+
+	return c.ProtectedPathDelete
+}
+
+// GetGitDiscard returns the action for git commands discarding uncommitted work.
+func (c *DestructiveValidatorConfig) GetGitDiscard() string {
+	if c == nil || c.GitDiscard == "" {
+		return DestructiveActionBlock
+	}
+
+	return c.GitDiscard
+}
+
+// GetFindDelete returns the action for find -delete.
+func (c *DestructiveValidatorConfig) GetFindDelete() string {
+	if c == nil || c.FindDelete == "" {
+		return DestructiveActionWarn
+	}
+
+	return c.FindDelete
+}
+
+// GetDeviceWrite returns the action for dd writes to devices.
+func (c *DestructiveValidatorConfig) GetDeviceWrite() string {
+	if c == nil || c.DeviceWrite == "" {
+		return DestructiveActionBlock
+	}
+
+	return c.DeviceWrite
+}
+
+// GetChmodRecursive returns the action for chmod -R 777.
+func (c *DestructiveValidatorConfig) GetChmodRecursive() string {
+	if c == nil || c.ChmodRecursive == "" {
+		return DestructiveActionBlock
+	}
+
+	return c.ChmodRecursive
+}
+
+// DefaultTestCommands are the commands treated as test runs by default.
+var DefaultTestCommands = []string{"go test", "task", "make test"}
+
+// DefaultTestFailureMaxOutputLines is the default number of output lines
+// reported for a failing test run.
+const DefaultTestFailureMaxOutputLines = 50
+
+// TestFailureValidatorConfig configures the test failure validator, which runs
+// after Bash commands (PostToolUse) and reports failing test runs to the model.
+type TestFailureValidatorConfig struct {
+	ValidatorConfig `koanf:",squash"`
+
+	// Commands are the command prefixes treated as test runs.
+	// Each entry is matched against the command name and its leading arguments.
+	// Default: ["go test", "task", "make test"]
+	Commands []string `json:"commands,omitempty" koanf:"commands" toml:"commands"`
+
+	// MaxOutputLines is the number of trailing output lines included in the report.
+	// Set to 0 to include the whole output.
+	// Default: 50
+	MaxOutputLines *int `json:"max_output_lines,omitempty" koanf:"max_output_lines" toml:"max_output_lines"`
+}
+
+// GetCommands returns the test command prefixes or the defaults.
+func (c *TestFailureValidatorConfig) GetCommands() []string {
+	if c == nil || len(c.Commands) == 0 {
+		return DefaultTestCommands
+	}
+
+	return c.Commands
+}
+
+// GetMaxOutputLines returns the number of reported output lines or the default.
+func (c *TestFailureValidatorConfig) GetMaxOutputLines() int {
+	if c == nil || c.MaxOutputLines == nil {
+		return DefaultTestFailureMaxOutputLines
+	}
+
+	return *c.MaxOutputLines
+}
@@ -23,6 +23,25 @@ const (
 
 	// EventTypeNotification is triggered for user notifications.
 	EventTypeNotification
+
+	// EventTypeUserPromptSubmit is triggered when the user submits a prompt,
+	// before Claude processes it.
+	EventTypeUserPromptSubmit
+
+	// EventTypeStop is triggered when the main agent finishes responding.
+	EventTypeStop
+
+	// EventTypeSubagentStop is triggered when a subagent finishes responding.
+	EventTypeSubagentStop
+
+	// EventTypeSessionStart is triggered when a session starts or resumes.
+	EventTypeSessionStart
+
+	// EventTypeSessionEnd is triggered when a session ends.
+	EventTypeSessionEnd
+
+	// EventTypePreCompact is triggered before the context is compacted.
+	EventTypePreCompact
 )
 
 // ToolType represents the type of tool being used.
@@ -74,16 +93,92 @@ type ToolInput struct {
 	// NewString is the replacement string for Edit tool.
 	NewString string `json:"new_string,omitempty"`
 
+	// ReplaceAll replaces every occurrence of OldString for Edit tool.
+	ReplaceAll bool `json:"replace_all,omitempty"`
+
 	// Pattern is the search pattern for Grep/Glob tools.
 	Pattern string `json:"pattern,omitempty"`
 
+	// Glob is the file filter for the Grep tool.
+	Glob string `json:"glob,omitempty"`
+
+	// Edits is the ordered list of replacements for MultiEdit tool.
+	Edits []EditOp `json:"edits,omitempty"`
+
 	// Additional fields stored as raw JSON.
 	Additional map[string]json.RawMessage `json:"-"`
 }
 
+// ToolResponse contains the result of a tool invocation (for PostToolUse events).
+type ToolResponse struct {
+	// Stdout is the standard output of a Bash command, or the text result of other tools.
+	Stdout string
+
+	// Stderr is the standard error of a Bash command.
+	Stderr string
+
+	// ExitCode is the exit code of a Bash command, if reported.
+	ExitCode *int
+
+	// Interrupted is true when the command was interrupted.
+	Interrupted bool
+
+	// FilePath is the file the tool operated on, if reported.
+	FilePath string
+
+	// Content is the file content after a Write, Edit or MultiEdit.
+	Content string
+
+	// Success is the reported success of the tool, if any.
+	Success *bool
+}
+
+// Output returns stdout and stderr combined.
+func (r *ToolResponse) Output() string {
+	if r == nil {
+		return ""
+	}
+
+	if r.Stderr == "" {
+		return r.Stdout
+	}
+
+	if r.Stdout == "" {
+		return r.Stderr
+	}
+
+	return r.Stdout + "\n" + r.Stderr
+}
+
+// Failed returns true if the tool reported a non-zero exit code or no success.
+func (r *ToolResponse) Failed() bool {
+	if r == nil {
+		return false
+	}
+
+	if r.ExitCode != nil && *r.ExitCode != 0 {
+		return true
+	}
+
+	return r.Success != nil && !*r.Success
+}
+
+// EditOp is a single replacement in a MultiEdit edits array.
+// Edits are applied in order, each against the result of the previous one.
+type EditOp struct {
+	// OldString is the string to replace.
+	OldString string `json:"old_string"`
+
+	// NewString is the replacement string.
+	NewString string `json:"new_string"`
+
+	// ReplaceAll replaces every occurrence of OldString instead of the first one.
+	ReplaceAll bool `json:"replace_all,omitempty"`
+}
+
 // Context represents the complete hook invocation context.
 type Context struct {
-	// EventType is the type of hook event (PreToolUse, PostToolUse, Notification).
+	// EventType is the type of hook event (PreToolUse, UserPromptSubmit, Stop, ...).
 	EventType EventType
 
 	// ToolName is the name of the tool being invoked.
@@ -92,6 +187,9 @@ type Context struct {
 	// ToolInput contains the tool-specific input parameters.
 	ToolInput ToolInput
 
+	// ToolResponse contains the tool result (for PostToolUse events), or nil.
+	ToolResponse *ToolResponse
+
 	// NotificationType is the type of notification (for Notification events).
 	NotificationType string
 
@@ -106,6 +204,28 @@ type Context struct {
 
 	// TranscriptPath is the path to the session transcript file.
 	TranscriptPath string
+
+	// CWD is the working directory of the session when the hook was invoked.
+	CWD string
+
+	// Prompt is the submitted prompt text (for UserPromptSubmit events).
+	Prompt string
+
+	// StopHookActive is true when Claude is already continuing as a result
+	// of a stop hook (for Stop and SubagentStop events).
+	StopHookActive bool
+
+	// Source is what started the session: startup, resume, clear or compact
+	// (for SessionStart events).
+	Source string
+
+	// Reason is why the session ended: clear, logout, prompt_input_exit or
+	// other (for SessionEnd events).
+	Reason string
+
+	// Trigger is what initiated the compaction: manual or auto
+	// (for PreCompact events).
+	Trigger string
 }
 
 // GetCommand returns the command from ToolInput.
@@ -122,6 +242,21 @@ func (c *Context) GetFilePath() string {
 	return c.ToolInput.Path
 }
 
+// GetToolOutput returns the combined stdout and stderr from ToolResponse.
+func (c *Context) GetToolOutput() string {
+	return c.ToolResponse.Output()
+}
+
+// GetWrittenContent returns the file content after the tool ran, or an empty
+// string if no tool response is available.
+func (c *Context) GetWrittenContent() string {
+	if c.ToolResponse == nil {
+		return ""
+	}
+
+	return c.ToolResponse.Content
+}
+
 // GetContent returns the file content from ToolInput.
 func (c *Context) GetContent() string {
 	return c.ToolInput.Content
@@ -139,6 +274,11 @@ func (c *Context) IsFileTool() bool {
 		c.ToolName == ToolTypeMultiEdit
 }
 
+// IsMultiEdit returns true if the tool is MultiEdit with at least one edit.
+func (c *Context) IsMultiEdit() bool {
+	return c.ToolName == ToolTypeMultiEdit && len(c.ToolInput.Edits) > 0
+}
+
 // HasSessionID returns true if a session ID is present.
 func (c *Context) HasSessionID() bool {
 	return c.SessionID != ""
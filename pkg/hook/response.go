@@ -0,0 +1,60 @@
+package hook
+
+// PermissionDecision is the permission decision returned to Claude Code for PreToolUse hooks.
+type PermissionDecision string
+
+const (
+	// PermissionDecisionAllow bypasses the Claude Code permission system.
+	PermissionDecisionAllow PermissionDecision = "allow"
+
+	// PermissionDecisionDeny prevents the tool call from executing.
+	PermissionDecisionDeny PermissionDecision = "deny"
+
+	// PermissionDecisionAsk prompts the user to confirm the tool call.
+	PermissionDecisionAsk PermissionDecision = "ask"
+)
+
+// DecisionBlock is the top-level decision value used by non-PreToolUse events
+// (PostToolUse, UserPromptSubmit, Stop, SubagentStop) to block.
+const DecisionBlock = "block"
+
+// Response is the JSON response written to stdout for Claude Code hooks.
+type Response struct {
+	// Continue controls whether Claude should continue after the hook runs.
+	// Nil means the field is omitted and Claude Code uses its default (true).
+	Continue *bool `json:"continue,omitempty"`
+
+	// StopReason is the message shown to the user when Continue is false.
+	StopReason string `json:"stopReason,omitempty"`
+
+	// SuppressOutput hides the hook output from the transcript.
+	SuppressOutput bool `json:"suppressOutput,omitempty"`
+
+	// SystemMessage is an optional message shown to the user.
+	SystemMessage string `json:"systemMessage,omitempty"`
+
+	// Decision is the top-level decision for events without hook-specific
+	// permission decisions (e.g. "block" for PostToolUse).
+	Decision string `json:"decision,omitempty"`
+
+	// Reason explains the top-level decision to Claude.
+	Reason string `json:"reason,omitempty"`
+
+	// HookSpecificOutput contains event-specific fields.
+	HookSpecificOutput *HookSpecificOutput `json:"hookSpecificOutput,omitempty"`
+}
+
+// HookSpecificOutput contains event-specific response fields.
+type HookSpecificOutput struct {
+	// HookEventName is the name of the event this output belongs to.
+	HookEventName string `json:"hookEventName"`
+
+	// PermissionDecision is the PreToolUse permission decision.
+	PermissionDecision PermissionDecision `json:"permissionDecision,omitempty"`
+
+	// PermissionDecisionReason explains the permission decision.
+	PermissionDecisionReason string `json:"permissionDecisionReason,omitempty"`
+
+	// AdditionalContext is added to the model context.
+	AdditionalContext string `json:"additionalContext,omitempty"`
+}
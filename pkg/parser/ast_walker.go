@@ -9,6 +9,28 @@ type astWalker struct {
 	commands   []Command
 	fileWrites []FileWrite
 	currentDir string // Tracks the effective working directory from cd commands
+
+	// Source ranges of the enclosing constructs seen so far. syntax.Walk visits
+	// parents before children, so these are known when a command is extracted.
+	pipelines  [][]nodeRange
+	subshells  []nodeRange
+	cmdSubsts  []nodeRange
+	innerPipes map[*syntax.BinaryCmd]bool
+}
+
+// nodeRange is the source offset range of a node.
+type nodeRange struct {
+	start, end uint
+}
+
+// rangeOf returns the source offset range of node.
+func rangeOf(node syntax.Node) nodeRange {
+	return nodeRange{start: node.Pos().Offset(), end: node.End().Offset()}
+}
+
+// contains reports whether pos lies within the range.
+func (r nodeRange) contains(pos syntax.Pos) bool {
+	return pos.Offset() >= r.start && pos.Offset() < r.end
 }
 
 // visit is called for each node in the AST.
@@ -18,25 +40,93 @@ func (w *astWalker) visit(node syntax.Node) bool {
 		w.extractCommand(n)
 	case *syntax.Stmt:
 		w.extractRedirect(n)
+	case *syntax.BinaryCmd:
+		w.recordPipeline(n)
 	case *syntax.Subshell:
 		// Subshells are handled recursively by syntax.Walk
-		return true
+		w.subshells = append(w.subshells, rangeOf(n))
 	case *syntax.CmdSubst:
 		// Command substitution is handled recursively
-		return true
+		w.cmdSubsts = append(w.cmdSubsts, rangeOf(n))
 	}
 
 	return true
 }
 
+// recordPipeline records the stages of a pipeline. "a | b | c" is parsed as
+// nested binary commands, so only the outermost one is recorded.
+func (w *astWalker) recordPipeline(cmd *syntax.BinaryCmd) {
+	if !isPipe(cmd) || w.innerPipes[cmd] {
+		return
+	}
+
+	if w.innerPipes == nil {
+		w.innerPipes = make(map[*syntax.BinaryCmd]bool)
+	}
+
+	w.pipelines = append(w.pipelines, w.pipelineStages(cmd, nil))
+}
+
+// pipelineStages flattens a pipeline into the ranges of its stages.
+func (w *astWalker) pipelineStages(cmd *syntax.BinaryCmd, stages []nodeRange) []nodeRange {
+	for _, stmt := range []*syntax.Stmt{cmd.X, cmd.Y} {
+		if inner, ok := stmt.Cmd.(*syntax.BinaryCmd); ok && isPipe(inner) {
+			w.innerPipes[inner] = true
+			stages = w.pipelineStages(inner, stages)
+
+			continue
+		}
+
+		stages = append(stages, rangeOf(stmt))
+	}
+
+	return stages
+}
+
+// isPipe reports whether cmd is a pipe (| or |&).
+func isPipe(cmd *syntax.BinaryCmd) bool {
+	return cmd.Op == syntax.Pipe || cmd.Op == syntax.PipeAll
+}
+
+// annotate sets the pipeline, subshell and command substitution context of
+// a command at pos.
+func (w *astWalker) annotate(cmd *Command, pos syntax.Pos) {
+	// Later pipelines are nested deeper, so the last match is the innermost.
+	for _, stages := range w.pipelines {
+		for i, stage := range stages {
+			if stage.contains(pos) {
+				cmd.PipelineStage = i + 1
+				cmd.PipelineLength = len(stages)
+			}
+		}
+	}
+
+	for _, r := range w.subshells {
+		if r.contains(pos) {
+			cmd.InSubshell = true
+		}
+	}
+
+	for _, r := range w.cmdSubsts {
+		if r.contains(pos) {
+			cmd.InCmdSubst = true
+		}
+	}
+}
+
 // extractCommand extracts a command from a CallExpr node.
 func (w *astWalker) extractCommand(call *syntax.CallExpr) {
 	if len(call.Args) == 0 {
 		return
 	}
 
-	// First word is the command name
+	// First word is the command name, kept as written if it is not literal
+	// (e.g., "$cmd"), so that dynamically named commands are not lost
 	name := wordToString(call.Args[0])
+	if name == "" {
+		name = wordToSource(call.Args[0])
+	}
+
 	if name == "" {
 		return
 	}
@@ -59,8 +149,11 @@ func (w *astWalker) extractCommand(call *syntax.CallExpr) {
 		Location:         loc,
 		Type:             cmdType,
 		WorkingDirectory: w.currentDir,
+		RawArgs:          wordsToSource(call.Args[1:]),
 	}
 
+	w.annotate(&cmd, call.Pos())
+
 	w.commands = append(w.commands, cmd)
 
 	// Check if this is a cd command and update current directory
@@ -27,6 +27,28 @@ var _ = Describe("BashParser", func() {
 			})
 		})
 
+		Context("with parameter expansions", func() {
+			It("keeps the source form of arguments in RawArgs", func() {
+				result, err := p.Parse(`rm -rf "$DIR/" ~/tmp ${HOME}`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Commands).To(HaveLen(1))
+
+				cmd := result.Commands[0]
+				Expect(cmd.Args).To(Equal([]string{"-rf", "/", "~/tmp"}))
+				Expect(cmd.RawArgs).To(Equal([]string{"-rf", `"$DIR/"`, "~/tmp", "${HOME}"}))
+			})
+
+			It("keeps the source form of dynamic command names", func() {
+				result, err := p.Parse(`k=klaudiush; $k exception list`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Commands).To(HaveLen(1))
+
+				cmd := result.Commands[0]
+				Expect(cmd.Name).To(Equal("$k"))
+				Expect(cmd.Args).To(Equal([]string{"exception", "list"}))
+			})
+		})
+
 		Context("with simple commands", func() {
 			It("parses single command", func() {
 				result, err := p.Parse("git status")
@@ -124,6 +146,32 @@ var _ = Describe("BashParser", func() {
 				Expect(result.Commands[1].Name).To(Equal("grep"))
 				Expect(result.Commands[2].Name).To(Equal("wc"))
 			})
+
+			It("records pipeline stages", func() {
+				result, err := p.Parse("cd x && curl -fsSL https://example.com/install.sh | sudo sh -s -- -y")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Commands).To(HaveLen(3))
+
+				Expect(result.Commands[0].InPipeline()).To(BeFalse())
+				Expect(result.Commands[1].PipelineStage).To(Equal(1))
+				Expect(result.Commands[1].PipelineLength).To(Equal(2))
+				Expect(result.Commands[2].Name).To(Equal("sudo"))
+				Expect(result.Commands[2].PipelineStage).To(Equal(2))
+			})
+
+			It("records stages of the innermost pipeline", func() {
+				result, err := p.Parse("echo $(git log | head -1) | tr a b | wc -c")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Commands).To(HaveLen(5))
+
+				Expect(result.Commands[0].PipelineStage).To(Equal(1))
+				Expect(result.Commands[0].PipelineLength).To(Equal(3))
+				Expect(result.Commands[1].Name).To(Equal("git"))
+				Expect(result.Commands[1].PipelineStage).To(Equal(1))
+				Expect(result.Commands[1].PipelineLength).To(Equal(2))
+				Expect(result.Commands[2].PipelineStage).To(Equal(2))
+				Expect(result.Commands[4].PipelineStage).To(Equal(3))
+			})
 		})
 
 		Context("with subshells", func() {
@@ -134,6 +182,8 @@ var _ = Describe("BashParser", func() {
 
 				Expect(result.Commands[0].Name).To(Equal("cd"))
 				Expect(result.Commands[1].Name).To(Equal("git"))
+				Expect(result.Commands[1].InSubshell).To(BeTrue())
+				Expect(result.Commands[1].InCmdSubst).To(BeFalse())
 			})
 
 			It("parses command substitution", func() {
@@ -142,7 +192,18 @@ var _ = Describe("BashParser", func() {
 				Expect(result.Commands).To(HaveLen(2))
 
 				Expect(result.Commands[0].Name).To(Equal("echo"))
+				Expect(result.Commands[0].InCmdSubst).To(BeFalse())
 				Expect(result.Commands[1].Name).To(Equal("git"))
+				Expect(result.Commands[1].InCmdSubst).To(BeTrue())
+				Expect(result.Commands[1].InSubshell).To(BeFalse())
+			})
+
+			It("records backtick command substitution", func() {
+				result, err := p.Parse("echo `whoami`")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Commands).To(HaveLen(2))
+
+				Expect(result.Commands[1].InCmdSubst).To(BeTrue())
 			})
 		})
 
@@ -417,6 +478,40 @@ EOF`
 				Expect(cmd.FullCommand()).To(Equal([]string{"git", "commit", "-m", "message"}))
 			})
 		})
+
+		Context("UnwrapSudo", func() {
+			DescribeTable("returns the command run by sudo",
+				func(command, name string, args []string) {
+					result, err := p.Parse(command)
+					Expect(err).NotTo(HaveOccurred())
+
+					gotName, gotArgs := result.Commands[0].UnwrapSudo()
+					Expect(gotName).To(Equal(name))
+					Expect(gotArgs).To(Equal(args))
+				},
+				Entry("plain command", "/bin/rm -rf dir", "rm", []string{"-rf", "dir"}),
+				Entry("sudo", "sudo rm -rf /", "rm", []string{"-rf", "/"}),
+				Entry("sudo with flags", "sudo -E -n cat /etc/shadow", "cat", []string{"/etc/shadow"}),
+				Entry("sudo with user", "sudo -u root cat .env", "cat", []string{".env"}),
+				Entry("quoted command", `sudo "/usr/bin/cat" .env`, "cat", []string{".env"}),
+				Entry("end of flags", "sudo -- rm -rf /", "rm", []string{"-rf", "/"}),
+				Entry("sudo only", "sudo -v", "sudo", nil),
+			)
+		})
+
+		Context("UnquoteArg", func() {
+			DescribeTable("returns the value of raw arguments",
+				func(raw, value string) {
+					Expect(parser.UnquoteArg(raw)).To(Equal(value))
+				},
+				Entry("plain", "-c", "-c"),
+				Entry("single quoted", `'echo "hi"'`, `echo "hi"`),
+				Entry("double quoted", `"bash -c 'ls'"`, "bash -c 'ls'"),
+				Entry("mixed quotes", `--command='ls -a'`, "--command=ls -a"),
+				Entry("expansions", `"$HOME/bin"`, "/bin"),
+				Entry("several words", "a b", "a b"),
+			)
+		})
 	})
 
 	Describe("FindDoubleQuotedBackticks", func() {
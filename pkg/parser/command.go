@@ -3,6 +3,8 @@ package parser
 
 import (
 	"fmt"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"mvdan.cc/sh/v3/syntax"
@@ -50,12 +52,30 @@ type Location struct {
 
 // Command represents a parsed command with metadata.
 type Command struct {
-	Name             string   // Command name (e.g., "git")
+	Name             string   // Command name (e.g., "git"), as written if dynamic (e.g., "$cmd")
 	Args             []string // Command arguments
 	Location         Location // Position in source
 	Type             CmdType  // Command type
 	Raw              string   // Raw command string
 	WorkingDirectory string   // Effective working directory from preceding cd commands
+
+	// RawArgs contains the arguments as written in the source, including quotes
+	// and parameter expansions (e.g., "$DIR/" or ~), which Args drops.
+	RawArgs []string
+
+	// PipelineStage is the 1-based position of the command in the innermost
+	// pipeline containing it, or 0 if the command is not part of a pipeline.
+	PipelineStage int
+
+	// PipelineLength is the number of stages of that pipeline, or 0.
+	PipelineLength int
+
+	// InSubshell reports whether the command runs in a subshell (e.g., "(cd dir && make)").
+	InSubshell bool
+
+	// InCmdSubst reports whether the command runs inside command substitution
+	// (e.g., "$(git log)" or backticks).
+	InCmdSubst bool
 }
 
 // String returns a string representation of the command.
@@ -67,6 +87,11 @@ func (c *Command) String() string {
 	return fmt.Sprintf("%s %s", c.Name, strings.Join(c.Args, " "))
 }
 
+// InPipeline reports whether the command is part of a pipeline.
+func (c *Command) InPipeline() bool {
+	return c.PipelineLength > 0
+}
+
 // FullCommand returns the complete command as a string slice.
 func (c *Command) FullCommand() []string {
 	result := []string{c.Name}
@@ -75,6 +100,61 @@ func (c *Command) FullCommand() []string {
 	return result
 }
 
+// sudoValueFlags are sudo flags that take a value as the next argument.
+var sudoValueFlags = []string{"-u", "-g", "-U", "-C", "-D", "-h", "-p", "-r", "-R", "-t", "-T"}
+
+// UnwrapSudo returns the base name and raw arguments of the command. For sudo
+// invocations it returns the command run by sudo and its arguments instead.
+func (c *Command) UnwrapSudo() (string, []string) {
+	name := filepath.Base(c.Name)
+	if name != "sudo" {
+		return name, c.RawArgs
+	}
+
+	for i := 0; i < len(c.RawArgs); i++ {
+		arg := unquote(c.RawArgs[i])
+
+		switch {
+		case arg == "--":
+			if i+1 < len(c.RawArgs) {
+				return filepath.Base(unquote(c.RawArgs[i+1])), c.RawArgs[i+2:]
+			}
+
+			return name, nil
+		case slices.Contains(sudoValueFlags, arg):
+			i++
+		case strings.HasPrefix(arg, "-"):
+		default:
+			return filepath.Base(arg), c.RawArgs[i+1:]
+		}
+	}
+
+	return name, nil
+}
+
+// unquote removes shell quotes from a raw argument.
+func unquote(raw string) string {
+	return strings.NewReplacer(`"`, "", "'", "").Replace(raw)
+}
+
+// UnquoteArg returns the value of a raw argument as the shell passes it to the
+// command, with quotes removed. As in Args, parameter expansions are left out.
+// Arguments that are not a single word are returned unchanged.
+func UnquoteArg(raw string) string {
+	var words []*syntax.Word
+
+	err := syntax.NewParser().Words(strings.NewReader(raw), func(word *syntax.Word) bool {
+		words = append(words, word)
+
+		return len(words) < 2
+	})
+	if err != nil || len(words) != 1 {
+		return raw
+	}
+
+	return wordToString(words[0])
+}
+
 // wordToString converts syntax.Word to string, handling quotes and expansions.
 func wordToString(word *syntax.Word) string {
 	if word == nil {
@@ -139,6 +219,33 @@ func extractHeredocFromCmdSubst(cmdSubst *syntax.CmdSubst) string {
 	return ""
 }
 
+// wordToSource returns the word as written in the source, including quotes
+// and expansions.
+func wordToSource(word *syntax.Word) string {
+	if word == nil {
+		return ""
+	}
+
+	var buf strings.Builder
+
+	if err := syntax.NewPrinter().Print(&buf, word); err != nil {
+		return ""
+	}
+
+	return buf.String()
+}
+
+// wordsToSource converts a slice of syntax.Word to their source representation.
+func wordsToSource(words []*syntax.Word) []string {
+	result := make([]string, 0, len(words))
+
+	for _, word := range words {
+		result = append(result, wordToSource(word))
+	}
+
+	return result
+}
+
 // wordsToStrings converts a slice of syntax.Word to string slice.
 func wordsToStrings(words []*syntax.Word) []string {
 	result := make([]string, 0, len(words))
@@ -59,7 +59,7 @@ type Info struct {
 
 // ValidateRequest contains the context passed to plugin validators.
 type ValidateRequest struct {
-	// EventType is the hook event type ("PreToolUse", "PostToolUse", "Notification").
+	// EventType is the hook event type ("PreToolUse", "PostToolUse", "UserPromptSubmit", "Stop", etc.).
 	EventType string `json:"event_type"`
 
 	// ToolName is the tool being invoked ("Bash", "Write", "Edit", etc.).